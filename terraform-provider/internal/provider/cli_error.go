@@ -0,0 +1,294 @@
+// Package provider contains the SousChef CLI invocation helpers
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// cliRetryAttempts is the number of times a CLI command is run when its
+	// failures match retryable_errors.
+	cliRetryAttempts = 3
+	// cliRetryDelay is the base pause between CLI attempts.
+	cliRetryDelay = 250 * time.Millisecond
+)
+
+// cliError describes a failed SousChef CLI invocation.
+type cliError struct {
+	// Subcommand is the souschef subcommand that was run, e.g. convert-recipe.
+	Subcommand string
+	// ExitCode is the process exit code, or -1 when the CLI could not be started.
+	ExitCode int
+	// Stderr is what the CLI wrote to standard error.
+	Stderr string
+	// Err is the underlying error from os/exec.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *cliError) Error() string {
+	msg := fmt.Sprintf("souschef %s failed: %s", e.Subcommand, e.Err)
+	if e.ExitCode >= 0 {
+		msg = fmt.Sprintf("souschef %s exited with code %d", e.Subcommand, e.ExitCode)
+	}
+	if stderr := strings.TrimSpace(e.Stderr); stderr != "" {
+		msg += ": " + stderr
+	}
+	return msg
+}
+
+// Unwrap returns the underlying os/exec error.
+func (e *cliError) Unwrap() error {
+	return e.Err
+}
+
+// runCLI runs a SousChef CLI command and returns its stdout, which callers
+// parse; stderr is kept out of it. The subcommand in args[0] is renamed per
+// command_overrides. A failed command is retried when its stderr matches
+// retryable_errors, and otherwise returned as a *cliError. When the command
+// succeeds but wrote to stderr, the text is added to diagnostics as a warning
+// unless surface_cli_warnings is false; diagnostics may be nil to ignore it.
+// Concurrent calls with an identical command share a single execution.
+// Each call is recorded in the report_path report, when one is configured,
+// against the resource ID set on ctx by withReportResourceID.
+func runCLI(ctx context.Context, client *SousChefClient, diagnostics *diag.Diagnostics, args ...string) ([]byte, error) {
+	result, err := runCLIWithLog(ctx, client, diagnostics, args...)
+	return result.stdout, err
+}
+
+// runCLIWithLog is runCLI for callers that also keep a log of the run. The
+// result holds stdout on its own and stdout and stderr interleaved as the
+// command wrote them.
+func runCLIWithLog(ctx context.Context, client *SousChefClient, diagnostics *diag.Diagnostics, args ...string) (cliResult, error) {
+	start := time.Now()
+	result, err := runCLICommand(ctx, client, diagnostics, args...)
+	recordCLIRun(client, newReportEntry(reportResourceID(ctx), args, start, err))
+	return result, err
+}
+
+// runCLICommand runs a SousChef CLI command for runCLI, retrying a failed
+// run whose stderr matches one of the client's retryable_errors patterns.
+func runCLICommand(ctx context.Context, client *SousChefClient, diagnostics *diag.Diagnostics, args ...string) (cliResult, error) {
+	if len(args) > 0 {
+		args = append([]string{client.subcommand(args[0])}, args[1:]...)
+	}
+
+	result, err := runCLIOnce(ctx, client, args)
+	for attempt := 1; attempt < cliRetryAttempts && err != nil && client.retryable(result.stderr); attempt++ {
+		tflog.Warn(ctx, "Retrying SousChef after a retryable error", map[string]interface{}{
+			"attempt": attempt,
+			"error":   err.Error(),
+		})
+		select {
+		case <-ctx.Done():
+			return result.clone(), err
+		case <-time.After(backoffWithJitter(attempt-1, cliRetryDelay)):
+		}
+		result, err = runCLIOnce(ctx, client, args)
+	}
+
+	output := result.clone()
+	if err != nil {
+		return output, err
+	}
+	if diagnostics != nil && client.surfacesCLIWarnings() {
+		if warnings := strings.TrimSpace(result.stderr); warnings != "" {
+			subcommand := ""
+			if len(args) > 0 {
+				subcommand = args[0]
+			}
+			diagnostics.AddWarning(
+				"SousChef CLI reported warnings",
+				fmt.Sprintf("souschef %s succeeded with warnings: %s", subcommand, warnings),
+			)
+		}
+	}
+	return output, nil
+}
+
+// runCLIOnce executes a SousChef CLI command once. Identical commands running
+// at the same time, e.g. two resources converting the same recipe to the same
+// output, share one execution rather than racing to write the same files. The
+// execution holds one of the client's max_concurrent_cli slots while it runs.
+// The shared execution is not tied to any one caller's context: a caller whose
+// context ends stops waiting for it, and it is only canceled once every
+// caller waiting on it has gone.
+func runCLIOnce(ctx context.Context, client *SousChefClient, args []string) (cliResult, error) {
+	key := cliCallKey(client.command(context.Background(), args...))
+	sharedCtx, leave := joinCLICall(ctx, key)
+	defer leave()
+
+	cmd := client.command(sharedCtx, args...)
+	tflog.Debug(ctx, "Executing SousChef", map[string]interface{}{
+		"command": cmd.String(),
+	})
+
+	results := cliCalls.DoChan(key, func() (interface{}, error) {
+		release, err := client.acquireCLISlot(sharedCtx)
+		if err != nil {
+			return cliResult{}, newCLIContextError(args, fmt.Errorf("waiting for a free CLI slot: %w", err))
+		}
+		defer release()
+		return runCommand(cmd, args)
+	})
+	select {
+	case result := <-results:
+		if result.Shared {
+			tflog.Debug(ctx, "Shared SousChef execution with an identical concurrent command", map[string]interface{}{
+				"command": cmd.String(),
+			})
+		}
+		return result.Val.(cliResult), result.Err
+	case <-ctx.Done():
+		return cliResult{}, newCLIContextError(args, ctx.Err())
+	}
+}
+
+// newCLIContextError returns the *cliError for a command that could not run
+// or finish, e.g. because the caller's context ended.
+func newCLIContextError(args []string, err error) *cliError {
+	cliErr := &cliError{ExitCode: -1, Err: err}
+	if len(args) > 0 {
+		cliErr.Subcommand = args[0]
+	}
+	return cliErr
+}
+
+// cliCalls coalesces concurrent identical CLI invocations.
+var cliCalls singleflight.Group
+
+// sharedCLICall is the context a coalesced CLI execution runs under and the
+// number of callers waiting on it.
+type sharedCLICall struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	waiters int
+}
+
+var (
+	// sharedCLICallsMu guards sharedCLICalls.
+	sharedCLICallsMu sync.Mutex
+	// sharedCLICalls holds the shared context of each command in cliCalls,
+	// keyed by cliCallKey.
+	sharedCLICalls = map[string]*sharedCLICall{}
+)
+
+// joinCLICall registers a caller waiting on the command identified by key and
+// returns the context the shared execution runs under. It keeps ctx's values
+// but not its cancellation. The returned leave function must be called once
+// the caller stops waiting; the last caller to leave cancels the context.
+func joinCLICall(ctx context.Context, key string) (context.Context, func()) {
+	sharedCLICallsMu.Lock()
+	defer sharedCLICallsMu.Unlock()
+
+	call, ok := sharedCLICalls[key]
+	if !ok {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &sharedCLICall{ctx: callCtx, cancel: cancel}
+		sharedCLICalls[key] = call
+	}
+	call.waiters++
+
+	return call.ctx, func() {
+		sharedCLICallsMu.Lock()
+		defer sharedCLICallsMu.Unlock()
+
+		call.waiters--
+		if call.waiters == 0 {
+			call.cancel()
+			delete(sharedCLICalls, key)
+		}
+	}
+}
+
+// cliResult is the outcome of one CLI execution, shared by every caller
+// whose command coalesced into it.
+type cliResult struct {
+	stdout   []byte
+	combined []byte
+	stderr   string
+}
+
+// clone copies the result's buffers so a caller cannot modify the ones
+// shared with other callers.
+func (r cliResult) clone() cliResult {
+	return cliResult{stdout: bytes.Clone(r.stdout), combined: bytes.Clone(r.combined), stderr: r.stderr}
+}
+
+// cliCallKey identifies a command by everything that affects its result:
+// the executable, arguments, environment and working directory.
+func cliCallKey(cmd *exec.Cmd) string {
+	return strings.Join([]string{
+		strings.Join(cmd.Args, "\x00"),
+		strings.Join(cmd.Env, "\x00"),
+		cmd.Dir,
+	}, "\x00\x00")
+}
+
+// runCommand runs cmd and returns its stdout, its stderr and both
+// interleaved, with a failure returned as a *cliError.
+func runCommand(cmd *exec.Cmd, args []string) (cliResult, error) {
+	var stdout, stderr, output bytes.Buffer
+	// os/exec copies stdout and stderr on separate goroutines, so the
+	// combined buffer they share must be locked.
+	combined := &lockedWriter{w: &output}
+	cmd.Stdout = io.MultiWriter(&stdout, combined)
+	cmd.Stderr = io.MultiWriter(&stderr, combined)
+
+	if err := cmd.Run(); err != nil {
+		cliErr := &cliError{ExitCode: -1, Stderr: stderr.String(), Err: err}
+		if len(args) > 0 {
+			cliErr.Subcommand = args[0]
+		}
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			cliErr.ExitCode = exitErr.ExitCode()
+		}
+		return cliResult{stdout: stdout.Bytes(), combined: output.Bytes(), stderr: stderr.String()}, cliErr
+	}
+	return cliResult{stdout: stdout.Bytes(), combined: output.Bytes(), stderr: stderr.String()}, nil
+}
+
+// lockedWriter serialises writes to an underlying writer.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// Write writes p to the underlying writer while holding the lock.
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+// diagnosticFromError converts an error into an error diagnostic. CLI
+// failures are summarised by subcommand, with the exit code and stderr as detail.
+func diagnosticFromError(err error) diag.Diagnostic {
+	var cliErr *cliError
+	if !errors.As(err, &cliErr) {
+		return diag.NewErrorDiagnostic("Error running SousChef", err.Error())
+	}
+
+	summary := fmt.Sprintf("Error running souschef %s", cliErr.Subcommand)
+	if cliErr.ExitCode < 0 {
+		return diag.NewErrorDiagnostic(summary, fmt.Sprintf("Could not run the SousChef CLI: %s", cliErr.Err))
+	}
+	detail := fmt.Sprintf("The command exited with code %d.", cliErr.ExitCode)
+	if stderr := strings.TrimSpace(cliErr.Stderr); stderr != "" {
+		detail += "\nOutput: " + stderr
+	}
+	return diag.NewErrorDiagnostic(summary, detail)
+}
@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"golang.org/x/sync/semaphore"
+)
+
+func TestCLIErrorError(t *testing.T) {
+	tests := map[string]struct {
+		err  *cliError
+		want string
+	}{
+		"exit code with stderr": {
+			err:  &cliError{Subcommand: "convert-recipe", ExitCode: 2, Stderr: "recipe not found\n", Err: errors.New("exit status 2")},
+			want: "souschef convert-recipe exited with code 2: recipe not found",
+		},
+		"exit code without stderr": {
+			err:  &cliError{Subcommand: "deps", ExitCode: 1, Err: errors.New("exit status 1")},
+			want: "souschef deps exited with code 1",
+		},
+		"not started": {
+			err:  &cliError{Subcommand: "validate", ExitCode: -1, Err: exec.ErrNotFound},
+			want: "souschef validate failed: " + exec.ErrNotFound.Error(),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Fatalf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiagnosticFromError(t *testing.T) {
+	tests := map[string]struct {
+		err         error
+		wantSummary string
+		wantDetail  string
+	}{
+		"CLI failure": {
+			err:         &cliError{Subcommand: "convert-habitat", ExitCode: 3, Stderr: "bad plan\n", Err: errors.New("exit status 3")},
+			wantSummary: "Error running souschef convert-habitat",
+			wantDetail:  "The command exited with code 3.\nOutput: bad plan",
+		},
+		"CLI not started": {
+			err:         &cliError{Subcommand: "deps", ExitCode: -1, Err: exec.ErrNotFound},
+			wantSummary: "Error running souschef deps",
+			wantDetail:  "Could not run the SousChef CLI: " + exec.ErrNotFound.Error(),
+		},
+		"other error": {
+			err:         errors.New("boom"),
+			wantSummary: "Error running SousChef",
+			wantDetail:  "boom",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := diagnosticFromError(tt.err)
+			if d.Summary() != tt.wantSummary || d.Detail() != tt.wantDetail {
+				t.Fatalf("got %q / %q, want %q / %q", d.Summary(), d.Detail(), tt.wantSummary, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestRunCLI(t *testing.T) {
+	client := &SousChefClient{Path: newFakeSousChef(t)}
+
+	t.Run("success", func(t *testing.T) {
+		output, err := runCLI(context.Background(), client, nil, "env")
+		if err != nil || len(output) == 0 {
+			t.Fatalf("expected output, got %q, %v", output, err)
+		}
+	})
+
+	t.Run("exit code", func(t *testing.T) {
+		t.Setenv("SOUSCHEF_TEST_FAIL", "deps")
+		_, err := runCLI(context.Background(), client, nil, "deps", "--cookbook-path", t.TempDir())
+		var cliErr *cliError
+		if !errors.As(err, &cliErr) {
+			t.Fatalf("expected *cliError, got %v", err)
+		}
+		if cliErr.Subcommand != "deps" || cliErr.ExitCode != 1 || cliErr.Stderr != "forced error\n" {
+			t.Fatalf("unexpected cliError %+v", cliErr)
+		}
+	})
+
+	t.Run("warnings", func(t *testing.T) {
+		t.Setenv("SOUSCHEF_TEST_WARN", "warning: guard converted on a best-effort basis")
+		var diags diag.Diagnostics
+		output, err := runCLI(context.Background(), client, &diags, "env")
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if strings.Contains(string(output), "guard converted") {
+			t.Fatalf("expected stderr to be kept out of the output, got %q", output)
+		}
+		if diags.HasError() || diags.WarningsCount() != 1 || !strings.Contains(diags.Warnings()[0].Detail(), "guard converted on a best-effort basis") {
+			t.Fatalf("expected a CLI warning, got %v", diags)
+		}
+
+		diags = nil
+		suppressed := &SousChefClient{Path: client.Path, SuppressCLIWarnings: true}
+		if _, err := runCLI(context.Background(), suppressed, &diags, "env"); err != nil || len(diags) != 0 {
+			t.Fatalf("expected no diagnostics with surface_cli_warnings false, got %v, %v", diags, err)
+		}
+	})
+
+	t.Run("retryable errors", func(t *testing.T) {
+		retrying := &SousChefClient{Path: client.Path, RetryableErrors: []*regexp.Regexp{regexp.MustCompile("(?i)temporary failure in name resolution")}}
+		tests := map[string]struct {
+			stderr  string
+			wantErr bool
+		}{
+			"retried":     {stderr: "Temporary failure in name resolution"},
+			"not retried": {stderr: "cookbook not found", wantErr: true},
+		}
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				t.Setenv("SOUSCHEF_TEST_FLAKY", filepath.Join(t.TempDir(), "failed"))
+				t.Setenv("SOUSCHEF_TEST_FLAKY_STDERR", tt.stderr)
+				_, err := runCLI(context.Background(), retrying, nil, "env")
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				if tt.wantErr && !strings.Contains(err.Error(), tt.stderr) {
+					t.Fatalf("expected the first attempt's error, got %v", err)
+				}
+			})
+		}
+	})
+
+	t.Run("missing binary", func(t *testing.T) {
+		missing := &SousChefClient{Path: filepath.Join(t.TempDir(), "souschef")}
+		_, err := runCLI(context.Background(), missing, nil, "validate")
+		var cliErr *cliError
+		if !errors.As(err, &cliErr) || cliErr.ExitCode != -1 {
+			t.Fatalf("expected *cliError with exit code -1, got %v", err)
+		}
+	})
+}
+
+func TestRunCLIMaxConcurrentCLI(t *testing.T) {
+	// The script registers itself in running/ while it works and logs how
+	// many invocations it saw running, itself included
+	dir := t.TempDir()
+	runningDir := filepath.Join(dir, "running")
+	logPath := filepath.Join(dir, "concurrency.log")
+	if err := os.Mkdir(runningDir, testDirPermissions); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+	script := "#!/bin/sh\n" +
+		"touch \"" + runningDir + "/$$\"\n" +
+		"ls \"" + runningDir + "\" | wc -l >> \"" + logPath + "\"\n" +
+		"sleep 0.1\n" +
+		"rm \"" + runningDir + "/$$\"\n"
+	scriptPath := filepath.Join(dir, "souschef")
+	if err := os.WriteFile(scriptPath, []byte(script), testDirPermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+
+	const limit = 2
+	client := &SousChefClient{Path: scriptPath, CLISlots: semaphore.NewWeighted(limit)}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Distinct arguments keep the calls from being coalesced
+			if _, err := runCLI(context.Background(), client, nil, "env", strconv.Itoa(i)); err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	content, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("failed to read concurrency log: %v", err)
+	}
+	counts := strings.Fields(string(content))
+	if len(counts) != 8 {
+		t.Fatalf("expected 8 CLI runs, got %q", content)
+	}
+	for _, count := range counts {
+		if n, err := strconv.Atoi(count); err != nil || n > limit {
+			t.Fatalf("expected at most %d concurrent CLI runs, got %q", limit, content)
+		}
+	}
+
+	t.Run("canceled while waiting", func(t *testing.T) {
+		full := &SousChefClient{Path: scriptPath, CLISlots: semaphore.NewWeighted(1)}
+		if err := full.CLISlots.Acquire(context.Background(), 1); err != nil {
+			t.Fatalf("failed to take the only CLI slot: %v", err)
+		}
+		defer full.CLISlots.Release(1)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := runCLI(ctx, full, nil, "env", "canceled")
+		var cliErr *cliError
+		if !errors.As(err, &cliErr) || !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected a canceled *cliError, got %v", err)
+		}
+	})
+}
+
+func TestRunCLICoalescedCallerCanceled(t *testing.T) {
+	countFile := filepath.Join(t.TempDir(), "invocations")
+	t.Setenv("SOUSCHEF_TEST_COUNT", countFile)
+	client := &SousChefClient{Path: newFakeSousChef(t)}
+
+	// The first caller starts the command, the second joins it
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := runCLI(ctx, client, nil, "env")
+		firstErr <- err
+	}()
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		if _, err := os.Stat(countFile); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the command to start")
+		}
+	}
+	secondErr := make(chan error, 1)
+	go func() {
+		_, err := runCLI(context.Background(), client, nil, "env")
+		secondErr <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	// Canceling the first caller only stops it waiting
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the canceled caller to stop with its context's error, got %v", err)
+	}
+	if err := <-secondErr; err != nil {
+		t.Fatalf("expected the shared command to complete for the other caller, got %v", err)
+	}
+	content, err := os.ReadFile(countFile)
+	if err != nil {
+		t.Fatalf("failed to read invocation count: %v", err)
+	}
+	if runs := strings.Count(string(content), "\n"); runs != 1 {
+		t.Fatalf("expected one shared CLI run, got %d", runs)
+	}
+}
@@ -157,6 +157,7 @@ func TestCostEstimateDataSourceReadDefaults(t *testing.T) {
 		CookbookPath:        types.StringValue(testTmpCookbook),
 		DeveloperHourlyRate: types.Float64Null(),
 		InfrastructureCost:  types.Float64Null(),
+		Timeouts:            types.ObjectNull(readTimeoutsAttrTypes),
 	})
 	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
 
@@ -219,9 +220,10 @@ func TestInSpecMigrationDeleteWithDifferentFormats(t *testing.T) {
 		}
 
 		state := newState(t, schema, inspecMigrationResourceModel{
-			ProfilePath:  types.StringValue(testTmpProfile),
-			OutputPath:   types.StringValue(testDir),
-			OutputFormat: types.StringValue(f.format),
+			ProfilePath:       types.StringValue(testTmpProfile),
+			OutputPath:        types.StringValue(testDir),
+			OutputFormat:      types.StringValue(f.format),
+			ConvertedControls: types.ListNull(types.StringType),
 		})
 
 		deleteResp := &resource.DeleteResponse{}
@@ -344,19 +346,26 @@ func createTestPlanForResource(t *testing.T, schema resourceschema.Schema, r res
 	switch r.(type) {
 	case *migrationResource:
 		return newPlan(t, schema, migrationResourceModel{
-			CookbookPath: types.StringValue(testTmpCookbook),
-			OutputPath:   types.StringValue(outputPath),
-			RecipeName:   types.StringValue("default"),
+			CookbookPath:      types.StringValue(testTmpCookbook),
+			OutputPath:        types.StringValue(outputPath),
+			RecipeName:        types.StringValue("default"),
+			ReferencedEnvVars: types.ListNull(types.StringType),
+			ModuleCounts:      types.MapNull(types.Int64Type),
+			VariableRenameMap: types.MapNull(types.StringType),
+			VariableMappings:  types.MapNull(types.StringType),
+			Outputs:           types.MapNull(types.StringType),
 		})
 	case *batchMigrationResource:
 		return newPlan(t, schema, batchMigrationResourceModel{
-			CookbookPath:  types.StringValue(testTmpCookbook),
-			OutputPath:    types.StringValue(outputPath),
-			RecipeNames:   []types.String{types.StringValue("default")},
-			ID:            types.StringNull(),
-			CookbookName:  types.StringNull(),
-			PlaybookCount: types.Int64Null(),
-			Playbooks:     types.MapNull(types.StringType),
+			CookbookPath:        types.StringValue(testTmpCookbook),
+			OutputPath:          types.StringValue(outputPath),
+			RecipeNames:         []types.String{types.StringValue("default")},
+			ID:                  types.StringNull(),
+			CookbookName:        types.StringNull(),
+			PlaybookCount:       types.Int64Null(),
+			Playbooks:           types.MapNull(types.StringType),
+			RecipeStatus:        types.MapNull(types.StringType),
+			ResolvedRecipeNames: types.ListNull(types.StringType),
 		})
 	case *habitatMigrationResource:
 		planPath := filepath.Join(t.TempDir(), testPlanSh)
@@ -373,12 +382,13 @@ func createTestPlanForResource(t *testing.T, schema resourceschema.Schema, r res
 		})
 	case *inspecMigrationResource:
 		return newPlan(t, schema, inspecMigrationResourceModel{
-			ProfilePath:  types.StringValue(testTmpProfile),
-			OutputPath:   types.StringValue(outputPath),
-			OutputFormat: types.StringValue("testinfra"),
-			ID:           types.StringNull(),
-			ProfileName:  types.StringNull(),
-			TestContent:  types.StringNull(),
+			ProfilePath:       types.StringValue(testTmpProfile),
+			OutputPath:        types.StringValue(outputPath),
+			OutputFormat:      types.StringValue("testinfra"),
+			ID:                types.StringNull(),
+			ProfileName:       types.StringNull(),
+			TestContent:       types.StringNull(),
+			ConvertedControls: types.ListNull(types.StringType),
 		})
 	default:
 		t.Fatalf("unsupported resource type: %T", r)
@@ -392,8 +402,13 @@ func createTestStateForResource(t *testing.T, schema resourceschema.Schema, r re
 	switch r.(type) {
 	case *migrationResource:
 		return newState(t, schema, migrationResourceModel{
-			RecipeName: types.StringValue("test"),
-			OutputPath: types.StringValue(outputPath),
+			RecipeName:        types.StringValue("test"),
+			OutputPath:        types.StringValue(outputPath),
+			ReferencedEnvVars: types.ListNull(types.StringType),
+			ModuleCounts:      types.MapNull(types.Int64Type),
+			VariableRenameMap: types.MapNull(types.StringType),
+			VariableMappings:  types.MapNull(types.StringType),
+			Outputs:           types.MapNull(types.StringType),
 		})
 	case *habitatMigrationResource:
 		return newState(t, schema, habitatMigrationResourceModel{
@@ -402,9 +417,10 @@ func createTestStateForResource(t *testing.T, schema resourceschema.Schema, r re
 		})
 	case *inspecMigrationResource:
 		return newState(t, schema, inspecMigrationResourceModel{
-			ProfilePath:  types.StringValue(testTmpProfile),
-			OutputPath:   types.StringValue(outputPath),
-			OutputFormat: types.StringValue("testinfra"),
+			ProfilePath:       types.StringValue(testTmpProfile),
+			OutputPath:        types.StringValue(outputPath),
+			OutputFormat:      types.StringValue("testinfra"),
+			ConvertedControls: types.ListNull(types.StringType),
 		})
 	case *batchMigrationResource:
 		return newState(t, schema, batchMigrationResourceModel{
@@ -412,10 +428,12 @@ func createTestStateForResource(t *testing.T, schema resourceschema.Schema, r re
 			RecipeNames: []types.String{
 				types.StringValue("default"),
 			},
-			OutputPath:    types.StringValue(outputPath),
-			CookbookName:  types.StringValue("test"),
-			PlaybookCount: types.Int64Value(1),
-			Playbooks:     types.MapNull(types.StringType),
+			OutputPath:          types.StringValue(outputPath),
+			CookbookName:        types.StringValue("test"),
+			PlaybookCount:       types.Int64Value(1),
+			Playbooks:           types.MapNull(types.StringType),
+			RecipeStatus:        types.MapNull(types.StringType),
+			ResolvedRecipeNames: types.ListNull(types.StringType),
 		})
 	default:
 		t.Fatalf("unsupported resource type: %T", r)
@@ -461,6 +479,9 @@ func testCreateOutputPathErrorHelper(t *testing.T, r resource.Resource, filePath
 	if !resp.Diagnostics.HasError() {
 		t.Fatalf("expected diagnostics for %T output path error", r)
 	}
+	if want := "output_path must be a directory, but " + filePath + " is a file"; resp.Diagnostics.Errors()[0].Detail() != want {
+		t.Fatalf("expected %q for %T, got %v", want, r, resp.Diagnostics)
+	}
 }
 
 func TestCreateOutputPathError(t *testing.T) {
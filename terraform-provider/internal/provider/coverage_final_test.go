@@ -33,10 +33,12 @@ func testReadOnlyDeleteHelper(t *testing.T, r resource.Resource, filePath, fileC
 			RecipeNames: []types.String{
 				types.StringValue("readonly"),
 			},
-			OutputPath:    types.StringValue(outputDir),
-			CookbookName:  types.StringValue("test"),
-			PlaybookCount: types.Int64Value(1),
-			Playbooks:     emptyPlaybooks,
+			OutputPath:          types.StringValue(outputDir),
+			CookbookName:        types.StringValue("test"),
+			PlaybookCount:       types.Int64Value(1),
+			Playbooks:           emptyPlaybooks,
+			RecipeStatus:        types.MapNull(types.StringType),
+			ResolvedRecipeNames: types.ListNull(types.StringType),
 		})
 	case *habitatMigrationResource:
 		state = newState(t, schema, habitatMigrationResourceModel{
@@ -45,9 +47,10 @@ func testReadOnlyDeleteHelper(t *testing.T, r resource.Resource, filePath, fileC
 		})
 	case *inspecMigrationResource:
 		state = newState(t, schema, inspecMigrationResourceModel{
-			ProfilePath:  types.StringValue("/tmp/profile"),
-			OutputPath:   types.StringValue(outputDir),
-			OutputFormat: types.StringValue("testinfra"),
+			ProfilePath:       types.StringValue("/tmp/profile"),
+			OutputPath:        types.StringValue(outputDir),
+			OutputFormat:      types.StringValue("testinfra"),
+			ConvertedControls: types.ListNull(types.StringType),
 		})
 	}
 
@@ -74,8 +77,13 @@ func TestMigrationDeleteWithDirectory(t *testing.T) {
 	}
 
 	state := newState(t, schema, migrationResourceModel{
-		RecipeName: types.StringValue("dir_recipe"),
-		OutputPath: types.StringValue(outputDir),
+		RecipeName:        types.StringValue("dir_recipe"),
+		OutputPath:        types.StringValue(outputDir),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
 	})
 
 	deleteResp := &resource.DeleteResponse{}
@@ -118,10 +126,12 @@ func TestBatchMigrationCreateAndReadWithMultipleRecipes(t *testing.T) {
 			types.StringValue("install"),
 			types.StringValue("configure"),
 		},
-		ID:            types.StringNull(),
-		CookbookName:  types.StringNull(),
-		PlaybookCount: types.Int64Null(),
-		Playbooks:     types.MapNull(types.StringType),
+		ID:                  types.StringNull(),
+		CookbookName:        types.StringNull(),
+		PlaybookCount:       types.Int64Null(),
+		Playbooks:           types.MapNull(types.StringType),
+		RecipeStatus:        types.MapNull(types.StringType),
+		ResolvedRecipeNames: types.ListNull(types.StringType),
 	})
 
 	testResourceCreatePhase(t, r, schema, plan)
@@ -168,12 +178,13 @@ func TestInSpecMigrationWithAllFormats(t *testing.T) {
 	for _, format := range formats {
 		outputDir := t.TempDir()
 		plan := newPlan(t, schema, inspecMigrationResourceModel{
-			ProfilePath:  types.StringValue("/tmp/profile"),
-			OutputPath:   types.StringValue(outputDir),
-			OutputFormat: types.StringValue(format),
-			ID:           types.StringNull(),
-			ProfileName:  types.StringNull(),
-			TestContent:  types.StringNull(),
+			ProfilePath:       types.StringValue("/tmp/profile"),
+			OutputPath:        types.StringValue(outputDir),
+			OutputFormat:      types.StringValue(format),
+			ID:                types.StringNull(),
+			ProfileName:       types.StringNull(),
+			TestContent:       types.StringNull(),
+			ConvertedControls: types.ListNull(types.StringType),
 		})
 
 		testResourceCreatePhase(t, r, schema, plan)
@@ -114,7 +114,7 @@ func TestProviderConfigureUnknownPath(t *testing.T) {
 	p := &SousChefProvider{}
 	schema := newProviderSchema(t, p)
 
-	config := newProviderConfig(t, schema, SousChefProviderModel{SousChefPath: types.StringUnknown()})
+	config := newProviderConfig(t, schema, SousChefProviderModel{SousChefPath: types.StringUnknown(), CLIEnv: types.MapNull(types.StringType), CommandOverrides: types.MapNull(types.StringType), RetryableErrors: types.ListNull(types.StringType)})
 	resp := &provider.ConfigureResponse{}
 
 	p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
@@ -128,7 +128,7 @@ func TestProviderConfigureDefaultsAndCustomPath(t *testing.T) {
 	p := &SousChefProvider{}
 	schema := newProviderSchema(t, p)
 
-	defaultConfig := newProviderConfig(t, schema, SousChefProviderModel{SousChefPath: types.StringNull()})
+	defaultConfig := newProviderConfig(t, schema, SousChefProviderModel{SousChefPath: types.StringNull(), CLIEnv: types.MapNull(types.StringType), CommandOverrides: types.MapNull(types.StringType), RetryableErrors: types.ListNull(types.StringType)})
 	defaultResp := &provider.ConfigureResponse{}
 	p.Configure(context.Background(), provider.ConfigureRequest{Config: defaultConfig}, defaultResp)
 
@@ -141,7 +141,7 @@ func TestProviderConfigureDefaultsAndCustomPath(t *testing.T) {
 		t.Fatalf("expected default souschef path, got %#v", defaultResp.ResourceData)
 	}
 
-	customConfig := newProviderConfig(t, schema, SousChefProviderModel{SousChefPath: types.StringValue("/custom/souschef")})
+	customConfig := newProviderConfig(t, schema, SousChefProviderModel{SousChefPath: types.StringValue("/custom/souschef"), CLIEnv: types.MapNull(types.StringType), CommandOverrides: types.MapNull(types.StringType), RetryableErrors: types.ListNull(types.StringType)})
 	customResp := &provider.ConfigureResponse{}
 	p.Configure(context.Background(), provider.ConfigureRequest{Config: customConfig}, customResp)
 
@@ -155,7 +155,7 @@ func TestAssessmentDataSourceRead(t *testing.T) {
 	ds := &assessmentDataSource{client: &SousChefClient{Path: newFakeSousChef(t)}}
 	schema := newDataSourceSchema(t, ds)
 
-	config := newDataSourceConfig(t, schema, assessmentDataSourceModel{CookbookPath: types.StringValue(testTmpCookbook)})
+	config := newDataSourceConfig(t, schema, assessmentDataSourceModel{CookbookPath: types.StringValue(testTmpCookbook), RecommendationsList: types.ListNull(types.StringType), ManualReviewRequired: types.ListNull(types.StringType), Timeouts: types.ObjectNull(readTimeoutsAttrTypes)})
 	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
 
 	ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
@@ -178,7 +178,7 @@ func TestAssessmentDataSourceReadErrors(t *testing.T) {
 	schema := newDataSourceSchema(t, ds)
 
 	t.Setenv("SOUSCHEF_TEST_FAIL", "assess-cookbook")
-	config := newDataSourceConfig(t, schema, assessmentDataSourceModel{CookbookPath: types.StringValue(testTmpCookbook)})
+	config := newDataSourceConfig(t, schema, assessmentDataSourceModel{CookbookPath: types.StringValue(testTmpCookbook), RecommendationsList: types.ListNull(types.StringType), ManualReviewRequired: types.ListNull(types.StringType), Timeouts: types.ObjectNull(readTimeoutsAttrTypes)})
 	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
 	ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
 	if !resp.Diagnostics.HasError() {
@@ -202,6 +202,7 @@ func TestCostEstimateDataSourceRead(t *testing.T) {
 		CookbookPath:        types.StringValue(testTmpCookbook),
 		DeveloperHourlyRate: types.Float64Value(200),
 		InfrastructureCost:  types.Float64Value(1000),
+		Timeouts:            types.ObjectNull(readTimeoutsAttrTypes),
 	})
 	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
 
@@ -218,9 +219,14 @@ func TestMigrationResourceUpdateAndRead(t *testing.T) {
 
 	outputDir := t.TempDir()
 	plan := newPlan(t, schema, migrationResourceModel{
-		CookbookPath: types.StringValue(testTmpCookbook),
-		OutputPath:   types.StringValue(outputDir),
-		RecipeName:   types.StringValue("default"),
+		CookbookPath:      types.StringValue(testTmpCookbook),
+		OutputPath:        types.StringValue(outputDir),
+		RecipeName:        types.StringValue("default"),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
 	})
 	updateResp := &resource.UpdateResponse{State: tfsdk.State{Schema: schema}}
 
@@ -230,8 +236,13 @@ func TestMigrationResourceUpdateAndRead(t *testing.T) {
 	}
 
 	state := newState(t, schema, migrationResourceModel{
-		RecipeName: types.StringValue("default"),
-		OutputPath: types.StringValue(outputDir),
+		RecipeName:        types.StringValue("default"),
+		OutputPath:        types.StringValue(outputDir),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
 	})
 	readResp := &resource.ReadResponse{State: tfsdk.State{Schema: schema}}
 	r.Read(context.Background(), resource.ReadRequest{State: state}, readResp)
@@ -246,9 +257,14 @@ func TestMigrationResourceUpdateErrors(t *testing.T) {
 
 	outputDir := t.TempDir()
 	plan := newPlan(t, schema, migrationResourceModel{
-		CookbookPath: types.StringValue(testTmpCookbook),
-		OutputPath:   types.StringValue(outputDir),
-		RecipeName:   types.StringValue("default"),
+		CookbookPath:      types.StringValue(testTmpCookbook),
+		OutputPath:        types.StringValue(outputDir),
+		RecipeName:        types.StringValue("default"),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
 	})
 
 	t.Setenv("SOUSCHEF_TEST_FAIL", testConvertRecipe)
@@ -273,9 +289,14 @@ func TestMigrationResourceCreateSuccess(t *testing.T) {
 
 	outputDir := t.TempDir()
 	plan := newPlan(t, schema, migrationResourceModel{
-		CookbookPath: types.StringValue(testTmpCookbook),
-		OutputPath:   types.StringValue(outputDir),
-		RecipeName:   types.StringValue("myrecipe"),
+		CookbookPath:      types.StringValue(testTmpCookbook),
+		OutputPath:        types.StringValue(outputDir),
+		RecipeName:        types.StringValue("myrecipe"),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
 	})
 
 	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
@@ -293,9 +314,14 @@ func TestMigrationResourceCreateSuccess(t *testing.T) {
 	// Test with null recipe name (should default to "default")
 	outputDir2 := t.TempDir()
 	plan2 := newPlan(t, schema, migrationResourceModel{
-		CookbookPath: types.StringValue(testTmpCookbook),
-		OutputPath:   types.StringValue(outputDir2),
-		RecipeName:   types.StringNull(),
+		CookbookPath:      types.StringValue(testTmpCookbook),
+		OutputPath:        types.StringValue(outputDir2),
+		RecipeName:        types.StringNull(),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
 	})
 
 	createResp2 := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
@@ -316,9 +342,14 @@ func TestMigrationResourceCreateErrors(t *testing.T) {
 	schema := newResourceSchema(t, r)
 
 	plan := newPlan(t, schema, migrationResourceModel{
-		CookbookPath: types.StringValue(testTmpCookbook),
-		OutputPath:   types.StringValue(t.TempDir()),
-		RecipeName:   types.StringNull(),
+		CookbookPath:      types.StringValue(testTmpCookbook),
+		OutputPath:        types.StringValue(t.TempDir()),
+		RecipeName:        types.StringNull(),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
 	})
 
 	t.Setenv("SOUSCHEF_TEST_FAIL", testConvertRecipe)
@@ -348,8 +379,13 @@ func TestMigrationResourceReadAndDeleteErrors(t *testing.T) {
 	}
 
 	state := newState(t, schema, migrationResourceModel{
-		RecipeName: types.StringValue("default"),
-		OutputPath: types.StringValue(outputDir),
+		RecipeName:        types.StringValue("default"),
+		OutputPath:        types.StringValue(outputDir),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
 	})
 
 	readResp := &resource.ReadResponse{State: tfsdk.State{Schema: schema}}
@@ -370,8 +406,13 @@ func TestMigrationResourceReadAndDeleteErrors(t *testing.T) {
 	}
 
 	dirState := newState(t, schema, migrationResourceModel{
-		RecipeName: types.StringValue("dir"),
-		OutputPath: types.StringValue(outputDir),
+		RecipeName:        types.StringValue("dir"),
+		OutputPath:        types.StringValue(outputDir),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
 	})
 	deleteResp := &resource.DeleteResponse{}
 	r.Delete(context.Background(), resource.DeleteRequest{State: dirState}, deleteResp)
@@ -385,8 +426,13 @@ func TestMigrationResourceReadRemovesMissingPlaybook(t *testing.T) {
 	schema := newResourceSchema(t, r)
 
 	state := newState(t, schema, migrationResourceModel{
-		RecipeName: types.StringValue("default"),
-		OutputPath: types.StringValue(t.TempDir()),
+		RecipeName:        types.StringValue("default"),
+		OutputPath:        types.StringValue(t.TempDir()),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
 	})
 	readResp := &resource.ReadResponse{State: tfsdk.State{Schema: schema}}
 
@@ -454,8 +500,9 @@ func TestDeleteOperationsWithWarnings(t *testing.T) {
 	}
 
 	inspecState := newState(t, inspecSchema, inspecMigrationResourceModel{
-		OutputPath:   types.StringValue(outputDir),
-		OutputFormat: types.StringValue("testinfra"),
+		OutputPath:        types.StringValue(outputDir),
+		OutputFormat:      types.StringValue("testinfra"),
+		ConvertedControls: types.ListNull(types.StringType),
 	})
 	inspecDeleteResp := &resource.DeleteResponse{}
 	inspecR.Delete(context.Background(), resource.DeleteRequest{State: inspecState}, inspecDeleteResp)
@@ -480,8 +527,13 @@ func TestMigrationDeleteSuccess(t *testing.T) {
 	}
 
 	state := newState(t, schema, migrationResourceModel{
-		RecipeName: types.StringValue("success"),
-		OutputPath: types.StringValue(outputDir),
+		RecipeName:        types.StringValue("success"),
+		OutputPath:        types.StringValue(outputDir),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
 	})
 
 	deleteResp := &resource.DeleteResponse{}
@@ -521,10 +573,12 @@ func TestBatchMigrationDeleteSuccess(t *testing.T) {
 			types.StringValue("default"),
 			types.StringValue("install"),
 		},
-		OutputPath:    types.StringValue(outputDir),
-		CookbookName:  types.StringValue("test"),
-		PlaybookCount: types.Int64Value(2),
-		Playbooks:     emptyPlaybooks,
+		OutputPath:          types.StringValue(outputDir),
+		CookbookName:        types.StringValue("test"),
+		PlaybookCount:       types.Int64Value(2),
+		Playbooks:           emptyPlaybooks,
+		RecipeStatus:        types.MapNull(types.StringType),
+		ResolvedRecipeNames: types.ListNull(types.StringType),
 	})
 
 	deleteResp := &resource.DeleteResponse{}
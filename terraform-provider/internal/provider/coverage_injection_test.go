@@ -35,10 +35,12 @@ func TestBatchMapValueFromErrors(t *testing.T) {
 		RecipeNames: []types.String{
 			types.StringValue("default"),
 		},
-		OutputPath:    types.StringValue(outputDir),
-		CookbookName:  types.StringValue("test"),
-		PlaybookCount: types.Int64Value(1),
-		Playbooks:     types.MapNull(types.StringType),
+		OutputPath:          types.StringValue(outputDir),
+		CookbookName:        types.StringValue("test"),
+		PlaybookCount:       types.Int64Value(1),
+		Playbooks:           types.MapNull(types.StringType),
+		RecipeStatus:        types.MapNull(types.StringType),
+		ResolvedRecipeNames: types.ListNull(types.StringType),
 	})
 
 	// Test operations that encounter map conversion errors
@@ -88,19 +90,21 @@ func createDeleteTestStateForResource(t *testing.T, r resource.Resource, outputD
 	schema := newResourceSchema(t, r)
 	switch r.(type) {
 	case *migrationResource:
-		return newState(t, schema, migrationResourceModel{RecipeName: types.StringValue("test"), OutputPath: types.StringValue(outputDir)})
+		return newState(t, schema, migrationResourceModel{RecipeName: types.StringValue("test"), OutputPath: types.StringValue(outputDir), ReferencedEnvVars: types.ListNull(types.StringType), ModuleCounts: types.MapNull(types.Int64Type), VariableRenameMap: types.MapNull(types.StringType), VariableMappings: types.MapNull(types.StringType), Outputs: types.MapNull(types.StringType)})
 	case *habitatMigrationResource:
 		return newState(t, schema, habitatMigrationResourceModel{PlanPath: types.StringValue("/tmp/plan.sh"), OutputPath: types.StringValue(outputDir)})
 	case *inspecMigrationResource:
-		return newState(t, schema, inspecMigrationResourceModel{ProfilePath: types.StringValue("/tmp/profile"), OutputPath: types.StringValue(outputDir), OutputFormat: types.StringValue("testinfra")})
+		return newState(t, schema, inspecMigrationResourceModel{ProfilePath: types.StringValue("/tmp/profile"), OutputPath: types.StringValue(outputDir), OutputFormat: types.StringValue("testinfra"), ConvertedControls: types.ListNull(types.StringType)})
 	case *batchMigrationResource:
 		return newState(t, schema, batchMigrationResourceModel{
-			ID:            types.StringValue("batch"),
-			RecipeNames:   []types.String{types.StringValue("default")},
-			OutputPath:    types.StringValue(outputDir),
-			CookbookName:  types.StringValue("test"),
-			PlaybookCount: types.Int64Value(1),
-			Playbooks:     types.MapNull(types.StringType),
+			ID:                  types.StringValue("batch"),
+			RecipeNames:         []types.String{types.StringValue("default")},
+			OutputPath:          types.StringValue(outputDir),
+			CookbookName:        types.StringValue("test"),
+			PlaybookCount:       types.Int64Value(1),
+			Playbooks:           types.MapNull(types.StringType),
+			RecipeStatus:        types.MapNull(types.StringType),
+			ResolvedRecipeNames: types.ListNull(types.StringType),
 		})
 	}
 	return tfsdk.State{}
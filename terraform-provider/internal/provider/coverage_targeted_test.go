@@ -18,28 +18,91 @@ const (
 
 var (
 	migrationAttributeTypes = map[string]tftypes.Type{
-		"id":               tftypes.String,
-		"cookbook_path":    tftypes.String,
-		"output_path":      tftypes.String,
-		"recipe_name":      tftypes.String,
-		"cookbook_name":    tftypes.String,
-		"playbook_content": tftypes.String,
+		"id":                         tftypes.String,
+		"cookbook_path":              tftypes.String,
+		"output_path":                tftypes.String,
+		"resolved_output_path":       tftypes.String,
+		"create_output_dir":          tftypes.Bool,
+		"prune_empty_dir":            tftypes.Bool,
+		"retain_on_delete":           tftypes.Bool,
+		"recipe_name":                tftypes.String,
+		"recipes_subdir":             tftypes.String,
+		"ansible_version":            tftypes.String,
+		"output_syntax":              tftypes.String,
+		"output_extension":           tftypes.String,
+		"cookbook_name":              tftypes.String,
+		"playbook_content":           tftypes.String,
+		"playbook_content_sensitive": tftypes.String,
+		"playbook_content_base64":    tftypes.String,
+		"content_truncated":          tftypes.Bool,
+		"was_changed":                tftypes.Bool,
+		"output_file_mode":           tftypes.String,
+		"applied_file_mode":          tftypes.String,
+		"add_header":                 tftypes.Bool,
+		"header_template":            tftypes.String,
+		"output_targets": tftypes.List{
+			ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+				"layout":      tftypes.String,
+				"output_path": tftypes.String,
+			}},
+		},
+		"outputs": tftypes.Map{
+			ElementType: tftypes.String,
+		},
+		"capture_output": tftypes.Bool,
+		"conversion_log": tftypes.String,
+		"referenced_env_vars": tftypes.List{
+			ElementType: tftypes.String,
+		},
+		"block_destructive": tftypes.Bool,
+		"destructive_patterns": tftypes.List{
+			ElementType: tftypes.String,
+		},
+		"output_layout":        tftypes.String,
+		"role_layout_template": tftypes.String,
+		"role_path":            tftypes.String,
+		"module_counts": tftypes.Map{
+			ElementType: tftypes.Number,
+		},
+		"git_url":    tftypes.String,
+		"git_ref":    tftypes.String,
+		"git_commit": tftypes.String,
+		"variable_rename_map": tftypes.Map{
+			ElementType: tftypes.String,
+		},
+		"variable_mappings": tftypes.Map{
+			ElementType: tftypes.String,
+		},
+		"content_sha256": tftypes.String,
+		"source_hash":    tftypes.String,
 	}
 	habitatAttributeTypes = map[string]tftypes.Type{
-		"id":                 tftypes.String,
-		"plan_path":          tftypes.String,
-		"output_path":        tftypes.String,
-		"base_image":         tftypes.String,
-		"package_name":       tftypes.String,
-		"dockerfile_content": tftypes.String,
+		"id":                   tftypes.String,
+		"plan_path":            tftypes.String,
+		"output_path":          tftypes.String,
+		"resolved_output_path": tftypes.String,
+		"create_output_dir":    tftypes.Bool,
+		"prune_empty_dir":      tftypes.Bool,
+		"retain_on_delete":     tftypes.Bool,
+		"base_image":           tftypes.String,
+		"package_name":         tftypes.String,
+		"dockerfile_content":   tftypes.String,
 	}
 	inspecAttributeTypes = map[string]tftypes.Type{
-		"id":            tftypes.String,
-		"profile_path":  tftypes.String,
-		"output_path":   tftypes.String,
-		"output_format": tftypes.String,
-		"profile_name":  tftypes.String,
-		"test_content":  tftypes.String,
+		"id":                   tftypes.String,
+		"profile_path":         tftypes.String,
+		"output_path":          tftypes.String,
+		"resolved_output_path": tftypes.String,
+		"create_output_dir":    tftypes.Bool,
+		"prune_empty_dir":      tftypes.Bool,
+		"retain_on_delete":     tftypes.Bool,
+		"output_format":        tftypes.String,
+		"profile_name":         tftypes.String,
+		"test_content":         tftypes.String,
+		"output_filename":      tftypes.String,
+		"converted_controls": tftypes.List{
+			ElementType: tftypes.String,
+		},
 	}
 )
 
@@ -97,37 +160,48 @@ func runDelete(t *testing.T, r DeleteResource, state tfsdk.State) *resource.Dele
 	return resp
 }
 
+// nullFilledValues sets every attribute in attributeTypes that is missing from
+// values to null, so raw test objects stay valid as schemas grow.
+func nullFilledValues(attributeTypes map[string]tftypes.Type, values map[string]tftypes.Value) map[string]tftypes.Value {
+	for name, attrType := range attributeTypes {
+		if _, ok := values[name]; !ok {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+	return values
+}
+
 func migrationValues(outputPath, playbookContent string) map[string]tftypes.Value {
-	return map[string]tftypes.Value{
+	return nullFilledValues(migrationAttributeTypes, map[string]tftypes.Value{
 		"id":               tftypes.NewValue(tftypes.String, "cookbook-default"),
 		"cookbook_path":    tftypes.NewValue(tftypes.String, "/tmp/cookbook"),
 		"output_path":      tftypes.NewValue(tftypes.String, outputPath),
 		"recipe_name":      tftypes.NewValue(tftypes.String, "default"),
 		"cookbook_name":    tftypes.NewValue(tftypes.String, "cookbook"),
 		"playbook_content": tftypes.NewValue(tftypes.String, playbookContent),
-	}
+	})
 }
 
 func habitatValues(outputPath, dockerfileContent string) map[string]tftypes.Value {
-	return map[string]tftypes.Value{
+	return nullFilledValues(habitatAttributeTypes, map[string]tftypes.Value{
 		"id":                 tftypes.NewValue(tftypes.String, "habitat-test"),
 		"plan_path":          tftypes.NewValue(tftypes.String, "/tmp/plan.sh"),
 		"output_path":        tftypes.NewValue(tftypes.String, outputPath),
 		"base_image":         tftypes.NewValue(tftypes.String, "ubuntu:latest"),
 		"package_name":       tftypes.NewValue(tftypes.String, "test"),
 		"dockerfile_content": tftypes.NewValue(tftypes.String, dockerfileContent),
-	}
+	})
 }
 
 func inspecValues(outputPath, profileName, testContent string) map[string]tftypes.Value {
-	return map[string]tftypes.Value{
+	return nullFilledValues(inspecAttributeTypes, map[string]tftypes.Value{
 		"id":            tftypes.NewValue(tftypes.String, "inspec-test"),
 		"profile_path":  tftypes.NewValue(tftypes.String, "/tmp/profile"),
 		"output_path":   tftypes.NewValue(tftypes.String, outputPath),
 		"output_format": tftypes.NewValue(tftypes.String, "testinfra"),
 		"profile_name":  tftypes.NewValue(tftypes.String, profileName),
 		"test_content":  tftypes.NewValue(tftypes.String, testContent),
-	}
+	})
 }
 
 // TestMigrationDeletePlaceholderFile tests delete when trying to delete file in restricted directory
@@ -5,11 +5,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces
@@ -30,13 +32,134 @@ type assessmentDataSource struct {
 
 // assessmentDataSourceModel maps the data source schema data.
 type assessmentDataSourceModel struct {
-	ID              types.String  `tfsdk:"id"`
-	CookbookPath    types.String  `tfsdk:"cookbook_path"`
-	Complexity      types.String  `tfsdk:"complexity"`
-	RecipeCount     types.Int64   `tfsdk:"recipe_count"`
-	ResourceCount   types.Int64   `tfsdk:"resource_count"`
-	EstimatedHours  types.Float64 `tfsdk:"estimated_hours"`
-	Recommendations types.String  `tfsdk:"recommendations"`
+	ID                   types.String  `tfsdk:"id"`
+	CookbookPath         types.String  `tfsdk:"cookbook_path"`
+	Complexity           types.String  `tfsdk:"complexity"`
+	RecipeCount          types.Int64   `tfsdk:"recipe_count"`
+	ResourceCount        types.Int64   `tfsdk:"resource_count"`
+	EstimatedHours       types.Float64 `tfsdk:"estimated_hours"`
+	Recommendations      types.String  `tfsdk:"recommendations"`
+	RecommendationsList  types.List    `tfsdk:"recommendations_list"`
+	ManualReviewRequired types.List    `tfsdk:"manual_review_required"`
+	OutputPath           types.String  `tfsdk:"output_path"`
+	LikelyMigrated       types.Bool    `tfsdk:"likely_migrated"`
+	Timeouts             types.Object  `tfsdk:"timeouts"`
+}
+
+// cookbookAssessment is the JSON output of the assess-cookbook command.
+type cookbookAssessment struct {
+	Complexity           string   `json:"complexity"`
+	RecipeCount          int64    `json:"recipe_count"`
+	ResourceCount        int64    `json:"resource_count"`
+	UnsupportedCount     int64    `json:"unsupported_count"`
+	EstimatedHours       float64  `json:"estimated_hours"`
+	Recommendations      string   `json:"recommendations"`
+	RecommendationsList  []string `json:"recommendations_list"`
+	ManualReviewRequired []string `json:"manual_review_required"`
+}
+
+// parseCookbookAssessment parses the JSON output of the assess-cookbook command.
+func parseCookbookAssessment(output []byte) (cookbookAssessment, error) {
+	var assessment cookbookAssessment
+	err := json.Unmarshal(output, &assessment)
+	return assessment, err
+}
+
+// assessCookbook runs the assess-cookbook command and parses its result,
+// adding an error diagnostic and returning false when either fails.
+func assessCookbook(ctx context.Context, client *SousChefClient, cookbookPath string, diagnostics *diag.Diagnostics) (cookbookAssessment, bool) {
+	args := []string{"assess-cookbook", "--cookbook-path", cookbookPath, "--format", "json"}
+	output, ok := executeSousChefCommand(ctx, client, args, diagnostics)
+	if !ok {
+		return cookbookAssessment{}, false
+	}
+
+	assessment, err := parseCookbookAssessment(output)
+	if err != nil {
+		diagnostics.AddError(
+			"Error parsing assessment",
+			fmt.Sprintf("Could not parse JSON output: %s", err),
+		)
+		return cookbookAssessment{}, false
+	}
+	return assessment, true
+}
+
+// recommendationsList returns the structured recommendations_list when the CLI
+// provides it (older SousChef releases do not), otherwise one entry per non-empty line of the recommendations
+// text with any leading list marker removed.
+func recommendationsList(assessment cookbookAssessment) []string {
+	if assessment.RecommendationsList != nil {
+		return assessment.RecommendationsList
+	}
+
+	recommendations := make([]string, 0)
+	for _, line := range strings.Split(assessment.Recommendations, "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "-*•"))
+		if line != "" {
+			recommendations = append(recommendations, line)
+		}
+	}
+	return recommendations
+}
+
+// manualReviewDirs are the cookbook directories holding custom resources and
+// library code, which SousChef cannot convert automatically.
+var manualReviewDirs = []string{"libraries", "resources"}
+
+// manualReviewFiles returns the sorted, cookbook-relative paths of the Ruby
+// files in the cookbook's manualReviewDirs. Missing directories are skipped.
+func manualReviewFiles(cookbookPath string) ([]string, error) {
+	files := make([]string, 0)
+	for _, dir := range manualReviewDirs {
+		names, err := listCopiedFiles(filepath.Join(cookbookPath, dir))
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if filepath.Ext(name) == ".rb" {
+				files = append(files, dir+"/"+name)
+			}
+		}
+	}
+	return files, nil
+}
+
+// mergeManualReview adds the files the CLI flagged for manual review to the
+// scanned files, returning a sorted list without duplicates.
+func mergeManualReview(scanned, flagged []string) []string {
+	seen := make(map[string]string, len(scanned)+len(flagged))
+	for _, files := range [][]string{scanned, flagged} {
+		for _, file := range files {
+			seen[filepath.ToSlash(file)] = ""
+		}
+	}
+	return sortedKeys(seen)
+}
+
+// likelyMigrated reports whether outputPath holds a generated playbook, in
+// any of the syntaxes the migration resource writes, for every recipe in the
+// cookbook. A cookbook without recipes or a missing output directory is
+// reported as not migrated.
+func likelyMigrated(cookbookPath, outputPath string) bool {
+	recipeNames, err := listCookbookRecipes(cookbookPath)
+	if err != nil || len(recipeNames) == 0 {
+		return false
+	}
+
+	for _, recipeName := range recipeNames {
+		found := false
+		for _, extension := range []string{playbookExtensionYML, playbookExtensionYAML, outputSyntaxJSON} {
+			if _, err := osStat(filepath.Join(outputPath, recipeName+"."+extension)); err == nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
 }
 
 // Metadata returns the data source type name.
@@ -77,6 +200,25 @@ func (d *assessmentDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 				Description: "Migration recommendations and best practices.",
 				Computed:    true,
 			},
+			"recommendations_list": schema.ListAttribute{
+				Description: "Migration recommendations as a list, one recommendation per element.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"manual_review_required": schema.ListAttribute{
+				Description: "Cookbook-relative paths of custom resources and libraries (resources/*.rb, libraries/*.rb) that need manual conversion, plus any files the CLI flags.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"output_path": schema.StringAttribute{
+				Description: "Directory a previous migration of the cookbook wrote its playbooks to, resolved like a migration's output_path. Used only to compute likely_migrated.",
+				Optional:    true,
+			},
+			"likely_migrated": schema.BoolAttribute{
+				Description: "Whether output_path already holds a playbook for every recipe in the cookbook, suggesting it was migrated before. Always false when output_path is unset.",
+				Computed:    true,
+			},
+			"timeouts": readTimeoutsAttribute(),
 		},
 	}
 }
@@ -111,35 +253,23 @@ func (d *assessmentDataSource) Read(ctx context.Context, req datasource.ReadRequ
 
 	cookbookPath := config.CookbookPath.ValueString()
 
-	// Call souschef CLI to assess cookbook
-	cmd := execCommandContext(ctx, d.client.Path, "assess-cookbook",
-		"--cookbook-path", cookbookPath,
-		"--format", "json",
-	)
-
-	tflog.Debug(ctx, "Executing SousChef assessment", map[string]interface{}{
-		"command": cmd.String(),
-	})
+	timeout := readTimeout(ctx, config.Timeouts, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	readCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	output, err := cmd.CombinedOutput()
+	// Call souschef CLI to assess cookbook
+	output, err := runCLI(readCtx, d.client, &resp.Diagnostics, "assess-cookbook", "--cookbook-path", cookbookPath, "--format", "json")
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error assessing cookbook",
-			fmt.Sprintf("Could not assess cookbook: %s\n%s", err, string(output)),
-		)
+		resp.Diagnostics.Append(readCLIDiagnostic(readCtx, err, timeout))
 		return
 	}
 
 	// Parse JSON output
-	var assessment struct {
-		Complexity      string  `json:"complexity"`
-		RecipeCount     int64   `json:"recipe_count"`
-		ResourceCount   int64   `json:"resource_count"`
-		EstimatedHours  float64 `json:"estimated_hours"`
-		Recommendations string  `json:"recommendations"`
-	}
-
-	if err := json.Unmarshal(output, &assessment); err != nil {
+	assessment, err := parseCookbookAssessment(output)
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error parsing assessment",
 			fmt.Sprintf("Could not parse JSON output: %s", err),
@@ -147,6 +277,17 @@ func (d *assessmentDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
+	// Custom resources and libraries are found by scanning the cookbook, so
+	// they are reported even by CLIs that do not flag them
+	reviewFiles, err := manualReviewFiles(cookbookPath)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error scanning cookbook",
+			fmt.Sprintf("Could not list custom resources and libraries in %s: %s", cookbookPath, err),
+		)
+		return
+	}
+
 	// Set state
 	config.ID = types.StringValue(cookbookPath)
 	config.Complexity = types.StringValue(assessment.Complexity)
@@ -154,6 +295,9 @@ func (d *assessmentDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	config.ResourceCount = types.Int64Value(assessment.ResourceCount)
 	config.EstimatedHours = types.Float64Value(assessment.EstimatedHours)
 	config.Recommendations = types.StringValue(assessment.Recommendations)
+	config.RecommendationsList = typesListFromStringSlice(recommendationsList(assessment))
+	config.ManualReviewRequired = typesListFromStringSlice(mergeManualReview(reviewFiles, assessment.ManualReviewRequired))
+	config.LikelyMigrated = types.BoolValue(!config.OutputPath.IsNull() && likelyMigrated(cookbookPath, d.client.resolveOutputPath(config.OutputPath.ValueString())))
 
 	diags = resp.State.Set(ctx, &config)
 	resp.Diagnostics.Append(diags...)
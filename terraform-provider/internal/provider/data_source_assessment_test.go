@@ -1,9 +1,16 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -28,6 +35,7 @@ func TestAccAssessmentDataSource(t *testing.T) {
 					resource.TestCheckResourceAttrSet(testAssessmentResourceName, "resource_count"),
 					resource.TestCheckResourceAttrSet(testAssessmentResourceName, "estimated_hours"),
 					resource.TestCheckResourceAttrSet(testAssessmentResourceName, "recommendations"),
+					resource.TestCheckResourceAttrSet(testAssessmentResourceName, "recommendations_list.#"),
 				),
 			},
 		},
@@ -49,3 +57,292 @@ data "souschef_assessment" "test" {
 }
 `, cookbookPath)
 }
+
+func TestRecommendationsList(t *testing.T) {
+	tests := map[string]struct {
+		assessment cookbookAssessment
+		want       []string
+	}{
+		"structured": {
+			assessment: cookbookAssessment{Recommendations: "ignored", RecommendationsList: []string{"Use handlers", "Pin versions"}},
+			want:       []string{"Use handlers", "Pin versions"},
+		},
+		"bulleted text": {
+			assessment: cookbookAssessment{Recommendations: "- Use handlers\n\n* Pin versions\n"},
+			want:       []string{"Use handlers", "Pin versions"},
+		},
+		"single line": {
+			assessment: cookbookAssessment{Recommendations: "ok"},
+			want:       []string{"ok"},
+		},
+		"empty": {
+			assessment: cookbookAssessment{},
+			want:       []string{},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			verifyStringSliceResult(t, recommendationsList(tt.assessment), tt.want)
+		})
+	}
+}
+
+func TestAssessmentDataSourceReadRecommendationsList(t *testing.T) {
+	tests := map[string]struct {
+		assessment string
+		wantText   string
+		wantList   []string
+	}{
+		"structured": {
+			assessment: `{"complexity":"Medium","recommendations":"Use handlers. Pin versions.","recommendations_list":["Use handlers","Pin versions"]}`,
+			wantText:   "Use handlers. Pin versions.",
+			wantList:   []string{"Use handlers", "Pin versions"},
+		},
+		"text only": {
+			assessment: `{"complexity":"Medium","recommendations":"- Use handlers\n- Pin versions"}`,
+			wantText:   "- Use handlers\n- Pin versions",
+			wantList:   []string{"Use handlers", "Pin versions"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cookbookPath := t.TempDir()
+			if err := os.WriteFile(filepath.Join(cookbookPath, "assessment.json"), []byte(tt.assessment), testFilePermissions); err != nil {
+				t.Fatalf(testFailedToWriteFile, err)
+			}
+
+			ds := &assessmentDataSource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+			schema := newDataSourceSchema(t, ds)
+			config := newDataSourceConfig(t, schema, assessmentDataSourceModel{
+				CookbookPath:         types.StringValue(cookbookPath),
+				RecommendationsList:  types.ListNull(types.StringType),
+				ManualReviewRequired: types.ListNull(types.StringType),
+				Timeouts:             types.ObjectNull(readTimeoutsAttrTypes),
+			})
+			resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+			ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+			}
+
+			var state assessmentDataSourceModel
+			if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+				t.Fatalf("failed to read state: %v", diags)
+			}
+			if state.Recommendations.ValueString() != tt.wantText {
+				t.Fatalf("expected recommendations %q, got %q", tt.wantText, state.Recommendations.ValueString())
+			}
+			var got []string
+			state.RecommendationsList.ElementsAs(context.Background(), &got, false)
+			verifyStringSliceResult(t, got, tt.wantList)
+		})
+	}
+}
+
+// readTimeoutsValue returns a timeouts object with the given read duration.
+func readTimeoutsValue(t *testing.T, read string) types.Object {
+	t.Helper()
+
+	value, diags := types.ObjectValue(readTimeoutsAttrTypes, map[string]attr.Value{"read": types.StringValue(read)})
+	if diags.HasError() {
+		t.Fatalf("failed to build timeouts: %v", diags)
+	}
+	return value
+}
+
+func TestAssessmentDataSourceReadTimeout(t *testing.T) {
+	t.Setenv("SOUSCHEF_TEST_SLEEP", "0.2")
+
+	tests := map[string]struct {
+		timeouts    types.Object
+		wantSummary string
+	}{
+		"expired":  {timeouts: readTimeoutsValue(t, "1ms"), wantSummary: "Read timed out"},
+		"invalid":  {timeouts: readTimeoutsValue(t, "soon"), wantSummary: "Invalid read timeout"},
+		"negative": {timeouts: readTimeoutsValue(t, "-1s"), wantSummary: "Invalid read timeout"},
+		"generous": {timeouts: readTimeoutsValue(t, "1m")},
+		"default":  {timeouts: types.ObjectNull(readTimeoutsAttrTypes)},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ds := &assessmentDataSource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+			schema := newDataSourceSchema(t, ds)
+			config := newDataSourceConfig(t, schema, assessmentDataSourceModel{
+				CookbookPath:         types.StringValue(testTmpCookbook),
+				RecommendationsList:  types.ListNull(types.StringType),
+				ManualReviewRequired: types.ListNull(types.StringType),
+				Timeouts:             tt.timeouts,
+			})
+			resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+			ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+
+			if tt.wantSummary == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != tt.wantSummary {
+				t.Fatalf("expected a single %q error, got %v", tt.wantSummary, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestAssessmentDataSourceReadManualReviewRequired(t *testing.T) {
+	libraryCookbook := t.TempDir()
+	for _, name := range []string{"libraries/helpers.rb", "libraries/matchers/nginx.rb", "libraries/README.md", "resources/site.rb"} {
+		filePath := filepath.Join(libraryCookbook, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), testDirPermissions); err != nil {
+			t.Fatalf(testFailedToCreateDirectory, err)
+		}
+		if err := os.WriteFile(filePath, []byte("# ruby\n"), testFilePermissions); err != nil {
+			t.Fatalf(testFailedToWriteFile, err)
+		}
+	}
+	// The CLI may flag further files, some of which the scan also finds
+	if err := os.WriteFile(filepath.Join(libraryCookbook, "assessment.json"), []byte(`{"complexity":"High","manual_review_required":["resources/site.rb","recipes/default.rb"]}`), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+
+	tests := map[string]struct {
+		cookbookPath string
+		want         []string
+	}{
+		"custom resources": {
+			cookbookPath: getFixturePath("sample_cookbook"),
+			want:         []string{"resources/app_config.rb", "resources/database.rb", "resources/simple.rb"},
+		},
+		"libraries and CLI flags": {
+			cookbookPath: libraryCookbook,
+			want:         []string{"libraries/helpers.rb", "libraries/matchers/nginx.rb", "recipes/default.rb", "resources/site.rb"},
+		},
+		"plain cookbook": {
+			cookbookPath: t.TempDir(),
+			want:         []string{},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ds := &assessmentDataSource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+			schema := newDataSourceSchema(t, ds)
+			config := newDataSourceConfig(t, schema, assessmentDataSourceModel{
+				CookbookPath:         types.StringValue(tt.cookbookPath),
+				RecommendationsList:  types.ListNull(types.StringType),
+				ManualReviewRequired: types.ListNull(types.StringType),
+				Timeouts:             types.ObjectNull(readTimeoutsAttrTypes),
+			})
+			resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+			ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+			}
+
+			var state assessmentDataSourceModel
+			if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+				t.Fatalf("failed to read state: %v", diags)
+			}
+			var got []string
+			state.ManualReviewRequired.ElementsAs(context.Background(), &got, false)
+			verifyStringSliceResult(t, got, tt.want)
+		})
+	}
+}
+
+func TestAssessmentDataSourceReadLikelyMigrated(t *testing.T) {
+	cookbookPath := t.TempDir()
+	for _, name := range []string{"recipes/default.rb", "recipes/install.rb"} {
+		filePath := filepath.Join(cookbookPath, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), testDirPermissions); err != nil {
+			t.Fatalf(testFailedToCreateDirectory, err)
+		}
+		if err := os.WriteFile(filePath, []byte("package 'nginx'\n"), testFilePermissions); err != nil {
+			t.Fatalf(testFailedToWriteFile, err)
+		}
+	}
+
+	// writePlaybooks creates an output directory holding the named playbooks
+	writePlaybooks := func(t *testing.T, names ...string) string {
+		outputDir := t.TempDir()
+		for _, name := range names {
+			if err := os.WriteFile(filepath.Join(outputDir, name), []byte("---\n"), testFilePermissions); err != nil {
+				t.Fatalf(testFailedToWriteFile, err)
+			}
+		}
+		return outputDir
+	}
+
+	tests := map[string]struct {
+		outputPath func(t *testing.T) types.String
+		want       bool
+	}{
+		"output path unset": {outputPath: func(*testing.T) types.String { return types.StringNull() }},
+		"missing output directory": {outputPath: func(t *testing.T) types.String {
+			return types.StringValue(filepath.Join(t.TempDir(), "missing"))
+		}},
+		"no playbooks": {outputPath: func(t *testing.T) types.String { return types.StringValue(writePlaybooks(t)) }},
+		"some recipes converted": {outputPath: func(t *testing.T) types.String {
+			return types.StringValue(writePlaybooks(t, testDefaultYml))
+		}},
+		"all recipes converted": {outputPath: func(t *testing.T) types.String {
+			return types.StringValue(writePlaybooks(t, testDefaultYml, "install.json"))
+		}, want: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ds := &assessmentDataSource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+			schema := newDataSourceSchema(t, ds)
+			config := newDataSourceConfig(t, schema, assessmentDataSourceModel{
+				CookbookPath:         types.StringValue(cookbookPath),
+				OutputPath:           tt.outputPath(t),
+				RecommendationsList:  types.ListNull(types.StringType),
+				ManualReviewRequired: types.ListNull(types.StringType),
+				Timeouts:             types.ObjectNull(readTimeoutsAttrTypes),
+			})
+			resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+			ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+			}
+
+			var state assessmentDataSourceModel
+			if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+				t.Fatalf("failed to read state: %v", diags)
+			}
+			if state.LikelyMigrated.ValueBool() != tt.want {
+				t.Fatalf("expected likely_migrated %v, got %v", tt.want, state.LikelyMigrated)
+			}
+		})
+	}
+}
+
+func TestAssessmentDataSourceReadIgnoresStderrWarnings(t *testing.T) {
+	cookbookPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(cookbookPath, "assessment.json"), []byte(`{"complexity":"Medium"}`), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	t.Setenv("SOUSCHEF_TEST_WARN", "warning: chef_version constraint ignored")
+
+	ds := &assessmentDataSource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newDataSourceSchema(t, ds)
+	config := newDataSourceConfig(t, schema, assessmentDataSourceModel{
+		CookbookPath:         types.StringValue(cookbookPath),
+		OutputPath:           types.StringNull(),
+		RecommendationsList:  types.ListNull(types.StringType),
+		ManualReviewRequired: types.ListNull(types.StringType),
+		Timeouts:             types.ObjectNull(readTimeoutsAttrTypes),
+	})
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected only the CLI warning, got %v", resp.Diagnostics)
+	}
+
+	var state assessmentDataSourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.Complexity.ValueString() != "Medium" {
+		t.Fatalf("expected complexity parsed from stdout, got %v", state.Complexity)
+	}
+}
@@ -0,0 +1,205 @@
+// Package provider implements the SousChef Terraform provider data sources
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource              = &batchCostEstimateDataSource{}
+	_ datasource.DataSourceWithConfigure = &batchCostEstimateDataSource{}
+)
+
+// NewBatchCostEstimateDataSource creates a new batch cost estimate data source
+func NewBatchCostEstimateDataSource() datasource.DataSource {
+	return &batchCostEstimateDataSource{}
+}
+
+// batchCostEstimateDataSource is the data source implementation
+type batchCostEstimateDataSource struct {
+	client *SousChefClient
+}
+
+// batchCostEstimateDataSourceModel describes the data source data model
+type batchCostEstimateDataSourceModel struct {
+	ID                  types.String                     `tfsdk:"id"`
+	CookbooksRoot       types.String                     `tfsdk:"cookbooks_root"`
+	DeveloperHourlyRate types.Float64                    `tfsdk:"developer_hourly_rate"`
+	InfrastructureCost  types.Float64                    `tfsdk:"infrastructure_cost"`
+	TotalHours          types.Float64                    `tfsdk:"total_hours"`
+	TotalLabourCost     types.Float64                    `tfsdk:"total_labour_cost"`
+	TotalProjectCost    types.Float64                    `tfsdk:"total_project_cost"`
+	Estimates           []batchCostEstimateCookbookModel `tfsdk:"estimates"`
+}
+
+// batchCostEstimateCookbookModel describes the cost estimate of a single cookbook
+type batchCostEstimateCookbookModel struct {
+	Name           types.String  `tfsdk:"name"`
+	CookbookPath   types.String  `tfsdk:"cookbook_path"`
+	Complexity     types.String  `tfsdk:"complexity"`
+	ResourceCount  types.Int64   `tfsdk:"resource_count"`
+	EstimatedHours types.Float64 `tfsdk:"estimated_hours"`
+	LabourCost     types.Float64 `tfsdk:"labour_cost"`
+}
+
+// Metadata returns the data source type name
+func (d *batchCostEstimateDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_batch_cost_estimate"
+}
+
+// Schema defines the schema for the data source
+func (d *batchCostEstimateDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Assesses every cookbook under a directory and aggregates the migration cost, for budgeting a whole portfolio at once.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier (the cookbooks root)",
+			},
+			"cookbooks_root": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Directory whose subdirectories containing a `metadata.rb` are assessed as cookbooks",
+			},
+			"developer_hourly_rate": schema.Float64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Developer hourly rate in USD for cost calculation (default: 150)",
+			},
+			"infrastructure_cost": schema.Float64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Additional infrastructure/tooling cost in USD, counted once for the whole project (default: 500)",
+			},
+			"total_hours": schema.Float64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Estimated migration effort in hours across all cookbooks",
+			},
+			"total_labour_cost": schema.Float64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Estimated labour cost in USD across all cookbooks",
+			},
+			"total_project_cost": schema.Float64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Total estimated project cost including labour and infrastructure",
+			},
+			"estimates": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Per-cookbook cost breakdown, sorted by name",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Cookbook directory name",
+						},
+						"cookbook_path": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Path to the cookbook",
+						},
+						"complexity": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Migration complexity level (Low/Medium/High)",
+						},
+						"resource_count": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Chef resources in the cookbook",
+						},
+						"estimated_hours": schema.Float64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Estimated migration effort for the cookbook in hours",
+						},
+						"labour_cost": schema.Float64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Estimated labour cost for the cookbook in USD",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *batchCostEstimateDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureDataSource(req, resp)
+}
+
+// Read assesses each cookbook under cookbooks_root and aggregates the estimated cost
+func (d *batchCostEstimateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config batchCostEstimateDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	developerRate := defaultDeveloperHourlyRate
+	if !config.DeveloperHourlyRate.IsNull() {
+		developerRate = config.DeveloperHourlyRate.ValueFloat64()
+	}
+
+	infraCost := defaultInfrastructureCost
+	if !config.InfrastructureCost.IsNull() {
+		infraCost = config.InfrastructureCost.ValueFloat64()
+	}
+
+	cookbooksRoot := config.CookbooksRoot.ValueString()
+	cookbookPaths, err := discoverCookbooks(cookbooksRoot)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error discovering cookbooks",
+			fmt.Sprintf("Could not find cookbooks in %s: %s", cookbooksRoot, err),
+		)
+		return
+	}
+
+	var totalHours, totalLabour float64
+	estimates := make([]batchCostEstimateCookbookModel, 0, len(cookbookPaths))
+	for _, cookbookPath := range cookbookPaths {
+		args := []string{"assess-cookbook", "--cookbook-path", cookbookPath, "--format", "json"}
+		output, ok := executeSousChefCommand(ctx, d.client, args, &resp.Diagnostics)
+		if !ok {
+			return
+		}
+
+		assessment, err := parseCookbookAssessment(output)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error parsing assessment",
+				fmt.Sprintf("Could not parse JSON output for %s: %s", cookbookPath, err),
+			)
+			return
+		}
+
+		// Infrastructure cost is a one-off for the project, so it is added
+		// to the total rather than to every cookbook.
+		hours, labourCost, _ := calculateCostEstimate(assessment.Complexity, assessment.ResourceCount, developerRate, 0)
+		totalHours += hours
+		totalLabour += labourCost
+		estimates = append(estimates, batchCostEstimateCookbookModel{
+			Name:           types.StringValue(d.client.cookbookName(cookbookPath)),
+			CookbookPath:   types.StringValue(cookbookPath),
+			Complexity:     types.StringValue(assessment.Complexity),
+			ResourceCount:  types.Int64Value(assessment.ResourceCount),
+			EstimatedHours: types.Float64Value(hours),
+			LabourCost:     types.Float64Value(labourCost),
+		})
+	}
+
+	config.ID = types.StringValue(cookbooksRoot)
+	config.DeveloperHourlyRate = types.Float64Value(developerRate)
+	config.InfrastructureCost = types.Float64Value(infraCost)
+	config.TotalHours = types.Float64Value(totalHours)
+	config.TotalLabourCost = types.Float64Value(totalLabour)
+	config.TotalProjectCost = types.Float64Value(totalLabour + infraCost)
+	config.Estimates = estimates
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// readBatchCostEstimate runs Read for cookbooksRoot with the given rates
+// against the fake CLI and returns the resulting state.
+func readBatchCostEstimate(t *testing.T, cookbooksRoot string, developerRate, infraCost types.Float64) (batchCostEstimateDataSourceModel, *datasource.ReadResponse) {
+	t.Helper()
+
+	ds := &batchCostEstimateDataSource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newDataSourceSchema(t, ds)
+	config := newDataSourceConfig(t, schema, batchCostEstimateDataSourceModel{
+		CookbooksRoot:       types.StringValue(cookbooksRoot),
+		DeveloperHourlyRate: developerRate,
+		InfrastructureCost:  infraCost,
+	})
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+
+	var state batchCostEstimateDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+			t.Fatalf("failed to read state: %v", diags)
+		}
+	}
+	return state, resp
+}
+
+func TestBatchCostEstimateDataSourceRead(t *testing.T) {
+	root := t.TempDir()
+	newCookbookFixture(t, root, "nginx", `{"complexity":"Low","resource_count":8}`)
+	newCookbookFixture(t, root, "apache", `{"complexity":"High","resource_count":10}`)
+	newCookbookFixture(t, root, "mysql", `{"complexity":"Medium","resource_count":4}`)
+
+	state, resp := readBatchCostEstimate(t, root, types.Float64Value(100), types.Float64Value(1000))
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	// apache: 10 * 1.5 = 15h, mysql: 4 * 1.0 = 4h, nginx: 8 * 0.5 = 4h
+	if got := state.TotalHours.ValueFloat64(); got != 23 {
+		t.Errorf("expected 23 total hours, got %v", got)
+	}
+	if got := state.TotalLabourCost.ValueFloat64(); got != 2300 {
+		t.Errorf("expected total labour cost 2300, got %v", got)
+	}
+	if got := state.TotalProjectCost.ValueFloat64(); got != 3300 {
+		t.Errorf("expected total project cost 3300, got %v", got)
+	}
+
+	if len(state.Estimates) != 3 {
+		t.Fatalf("expected 3 estimates, got %d", len(state.Estimates))
+	}
+	want := []struct {
+		name   string
+		hours  float64
+		labour float64
+	}{
+		{"apache", 15, 1500},
+		{"mysql", 4, 400},
+		{"nginx", 4, 400},
+	}
+	for i, w := range want {
+		got := state.Estimates[i]
+		if got.Name.ValueString() != w.name || got.EstimatedHours.ValueFloat64() != w.hours || got.LabourCost.ValueFloat64() != w.labour {
+			t.Errorf("estimate %d: expected %s with %vh and %v labour, got %+v", i, w.name, w.hours, w.labour, got)
+		}
+	}
+}
+
+func TestBatchCostEstimateDataSourceDefaultRates(t *testing.T) {
+	root := t.TempDir()
+	newCookbookFixture(t, root, "nginx", `{"complexity":"Medium","resource_count":2}`)
+	newCookbookFixture(t, root, "apache", `{"complexity":"Medium","resource_count":3}`)
+
+	state, resp := readBatchCostEstimate(t, root, types.Float64Null(), types.Float64Null())
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	if state.DeveloperHourlyRate.ValueFloat64() != 150 || state.InfrastructureCost.ValueFloat64() != 500 {
+		t.Fatalf("expected default rates 150/500, got %v/%v",
+			state.DeveloperHourlyRate.ValueFloat64(), state.InfrastructureCost.ValueFloat64())
+	}
+	if got := state.TotalLabourCost.ValueFloat64(); got != 750 {
+		t.Errorf("expected total labour cost 750, got %v", got)
+	}
+	if got := state.TotalProjectCost.ValueFloat64(); got != 1250 {
+		t.Errorf("expected total project cost 1250, got %v", got)
+	}
+}
+
+func TestBatchCostEstimateDataSourceErrors(t *testing.T) {
+	t.Run("no cookbooks", func(t *testing.T) {
+		_, resp := readBatchCostEstimate(t, t.TempDir(), types.Float64Null(), types.Float64Null())
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("expected error when no cookbooks are found")
+		}
+	})
+
+	t.Run("assessment failure", func(t *testing.T) {
+		root := t.TempDir()
+		newCookbookFixture(t, root, "nginx", `{"resource_count":1}`)
+		t.Setenv("SOUSCHEF_TEST_FAIL", "assess-cookbook")
+
+		_, resp := readBatchCostEstimate(t, root, types.Float64Null(), types.Float64Null())
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("expected error when assessment fails")
+		}
+	})
+
+	t.Run("invalid output", func(t *testing.T) {
+		root := t.TempDir()
+		newCookbookFixture(t, root, "nginx", `{not json`)
+
+		_, resp := readBatchCostEstimate(t, root, types.Float64Null(), types.Float64Null())
+		if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Error parsing assessment" {
+			t.Fatalf("expected parse error, got %v", resp.Diagnostics)
+		}
+	})
+}
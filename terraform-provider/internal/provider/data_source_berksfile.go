@@ -0,0 +1,152 @@
+// Package provider implements the SousChef Terraform provider data sources
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource              = &berksfileDataSource{}
+	_ datasource.DataSourceWithConfigure = &berksfileDataSource{}
+)
+
+// NewBerksfileDataSource creates a new Berksfile data source
+func NewBerksfileDataSource() datasource.DataSource {
+	return &berksfileDataSource{}
+}
+
+// berksfileDataSource is the data source implementation
+type berksfileDataSource struct {
+	client *SousChefClient
+}
+
+// berksfileDataSourceModel describes the data source data model
+type berksfileDataSourceModel struct {
+	ID            types.String             `tfsdk:"id"`
+	BerksfilePath types.String             `tfsdk:"berksfile_path"`
+	Cookbooks     []berksfileCookbookModel `tfsdk:"cookbooks"`
+}
+
+// berksfileCookbookModel describes a single cookbook declared in a Berksfile
+type berksfileCookbookModel struct {
+	Name              types.String `tfsdk:"name"`
+	Source            types.String `tfsdk:"source"`
+	VersionConstraint types.String `tfsdk:"version_constraint"`
+}
+
+// berksfileInfo is the JSON output of the berksfile-info command.
+type berksfileInfo struct {
+	Cookbooks []struct {
+		Name              string `json:"name"`
+		Source            string `json:"source"`
+		VersionConstraint string `json:"version_constraint"`
+	} `json:"cookbooks"`
+}
+
+// Metadata returns the data source type name
+func (d *berksfileDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_berksfile"
+}
+
+// Schema defines the schema for the data source
+func (d *berksfileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the cookbook dependencies declared in a Berkshelf `Berksfile`, e.g. to enumerate the cookbooks in scope for a migration.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier (the Berksfile path)",
+			},
+			"berksfile_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the `Berksfile`",
+			},
+			"cookbooks": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Cookbooks declared in the Berksfile, in the order they are declared",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Cookbook name",
+						},
+						"source": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Where the cookbook is fetched from: the Berksfile `source`, or the cookbook's `git` URL or `path`",
+						},
+						"version_constraint": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Version constraint such as `~> 12.0`, or empty when the cookbook is unconstrained",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *berksfileDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureDataSource(req, resp)
+}
+
+// Read lists the Berksfile's cookbooks through the SousChef CLI
+func (d *berksfileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config berksfileDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Report a missing Berksfile directly rather than as a CLI failure
+	berksfilePath := config.BerksfilePath.ValueString()
+	if _, err := osStat(berksfilePath); os.IsNotExist(err) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("berksfile_path"),
+			"Berksfile not found",
+			fmt.Sprintf("Berksfile does not exist: %s", berksfilePath),
+		)
+		return
+	}
+
+	args := []string{"berksfile-info", "--path", berksfilePath, "--format", "json"}
+	output, ok := executeSousChefCommand(ctx, d.client, args, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	var info berksfileInfo
+	if err := json.Unmarshal(output, &info); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing Berksfile",
+			fmt.Sprintf("Could not parse JSON output: %s", err),
+		)
+		return
+	}
+
+	cookbooks := make([]berksfileCookbookModel, len(info.Cookbooks))
+	for i, cookbook := range info.Cookbooks {
+		cookbooks[i] = berksfileCookbookModel{
+			Name:              types.StringValue(cookbook.Name),
+			Source:            types.StringValue(cookbook.Source),
+			VersionConstraint: types.StringValue(cookbook.VersionConstraint),
+		}
+	}
+
+	config.ID = types.StringValue(berksfilePath)
+	config.Cookbooks = cookbooks
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// readBerksfile runs Read for the Berksfile and returns the resulting state.
+func readBerksfile(t *testing.T, berksfilePath string) (berksfileDataSourceModel, *datasource.ReadResponse) {
+	t.Helper()
+
+	ds := &berksfileDataSource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newDataSourceSchema(t, ds)
+	config := newDataSourceConfig(t, schema, berksfileDataSourceModel{
+		BerksfilePath: types.StringValue(berksfilePath),
+	})
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+
+	var state berksfileDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+			t.Fatalf("failed to read state: %v", diags)
+		}
+	}
+	return state, resp
+}
+
+func TestBerksfileDataSourceRead(t *testing.T) {
+	berksfilePath := filepath.Join(getFixturePath("berkshelf"), "Berksfile")
+	state, resp := readBerksfile(t, berksfilePath)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	if state.ID.ValueString() != berksfilePath {
+		t.Fatalf("unexpected id %q", state.ID.ValueString())
+	}
+	want := []struct{ name, source, constraint string }{
+		{"nginx", "https://supermarket.chef.io", "~> 12.0"},
+		{"mysql", "https://supermarket.chef.io", ">= 8.0.0"},
+		{"app_base", "https://github.com/example/app_base.git", ""},
+		{"local_tools", "../local_tools", ""},
+	}
+	if len(state.Cookbooks) != len(want) {
+		t.Fatalf("expected %d cookbooks, got %+v", len(want), state.Cookbooks)
+	}
+	for i, cookbook := range state.Cookbooks {
+		if cookbook.Name.ValueString() != want[i].name || cookbook.Source.ValueString() != want[i].source || cookbook.VersionConstraint.ValueString() != want[i].constraint {
+			t.Errorf("cookbook %d: expected %+v, got %+v", i, want[i], cookbook)
+		}
+	}
+}
+
+func TestBerksfileDataSourceReadErrors(t *testing.T) {
+	t.Run("missing Berksfile", func(t *testing.T) {
+		_, resp := readBerksfile(t, filepath.Join(t.TempDir(), "Berksfile"))
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("expected error")
+		}
+		if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Berksfile not found" {
+			t.Fatalf("unexpected diagnostic %q", summary)
+		}
+	})
+	t.Run("CLI failure", func(t *testing.T) {
+		t.Setenv("SOUSCHEF_TEST_FAIL", "berksfile-info")
+		if _, resp := readBerksfile(t, filepath.Join(getFixturePath("berkshelf"), "Berksfile")); !resp.Diagnostics.HasError() {
+			t.Fatal("expected error")
+		}
+	})
+}
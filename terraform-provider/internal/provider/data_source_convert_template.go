@@ -0,0 +1,132 @@
+// Package provider implements the SousChef Terraform provider data sources
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource              = &convertTemplateDataSource{}
+	_ datasource.DataSourceWithConfigure = &convertTemplateDataSource{}
+)
+
+// NewConvertTemplateDataSource creates a new template conversion data source
+func NewConvertTemplateDataSource() datasource.DataSource {
+	return &convertTemplateDataSource{}
+}
+
+// convertTemplateDataSource is the data source implementation
+type convertTemplateDataSource struct {
+	client *SousChefClient
+}
+
+// convertTemplateDataSourceModel describes the data source data model
+type convertTemplateDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	TemplatePath  types.String `tfsdk:"template_path"`
+	Jinja2Content types.String `tfsdk:"jinja2_content"`
+	Variables     types.List   `tfsdk:"variables"`
+}
+
+// Metadata returns the data source type name
+func (d *convertTemplateDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_convert_template"
+}
+
+// Schema defines the schema for the data source
+func (d *convertTemplateDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Converts a Chef ERB template to Jinja2 without writing a file, e.g. to write it with `local_file` wherever the configuration needs it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier (the template path)",
+			},
+			"template_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the Chef ERB template",
+			},
+			"jinja2_content": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Converted Jinja2 template content",
+			},
+			"variables": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Sorted, de-duplicated variables the Jinja2 template references in `{{ }}` expressions",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *convertTemplateDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureDataSource(req, resp)
+}
+
+// Read converts the template into a temporary directory, which is removed
+// once its content has been read
+func (d *convertTemplateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config convertTemplateDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	templatePath := config.TemplatePath.ValueString()
+	if !checkFileExists(templatePath, "Template", &resp.Diagnostics) {
+		return
+	}
+
+	tempDir, err := osMkdirTemp("", "souschef-template-")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating temporary directory",
+			fmt.Sprintf("Could not create temporary directory: %s", err),
+		)
+		return
+	}
+	defer func() {
+		if err := osRemoveAll(tempDir); err != nil {
+			tflog.Warn(ctx, "Could not remove temporary directory", map[string]interface{}{
+				"path":  tempDir,
+				"error": err.Error(),
+			})
+		}
+	}()
+
+	args := []string{"convert-template", "--template-path", templatePath, "--output-path", tempDir}
+	if _, ok := executeSousChefCommand(ctx, d.client, args, &resp.Diagnostics); !ok {
+		return
+	}
+
+	content := readGeneratedFile(filepath.Join(tempDir, jinja2TemplateName(templatePath)), "Error reading template", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.ID = types.StringValue(templatePath)
+	config.Jinja2Content = types.StringValue(content)
+	config.Variables = typesListFromStringSlice(parseTemplateVariables(content))
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// jinja2TemplateName returns the name convert-template gives the Jinja2
+// template converted from templatePath: its base name with `.erb` replaced
+// by `.j2`, e.g. nginx.conf.erb becomes nginx.conf.j2.
+func jinja2TemplateName(templatePath string) string {
+	return strings.TrimSuffix(filepath.Base(templatePath), ".erb") + ".j2"
+}
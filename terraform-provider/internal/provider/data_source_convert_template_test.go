@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testERBTemplate is a fixture Chef template referencing two variables.
+const testERBTemplate = "worker_processes <%= @workers %>;\nserver_name <%= @server_name %>;\nerror_log <%= @server_name %>.log;\n"
+
+// readConvertTemplate runs Read for the template at templatePath and returns the resulting state.
+func readConvertTemplate(t *testing.T, templatePath string) (convertTemplateDataSourceModel, *datasource.ReadResponse) {
+	t.Helper()
+
+	ds := &convertTemplateDataSource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newDataSourceSchema(t, ds)
+	config := newDataSourceConfig(t, schema, convertTemplateDataSourceModel{
+		TemplatePath: types.StringValue(templatePath),
+		Variables:    types.ListNull(types.StringType),
+	})
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+
+	var state convertTemplateDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+			t.Fatalf("failed to read state: %v", diags)
+		}
+	}
+	return state, resp
+}
+
+// verifyTempDirEmpty fails the test when anything is left in tempDir.
+func verifyTempDirEmpty(t *testing.T, tempDir string) {
+	t.Helper()
+
+	entries, err := os.ReadDir(tempDir)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", tempDir, err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected the temporary output directory to be removed, found %v", entries)
+	}
+}
+
+func TestConvertTemplateDataSourceRead(t *testing.T) {
+	templatePath := filepath.Join(t.TempDir(), "nginx.conf.erb")
+	if err := os.WriteFile(templatePath, []byte(testERBTemplate), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	tempDir := t.TempDir()
+	t.Setenv("TMPDIR", tempDir)
+
+	state, resp := readConvertTemplate(t, templatePath)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	want := "worker_processes {{ workers }};\nserver_name {{ server_name }};\nerror_log {{ server_name }}.log;\n"
+	if state.ID.ValueString() != templatePath || state.Jinja2Content.ValueString() != want {
+		t.Fatalf("unexpected state: id=%s content=%q", state.ID.ValueString(), state.Jinja2Content.ValueString())
+	}
+	var variables []string
+	state.Variables.ElementsAs(context.Background(), &variables, false)
+	verifyStringSliceResult(t, variables, []string{"server_name", "workers"})
+	verifyTempDirEmpty(t, tempDir)
+}
+
+func TestConvertTemplateDataSourceReadMissingTemplate(t *testing.T) {
+	_, resp := readConvertTemplate(t, filepath.Join(t.TempDir(), "missing.conf.erb"))
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Template not found" {
+		t.Fatalf("expected template not found error, got %v", resp.Diagnostics)
+	}
+}
+
+func TestConvertTemplateDataSourceReadCLIError(t *testing.T) {
+	templatePath := filepath.Join(t.TempDir(), "nginx.conf.erb")
+	if err := os.WriteFile(templatePath, []byte(testERBTemplate), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	tempDir := t.TempDir()
+	t.Setenv("TMPDIR", tempDir)
+	t.Setenv("SOUSCHEF_TEST_FAIL", "convert-template")
+
+	if _, resp := readConvertTemplate(t, templatePath); !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when the CLI fails")
+	}
+	verifyTempDirEmpty(t, tempDir)
+}
+
+func TestJinja2TemplateName(t *testing.T) {
+	for templatePath, want := range map[string]string{
+		"/cookbooks/nginx/templates/default/nginx.conf.erb": "nginx.conf.j2",
+		"motd": "motd.j2",
+	} {
+		if got := jinja2TemplateName(templatePath); got != want {
+			t.Fatalf("jinja2TemplateName(%q) = %q, want %q", templatePath, got, want)
+		}
+	}
+}
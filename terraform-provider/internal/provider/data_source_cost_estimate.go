@@ -4,12 +4,19 @@ package provider
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// Default rates used when a cost estimate does not configure its own
+const (
+	defaultDeveloperHourlyRate = 150.0
+	defaultInfrastructureCost  = 500.0
+)
+
 // Ensure the implementation satisfies the expected interfaces
 var (
 	_ datasource.DataSource              = &costEstimateDataSource{}
@@ -39,6 +46,7 @@ type costEstimateDataSourceModel struct {
 	InfrastructureCost  types.Float64 `tfsdk:"infrastructure_cost"`
 	TotalProjectCostUSD types.Float64 `tfsdk:"total_project_cost_usd"`
 	Recommendations     types.String  `tfsdk:"recommendations"`
+	Timeouts            types.Object  `tfsdk:"timeouts"`
 }
 
 // Metadata returns the data source type name
@@ -54,7 +62,7 @@ func (d *costEstimateDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "Unique identifier (cookbook path)",
+				MarkdownDescription: "Unique identifier: the absolute cookbook path with a short hash of the rates appended",
 			},
 			"cookbook_path": schema.StringAttribute{
 				Required:            true,
@@ -96,6 +104,7 @@ func (d *costEstimateDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 				Computed:            true,
 				MarkdownDescription: "Migration recommendations and best practices",
 			},
+			"timeouts": readTimeoutsAttribute(),
 		},
 	}
 }
@@ -129,13 +138,20 @@ func (d *costEstimateDataSource) Read(ctx context.Context, req datasource.ReadRe
 
 	cookbookPath := config.CookbookPath.ValueString()
 
+	timeout := readTimeout(ctx, config.Timeouts, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	readCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	// Default rates
-	developerRate := 150.0
+	developerRate := defaultDeveloperHourlyRate
 	if !config.DeveloperHourlyRate.IsNull() {
 		developerRate = config.DeveloperHourlyRate.ValueFloat64()
 	}
 
-	infraCost := 500.0
+	infraCost := defaultInfrastructureCost
 	if !config.InfrastructureCost.IsNull() {
 		infraCost = config.InfrastructureCost.ValueFloat64()
 	}
@@ -145,11 +161,27 @@ func (d *costEstimateDataSource) Read(ctx context.Context, req datasource.ReadRe
 
 	// For now, we'll do a simple analysis similar to assessment
 	// In production, this would call: souschef assess-cookbook --cookbook-path <path> --format json
-
-	// Simplified analysis (in production this would parse actual cookbook)
-	recipeCount := int64(1)    // Placeholder
-	resourceCount := int64(10) // Placeholder
-	complexity := "Medium"
+	// Any intermediate files the CLI produces belong in the per-read scratch directory.
+	var recipeCount, resourceCount int64
+	var complexity string
+	err := withTempDir(func(_ string) error {
+		// Simplified analysis (in production this would parse actual cookbook)
+		recipeCount = 1    // Placeholder
+		resourceCount = 10 // Placeholder
+		complexity = "Medium"
+		return readCtx.Err()
+	})
+	if err != nil && readCtx.Err() != nil {
+		resp.Diagnostics.Append(readCLIDiagnostic(readCtx, err, timeout))
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error estimating cost",
+			fmt.Sprintf("Could not assess cookbook %s: %s", cookbookPath, err),
+		)
+		return
+	}
 
 	estimatedHours, labourCost, totalCost := calculateCostEstimate(complexity, resourceCount, developerRate, infraCost)
 
@@ -162,7 +194,7 @@ func (d *costEstimateDataSource) Read(ctx context.Context, req datasource.ReadRe
 	)
 
 	// Set computed values
-	config.ID = types.StringValue(cookbookPath)
+	config.ID = types.StringValue(costEstimateID(cookbookPath, developerRate, infraCost))
 	config.Complexity = types.StringValue(complexity)
 	config.RecipeCount = types.Int64Value(recipeCount)
 	config.ResourceCount = types.Int64Value(resourceCount)
@@ -177,6 +209,16 @@ func (d *costEstimateDataSource) Read(ctx context.Context, req datasource.ReadRe
 	resp.Diagnostics.Append(diags...)
 }
 
+// costEstimateID returns the absolute cookbook path with a short hash of the
+// rates appended, so estimates of one cookbook at different rates get
+// distinct IDs while identical inputs always get the same one.
+func costEstimateID(cookbookPath string, developerRate, infraCost float64) string {
+	if absPath, err := filepath.Abs(cookbookPath); err == nil {
+		cookbookPath = absPath
+	}
+	return cookbookPath + "-" + contentSHA256([]byte(fmt.Sprintf("%g\x00%g", developerRate, infraCost)))[:12]
+}
+
 func calculateCostEstimate(complexity string, resourceCount int64, developerRate float64, infraCost float64) (float64, float64, float64) {
 	var estimatedHours float64
 	switch complexity {
@@ -1,9 +1,14 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"path/filepath"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -76,3 +81,56 @@ data "souschef_cost_estimate" "test" {
 `
 	return config
 }
+
+func TestCostEstimateDataSourceReadTimeouts(t *testing.T) {
+	ds := &costEstimateDataSource{client: &SousChefClient{Path: "souschef"}}
+	schema := newDataSourceSchema(t, ds)
+
+	for read, wantErr := range map[string]bool{"30s": false, "0s": true, "later": true} {
+		config := newDataSourceConfig(t, schema, costEstimateDataSourceModel{
+			CookbookPath:        types.StringValue(testTmpCookbook),
+			DeveloperHourlyRate: types.Float64Null(),
+			InfrastructureCost:  types.Float64Null(),
+			Timeouts:            readTimeoutsValue(t, read),
+		})
+		resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+		ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+		if resp.Diagnostics.HasError() != wantErr {
+			t.Errorf("timeouts.read %q: expected error %v, got %v", read, wantErr, resp.Diagnostics)
+		}
+	}
+}
+
+func TestCostEstimateDataSourceIDIncludesRates(t *testing.T) {
+	ds := &costEstimateDataSource{client: &SousChefClient{Path: "souschef"}}
+	schema := newDataSourceSchema(t, ds)
+
+	readID := func(cookbookPath string, developerRate types.Float64) string {
+		t.Helper()
+		config := newDataSourceConfig(t, schema, costEstimateDataSourceModel{
+			CookbookPath:        types.StringValue(cookbookPath),
+			DeveloperHourlyRate: developerRate,
+			InfrastructureCost:  types.Float64Null(),
+			Timeouts:            readTimeoutsValue(t, "30s"),
+		})
+		resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+		ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+		}
+		var state costEstimateDataSourceModel
+		resp.State.Get(context.Background(), &state)
+		return state.ID.ValueString()
+	}
+
+	defaultID := readID(testTmpCookbook, types.Float64Null())
+	if readID(testTmpCookbook, types.Float64Value(200)) == defaultID {
+		t.Fatal("expected a different developer_hourly_rate to produce a different id")
+	}
+	if readID(testTmpCookbook, types.Float64Value(defaultDeveloperHourlyRate)) != defaultID {
+		t.Fatal("expected identical inputs to produce the same id")
+	}
+	if !filepath.IsAbs(defaultID) {
+		t.Fatalf("expected an absolute cookbook path in the id, got %q", defaultID)
+	}
+}
@@ -0,0 +1,207 @@
+// Package provider implements the SousChef Terraform provider data sources
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource              = &coverageReportDataSource{}
+	_ datasource.DataSourceWithConfigure = &coverageReportDataSource{}
+)
+
+// NewCoverageReportDataSource creates a new coverage report data source
+func NewCoverageReportDataSource() datasource.DataSource {
+	return &coverageReportDataSource{}
+}
+
+// coverageReportDataSource is the data source implementation
+type coverageReportDataSource struct {
+	client *SousChefClient
+}
+
+// coverageReportDataSourceModel describes the data source data model
+type coverageReportDataSourceModel struct {
+	ID               types.String                  `tfsdk:"id"`
+	CookbooksRoot    types.String                  `tfsdk:"cookbooks_root"`
+	CoveragePercent  types.Float64                 `tfsdk:"coverage_percent"`
+	ResourceCount    types.Int64                   `tfsdk:"resource_count"`
+	UnsupportedCount types.Int64                   `tfsdk:"unsupported_count"`
+	Cookbooks        []coverageReportCookbookModel `tfsdk:"cookbooks"`
+}
+
+// coverageReportCookbookModel describes the coverage of a single cookbook
+type coverageReportCookbookModel struct {
+	Name             types.String  `tfsdk:"name"`
+	CookbookPath     types.String  `tfsdk:"cookbook_path"`
+	CoveragePercent  types.Float64 `tfsdk:"coverage_percent"`
+	ResourceCount    types.Int64   `tfsdk:"resource_count"`
+	UnsupportedCount types.Int64   `tfsdk:"unsupported_count"`
+}
+
+// Metadata returns the data source type name
+func (d *coverageReportDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_coverage_report"
+}
+
+// Schema defines the schema for the data source
+func (d *coverageReportDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Assesses every cookbook under a directory and aggregates how much of the portfolio SousChef can convert.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier (the cookbooks root)",
+			},
+			"cookbooks_root": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Directory whose subdirectories containing a `metadata.rb` are assessed as cookbooks",
+			},
+			"coverage_percent": schema.Float64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Percentage of Chef resources across all cookbooks that can be converted",
+			},
+			"resource_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Total Chef resources across all cookbooks",
+			},
+			"unsupported_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Total Chef resources that cannot be converted",
+			},
+			"cookbooks": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Per-cookbook coverage breakdown, sorted by name",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Cookbook directory name",
+						},
+						"cookbook_path": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Path to the cookbook",
+						},
+						"coverage_percent": schema.Float64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Percentage of the cookbook's resources that can be converted",
+						},
+						"resource_count": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Chef resources in the cookbook",
+						},
+						"unsupported_count": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Chef resources in the cookbook that cannot be converted",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *coverageReportDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureDataSource(req, resp)
+}
+
+// Read assesses each cookbook under cookbooks_root and aggregates the results
+func (d *coverageReportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config coverageReportDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cookbooksRoot := config.CookbooksRoot.ValueString()
+	cookbookPaths, err := discoverCookbooks(cookbooksRoot)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error discovering cookbooks",
+			fmt.Sprintf("Could not find cookbooks in %s: %s", cookbooksRoot, err),
+		)
+		return
+	}
+
+	var totalResources, totalUnsupported int64
+	cookbooks := make([]coverageReportCookbookModel, 0, len(cookbookPaths))
+	for _, cookbookPath := range cookbookPaths {
+		args := []string{"assess-cookbook", "--cookbook-path", cookbookPath, "--format", "json"}
+		output, ok := executeSousChefCommand(ctx, d.client, args, &resp.Diagnostics)
+		if !ok {
+			return
+		}
+
+		assessment, err := parseCookbookAssessment(output)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error parsing assessment",
+				fmt.Sprintf("Could not parse JSON output for %s: %s", cookbookPath, err),
+			)
+			return
+		}
+
+		totalResources += assessment.ResourceCount
+		totalUnsupported += assessment.UnsupportedCount
+		cookbooks = append(cookbooks, coverageReportCookbookModel{
+			Name:             types.StringValue(d.client.cookbookName(cookbookPath)),
+			CookbookPath:     types.StringValue(cookbookPath),
+			CoveragePercent:  types.Float64Value(coveragePercent(assessment.ResourceCount, assessment.UnsupportedCount)),
+			ResourceCount:    types.Int64Value(assessment.ResourceCount),
+			UnsupportedCount: types.Int64Value(assessment.UnsupportedCount),
+		})
+	}
+
+	config.ID = types.StringValue(cookbooksRoot)
+	config.CoveragePercent = types.Float64Value(coveragePercent(totalResources, totalUnsupported))
+	config.ResourceCount = types.Int64Value(totalResources)
+	config.UnsupportedCount = types.Int64Value(totalUnsupported)
+	config.Cookbooks = cookbooks
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// discoverCookbooks returns the subdirectories of root containing a
+// metadata.rb, sorted by name.
+func discoverCookbooks(root string) ([]string, error) {
+	entries, err := osReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	cookbooks := make([]string, 0)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		cookbookPath := filepath.Join(root, entry.Name())
+		if _, err := osStat(filepath.Join(cookbookPath, "metadata.rb")); err == nil {
+			cookbooks = append(cookbooks, cookbookPath)
+		}
+	}
+
+	if len(cookbooks) == 0 {
+		return nil, fmt.Errorf("no subdirectories containing metadata.rb")
+	}
+	return cookbooks, nil
+}
+
+// coveragePercent returns the share of convertible resources, treating a
+// cookbook without resources as fully covered.
+func coveragePercent(resourceCount, unsupportedCount int64) float64 {
+	if resourceCount == 0 {
+		return 100
+	}
+	return float64(resourceCount-unsupportedCount) / float64(resourceCount) * 100
+}
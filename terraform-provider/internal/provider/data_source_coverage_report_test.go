@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newCookbookFixture creates a cookbook under root whose fake assessment reports the given counts.
+func newCookbookFixture(t *testing.T, root, name, assessment string) {
+	t.Helper()
+
+	cookbookPath := filepath.Join(root, name)
+	if err := os.MkdirAll(cookbookPath, testDirPermissions); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+	if err := os.WriteFile(filepath.Join(cookbookPath, "metadata.rb"), []byte("name '"+name+"'\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	if err := os.WriteFile(filepath.Join(cookbookPath, "assessment.json"), []byte(assessment), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+}
+
+// readCoverageReport runs Read for cookbooksRoot against the fake CLI and returns the response.
+func readCoverageReport(t *testing.T, cookbooksRoot string) *datasource.ReadResponse {
+	t.Helper()
+
+	ds := &coverageReportDataSource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newDataSourceSchema(t, ds)
+
+	config := newDataSourceConfig(t, schema, coverageReportDataSourceModel{
+		CookbooksRoot: types.StringValue(cookbooksRoot),
+	})
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+	return resp
+}
+
+func TestCoverageReportDataSourceRead(t *testing.T) {
+	root := t.TempDir()
+	newCookbookFixture(t, root, "nginx", `{"complexity":"Low","resource_count":8,"unsupported_count":2}`)
+	newCookbookFixture(t, root, "apache", `{"complexity":"Medium","resource_count":12,"unsupported_count":0}`)
+	if err := os.MkdirAll(filepath.Join(root, "docs"), testDirPermissions); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+
+	resp := readCoverageReport(t, root)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var state coverageReportDataSourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+
+	if state.ResourceCount.ValueInt64() != 20 || state.UnsupportedCount.ValueInt64() != 2 {
+		t.Fatalf("expected 20 resources and 2 unsupported, got %d and %d",
+			state.ResourceCount.ValueInt64(), state.UnsupportedCount.ValueInt64())
+	}
+	if state.CoveragePercent.ValueFloat64() != 90 {
+		t.Fatalf("expected 90%% coverage, got %v", state.CoveragePercent.ValueFloat64())
+	}
+
+	if len(state.Cookbooks) != 2 {
+		t.Fatalf("expected 2 cookbooks, got %d", len(state.Cookbooks))
+	}
+	apache, nginx := state.Cookbooks[0], state.Cookbooks[1]
+	if apache.Name.ValueString() != "apache" || apache.CoveragePercent.ValueFloat64() != 100 {
+		t.Fatalf("unexpected apache breakdown: %+v", apache)
+	}
+	if nginx.Name.ValueString() != "nginx" || nginx.CoveragePercent.ValueFloat64() != 75 || nginx.UnsupportedCount.ValueInt64() != 2 {
+		t.Fatalf("unexpected nginx breakdown: %+v", nginx)
+	}
+}
+
+func TestCoverageReportDataSourceNoCookbooks(t *testing.T) {
+	resp := readCoverageReport(t, t.TempDir())
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when no cookbooks are found")
+	}
+}
+
+func TestCoverageReportDataSourceAssessmentError(t *testing.T) {
+	root := t.TempDir()
+	newCookbookFixture(t, root, "nginx", `{"resource_count":1}`)
+	t.Setenv("SOUSCHEF_TEST_FAIL", "assess-cookbook")
+
+	resp := readCoverageReport(t, root)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when assessment fails")
+	}
+}
+
+func TestCoverageReportDataSourceBadJSON(t *testing.T) {
+	root := t.TempDir()
+	newCookbookFixture(t, root, "nginx", `{not json`)
+
+	resp := readCoverageReport(t, root)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid assessment output")
+	}
+}
+
+func TestCoveragePercent(t *testing.T) {
+	if got := coveragePercent(0, 0); got != 100 {
+		t.Fatalf("expected 100 for no resources, got %v", got)
+	}
+	if got := coveragePercent(4, 1); got != 75 {
+		t.Fatalf("expected 75, got %v", got)
+	}
+}
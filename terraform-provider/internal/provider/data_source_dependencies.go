@@ -0,0 +1,169 @@
+// Package provider implements the SousChef Terraform provider data sources
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource              = &dependenciesDataSource{}
+	_ datasource.DataSourceWithConfigure = &dependenciesDataSource{}
+)
+
+// NewDependenciesDataSource creates a new cookbook dependencies data source
+func NewDependenciesDataSource() datasource.DataSource {
+	return &dependenciesDataSource{}
+}
+
+// dependenciesDataSource is the data source implementation
+type dependenciesDataSource struct {
+	client *SousChefClient
+}
+
+// dependenciesDataSourceModel describes the data source data model
+type dependenciesDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	CookbookPath types.String `tfsdk:"cookbook_path"`
+	Direct       types.List   `tfsdk:"direct"`
+	Transitive   types.List   `tfsdk:"transitive"`
+	HasCycles    types.Bool   `tfsdk:"has_cycles"`
+}
+
+// dependencyGraph is the JSON output of the deps command: the cookbook's
+// name and, for it and every cookbook it pulls in, its direct dependencies.
+type dependencyGraph struct {
+	Cookbook     string              `json:"cookbook"`
+	Dependencies map[string][]string `json:"dependencies"`
+}
+
+// Metadata returns the data source type name
+func (d *dependenciesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cookbook_dependencies"
+}
+
+// Schema defines the schema for the data source
+func (d *dependenciesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resolves the dependency graph of a Chef cookbook, e.g. to migrate cookbooks in dependency order.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier (the cookbook path)",
+			},
+			"cookbook_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the Chef cookbook directory",
+			},
+			"direct": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Sorted names of the cookbooks this cookbook depends on directly",
+			},
+			"transitive": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "All cookbooks this cookbook depends on, each listed after its own dependencies (ties sorted by name)",
+			},
+			"has_cycles": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the dependency graph contains a cycle",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *dependenciesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureDataSource(req, resp)
+}
+
+// Read resolves the cookbook's dependency graph
+func (d *dependenciesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config dependenciesDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cookbookPath := config.CookbookPath.ValueString()
+	args := []string{"deps", "--cookbook-path", cookbookPath, "--format", "json"}
+	output, ok := executeSousChefCommand(ctx, d.client, args, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	var graph dependencyGraph
+	if err := json.Unmarshal(output, &graph); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing dependencies",
+			fmt.Sprintf("Could not parse JSON output: %s", err),
+		)
+		return
+	}
+	if graph.Cookbook == "" {
+		graph.Cookbook = d.client.cookbookName(cookbookPath)
+	}
+
+	direct := append([]string(nil), graph.Dependencies[graph.Cookbook]...)
+	sort.Strings(direct)
+	transitive, hasCycles := resolveDependencyOrder(graph)
+
+	config.ID = types.StringValue(cookbookPath)
+	config.Direct = typesListFromStringSlice(direct)
+	config.Transitive = typesListFromStringSlice(transitive)
+	config.HasCycles = types.BoolValue(hasCycles)
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// resolveDependencyOrder walks the graph depth-first from the root cookbook,
+// visiting dependencies in sorted order, and returns every cookbook reachable
+// from it in post-order, so each is listed after its own dependencies. It also
+// reports whether the walk found a cycle; the members of a cycle are still
+// listed once each.
+func resolveDependencyOrder(graph dependencyGraph) ([]string, bool) {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[string]int)
+	order := make([]string, 0)
+	hasCycles := false
+
+	var visit func(name string)
+	visit = func(name string) {
+		switch state[name] {
+		case visiting:
+			hasCycles = true
+			return
+		case visited:
+			return
+		}
+		state[name] = visiting
+
+		deps := append([]string(nil), graph.Dependencies[name]...)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			visit(dep)
+		}
+
+		state[name] = visited
+		if name != graph.Cookbook {
+			order = append(order, name)
+		}
+	}
+	visit(graph.Cookbook)
+
+	return order, hasCycles
+}
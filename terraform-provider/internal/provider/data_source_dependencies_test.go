@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testDependencyGraph is the deps output for a web cookbook whose
+// dependencies share a common base cookbook.
+const testDependencyGraph = `{
+  "cookbook": "web",
+  "dependencies": {
+    "web": ["nginx", "apt"],
+    "nginx": ["openssl", "apt"],
+    "openssl": [],
+    "apt": []
+  }
+}`
+
+// testCyclicDependencyGraph is the deps output for cookbooks depending on each other.
+const testCyclicDependencyGraph = `{
+  "cookbook": "web",
+  "dependencies": {
+    "web": ["nginx"],
+    "nginx": ["logrotate"],
+    "logrotate": ["nginx"]
+  }
+}`
+
+// readDependencies writes graph as the fake CLI's deps output for a new
+// cookbook, runs Read and returns the resulting state.
+func readDependencies(t *testing.T, graph string) (dependenciesDataSourceModel, *datasource.ReadResponse) {
+	t.Helper()
+
+	cookbookPath := t.TempDir()
+	if graph != "" {
+		if err := os.WriteFile(filepath.Join(cookbookPath, "deps.json"), []byte(graph), testFilePermissions); err != nil {
+			t.Fatalf(testFailedToWriteFile, err)
+		}
+	}
+
+	ds := &dependenciesDataSource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newDataSourceSchema(t, ds)
+	config := newDataSourceConfig(t, schema, dependenciesDataSourceModel{
+		CookbookPath: types.StringValue(cookbookPath),
+		Direct:       types.ListNull(types.StringType),
+		Transitive:   types.ListNull(types.StringType),
+	})
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+
+	var state dependenciesDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+			t.Fatalf("failed to read state: %v", diags)
+		}
+	}
+	return state, resp
+}
+
+// stringList converts a types.List of strings to a slice.
+func stringList(t *testing.T, list types.List) []string {
+	t.Helper()
+
+	var values []string
+	if diags := list.ElementsAs(context.Background(), &values, false); diags.HasError() {
+		t.Fatalf("failed to read list: %v", diags)
+	}
+	return values
+}
+
+func TestDependenciesDataSourceRead(t *testing.T) {
+	state, resp := readDependencies(t, testDependencyGraph)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	verifyStringSliceResult(t, stringList(t, state.Direct), []string{"apt", "nginx"})
+	verifyStringSliceResult(t, stringList(t, state.Transitive), []string{"apt", "openssl", "nginx"})
+	if state.HasCycles.ValueBool() {
+		t.Fatal("expected no cycles")
+	}
+}
+
+func TestDependenciesDataSourceReadCycle(t *testing.T) {
+	state, resp := readDependencies(t, testCyclicDependencyGraph)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	verifyStringSliceResult(t, stringList(t, state.Direct), []string{"nginx"})
+	verifyStringSliceResult(t, stringList(t, state.Transitive), []string{"logrotate", "nginx"})
+	if !state.HasCycles.ValueBool() {
+		t.Fatal("expected has_cycles to be true")
+	}
+}
+
+func TestDependenciesDataSourceReadNoDependencies(t *testing.T) {
+	state, resp := readDependencies(t, "")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	if len(state.Direct.Elements()) != 0 || len(state.Transitive.Elements()) != 0 || state.HasCycles.ValueBool() {
+		t.Fatalf("expected an empty graph, got %+v", state)
+	}
+}
+
+func TestDependenciesDataSourceReadErrors(t *testing.T) {
+	t.Run("CLI failure", func(t *testing.T) {
+		t.Setenv("SOUSCHEF_TEST_FAIL", "deps")
+		if _, resp := readDependencies(t, testDependencyGraph); !resp.Diagnostics.HasError() {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("invalid JSON", func(t *testing.T) {
+		if _, resp := readDependencies(t, "{bad json"); !resp.Diagnostics.HasError() {
+			t.Fatal("expected error")
+		}
+	})
+}
@@ -0,0 +1,165 @@
+// Package provider implements the SousChef Terraform provider data sources
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource              = &diffDataSource{}
+	_ datasource.DataSourceWithConfigure = &diffDataSource{}
+)
+
+// NewDiffDataSource creates a new diff data source
+func NewDiffDataSource() datasource.DataSource {
+	return &diffDataSource{}
+}
+
+// diffDataSource is the data source implementation
+type diffDataSource struct {
+	client *SousChefClient
+}
+
+// diffDataSourceModel describes the data source data model
+type diffDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	CookbookPath types.String `tfsdk:"cookbook_path"`
+	RecipeName   types.String `tfsdk:"recipe_name"`
+	OutputPath   types.String `tfsdk:"output_path"`
+	HasDiff      types.Bool   `tfsdk:"has_diff"`
+	DiffSummary  types.String `tfsdk:"diff_summary"`
+}
+
+// Metadata returns the data source type name
+func (d *diffDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_diff"
+}
+
+// Schema defines the schema for the data source
+func (d *diffDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Compares a freshly converted Chef recipe against the playbook already on disk to detect drift.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier (cookbook-recipe)",
+			},
+			"cookbook_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the Chef cookbook directory",
+			},
+			"recipe_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the recipe to convert",
+			},
+			"output_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Directory containing the previously generated playbook",
+			},
+			"has_diff": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the regenerated playbook differs from the on-disk playbook",
+			},
+			"diff_summary": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Human-readable summary of the differences",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *diffDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureDataSource(req, resp)
+}
+
+// Read regenerates the playbook into a temporary directory and compares it with the on-disk playbook
+func (d *diffDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config diffDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cookbookPath := config.CookbookPath.ValueString()
+	recipeName := config.RecipeName.ValueString()
+	outputPath := config.OutputPath.ValueString()
+
+	tempDir, err := osMkdirTemp("", "souschef-diff-")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating temporary directory",
+			fmt.Sprintf("Could not create temporary directory: %s", err),
+		)
+		return
+	}
+	defer func() {
+		if err := osRemoveAll(tempDir); err != nil {
+			tflog.Warn(ctx, "Could not remove temporary directory", map[string]interface{}{
+				"path":  tempDir,
+				"error": err.Error(),
+			})
+		}
+	}()
+
+	// Regenerate the playbook into the temporary directory
+	args := []string{"convert-recipe", "--cookbook-path", cookbookPath, "--recipe-name", recipeName, "--output-path", tempDir}
+	if _, ok := executeSousChefCommand(ctx, d.client, args, &resp.Diagnostics); !ok {
+		return
+	}
+
+	fresh := readGeneratedFile(filepath.Join(tempDir, recipeName+".yml"), errorReadingPlaybook, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Compare with the on-disk playbook; a missing playbook counts as drift
+	playbookPath := filepath.Join(outputPath, recipeName+".yml")
+	var hasDiff bool
+	var summary string
+	if existing, err := osReadFile(playbookPath); err != nil {
+		hasDiff = true
+		summary = fmt.Sprintf("Could not read on-disk playbook %s: %s", playbookPath, err)
+	} else {
+		hasDiff, summary = summarizePlaybookDiff(string(existing), fresh)
+	}
+
+	config.ID = types.StringValue(fmt.Sprintf("%s-%s", d.client.cookbookName(cookbookPath), recipeName))
+	config.HasDiff = types.BoolValue(hasDiff)
+	config.DiffSummary = types.StringValue(summary)
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// summarizePlaybookDiff compares two playbooks byte-for-byte and, when they
+// differ, reports how many lines differ by position.
+func summarizePlaybookDiff(existing, fresh string) (bool, string) {
+	if existing == fresh {
+		return false, "No differences between regenerated and on-disk playbook"
+	}
+
+	existingLines := strings.Split(strings.TrimSuffix(existing, "\n"), "\n")
+	freshLines := strings.Split(strings.TrimSuffix(fresh, "\n"), "\n")
+	total := max(len(existingLines), len(freshLines))
+
+	differing := 0
+	for i := 0; i < total; i++ {
+		if i >= len(existingLines) || i >= len(freshLines) || existingLines[i] != freshLines[i] {
+			differing++
+		}
+	}
+
+	return true, fmt.Sprintf("%d of %d lines differ between regenerated and on-disk playbook", differing, total)
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// readDiffDataSource writes existing content as the on-disk playbook (unless empty),
+// runs Read against the fake CLI and returns the resulting state.
+func readDiffDataSource(t *testing.T, existing string) (diffDataSourceModel, string) {
+	t.Helper()
+
+	var tempDirs []string
+	original := osMkdirTemp
+	osMkdirTemp = func(dir, pattern string) (string, error) {
+		created, err := original(dir, pattern)
+		tempDirs = append(tempDirs, created)
+		return created, err
+	}
+	t.Cleanup(func() { osMkdirTemp = original })
+
+	ds := &diffDataSource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newDataSourceSchema(t, ds)
+
+	outputDir := t.TempDir()
+	if existing != "" {
+		if err := os.WriteFile(filepath.Join(outputDir, testDefaultYml), []byte(existing), testFilePermissions); err != nil {
+			t.Fatalf(testFailedToWritePlaybook, err)
+		}
+	}
+
+	config := newDataSourceConfig(t, schema, diffDataSourceModel{
+		CookbookPath: types.StringValue(testTmpCookbook),
+		RecipeName:   types.StringValue("default"),
+		OutputPath:   types.StringValue(outputDir),
+	})
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	for _, dir := range tempDirs {
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Fatalf("expected temporary directory %s to be removed", dir)
+		}
+	}
+
+	var state diffDataSourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+
+	return state, outputDir
+}
+
+func TestDiffDataSourceReadMatching(t *testing.T) {
+	state, _ := readDiffDataSource(t, "recipe: default\n")
+
+	if state.HasDiff.ValueBool() {
+		t.Fatalf("expected no diff, got summary %q", state.DiffSummary.ValueString())
+	}
+	if state.ID.ValueString() != "cookbook-default" {
+		t.Fatalf("unexpected id %q", state.ID.ValueString())
+	}
+}
+
+func TestDiffDataSourceReadMismatching(t *testing.T) {
+	state, _ := readDiffDataSource(t, "recipe: changed\nextra: line\n")
+
+	if !state.HasDiff.ValueBool() {
+		t.Fatal("expected diff to be detected")
+	}
+	if !strings.HasPrefix(state.DiffSummary.ValueString(), "2 of 2 lines differ") {
+		t.Fatalf("unexpected diff summary %q", state.DiffSummary.ValueString())
+	}
+}
+
+func TestDiffDataSourceReadMissingPlaybook(t *testing.T) {
+	state, _ := readDiffDataSource(t, "")
+
+	if !state.HasDiff.ValueBool() {
+		t.Fatal("expected missing playbook to be reported as a diff")
+	}
+}
+
+func TestDiffDataSourceReadConversionError(t *testing.T) {
+	ds := &diffDataSource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newDataSourceSchema(t, ds)
+
+	t.Setenv("SOUSCHEF_TEST_FAIL", testConvertRecipe)
+	config := newDataSourceConfig(t, schema, diffDataSourceModel{
+		CookbookPath: types.StringValue(testTmpCookbook),
+		RecipeName:   types.StringValue("default"),
+		OutputPath:   types.StringValue(t.TempDir()),
+	})
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal(testExpectedConvertError)
+	}
+}
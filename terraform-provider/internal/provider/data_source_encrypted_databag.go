@@ -0,0 +1,140 @@
+// Package provider implements the SousChef Terraform provider data sources
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource              = &encryptedDatabagDataSource{}
+	_ datasource.DataSourceWithConfigure = &encryptedDatabagDataSource{}
+)
+
+// NewEncryptedDatabagDataSource creates a new encrypted data bag data source
+func NewEncryptedDatabagDataSource() datasource.DataSource {
+	return &encryptedDatabagDataSource{}
+}
+
+// encryptedDatabagDataSource is the data source implementation
+type encryptedDatabagDataSource struct {
+	client *SousChefClient
+}
+
+// encryptedDatabagDataSourceModel describes the data source data model
+type encryptedDatabagDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	DatabagPath types.String `tfsdk:"databag_path"`
+	ItemNames   types.List   `tfsdk:"item_names"`
+	Encrypted   types.Bool   `tfsdk:"encrypted"`
+}
+
+// Metadata returns the data source type name
+func (d *encryptedDatabagDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_encrypted_databag"
+}
+
+// Schema defines the schema for the data source
+func (d *encryptedDatabagDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the items in a Chef data bag and whether they are encrypted, without decrypting them, e.g. to decide whether `souschef_databag_migration` needs a `secret_key_path`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier (the data bag path)",
+			},
+			"databag_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the data bag directory containing one JSON file per item",
+			},
+			"item_names": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Sorted names of the items in `*.json`, without extension",
+			},
+			"encrypted": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether any item holds encrypted values, in which case migrating the data bag needs its secret",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *encryptedDatabagDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureDataSource(req, resp)
+}
+
+// Read lists the data bag items and checks each for encrypted values
+func (d *encryptedDatabagDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config encryptedDatabagDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	databagPath := config.DatabagPath.ValueString()
+	itemNames, err := listDatabagItems(databagPath)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing data bag items",
+			fmt.Sprintf("Could not list items in %s: %s", databagPath, err),
+		)
+		return
+	}
+
+	encrypted := false
+	for _, itemName := range itemNames {
+		itemPath := filepath.Join(databagPath, itemName+".json")
+		itemEncrypted, err := databagItemEncrypted(itemPath)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading data bag item",
+				fmt.Sprintf("Could not read %s: %s", itemPath, err),
+			)
+			return
+		}
+		encrypted = encrypted || itemEncrypted
+	}
+
+	config.ID = types.StringValue(databagPath)
+	config.ItemNames = typesListFromStringSlice(itemNames)
+	config.Encrypted = types.BoolValue(encrypted)
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// databagItemEncrypted reports whether any top-level value of the data bag
+// item at itemPath is an encrypted value, i.e. an object with an
+// encrypted_data key. The values themselves are never decrypted.
+func databagItemEncrypted(itemPath string) (bool, error) {
+	content, err := osReadFile(itemPath)
+	if err != nil {
+		return false, err
+	}
+
+	var item map[string]json.RawMessage
+	if err := json.Unmarshal(content, &item); err != nil {
+		return false, err
+	}
+	for _, value := range item {
+		var field map[string]json.RawMessage
+		if json.Unmarshal(value, &field) != nil {
+			continue
+		}
+		if _, ok := field["encrypted_data"]; ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
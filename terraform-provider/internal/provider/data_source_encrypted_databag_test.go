@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// readEncryptedDatabag runs Read for databagPath and returns the response.
+func readEncryptedDatabag(t *testing.T, databagPath string) *datasource.ReadResponse {
+	t.Helper()
+
+	ds := &encryptedDatabagDataSource{}
+	schema := newDataSourceSchema(t, ds)
+
+	config := newDataSourceConfig(t, schema, encryptedDatabagDataSourceModel{
+		DatabagPath: types.StringValue(databagPath),
+		ItemNames:   types.ListNull(types.StringType),
+	})
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+	return resp
+}
+
+func TestEncryptedDatabagDataSourceRead(t *testing.T) {
+	tests := map[string]struct {
+		databagPath   string
+		wantItems     []string
+		wantEncrypted bool
+	}{
+		"encrypted": {databagPath: testDatabagPath(), wantItems: []string{"api", "database"}, wantEncrypted: true},
+		"plain":     {databagPath: filepath.Join(getFixturePath("data_bags"), "users"), wantItems: []string{"alice", "deploy"}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := readEncryptedDatabag(t, tt.databagPath)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+			}
+
+			var state encryptedDatabagDataSourceModel
+			if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+				t.Fatalf("failed to read state: %v", diags)
+			}
+			var itemNames []string
+			state.ItemNames.ElementsAs(context.Background(), &itemNames, false)
+			verifyStringSliceResult(t, itemNames, tt.wantItems)
+			if state.Encrypted.ValueBool() != tt.wantEncrypted {
+				t.Fatalf("expected encrypted %v, got %v", tt.wantEncrypted, state.Encrypted)
+			}
+		})
+	}
+}
+
+func TestEncryptedDatabagDataSourceReadErrors(t *testing.T) {
+	invalidBag := t.TempDir()
+	if err := os.WriteFile(filepath.Join(invalidBag, "broken.json"), []byte("{not json"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+
+	for name, databagPath := range map[string]string{
+		"missing directory": filepath.Join(t.TempDir(), "missing"),
+		"invalid item":      invalidBag,
+	} {
+		if resp := readEncryptedDatabag(t, databagPath); !resp.Diagnostics.HasError() {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Package provider implements the SousChef Terraform provider data sources
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource              = &gitCookbookDataSource{}
+	_ datasource.DataSourceWithConfigure = &gitCookbookDataSource{}
+)
+
+// NewGitCookbookDataSource creates a new Git cookbook data source
+func NewGitCookbookDataSource() datasource.DataSource {
+	return &gitCookbookDataSource{}
+}
+
+// gitCookbookDataSource is the data source implementation
+type gitCookbookDataSource struct {
+	client *SousChefClient
+}
+
+// gitCookbookDataSourceModel describes the data source data model
+type gitCookbookDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	GitURL         types.String `tfsdk:"git_url"`
+	GitRef         types.String `tfsdk:"git_ref"`
+	ResolvedCommit types.String `tfsdk:"resolved_commit"`
+	LocalPath      types.String `tfsdk:"local_path"`
+	CookbookName   types.String `tfsdk:"cookbook_name"`
+}
+
+// Metadata returns the data source type name
+func (d *gitCookbookDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_git_cookbook"
+}
+
+// Schema defines the schema for the data source
+func (d *gitCookbookDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resolves a cookbook in a Git repository to a commit and a cached local clone, so other resources and data sources can use it through `local_path`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier (the local clone path)",
+			},
+			"git_url": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "URL of the Git repository containing the cookbook",
+			},
+			"git_ref": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Branch or tag to check out (default: the repository's default branch)",
+			},
+			"resolved_commit": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Commit SHA `git_ref` resolved to",
+			},
+			"local_path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Path of the clone in the user cache directory, reused while `git_ref` still points to the same commit",
+			},
+			"cookbook_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Cookbook `name` from `metadata.rb`, or the repository name when undeclared",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *gitCookbookDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureDataSource(req, resp)
+}
+
+// Read clones the cookbook into the cache, or reuses an up-to-date clone
+func (d *gitCookbookDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config gitCookbookDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	gitURL := config.GitURL.ValueString()
+	clonePath, commit, err := cachedGitCookbook(ctx, gitURL, config.GitRef.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error cloning cookbook",
+			fmt.Sprintf("Could not clone %s: %s", gitURL, err),
+		)
+		return
+	}
+
+	cookbookName := filepath.Base(clonePath)
+	metadataPath := filepath.Join(clonePath, "metadata.rb")
+	if content, err := osReadFile(metadataPath); err == nil {
+		cookbookName, _ = parseCookbookMetadata(metadataPath, string(content))
+	}
+
+	config.ID = types.StringValue(clonePath)
+	config.ResolvedCommit = types.StringValue(commit)
+	config.LocalPath = types.StringValue(clonePath)
+	config.CookbookName = types.StringValue(cookbookName)
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
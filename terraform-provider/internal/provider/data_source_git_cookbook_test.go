@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// readGitCookbook runs Read for gitURL at gitRef and returns the resulting state.
+func readGitCookbook(t *testing.T, gitURL string, gitRef types.String) (gitCookbookDataSourceModel, *datasource.ReadResponse) {
+	t.Helper()
+
+	ds := &gitCookbookDataSource{}
+	schema := newDataSourceSchema(t, ds)
+
+	config := newDataSourceConfig(t, schema, gitCookbookDataSourceModel{
+		GitURL: types.StringValue(gitURL),
+		GitRef: gitRef,
+	})
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+
+	var state gitCookbookDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+			t.Fatalf("failed to read state: %v", diags)
+		}
+	}
+	return state, resp
+}
+
+func TestGitCookbookDataSourceRead(t *testing.T) {
+	repo := newGitCookbookRepo(t)
+	cacheDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheDir)
+	first := runTestGit(t, repo.workDir, "rev-parse", "HEAD")
+
+	state, resp := readGitCookbook(t, repo.url, types.StringValue(testGitBranch))
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+	localPath := state.LocalPath.ValueString()
+	if state.ResolvedCommit.ValueString() != first || state.CookbookName.ValueString() != "web" || state.ID.ValueString() != localPath {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+	if !strings.HasPrefix(localPath, cacheDir) || filepath.Base(localPath) != "web-cookbook" {
+		t.Fatalf("expected a clone named after the repository in the cache directory, got %s", localPath)
+	}
+	if _, err := os.Stat(filepath.Join(localPath, "metadata.rb")); err != nil {
+		t.Fatalf("expected cookbook in clone: %v", err)
+	}
+
+	// An up-to-date clone is reused as it is
+	marker := filepath.Join(localPath, "marker")
+	if err := os.WriteFile(marker, []byte("kept"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	if state, resp = readGitCookbook(t, repo.url, types.StringValue(testGitBranch)); resp.Diagnostics.HasError() || state.LocalPath.ValueString() != localPath {
+		t.Fatalf("expected the cached clone to be reused, got %+v, %v", state, resp.Diagnostics)
+	}
+	if _, err := os.Stat(marker); err != nil {
+		t.Fatalf("expected the cached clone to be kept: %v", err)
+	}
+
+	// A new commit on the ref replaces the clone
+	second := repo.commit(t, "metadata.rb", "name 'web_server'\n")
+	repo.push(t)
+	state, resp = readGitCookbook(t, repo.url, types.StringValue(testGitBranch))
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+	if state.ResolvedCommit.ValueString() != second || state.CookbookName.ValueString() != "web_server" || state.LocalPath.ValueString() != localPath {
+		t.Fatalf("expected the clone to be refreshed to %s, got %+v", second, state)
+	}
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Fatalf("expected the stale clone to be replaced, got %v", err)
+	}
+
+	// The default branch gets its own cache entry
+	state, resp = readGitCookbook(t, repo.url, types.StringNull())
+	if resp.Diagnostics.HasError() || state.ResolvedCommit.ValueString() != second || state.LocalPath.ValueString() == localPath {
+		t.Fatalf("expected a separate clone of the default branch, got %+v, %v", state, resp.Diagnostics)
+	}
+}
+
+func TestGitCookbookDataSourceReadErrors(t *testing.T) {
+	repo := newGitCookbookRepo(t)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	tests := map[string]struct {
+		gitURL string
+		gitRef types.String
+	}{
+		"missing ref":        {gitURL: repo.url, gitRef: types.StringValue("does-not-exist")},
+		"missing repository": {gitURL: "file://" + filepath.Join(t.TempDir(), "missing.git"), gitRef: types.StringNull()},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, resp := readGitCookbook(t, tt.gitURL, tt.gitRef)
+			if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics[0].Summary(), "Error cloning cookbook") {
+				t.Fatalf("expected a clone error, got %v", resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Package provider contains common helper functions for Terraform data sources
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// defaultReadTimeout bounds a data source Read when timeouts.read is not set.
+const defaultReadTimeout = 2 * time.Minute
+
+// readTimeoutsAttrTypes are the attribute types of the timeouts object.
+var readTimeoutsAttrTypes = map[string]attr.Type{"read": types.StringType}
+
+// readTimeoutsModel describes the timeouts object.
+type readTimeoutsModel struct {
+	Read types.String `tfsdk:"read"`
+}
+
+// readTimeoutsAttribute returns the `timeouts` attribute of data sources that
+// run the SousChef CLI, in the same `timeouts = { read = "5m" }` shape as
+// terraform-plugin-framework-timeouts.
+func readTimeoutsAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:    true,
+		Description: "Timeouts for reading the data source.",
+		Attributes: map[string]schema.Attribute{
+			"read": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long Read may run the SousChef CLI, as a Go duration such as \"30s\" or \"5m\" (default: \"2m\").",
+			},
+		},
+	}
+}
+
+// readTimeout returns the configured timeouts.read duration, or
+// defaultReadTimeout when it is not set. Invalid durations add an error.
+func readTimeout(ctx context.Context, value types.Object, diagnostics *diag.Diagnostics) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return defaultReadTimeout
+	}
+
+	var timeouts readTimeoutsModel
+	diagnostics.Append(value.As(ctx, &timeouts, basetypes.ObjectAsOptions{})...)
+	if diagnostics.HasError() || timeouts.Read.IsNull() || timeouts.Read.IsUnknown() {
+		return defaultReadTimeout
+	}
+
+	timeout, err := time.ParseDuration(timeouts.Read.ValueString())
+	if err != nil || timeout <= 0 {
+		diagnostics.AddAttributeError(
+			path.Root("timeouts").AtName("read"),
+			"Invalid read timeout",
+			fmt.Sprintf("timeouts.read must be a positive duration such as \"30s\" or \"5m\", got %q.", timeouts.Read.ValueString()),
+		)
+		return defaultReadTimeout
+	}
+	return timeout
+}
+
+// readCLIDiagnostic converts a CLI error from a data source Read into a
+// diagnostic, reporting an exceeded read timeout separately from CLI failures.
+func readCLIDiagnostic(ctx context.Context, err error, timeout time.Duration) diag.Diagnostic {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return diag.NewErrorDiagnostic(
+			"Read timed out",
+			fmt.Sprintf("The SousChef CLI did not finish within the %s read timeout. Increase timeouts.read for large cookbooks.", timeout),
+		)
+	}
+	return diagnosticFromError(err)
+}
+
+// configureDataSource is a common helper for data source Configure methods.
+// It extracts the SousChefClient from ProviderData and returns it,
+// or adds an error diagnostic if the type is unexpected.
+func configureDataSource(req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) *SousChefClient {
+	if req.ProviderData == nil {
+		return nil
+	}
+
+	client, ok := req.ProviderData.(*SousChefClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *SousChefClient, got: %T", req.ProviderData),
+		)
+		return nil
+	}
+
+	return client
+}
+
+// withTempDir creates a unique scratch directory, passes it to fn and always
+// removes it afterwards. Each call gets its own directory so concurrent data
+// source reads never share intermediate files.
+func withTempDir(fn func(dir string) error) error {
+	dir, err := osMkdirTemp("", "souschef-scratch-")
+	if err != nil {
+		return fmt.Errorf("could not create scratch directory: %w", err)
+	}
+
+	fnErr := fn(dir)
+	if err := osRemoveAll(dir); err != nil {
+		return errors.Join(fnErr, fmt.Errorf("could not remove scratch directory %s: %w", dir, err))
+	}
+	return fnErr
+}
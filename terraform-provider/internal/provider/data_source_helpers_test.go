@@ -0,0 +1,81 @@
+// Package provider contains unit tests for the data source helpers.
+package provider
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestWithTempDir(t *testing.T) {
+	var scratch string
+	err := withTempDir(func(dir string) error {
+		scratch = dir
+		return os.WriteFile(filepath.Join(dir, "assessment.json"), []byte("{}"), testFilePermissions)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(scratch); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be removed, got %v", scratch, err)
+	}
+}
+
+func TestWithTempDirCleansUpOnCallbackError(t *testing.T) {
+	wantErr := errors.New("assessment failed")
+	var scratch string
+	err := withTempDir(func(dir string) error {
+		scratch = dir
+		if _, err := os.Stat(dir); err != nil {
+			t.Fatalf("expected scratch directory to exist: %v", err)
+		}
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected callback error, got %v", err)
+	}
+	if _, err := os.Stat(scratch); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be removed, got %v", scratch, err)
+	}
+}
+
+func TestWithTempDirMkdirError(t *testing.T) {
+	orig := osMkdirTemp
+	osMkdirTemp = func(string, string) (string, error) { return "", errors.New("no space") }
+	t.Cleanup(func() { osMkdirTemp = orig })
+
+	called := false
+	if err := withTempDir(func(string) error { called = true; return nil }); err == nil {
+		t.Fatal("expected error when the scratch directory cannot be created")
+	}
+	if called {
+		t.Fatal("expected callback not to run")
+	}
+}
+
+func TestWithTempDirConcurrentCallsAreIsolated(t *testing.T) {
+	const workers = 8
+	dirs := make(chan string, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := withTempDir(func(dir string) error { dirs <- dir; return nil }); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	close(dirs)
+
+	seen := make(map[string]bool)
+	for dir := range dirs {
+		if seen[dir] {
+			t.Fatalf("scratch directory %s shared between reads", dir)
+		}
+		seen[dir] = true
+	}
+}
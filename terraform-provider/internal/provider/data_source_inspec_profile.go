@@ -0,0 +1,149 @@
+// Package provider implements the SousChef Terraform provider data sources
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// inspecProfileMetadataFile is the metadata file every InSpec profile has at its root.
+const inspecProfileMetadataFile = "inspec.yml"
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource              = &inspecProfileDataSource{}
+	_ datasource.DataSourceWithConfigure = &inspecProfileDataSource{}
+)
+
+// NewInSpecProfileDataSource creates a new InSpec profile data source
+func NewInSpecProfileDataSource() datasource.DataSource {
+	return &inspecProfileDataSource{}
+}
+
+// inspecProfileDataSource is the data source implementation
+type inspecProfileDataSource struct {
+	client *SousChefClient
+}
+
+// inspecProfileDataSourceModel describes the data source data model
+type inspecProfileDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	ProfilePath  types.String `tfsdk:"profile_path"`
+	Name         types.String `tfsdk:"name"`
+	Version      types.String `tfsdk:"version"`
+	ControlCount types.Int64  `tfsdk:"control_count"`
+	ControlIDs   types.List   `tfsdk:"control_ids"`
+}
+
+// inspecProfileInfo is the JSON output of the inspec-info command.
+type inspecProfileInfo struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Controls []struct {
+		ID string `json:"id"`
+	} `json:"controls"`
+}
+
+// Metadata returns the data source type name
+func (d *inspecProfileDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_inspec_profile"
+}
+
+// Schema defines the schema for the data source
+func (d *inspecProfileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the controls of an InSpec profile, e.g. to review a profile before converting it with `souschef_inspec_migration`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier (the profile path)",
+			},
+			"profile_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the InSpec profile directory containing `inspec.yml`",
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Profile name from `inspec.yml`",
+			},
+			"version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Profile version from `inspec.yml`",
+			},
+			"control_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of controls in the profile",
+			},
+			"control_ids": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of the profile's controls, in the order they are defined",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *inspecProfileDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureDataSource(req, resp)
+}
+
+// Read lists the profile's controls through the SousChef CLI
+func (d *inspecProfileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config inspecProfileDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Report a missing inspec.yml directly rather than as a CLI failure
+	profilePath := config.ProfilePath.ValueString()
+	metadataPath := filepath.Join(profilePath, inspecProfileMetadataFile)
+	if _, err := osStat(metadataPath); os.IsNotExist(err) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile_path"),
+			"InSpec profile metadata not found",
+			fmt.Sprintf("%s is not an InSpec profile: %s does not exist.", profilePath, metadataPath),
+		)
+		return
+	}
+
+	args := []string{"inspec-info", "--profile-path", profilePath, "--format", "json"}
+	output, ok := executeSousChefCommand(ctx, d.client, args, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	var profile inspecProfileInfo
+	if err := json.Unmarshal(output, &profile); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing InSpec profile",
+			fmt.Sprintf("Could not parse JSON output: %s", err),
+		)
+		return
+	}
+
+	controlIDs := make([]string, len(profile.Controls))
+	for i, control := range profile.Controls {
+		controlIDs[i] = control.ID
+	}
+
+	config.ID = types.StringValue(profilePath)
+	config.Name = types.StringValue(profile.Name)
+	config.Version = types.StringValue(profile.Version)
+	config.ControlCount = types.Int64Value(int64(len(controlIDs)))
+	config.ControlIDs = typesListFromStringSlice(controlIDs)
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testInSpecProfileFiles is a fixture InSpec profile with controls split across files.
+var testInSpecProfileFiles = map[string]string{
+	"inspec.yml": "name: linux-baseline\ntitle: Linux Baseline\nversion: 2.1.0\n",
+	"controls/os.rb": `control 'os-01' do
+  impact 1.0
+  describe file('/etc/passwd') do
+    it { should exist }
+  end
+end
+
+control "os-02" do
+  describe file('/etc/shadow') do
+    its('mode') { should cmp '0640' }
+  end
+end
+`,
+	"controls/ssh.rb": `control 'ssh-01' do
+  describe sshd_config do
+    its('PermitRootLogin') { should eq 'no' }
+  end
+end
+`,
+}
+
+// writeInSpecProfile writes files into a new profile directory and returns its path.
+func writeInSpecProfile(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	profilePath := t.TempDir()
+	for name, content := range files {
+		filePath := filepath.Join(profilePath, name)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			t.Fatalf("failed to create %s: %v", filepath.Dir(filePath), err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), testFilePermissions); err != nil {
+			t.Fatalf(testFailedToWriteFile, err)
+		}
+	}
+	return profilePath
+}
+
+// readInSpecProfile runs Read for the profile at profilePath and returns the resulting state.
+func readInSpecProfile(t *testing.T, profilePath string) (inspecProfileDataSourceModel, *datasource.ReadResponse) {
+	t.Helper()
+
+	ds := &inspecProfileDataSource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newDataSourceSchema(t, ds)
+	config := newDataSourceConfig(t, schema, inspecProfileDataSourceModel{
+		ProfilePath: types.StringValue(profilePath),
+		ControlIDs:  types.ListNull(types.StringType),
+	})
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+
+	var state inspecProfileDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+			t.Fatalf("failed to read state: %v", diags)
+		}
+	}
+	return state, resp
+}
+
+func TestInSpecProfileDataSourceRead(t *testing.T) {
+	profilePath := writeInSpecProfile(t, testInSpecProfileFiles)
+	state, resp := readInSpecProfile(t, profilePath)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	if state.ID.ValueString() != profilePath {
+		t.Errorf("expected id %q, got %q", profilePath, state.ID.ValueString())
+	}
+	if state.Name.ValueString() != "linux-baseline" || state.Version.ValueString() != "2.1.0" {
+		t.Errorf("unexpected name/version: %q %q", state.Name.ValueString(), state.Version.ValueString())
+	}
+	if got, want := stringList(t, state.ControlIDs), []string{"os-01", "os-02", "ssh-01"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected control_ids %v, got %v", want, got)
+	}
+	if state.ControlCount.ValueInt64() != 3 {
+		t.Errorf("expected control_count 3, got %d", state.ControlCount.ValueInt64())
+	}
+}
+
+func TestInSpecProfileDataSourceReadNoControls(t *testing.T) {
+	profilePath := writeInSpecProfile(t, map[string]string{"inspec.yml": "name: empty\nversion: 0.1.0\n"})
+	state, resp := readInSpecProfile(t, profilePath)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+	if state.ControlCount.ValueInt64() != 0 || state.ControlIDs.IsNull() || len(state.ControlIDs.Elements()) != 0 {
+		t.Errorf("expected no controls, got %d %v", state.ControlCount.ValueInt64(), state.ControlIDs)
+	}
+}
+
+func TestInSpecProfileDataSourceReadErrors(t *testing.T) {
+	t.Run("missing inspec.yml", func(t *testing.T) {
+		// The CLI would fail to read the profile, so the check must run first
+		t.Setenv("SOUSCHEF_TEST_FAIL", "inspec-info")
+		_, resp := readInSpecProfile(t, t.TempDir())
+		if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "InSpec profile metadata not found" {
+			t.Fatalf("expected missing inspec.yml diagnostic, got %v", resp.Diagnostics)
+		}
+	})
+
+	t.Run("cli failure", func(t *testing.T) {
+		t.Setenv("SOUSCHEF_TEST_FAIL", "inspec-info")
+		_, resp := readInSpecProfile(t, writeInSpecProfile(t, testInSpecProfileFiles))
+		if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Error running souschef inspec-info" {
+			t.Fatalf("expected CLI error, got %v", resp.Diagnostics)
+		}
+	})
+}
@@ -0,0 +1,196 @@
+// Package provider implements the SousChef Terraform provider data sources
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultLintBinary = "ansible-lint"
+	// lintViolationsExitCode is the ansible-lint exit code reporting violations.
+	lintViolationsExitCode = 2
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource              = &lintDataSource{}
+	_ datasource.DataSourceWithConfigure = &lintDataSource{}
+)
+
+// NewLintDataSource creates a new lint data source
+func NewLintDataSource() datasource.DataSource {
+	return &lintDataSource{}
+}
+
+// lintDataSource is the data source implementation
+type lintDataSource struct {
+	client *SousChefClient
+}
+
+// lintDataSourceModel describes the data source data model
+type lintDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	PlaybookPath   types.String `tfsdk:"playbook_path"`
+	LintBinary     types.String `tfsdk:"lint_binary"`
+	Passed         types.Bool   `tfsdk:"passed"`
+	ViolationCount types.Int64  `tfsdk:"violation_count"`
+	Violations     types.List   `tfsdk:"violations"`
+}
+
+// lintIssue is a single entry of ansible-lint's JSON (Code Climate) output
+type lintIssue struct {
+	CheckName   string `json:"check_name"`
+	Description string `json:"description"`
+	Location    struct {
+		Path  string `json:"path"`
+		Lines struct {
+			Begin int `json:"begin"`
+		} `json:"lines"`
+	} `json:"location"`
+}
+
+// Metadata returns the data source type name
+func (d *lintDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_lint"
+}
+
+// Schema defines the schema for the data source
+func (d *lintDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs ansible-lint against a generated playbook and reports the violations found.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier (the playbook path)",
+			},
+			"playbook_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the playbook to lint",
+			},
+			"lint_binary": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "ansible-lint executable to run (default: `ansible-lint`)",
+			},
+			"passed": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the playbook has no lint violations",
+			},
+			"violation_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of lint violations",
+			},
+			"violations": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Lint violations formatted as `path:line: rule: description`",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *lintDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureDataSource(req, resp)
+}
+
+// Read runs ansible-lint with JSON output and records the violations
+func (d *lintDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config lintDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	playbookPath := config.PlaybookPath.ValueString()
+	if !checkFileExists(playbookPath, "Playbook", &resp.Diagnostics) {
+		return
+	}
+
+	lintBinary := defaultLintBinary
+	if !config.LintBinary.IsNull() && config.LintBinary.ValueString() != "" {
+		lintBinary = config.LintBinary.ValueString()
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := execCommandContext(ctx, lintBinary, "--format", "json", playbookPath)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	tflog.Debug(ctx, "Executing ansible-lint", map[string]interface{}{
+		"command": cmd.String(),
+	})
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) || errors.Is(err, fs.ErrNotExist) {
+			resp.Diagnostics.AddError(
+				"ansible-lint not found",
+				fmt.Sprintf("Could not run %q: %s. Install ansible-lint or set lint_binary to its path.", lintBinary, err),
+			)
+			return
+		}
+
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != lintViolationsExitCode {
+			resp.Diagnostics.AddError(
+				"Error running ansible-lint",
+				fmt.Sprintf("Command failed: %s\nOutput: %s", err, stderr.String()),
+			)
+			return
+		}
+	}
+
+	violations, err := parseLintViolations(stdout.Bytes())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing ansible-lint output",
+			fmt.Sprintf("Could not parse JSON output: %s", err),
+		)
+		return
+	}
+
+	values := make([]attr.Value, len(violations))
+	for i, violation := range violations {
+		values[i] = types.StringValue(violation)
+	}
+
+	config.ID = types.StringValue(playbookPath)
+	config.Passed = types.BoolValue(len(violations) == 0)
+	config.ViolationCount = types.Int64Value(int64(len(violations)))
+	config.Violations = types.ListValueMust(types.StringType, values)
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// parseLintViolations converts ansible-lint JSON output into
+// "path:line: rule: description" strings. Empty output means no violations.
+func parseLintViolations(output []byte) ([]string, error) {
+	violations := make([]string, 0)
+	if len(bytes.TrimSpace(output)) == 0 {
+		return violations, nil
+	}
+
+	var issues []lintIssue
+	if err := json.Unmarshal(output, &issues); err != nil {
+		return nil, err
+	}
+
+	for _, issue := range issues {
+		violations = append(violations, fmt.Sprintf("%s:%d: %s: %s",
+			issue.Location.Path, issue.Location.Lines.Begin, issue.CheckName, issue.Description))
+	}
+	return violations, nil
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testLintViolations = `[{"type":"issue","check_name":"name[missing]","description":"All tasks should be named.","location":{"path":"default.yml","lines":{"begin":4}}},` +
+	`{"type":"issue","check_name":"fqcn[action-core]","description":"Use FQCN for builtin module actions (command).","location":{"path":"default.yml","lines":{"begin":5}}}]`
+
+// newFakeAnsibleLint writes a fake ansible-lint that prints output and exits with exitCode.
+func newFakeAnsibleLint(t *testing.T, output string, exitCode int) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "output.json"), []byte(output), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	script := "#!/bin/sh\ncat \"" + filepath.Join(dir, "output.json") + "\"\necho 'lint summary' >&2\nexit " + strconv.Itoa(exitCode) + "\n"
+	path := filepath.Join(dir, "ansible-lint")
+	if err := os.WriteFile(path, []byte(script), 0700); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	return path
+}
+
+// readLintDataSource runs Read for a playbook using lintBinary and returns the response.
+func readLintDataSource(t *testing.T, lintBinary string) *datasource.ReadResponse {
+	t.Helper()
+
+	ds := &lintDataSource{}
+	schema := newDataSourceSchema(t, ds)
+
+	playbookPath := filepath.Join(t.TempDir(), testDefaultYml)
+	if err := os.WriteFile(playbookPath, []byte("- hosts: all\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWritePlaybook, err)
+	}
+
+	config := newDataSourceConfig(t, schema, lintDataSourceModel{
+		PlaybookPath: types.StringValue(playbookPath),
+		LintBinary:   types.StringValue(lintBinary),
+		Violations:   types.ListNull(types.StringType),
+	})
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+	return resp
+}
+
+func readLintState(t *testing.T, resp *datasource.ReadResponse) (lintDataSourceModel, []string) {
+	t.Helper()
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var state lintDataSourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	var violations []string
+	if diags := state.Violations.ElementsAs(context.Background(), &violations, false); diags.HasError() {
+		t.Fatalf("failed to read violations: %v", diags)
+	}
+	return state, violations
+}
+
+func TestLintDataSourceReadClean(t *testing.T) {
+	state, violations := readLintState(t, readLintDataSource(t, newFakeAnsibleLint(t, "[]", 0)))
+
+	if !state.Passed.ValueBool() || state.ViolationCount.ValueInt64() != 0 || len(violations) != 0 {
+		t.Fatalf("expected clean lint result, got passed=%v count=%d violations=%v",
+			state.Passed.ValueBool(), state.ViolationCount.ValueInt64(), violations)
+	}
+}
+
+func TestLintDataSourceReadViolations(t *testing.T) {
+	state, violations := readLintState(t, readLintDataSource(t, newFakeAnsibleLint(t, testLintViolations, 2)))
+
+	if state.Passed.ValueBool() || state.ViolationCount.ValueInt64() != 2 {
+		t.Fatalf("expected 2 violations, got passed=%v count=%d", state.Passed.ValueBool(), state.ViolationCount.ValueInt64())
+	}
+	verifyStringSliceResult(t, violations, []string{
+		"default.yml:4: name[missing]: All tasks should be named.",
+		"default.yml:5: fqcn[action-core]: Use FQCN for builtin module actions (command).",
+	})
+}
+
+func TestLintDataSourceReadBinaryNotFound(t *testing.T) {
+	resp := readLintDataSource(t, filepath.Join(t.TempDir(), "missing-ansible-lint"))
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "ansible-lint not found" {
+		t.Fatalf("expected binary not found error, got %v", resp.Diagnostics)
+	}
+}
+
+func TestLintDataSourceReadLintFailure(t *testing.T) {
+	resp := readLintDataSource(t, newFakeAnsibleLint(t, "", 1))
+
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "lint summary") {
+		t.Fatalf("expected lint failure error with output, got %v", resp.Diagnostics)
+	}
+}
+
+func TestLintDataSourceReadInvalidJSON(t *testing.T) {
+	resp := readLintDataSource(t, newFakeAnsibleLint(t, "not json", 2))
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid JSON output")
+	}
+}
@@ -0,0 +1,245 @@
+// Package provider implements the SousChef Terraform provider data sources
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource              = &migrationPlanDataSource{}
+	_ datasource.DataSourceWithConfigure = &migrationPlanDataSource{}
+)
+
+// NewMigrationPlanDataSource creates a new migration plan data source
+func NewMigrationPlanDataSource() datasource.DataSource {
+	return &migrationPlanDataSource{}
+}
+
+// migrationPlanDataSource is the data source implementation
+type migrationPlanDataSource struct {
+	client *SousChefClient
+}
+
+// migrationPlanDataSourceModel describes the data source data model
+type migrationPlanDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	CookbookPath   types.String `tfsdk:"cookbook_path"`
+	OrderedRecipes types.List   `tfsdk:"ordered_recipes"`
+	Phases         types.List   `tfsdk:"phases"`
+}
+
+// Metadata returns the data source type name
+func (d *migrationPlanDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_migration_plan"
+}
+
+// Schema defines the schema for the data source
+func (d *migrationPlanDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Orders the recipes in a Chef cookbook by their `include_recipe` dependencies, e.g. to drive staged applies of `souschef_migration` resources.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier (the cookbook path)",
+			},
+			"cookbook_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the Chef cookbook directory",
+			},
+			"ordered_recipes": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Recipe names in an order where each recipe comes after the recipes it includes",
+			},
+			"phases": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.ListType{ElemType: types.StringType},
+				MarkdownDescription: "Recipes grouped into batches that can be migrated in parallel; every recipe's dependencies are in an earlier phase. Names within a phase are sorted",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *migrationPlanDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureDataSource(req, resp)
+}
+
+// Read lists the cookbook's recipes, resolves their dependencies and orders them
+func (d *migrationPlanDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config migrationPlanDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cookbookPath := config.CookbookPath.ValueString()
+	recipeNames, err := listCookbookRecipes(cookbookPath)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing recipes",
+			fmt.Sprintf("Could not list recipes in %s: %s", cookbookPath, err),
+		)
+		return
+	}
+
+	args := []string{"deps", "--cookbook-path", cookbookPath, "--format", "json", "--level", "recipe"}
+	output, ok := executeSousChefCommand(ctx, d.client, args, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	var graph dependencyGraph
+	if err := json.Unmarshal(output, &graph); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing dependencies",
+			fmt.Sprintf("Could not parse JSON output: %s", err),
+		)
+		return
+	}
+	if graph.Cookbook == "" {
+		graph.Cookbook = d.client.cookbookName(cookbookPath)
+	}
+
+	phases, cycle := planRecipePhases(recipeNames, recipeDependencies(graph, recipeNames))
+	if len(cycle) > 0 {
+		resp.Diagnostics.AddError(
+			"Dependency cycle detected",
+			fmt.Sprintf("Recipes in %s cannot be ordered because they depend on each other: %s", cookbookPath, strings.Join(cycle, " -> ")),
+		)
+		return
+	}
+
+	orderedRecipes := make([]string, 0, len(recipeNames))
+	for _, phase := range phases {
+		orderedRecipes = append(orderedRecipes, phase...)
+	}
+	phaseList, listDiags := types.ListValueFrom(ctx, types.ListType{ElemType: types.StringType}, phases)
+	resp.Diagnostics.Append(listDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.ID = types.StringValue(cookbookPath)
+	config.OrderedRecipes = typesListFromStringSlice(orderedRecipes)
+	config.Phases = phaseList
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// recipeDependencies maps each of the cookbook's recipes to the recipes of the
+// same cookbook it depends on. Names may be qualified with the cookbook, as in
+// "web::database"; dependencies on other cookbooks' recipes are dropped since
+// they are migrated separately.
+func recipeDependencies(graph dependencyGraph, recipeNames []string) map[string][]string {
+	known := make(map[string]bool, len(recipeNames))
+	for _, name := range recipeNames {
+		known[name] = true
+	}
+	localName := func(name string) (string, bool) {
+		if cookbook, recipe, found := strings.Cut(name, "::"); found {
+			if cookbook != graph.Cookbook {
+				return "", false
+			}
+			name = recipe
+		}
+		return name, known[name]
+	}
+
+	dependencies := make(map[string][]string, len(recipeNames))
+	for name, deps := range graph.Dependencies {
+		recipe, ok := localName(name)
+		if !ok {
+			continue
+		}
+		for _, dep := range deps {
+			if dep, ok := localName(dep); ok && dep != recipe {
+				dependencies[recipe] = append(dependencies[recipe], dep)
+			}
+		}
+	}
+	return dependencies
+}
+
+// planRecipePhases topologically sorts the sorted recipeNames, grouping into
+// each phase the recipes whose dependencies are all in earlier phases. If the
+// recipes cannot be ordered it returns a dependency cycle instead, starting
+// and ending with the same recipe.
+func planRecipePhases(recipeNames []string, dependencies map[string][]string) ([][]string, []string) {
+	remaining := make(map[string]bool, len(recipeNames))
+	for _, name := range recipeNames {
+		remaining[name] = true
+	}
+
+	phases := make([][]string, 0)
+	for len(remaining) > 0 {
+		phase := make([]string, 0)
+		for _, name := range recipeNames {
+			if !remaining[name] {
+				continue
+			}
+			ready := true
+			for _, dep := range dependencies[name] {
+				if remaining[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				phase = append(phase, name)
+			}
+		}
+		if len(phase) == 0 {
+			return nil, findDependencyCycle(recipeNames, remaining, dependencies)
+		}
+		for _, name := range phase {
+			delete(remaining, name)
+		}
+		phases = append(phases, phase)
+	}
+	return phases, nil
+}
+
+// findDependencyCycle follows unresolved dependencies from the first remaining
+// recipe until one repeats. Every remaining recipe has an unresolved
+// dependency, so the walk always ends in a cycle.
+func findDependencyCycle(recipeNames []string, remaining map[string]bool, dependencies map[string][]string) []string {
+	name := ""
+	for _, candidate := range recipeNames {
+		if remaining[candidate] {
+			name = candidate
+			break
+		}
+	}
+
+	position := make(map[string]int)
+	path := make([]string, 0)
+	for {
+		if start, seen := position[name]; seen {
+			return append(path[start:], name)
+		}
+		position[name] = len(path)
+		path = append(path, name)
+
+		deps := append([]string(nil), dependencies[name]...)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if remaining[dep] {
+				name = dep
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// readMigrationPlan runs Read for the cookbook and returns the resulting state.
+func readMigrationPlan(t *testing.T, cookbookPath string) (migrationPlanDataSourceModel, *datasource.ReadResponse) {
+	t.Helper()
+
+	ds := &migrationPlanDataSource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newDataSourceSchema(t, ds)
+	config := newDataSourceConfig(t, schema, migrationPlanDataSourceModel{
+		CookbookPath:   types.StringValue(cookbookPath),
+		OrderedRecipes: types.ListNull(types.StringType),
+		Phases:         types.ListNull(types.ListType{ElemType: types.StringType}),
+	})
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+
+	var state migrationPlanDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+			t.Fatalf("failed to read state: %v", diags)
+		}
+	}
+	return state, resp
+}
+
+func TestMigrationPlanDataSourceRead(t *testing.T) {
+	state, resp := readMigrationPlan(t, filepath.Join(getFixturePath("migration_plan"), "ordered"))
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	verifyStringSliceResult(t, stringList(t, state.OrderedRecipes), []string{"database", "monitoring", "web", "default"})
+
+	var phases [][]string
+	if diags := state.Phases.ElementsAs(context.Background(), &phases, false); diags.HasError() {
+		t.Fatalf("failed to read phases: %v", diags)
+	}
+	if len(phases) != 3 {
+		t.Fatalf("expected 3 phases, got %v", phases)
+	}
+	verifyStringSliceResult(t, phases[0], []string{"database", "monitoring"})
+	verifyStringSliceResult(t, phases[1], []string{"web"})
+	verifyStringSliceResult(t, phases[2], []string{"default"})
+}
+
+func TestMigrationPlanDataSourceReadCycle(t *testing.T) {
+	_, resp := readMigrationPlan(t, filepath.Join(getFixturePath("migration_plan"), "cyclic"))
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for a dependency cycle")
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "app -> config -> app") {
+		t.Fatalf("expected the cycle to be named, got %q", detail)
+	}
+}
+
+func TestMigrationPlanDataSourceReadNoDependencies(t *testing.T) {
+	state, resp := readMigrationPlan(t, getFixturePath("sample_cookbook"))
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+	verifyStringSliceResult(t, stringList(t, state.OrderedRecipes), []string{"default", "server"})
+	if len(state.Phases.Elements()) != 1 {
+		t.Fatalf("expected a single phase, got %v", state.Phases)
+	}
+}
+
+func TestMigrationPlanDataSourceReadErrors(t *testing.T) {
+	t.Run("missing recipes", func(t *testing.T) {
+		if _, resp := readMigrationPlan(t, t.TempDir()); !resp.Diagnostics.HasError() {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("CLI failure", func(t *testing.T) {
+		t.Setenv("SOUSCHEF_TEST_FAIL", "deps")
+		if _, resp := readMigrationPlan(t, filepath.Join(getFixturePath("migration_plan"), "ordered")); !resp.Diagnostics.HasError() {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestRecipeDependencies(t *testing.T) {
+	graph := dependencyGraph{
+		Cookbook: "web",
+		Dependencies: map[string][]string{
+			"web::default": {"web::server", "apt::default", "web::default"},
+			"server":       {"config"},
+			"other::x":     {"web::config"},
+		},
+	}
+
+	deps := recipeDependencies(graph, []string{"config", "default", "server"})
+	verifyStringSliceResult(t, deps["default"], []string{"server"})
+	verifyStringSliceResult(t, deps["server"], []string{"config"})
+	if len(deps) != 2 {
+		t.Fatalf("expected dependencies for 2 recipes, got %v", deps)
+	}
+}
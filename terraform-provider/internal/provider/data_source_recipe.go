@@ -0,0 +1,159 @@
+// Package provider implements the SousChef Terraform provider data sources
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource              = &recipeDataSource{}
+	_ datasource.DataSourceWithConfigure = &recipeDataSource{}
+)
+
+// NewRecipeDataSource creates a new recipe data source
+func NewRecipeDataSource() datasource.DataSource {
+	return &recipeDataSource{}
+}
+
+// recipeDataSource is the data source implementation
+type recipeDataSource struct {
+	client *SousChefClient
+}
+
+// recipeDataSourceModel describes the data source data model
+type recipeDataSourceModel struct {
+	ID            types.String          `tfsdk:"id"`
+	CookbookPath  types.String          `tfsdk:"cookbook_path"`
+	RecipeName    types.String          `tfsdk:"recipe_name"`
+	ResourceCount types.Int64           `tfsdk:"resource_count"`
+	Resources     []recipeResourceModel `tfsdk:"resources"`
+}
+
+// recipeResourceModel describes a single Chef resource declared in a recipe
+type recipeResourceModel struct {
+	Type types.String `tfsdk:"type"`
+	Name types.String `tfsdk:"name"`
+}
+
+// recipeInfo is the JSON output of the recipe-info command.
+type recipeInfo struct {
+	Resources []struct {
+		Type string `json:"type"`
+		Name string `json:"name"`
+	} `json:"resources"`
+}
+
+// Metadata returns the data source type name
+func (d *recipeDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_recipe"
+}
+
+// Schema defines the schema for the data source
+func (d *recipeDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Chef resources a recipe declares without converting it, e.g. to plan a migration recipe by recipe.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier (`<cookbook>-<recipe>`)",
+			},
+			"cookbook_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the Chef cookbook directory",
+			},
+			"recipe_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the recipe, without the `.rb` extension",
+			},
+			"resource_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of resources the recipe declares",
+			},
+			"resources": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resources declared in the recipe, in the order they are declared",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Resource type, e.g. `package` or `template`",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Resource name, e.g. `nginx` or `/etc/nginx/nginx.conf`",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *recipeDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureDataSource(req, resp)
+}
+
+// Read lists the recipe's resources through the SousChef CLI
+func (d *recipeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config recipeDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Report a missing recipe directly rather than as a CLI failure
+	cookbookPath := config.CookbookPath.ValueString()
+	recipeName := config.RecipeName.ValueString()
+	recipePath := filepath.Join(cookbookPath, "recipes", recipeName+".rb")
+	if _, err := osStat(recipePath); os.IsNotExist(err) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("recipe_name"),
+			"Recipe not found",
+			fmt.Sprintf("Cookbook %s has no recipe %q: %s does not exist.", cookbookPath, recipeName, recipePath),
+		)
+		return
+	}
+
+	args := []string{"recipe-info", "--cookbook-path", cookbookPath, "--recipe-name", recipeName, "--format", "json"}
+	output, ok := executeSousChefCommand(ctx, d.client, args, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	var recipe recipeInfo
+	if err := json.Unmarshal(output, &recipe); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing recipe",
+			fmt.Sprintf("Could not parse JSON output: %s", err),
+		)
+		return
+	}
+
+	resources := make([]recipeResourceModel, len(recipe.Resources))
+	for i, resource := range recipe.Resources {
+		resources[i] = recipeResourceModel{
+			Type: types.StringValue(resource.Type),
+			Name: types.StringValue(resource.Name),
+		}
+	}
+
+	config.ID = types.StringValue(fmt.Sprintf("%s-%s", d.client.cookbookName(cookbookPath), recipeName))
+	config.ResourceCount = types.Int64Value(int64(len(resources)))
+	config.Resources = resources
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
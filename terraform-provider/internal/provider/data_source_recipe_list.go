@@ -0,0 +1,121 @@
+// Package provider implements the SousChef Terraform provider data sources
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource              = &recipeListDataSource{}
+	_ datasource.DataSourceWithConfigure = &recipeListDataSource{}
+)
+
+// NewRecipeListDataSource creates a new recipe list data source
+func NewRecipeListDataSource() datasource.DataSource {
+	return &recipeListDataSource{}
+}
+
+// recipeListDataSource is the data source implementation
+type recipeListDataSource struct {
+	client *SousChefClient
+}
+
+// recipeListDataSourceModel describes the data source data model
+type recipeListDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	CookbookPath types.String `tfsdk:"cookbook_path"`
+	RecipeNames  types.List   `tfsdk:"recipe_names"`
+	RecipeCount  types.Int64  `tfsdk:"recipe_count"`
+}
+
+// Metadata returns the data source type name
+func (d *recipeListDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_recipe_list"
+}
+
+// Schema defines the schema for the data source
+func (d *recipeListDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the recipes in a Chef cookbook, e.g. to drive `for_each` over `souschef_migration` resources.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier (the cookbook path)",
+			},
+			"cookbook_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the Chef cookbook directory",
+			},
+			"recipe_names": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Sorted names of the recipes in `recipes/*.rb`, without extension",
+			},
+			"recipe_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of recipes in the cookbook",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *recipeListDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureDataSource(req, resp)
+}
+
+// Read lists the recipe files in the cookbook's recipes directory
+func (d *recipeListDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config recipeListDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cookbookPath := config.CookbookPath.ValueString()
+	recipeNames, err := listCookbookRecipes(cookbookPath)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing recipes",
+			fmt.Sprintf("Could not list recipes in %s: %s", cookbookPath, err),
+		)
+		return
+	}
+
+	config.ID = types.StringValue(cookbookPath)
+	config.RecipeNames = typesListFromStringSlice(recipeNames)
+	config.RecipeCount = types.Int64Value(int64(len(recipeNames)))
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// listCookbookRecipes returns the sorted names of the *.rb files in the
+// cookbook's recipes directory, without the extension.
+func listCookbookRecipes(cookbookPath string) ([]string, error) {
+	entries, err := osReadDir(filepath.Join(cookbookPath, "recipes"))
+	if err != nil {
+		return nil, err
+	}
+
+	recipeNames := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".rb" {
+			continue
+		}
+		recipeNames = append(recipeNames, strings.TrimSuffix(entry.Name(), ".rb"))
+	}
+	sort.Strings(recipeNames)
+	return recipeNames, nil
+}
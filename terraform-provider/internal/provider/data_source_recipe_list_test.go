@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// readRecipeList runs Read for cookbookPath and returns the response.
+func readRecipeList(t *testing.T, cookbookPath string) *datasource.ReadResponse {
+	t.Helper()
+
+	ds := &recipeListDataSource{}
+	schema := newDataSourceSchema(t, ds)
+
+	config := newDataSourceConfig(t, schema, recipeListDataSourceModel{
+		CookbookPath: types.StringValue(cookbookPath),
+		RecipeNames:  types.ListNull(types.StringType),
+	})
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+	return resp
+}
+
+func TestRecipeListDataSourceRead(t *testing.T) {
+	cookbookPath := t.TempDir()
+	recipesDir := filepath.Join(cookbookPath, "recipes")
+	if err := os.MkdirAll(filepath.Join(recipesDir, "partials"), testDirPermissions); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+	for _, name := range []string{"server.rb", "default.rb", "client.rb", "README.md"} {
+		if err := os.WriteFile(filepath.Join(recipesDir, name), []byte("# recipe\n"), testFilePermissions); err != nil {
+			t.Fatalf(testFailedToWriteFile, err)
+		}
+	}
+
+	resp := readRecipeList(t, cookbookPath)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var state recipeListDataSourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	var recipeNames []string
+	if diags := state.RecipeNames.ElementsAs(context.Background(), &recipeNames, false); diags.HasError() {
+		t.Fatalf("failed to read recipe_names: %v", diags)
+	}
+
+	verifyStringSliceResult(t, recipeNames, []string{"client", "default", "server"})
+	if state.RecipeCount.ValueInt64() != 3 {
+		t.Fatalf("expected recipe_count 3, got %d", state.RecipeCount.ValueInt64())
+	}
+}
+
+func TestRecipeListDataSourceMissingRecipesDirectory(t *testing.T) {
+	resp := readRecipeList(t, t.TempDir())
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when recipes directory is absent")
+	}
+}
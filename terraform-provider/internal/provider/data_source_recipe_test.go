@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testRecipeContent is a fixture recipe declaring several resources.
+const testRecipeContent = `package 'nginx' do
+  action :install
+end
+
+template '/etc/nginx/nginx.conf' do
+  source 'nginx.conf.erb'
+  notifies :reload, 'service[nginx]'
+end
+
+directory "/var/www/html" do
+  recursive true
+end
+
+service 'nginx' do
+  action [:enable, :start]
+end
+`
+
+// writeRecipe writes content as recipes/<recipeName>.rb of a new cookbook named web.
+func writeRecipe(t *testing.T, recipeName, content string) string {
+	t.Helper()
+
+	cookbookPath := filepath.Join(t.TempDir(), "web")
+	if err := os.MkdirAll(filepath.Join(cookbookPath, "recipes"), testDirPermissions); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+	if err := os.WriteFile(filepath.Join(cookbookPath, "recipes", recipeName+".rb"), []byte(content), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	return cookbookPath
+}
+
+// readRecipe runs Read for recipeName of the cookbook at cookbookPath and returns the resulting state.
+func readRecipe(t *testing.T, cookbookPath, recipeName string) (recipeDataSourceModel, *datasource.ReadResponse) {
+	t.Helper()
+
+	ds := &recipeDataSource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newDataSourceSchema(t, ds)
+	config := newDataSourceConfig(t, schema, recipeDataSourceModel{
+		CookbookPath: types.StringValue(cookbookPath),
+		RecipeName:   types.StringValue(recipeName),
+	})
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+
+	var state recipeDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+			t.Fatalf("failed to read state: %v", diags)
+		}
+	}
+	return state, resp
+}
+
+func TestRecipeDataSourceRead(t *testing.T) {
+	state, resp := readRecipe(t, writeRecipe(t, "server", testRecipeContent), "server")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	if state.ID.ValueString() != "web-server" || state.ResourceCount.ValueInt64() != 4 {
+		t.Fatalf("unexpected state: id=%s resource_count=%d", state.ID.ValueString(), state.ResourceCount.ValueInt64())
+	}
+	got := make([]string, len(state.Resources))
+	for i, resource := range state.Resources {
+		got[i] = resource.Type.ValueString() + "[" + resource.Name.ValueString() + "]"
+	}
+	want := []string{"package[nginx]", "template[/etc/nginx/nginx.conf]", "directory[/var/www/html]", "service[nginx]"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected resources %v, got %v", want, got)
+	}
+}
+
+func TestRecipeDataSourceReadEmptyRecipe(t *testing.T) {
+	state, resp := readRecipe(t, writeRecipe(t, "default", "# nothing yet\n"), "default")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+	if state.ResourceCount.ValueInt64() != 0 || state.Resources == nil || len(state.Resources) != 0 {
+		t.Fatalf("expected an empty resource list, got %+v", state.Resources)
+	}
+}
+
+func TestRecipeDataSourceReadMissingRecipe(t *testing.T) {
+	_, resp := readRecipe(t, writeRecipe(t, "default", testRecipeContent), "server")
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Recipe not found" ||
+		!strings.Contains(resp.Diagnostics.Errors()[0].Detail(), filepath.Join("recipes", "server.rb")) {
+		t.Fatalf("expected recipe not found error, got %v", resp.Diagnostics)
+	}
+}
+
+func TestRecipeDataSourceReadCLIError(t *testing.T) {
+	t.Setenv("SOUSCHEF_TEST_FAIL", "recipe-info")
+	_, resp := readRecipe(t, writeRecipe(t, "default", testRecipeContent), "default")
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when the CLI fails")
+	}
+}
@@ -0,0 +1,166 @@
+// Package provider implements the SousChef Terraform provider data sources
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource              = &roleDataSource{}
+	_ datasource.DataSourceWithConfigure = &roleDataSource{}
+)
+
+// NewRoleDataSource creates a new role data source
+func NewRoleDataSource() datasource.DataSource {
+	return &roleDataSource{}
+}
+
+// roleDataSource is the data source implementation
+type roleDataSource struct {
+	client *SousChefClient
+}
+
+// roleDataSourceModel describes the data source data model
+type roleDataSourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	RolePath           types.String `tfsdk:"role_path"`
+	Name               types.String `tfsdk:"name"`
+	RunList            types.List   `tfsdk:"run_list"`
+	DefaultAttributes  types.Map    `tfsdk:"default_attributes"`
+	OverrideAttributes types.Map    `tfsdk:"override_attributes"`
+}
+
+// roleInfo is the JSON output of the role-info command.
+type roleInfo struct {
+	Name               string                 `json:"name"`
+	RunList            []string               `json:"run_list"`
+	DefaultAttributes  map[string]interface{} `json:"default_attributes"`
+	OverrideAttributes map[string]interface{} `json:"override_attributes"`
+}
+
+// Metadata returns the data source type name
+func (d *roleDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role"
+}
+
+// Schema defines the schema for the data source
+func (d *roleDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads a Chef role's run-list and attributes without converting it, e.g. to decide how to group recipes into migrations.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier (the role path)",
+			},
+			"role_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the Chef role file, in JSON (`.json`) or Ruby DSL (`.rb`) format",
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the role",
+			},
+			"run_list": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Run-list entries in order, e.g. `recipe[nginx::default]` or `role[base]`",
+			},
+			"default_attributes": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Default attributes keyed by dotted attribute path. Values that are not strings are JSON-encoded",
+			},
+			"override_attributes": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Override attributes keyed by dotted attribute path. Values that are not strings are JSON-encoded",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *roleDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureDataSource(req, resp)
+}
+
+// Read loads the role through the SousChef CLI
+func (d *roleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config roleDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The CLI understands both JSON and Ruby DSL roles
+	rolePath := config.RolePath.ValueString()
+	args := []string{"role-info", "--role-path", rolePath, "--format", "json"}
+	output, ok := executeSousChefCommand(ctx, d.client, args, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	var role roleInfo
+	if err := json.Unmarshal(output, &role); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing role",
+			fmt.Sprintf("Could not parse JSON output: %s", err),
+		)
+		return
+	}
+
+	defaultAttributes, mapDiags := typesMapValueFrom(ctx, types.StringType, flattenRoleAttributes(role.DefaultAttributes))
+	resp.Diagnostics.Append(mapDiags...)
+	overrideAttributes, mapDiags := typesMapValueFrom(ctx, types.StringType, flattenRoleAttributes(role.OverrideAttributes))
+	resp.Diagnostics.Append(mapDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.ID = types.StringValue(rolePath)
+	config.Name = types.StringValue(role.Name)
+	config.RunList = typesListFromStringSlice(role.RunList)
+	config.DefaultAttributes = defaultAttributes
+	config.OverrideAttributes = overrideAttributes
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// flattenRoleAttributes flattens nested role attributes into a map keyed by
+// dotted attribute path, e.g. {"nginx": {"port": 80}} becomes
+// {"nginx.port": "80"}. Strings are kept as-is and any other leaf value,
+// including lists and empty hashes, is JSON-encoded.
+func flattenRoleAttributes(attributes map[string]interface{}) map[string]string {
+	flattened := make(map[string]string)
+
+	var walk func(prefix string, value interface{})
+	walk = func(prefix string, value interface{}) {
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			for key, child := range nested {
+				walk(prefix+"."+key, child)
+			}
+			return
+		}
+		if s, ok := value.(string); ok {
+			flattened[prefix] = s
+			return
+		}
+		encoded, _ := json.Marshal(value)
+		flattened[prefix] = string(encoded)
+	}
+
+	for key, value := range attributes {
+		walk(key, value)
+	}
+	return flattened
+}
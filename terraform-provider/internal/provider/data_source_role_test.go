@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testJSONRole is a Chef role in JSON format.
+const testJSONRole = `{
+  "name": "webserver",
+  "json_class": "Chef::Role",
+  "chef_type": "role",
+  "run_list": ["role[base]", "recipe[nginx::default]", "recipe[app]"],
+  "default_attributes": {
+    "nginx": {"port": 8080, "worker_processes": "auto", "modules": ["gzip", "ssl"]},
+    "app": {"enabled": true}
+  },
+  "override_attributes": {
+    "nginx": {"port": 80}
+  }
+}`
+
+// testRubyRole is a Chef role in Ruby DSL format.
+const testRubyRole = `name "database"
+description "Database servers"
+run_list "recipe[postgresql::server]", "recipe[backup]"
+default_attributes "postgresql" => { "version" => "15" }
+`
+
+// readRole writes content to a role file named filename, runs Read and
+// returns the resulting state.
+func readRole(t *testing.T, filename, content string) (roleDataSourceModel, *datasource.ReadResponse) {
+	t.Helper()
+
+	rolePath := filepath.Join(t.TempDir(), filename)
+	if err := os.WriteFile(rolePath, []byte(content), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+
+	ds := &roleDataSource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newDataSourceSchema(t, ds)
+	config := newDataSourceConfig(t, schema, roleDataSourceModel{
+		RolePath:           types.StringValue(rolePath),
+		RunList:            types.ListNull(types.StringType),
+		DefaultAttributes:  types.MapNull(types.StringType),
+		OverrideAttributes: types.MapNull(types.StringType),
+	})
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+
+	var state roleDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+			t.Fatalf("failed to read state: %v", diags)
+		}
+	}
+	return state, resp
+}
+
+// stringMap converts a types.Map of strings to a Go map.
+func stringMap(t *testing.T, m types.Map) map[string]string {
+	t.Helper()
+
+	values := make(map[string]string)
+	if diags := m.ElementsAs(context.Background(), &values, false); diags.HasError() {
+		t.Fatalf("failed to read map: %v", diags)
+	}
+	return values
+}
+
+func TestRoleDataSourceReadJSON(t *testing.T) {
+	state, resp := readRole(t, "webserver.json", testJSONRole)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	if state.Name.ValueString() != "webserver" {
+		t.Errorf("expected name webserver, got %q", state.Name.ValueString())
+	}
+	if got, want := stringList(t, state.RunList), []string{"role[base]", "recipe[nginx::default]", "recipe[app]"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected run_list %v, got %v", want, got)
+	}
+
+	wantDefaults := map[string]string{
+		"nginx.port":             "8080",
+		"nginx.worker_processes": "auto",
+		"nginx.modules":          `["gzip","ssl"]`,
+		"app.enabled":            "true",
+	}
+	if got := stringMap(t, state.DefaultAttributes); !reflect.DeepEqual(got, wantDefaults) {
+		t.Errorf("expected default_attributes %v, got %v", wantDefaults, got)
+	}
+	if got, want := stringMap(t, state.OverrideAttributes), map[string]string{"nginx.port": "80"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected override_attributes %v, got %v", want, got)
+	}
+}
+
+func TestRoleDataSourceReadRuby(t *testing.T) {
+	state, resp := readRole(t, "database.rb", testRubyRole)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	if state.Name.ValueString() != "database" {
+		t.Errorf("expected name database, got %q", state.Name.ValueString())
+	}
+	if got, want := stringList(t, state.RunList), []string{"recipe[postgresql::server]", "recipe[backup]"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected run_list %v, got %v", want, got)
+	}
+	if got, want := stringMap(t, state.DefaultAttributes), map[string]string{"postgresql.version": "15"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected default_attributes %v, got %v", want, got)
+	}
+	if state.OverrideAttributes.IsNull() || len(state.OverrideAttributes.Elements()) != 0 {
+		t.Errorf("expected empty override_attributes, got %v", state.OverrideAttributes)
+	}
+}
+
+func TestRoleDataSourceReadErrors(t *testing.T) {
+	t.Run("unsupported format", func(t *testing.T) {
+		_, resp := readRole(t, "role.yaml", "name: web\n")
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("expected error for an unsupported role format")
+		}
+	})
+
+	t.Run("invalid output", func(t *testing.T) {
+		_, resp := readRole(t, "broken.json", "not json")
+		if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Error parsing role" {
+			t.Fatalf("expected parse error, got %v", resp.Diagnostics)
+		}
+	})
+
+	t.Run("cli failure", func(t *testing.T) {
+		t.Setenv("SOUSCHEF_TEST_FAIL", "role-info")
+		_, resp := readRole(t, "webserver.json", testJSONRole)
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("expected error when the CLI fails")
+		}
+	})
+}
+
+func TestFlattenRoleAttributes(t *testing.T) {
+	got := flattenRoleAttributes(map[string]interface{}{
+		"top":   "value",
+		"empty": map[string]interface{}{},
+		"deep":  map[string]interface{}{"a": map[string]interface{}{"b": nil}},
+	})
+	want := map[string]string{"top": "value", "empty": "{}", "deep.a.b": "null"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
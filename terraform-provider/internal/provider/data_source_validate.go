@@ -0,0 +1,150 @@
+// Package provider implements the SousChef Terraform provider data sources
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource              = &validateDataSource{}
+	_ datasource.DataSourceWithConfigure = &validateDataSource{}
+)
+
+// NewValidateDataSource creates a new validate data source
+func NewValidateDataSource() datasource.DataSource {
+	return &validateDataSource{}
+}
+
+// validateDataSource is the data source implementation
+type validateDataSource struct {
+	client *SousChefClient
+}
+
+// validateDataSourceModel describes the data source data model
+type validateDataSourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	CookbookPath        types.String `tfsdk:"cookbook_path"`
+	FailOnInvalid       types.Bool   `tfsdk:"fail_on_invalid"`
+	Valid               types.Bool   `tfsdk:"valid"`
+	UnsupportedFeatures types.List   `tfsdk:"unsupported_features"`
+	Warnings            types.List   `tfsdk:"warnings"`
+}
+
+// cookbookValidation is the JSON output of the validate command
+type cookbookValidation struct {
+	Valid               bool     `json:"valid"`
+	UnsupportedFeatures []string `json:"unsupported_features"`
+	Warnings            []string `json:"warnings"`
+}
+
+// Metadata returns the data source type name
+func (d *validateDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_validate"
+}
+
+// Schema defines the schema for the data source
+func (d *validateDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Checks whether a cookbook can be converted, reporting any unsupported constructs.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier (the cookbook path)",
+			},
+			"cookbook_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the Chef cookbook directory",
+			},
+			"fail_on_invalid": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Raise an error when the cookbook is not convertible (default: false)",
+			},
+			"valid": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the cookbook can be converted",
+			},
+			"unsupported_features": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Chef constructs that SousChef cannot convert",
+			},
+			"warnings": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Constructs that convert but may need manual review",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *validateDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureDataSource(req, resp)
+}
+
+// Read validates the cookbook with the SousChef CLI and records the result
+func (d *validateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config validateDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cookbookPath := config.CookbookPath.ValueString()
+	validation, ok := validateCookbook(ctx, d.client, cookbookPath, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	if !validation.Valid && config.FailOnInvalid.ValueBool() {
+		addCookbookNotConvertibleError(cookbookPath, validation, &resp.Diagnostics)
+		return
+	}
+
+	config.ID = types.StringValue(cookbookPath)
+	config.Valid = types.BoolValue(validation.Valid)
+	config.UnsupportedFeatures = typesListFromStringSlice(validation.UnsupportedFeatures)
+	config.Warnings = typesListFromStringSlice(validation.Warnings)
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// validateCookbook runs `souschef validate` for cookbookPath and parses its
+// JSON output, adding any failure to diagnostics.
+func validateCookbook(ctx context.Context, client *SousChefClient, cookbookPath string, diagnostics *diag.Diagnostics) (cookbookValidation, bool) {
+	var validation cookbookValidation
+	args := []string{"validate", "--cookbook-path", cookbookPath, "--format", "json"}
+	output, ok := executeSousChefCommand(ctx, client, args, diagnostics)
+	if !ok {
+		return validation, false
+	}
+
+	if err := json.Unmarshal(output, &validation); err != nil {
+		diagnostics.AddError(
+			"Error parsing validation",
+			fmt.Sprintf("Could not parse JSON output: %s", err),
+		)
+		return validation, false
+	}
+	return validation, true
+}
+
+// addCookbookNotConvertibleError reports that cookbookPath failed validation.
+func addCookbookNotConvertibleError(cookbookPath string, validation cookbookValidation, diagnostics *diag.Diagnostics) {
+	diagnostics.AddError(
+		"Cookbook is not convertible",
+		fmt.Sprintf("%s uses unsupported features: %s", cookbookPath, strings.Join(validation.UnsupportedFeatures, ", ")),
+	)
+}
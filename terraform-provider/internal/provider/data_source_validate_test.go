@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testInvalidValidation = `{"valid":false,"unsupported_features":["ruby_block","chef_gem"],"warnings":["search() call"]}`
+
+// readValidate runs Read for cookbookPath against the fake CLI and returns the response.
+func readValidate(t *testing.T, cookbookPath string, failOnInvalid types.Bool) *datasource.ReadResponse {
+	t.Helper()
+
+	ds := &validateDataSource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newDataSourceSchema(t, ds)
+
+	config := newDataSourceConfig(t, schema, validateDataSourceModel{
+		CookbookPath:        types.StringValue(cookbookPath),
+		FailOnInvalid:       failOnInvalid,
+		UnsupportedFeatures: types.ListNull(types.StringType),
+		Warnings:            types.ListNull(types.StringType),
+	})
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+	return resp
+}
+
+// newInvalidCookbookFixture creates a cookbook whose fake validation reports unsupported features.
+func newInvalidCookbookFixture(t *testing.T) string {
+	t.Helper()
+
+	cookbookPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(cookbookPath, "validation.json"), []byte(testInvalidValidation), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	return cookbookPath
+}
+
+func TestValidateDataSourceReadValid(t *testing.T) {
+	resp := readValidate(t, t.TempDir(), types.BoolValue(true))
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var state validateDataSourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if !state.Valid.ValueBool() {
+		t.Fatal("expected cookbook to be valid")
+	}
+	if len(state.UnsupportedFeatures.Elements()) != 0 || len(state.Warnings.Elements()) != 0 {
+		t.Fatalf("expected no findings, got %v and %v", state.UnsupportedFeatures, state.Warnings)
+	}
+}
+
+func TestValidateDataSourceReadInvalid(t *testing.T) {
+	resp := readValidate(t, newInvalidCookbookFixture(t), types.BoolNull())
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var state validateDataSourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.Valid.ValueBool() {
+		t.Fatal("expected cookbook to be invalid")
+	}
+
+	var unsupported, warnings []string
+	state.UnsupportedFeatures.ElementsAs(context.Background(), &unsupported, false)
+	state.Warnings.ElementsAs(context.Background(), &warnings, false)
+	verifyStringSliceResult(t, unsupported, []string{"ruby_block", "chef_gem"})
+	verifyStringSliceResult(t, warnings, []string{"search() call"})
+}
+
+func TestValidateDataSourceReadFailOnInvalid(t *testing.T) {
+	resp := readValidate(t, newInvalidCookbookFixture(t), types.BoolValue(true))
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when fail_on_invalid is set for an invalid cookbook")
+	}
+}
+
+func TestValidateDataSourceReadCommandError(t *testing.T) {
+	t.Setenv("SOUSCHEF_TEST_FAIL", "validate")
+
+	resp := readValidate(t, t.TempDir(), types.BoolNull())
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when the CLI fails")
+	}
+}
+
+func TestValidateDataSourceReadBadJSON(t *testing.T) {
+	cookbookPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(cookbookPath, "validation.json"), []byte("{bad json"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+
+	resp := readValidate(t, cookbookPath, types.BoolNull())
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid JSON output")
+	}
+}
@@ -0,0 +1,130 @@
+// Package provider implements the SousChef Terraform provider data sources
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// unknownVersion is reported when a version cannot be determined.
+const unknownVersion = "unknown"
+
+// versionFromOutput returns the version in `souschef --version` output: the
+// last word of its first non-empty line, e.g. "3.2.1" from "souschef 3.2.1".
+func versionFromOutput(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			return fields[len(fields)-1]
+		}
+	}
+	return ""
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource              = &versionDataSource{}
+	_ datasource.DataSourceWithConfigure = &versionDataSource{}
+)
+
+// NewVersionDataSource creates a new version data source
+func NewVersionDataSource() datasource.DataSource {
+	return &versionDataSource{}
+}
+
+// versionDataSource is the data source implementation
+type versionDataSource struct {
+	client *SousChefClient
+}
+
+// versionDataSourceModel describes the data source data model
+type versionDataSourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	ProviderVersion types.String `tfsdk:"provider_version"`
+	CLIVersion      types.String `tfsdk:"cli_version"`
+	CLIPath         types.String `tfsdk:"cli_path"`
+}
+
+// Metadata returns the data source type name
+func (d *versionDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_version"
+}
+
+// Schema defines the schema for the data source
+func (d *versionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports the provider and SousChef CLI versions, for debugging and for gating configuration on CLI features.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier (the CLI path)",
+			},
+			"provider_version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Version of the SousChef Terraform provider",
+			},
+			"cli_version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Version reported by `souschef --version`, or `unknown` when the CLI does not support the flag",
+			},
+			"cli_path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Path of the SousChef CLI executable, resolved against `PATH` when it is found there",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *versionDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client = configureDataSource(req, resp)
+}
+
+// Read reports the provider version and asks the CLI for its version
+func (d *versionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config versionDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cliPath := d.client.Path
+	if resolved, err := execLookPath(cliPath); err == nil {
+		cliPath = resolved
+	}
+
+	providerVersion := d.client.ProviderVersion
+	if providerVersion == "" {
+		providerVersion = unknownVersion
+	}
+
+	// Older CLIs have no --version flag; report the version as unknown
+	cliVersion := unknownVersion
+	output, err := runCLI(ctx, d.client, nil, "--version")
+	if version := versionFromOutput(string(output)); err == nil && version != "" {
+		cliVersion = version
+	} else {
+		detail := "The CLI printed no version."
+		if err != nil {
+			detail = diagnosticFromError(err).Detail()
+		}
+		resp.Diagnostics.AddWarning(
+			"Unable to determine SousChef CLI version",
+			fmt.Sprintf("souschef --version did not report a version, so cli_version is %q. The CLI may predate the --version flag. %s", unknownVersion, detail),
+		)
+	}
+
+	config.ID = types.StringValue(cliPath)
+	config.ProviderVersion = types.StringValue(providerVersion)
+	config.CLIVersion = types.StringValue(cliVersion)
+	config.CLIPath = types.StringValue(cliPath)
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// readVersion runs Read against client and returns the response and state.
+func readVersion(t *testing.T, client *SousChefClient) (*datasource.ReadResponse, versionDataSourceModel) {
+	t.Helper()
+
+	ds := &versionDataSource{client: client}
+	schema := newDataSourceSchema(t, ds)
+
+	config := newDataSourceConfig(t, schema, versionDataSourceModel{})
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	ds.Read(context.Background(), datasource.ReadRequest{Config: config}, resp)
+
+	var state versionDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+			t.Fatalf("failed to read state: %v", diags)
+		}
+	}
+	return resp, state
+}
+
+func TestVersionDataSourceRead(t *testing.T) {
+	cliPath := newFakeSousChef(t)
+	resp, state := readVersion(t, &SousChefClient{Path: cliPath, ProviderVersion: "1.4.0"})
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 0 {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	if state.ProviderVersion.ValueString() != "1.4.0" {
+		t.Errorf("unexpected provider_version %q", state.ProviderVersion.ValueString())
+	}
+	if state.CLIVersion.ValueString() != "3.2.1" {
+		t.Errorf("unexpected cli_version %q", state.CLIVersion.ValueString())
+	}
+	if state.CLIPath.ValueString() != cliPath || state.ID.ValueString() != cliPath {
+		t.Errorf("unexpected cli_path %q and id %q", state.CLIPath.ValueString(), state.ID.ValueString())
+	}
+}
+
+func TestVersionDataSourceReadCLIWithoutVersionFlag(t *testing.T) {
+	t.Setenv("SOUSCHEF_TEST_FAIL", "--version")
+
+	resp, state := readVersion(t, &SousChefClient{Path: newFakeSousChef(t)})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning, got %v", resp.Diagnostics)
+	}
+
+	if state.CLIVersion.ValueString() != unknownVersion {
+		t.Errorf("expected cli_version %q, got %q", unknownVersion, state.CLIVersion.ValueString())
+	}
+	if state.ProviderVersion.ValueString() != unknownVersion {
+		t.Errorf("expected provider_version %q without an injected version, got %q", unknownVersion, state.ProviderVersion.ValueString())
+	}
+}
+
+func TestVersionFromOutput(t *testing.T) {
+	tests := map[string]string{
+		"souschef 3.2.1\n":            "3.2.1",
+		"\nsouschef, version 2.0.0\n": "2.0.0",
+		"4.0.0":                       "4.0.0",
+		"":                            "",
+		"  \n\t\n":                    "",
+		"souschef 1.0.0\nbuilt today": "1.0.0",
+	}
+	for output, want := range tests {
+		if got := versionFromOutput(output); got != want {
+			t.Errorf("versionFromOutput(%q) = %q, want %q", output, got, want)
+		}
+	}
+}
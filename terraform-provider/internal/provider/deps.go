@@ -10,9 +10,19 @@ import (
 
 var (
 	execCommandContext = exec.CommandContext
+	execLookPath       = exec.LookPath
 	osMkdirAll         = os.MkdirAll
 	osReadFile         = os.ReadFile
+	osReadDir          = os.ReadDir
 	osStat             = os.Stat
 	osRemove           = os.Remove
+	osRename           = os.Rename
+	osMkdirTemp        = os.MkdirTemp
+	osOpen             = os.Open
+	osCreateTemp       = os.CreateTemp
+	osRemoveAll        = os.RemoveAll
+	osWriteFile        = os.WriteFile
+	osChmod            = os.Chmod
+	osUserCacheDir     = os.UserCacheDir
 	typesMapValueFrom  = types.MapValueFrom
 )
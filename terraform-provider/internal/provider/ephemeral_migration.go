@@ -0,0 +1,181 @@
+// Package provider implements the SousChef Terraform provider ephemeral resources
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// migrationPreviewDirKey is the private data key holding the temporary
+// directory a preview conversion was written to.
+const migrationPreviewDirKey = "preview_dir"
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ ephemeral.EphemeralResource                   = &migrationEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure      = &migrationEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithValidateConfig = &migrationEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose          = &migrationEphemeralResource{}
+)
+
+// NewMigrationEphemeralResource creates a new preview-only migration
+func NewMigrationEphemeralResource() ephemeral.EphemeralResource {
+	return &migrationEphemeralResource{}
+}
+
+// migrationEphemeralResource converts a recipe without storing anything in state.
+type migrationEphemeralResource struct {
+	client *SousChefClient
+}
+
+// migrationEphemeralResourceModel describes the ephemeral resource data model.
+type migrationEphemeralResourceModel struct {
+	CookbookPath    types.String `tfsdk:"cookbook_path"`
+	RecipeName      types.String `tfsdk:"recipe_name"`
+	OutputSyntax    types.String `tfsdk:"output_syntax"`
+	PlaybookPath    types.String `tfsdk:"playbook_path"`
+	PlaybookContent types.String `tfsdk:"playbook_content"`
+}
+
+// Metadata returns the ephemeral resource type name.
+func (e *migrationEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_migration"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (e *migrationEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Previews a Chef recipe to Ansible playbook conversion without storing the result in state.",
+		Attributes: map[string]schema.Attribute{
+			"cookbook_path": schema.StringAttribute{
+				Description: "Path to the Chef cookbook directory.",
+				Required:    true,
+			},
+			"recipe_name": schema.StringAttribute{
+				Description: "Name of the recipe to convert (default: 'default').",
+				Optional:    true,
+			},
+			"output_syntax": schema.StringAttribute{
+				Description: "Syntax of the generated playbook: 'yaml' or 'json' (default: 'yaml').",
+				Optional:    true,
+			},
+			"playbook_path": schema.StringAttribute{
+				Description: "Temporary path of the generated playbook. The file is removed when Terraform closes the ephemeral resource.",
+				Computed:    true,
+			},
+			"playbook_content": schema.StringAttribute{
+				Description: "Generated Ansible playbook content.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (e *migrationEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*SousChefClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *SousChefClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	e.client = client
+}
+
+// ValidateConfig rejects an invalid output_syntax.
+func (e *migrationEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	validateOutputSyntax(ctx, req.Config, &resp.Diagnostics)
+}
+
+// Open converts the recipe into a temporary directory and returns the playbook.
+func (e *migrationEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data migrationEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recipeName := "default"
+	if !data.RecipeName.IsNull() && data.RecipeName.ValueString() != "" {
+		recipeName = data.RecipeName.ValueString()
+	}
+
+	previewDir, err := osMkdirTemp("", "souschef-preview-")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating preview directory",
+			fmt.Sprintf("Could not create temporary directory: %s", err),
+		)
+		return
+	}
+
+	converter := &migrationResource{client: e.client}
+	content, _, err := converter.runConversion(ctx, data.CookbookPath.ValueString(), recipeName, previewDir, "", data.OutputSyntax, types.StringNull(), types.StringNull(), types.StringNull(), &resp.Diagnostics)
+	if err != nil {
+		removePreviewDir(ctx, previewDir)
+		addConversionError(&resp.Diagnostics, "Could not read generated playbook", err)
+		return
+	}
+
+	// Keep the playbook on disk until Close so other resources can read it.
+	// Marshaling a string cannot fail.
+	privateDir, _ := json.Marshal(previewDir)
+	if diags := resp.Private.SetKey(ctx, migrationPreviewDirKey, privateDir); diags.HasError() {
+		removePreviewDir(ctx, previewDir)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	data.RecipeName = types.StringValue(recipeName)
+	playbookPath, _ := findPlaybookFile(previewDir, recipeName, data.OutputSyntax, types.StringNull())
+	data.PlaybookPath = types.StringValue(playbookPath)
+	data.PlaybookContent = types.StringValue(string(content))
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// Close removes the temporary directory the preview was written to.
+func (e *migrationEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	privateDir, diags := req.Private.GetKey(ctx, migrationPreviewDirKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || privateDir == nil {
+		return
+	}
+
+	var previewDir string
+	if err := json.Unmarshal(privateDir, &previewDir); err != nil {
+		resp.Diagnostics.AddWarning(
+			"Error reading preview directory",
+			fmt.Sprintf("Could not determine the temporary directory to remove: %s", err),
+		)
+		return
+	}
+	if err := osRemoveAll(previewDir); err != nil {
+		resp.Diagnostics.AddWarning(
+			"Error removing preview directory",
+			fmt.Sprintf("Could not remove %s: %s", previewDir, err),
+		)
+	}
+}
+
+// removePreviewDir removes a preview directory after a failed Open.
+func removePreviewDir(ctx context.Context, previewDir string) {
+	if err := osRemoveAll(previewDir); err != nil {
+		tflog.Warn(ctx, "Failed to remove preview directory", map[string]interface{}{
+			"path":  previewDir,
+			"error": err.Error(),
+		})
+	}
+}
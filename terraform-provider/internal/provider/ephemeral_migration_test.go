@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// newEphemeralTestServer returns a provider server configured with the fake
+// SousChef CLI, and the object type of the souschef_migration ephemeral resource.
+func newEphemeralTestServer(t *testing.T) (tfprotov6.ProviderServer, tftypes.Object) {
+	t.Helper()
+
+	server := providerserver.NewProtocol6(New("test")())()
+	schemaResp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil || len(schemaResp.Diagnostics) > 0 {
+		t.Fatalf("failed to get provider schema: %v %v", err, schemaResp.Diagnostics)
+	}
+
+	providerType := schemaResp.Provider.ValueType().(tftypes.Object)
+	providerConfig, err := tfprotov6.NewDynamicValue(providerType, tftypes.NewValue(providerType, nullFilledValues(providerType.AttributeTypes, map[string]tftypes.Value{
+		"souschef_path": tftypes.NewValue(tftypes.String, newFakeSousChef(t)),
+	})))
+	if err != nil {
+		t.Fatalf("failed to build provider config: %v", err)
+	}
+	configureResp, err := server.ConfigureProvider(context.Background(), &tfprotov6.ConfigureProviderRequest{Config: &providerConfig})
+	if err != nil || len(configureResp.Diagnostics) > 0 {
+		t.Fatalf("failed to configure provider: %v %v", err, configureResp.Diagnostics)
+	}
+
+	ephemeralSchema, ok := schemaResp.EphemeralResourceSchemas["souschef_migration"]
+	if !ok {
+		t.Fatal("expected souschef_migration ephemeral resource schema")
+	}
+	return server, ephemeralSchema.ValueType().(tftypes.Object)
+}
+
+// ephemeralMigrationConfig builds the ephemeral resource config from values.
+func ephemeralMigrationConfig(t *testing.T, objectType tftypes.Object, values map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	config, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, nullFilledValues(objectType.AttributeTypes, values)))
+	if err != nil {
+		t.Fatalf("failed to build config: %v", err)
+	}
+	return &config
+}
+
+func TestMigrationEphemeralResourceOpenClose(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	server, objectType := newEphemeralTestServer(t)
+
+	openResp, err := server.OpenEphemeralResource(context.Background(), &tfprotov6.OpenEphemeralResourceRequest{
+		TypeName: "souschef_migration",
+		Config: ephemeralMigrationConfig(t, objectType, map[string]tftypes.Value{
+			"cookbook_path": tftypes.NewValue(tftypes.String, testTmpCookbook),
+		}),
+	})
+	if err != nil || len(openResp.Diagnostics) > 0 {
+		t.Fatalf("unexpected Open failure: %v %v", err, openResp.Diagnostics)
+	}
+
+	result, err := openResp.Result.Unmarshal(objectType)
+	if err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	var attributes map[string]tftypes.Value
+	if err := result.As(&attributes); err != nil {
+		t.Fatalf("failed to decode result attributes: %v", err)
+	}
+	var recipeName, playbookPath, playbookContent string
+	for name, target := range map[string]*string{"recipe_name": &recipeName, "playbook_path": &playbookPath, "playbook_content": &playbookContent} {
+		if err := attributes[name].As(target); err != nil {
+			t.Fatalf("failed to decode %s: %v", name, err)
+		}
+	}
+	if recipeName != "default" || playbookContent == "" {
+		t.Fatalf("unexpected result: recipe_name %q, playbook_content %q", recipeName, playbookContent)
+	}
+
+	// The playbook stays on disk until the ephemeral resource is closed
+	if _, err := os.Stat(playbookPath); err != nil {
+		t.Fatalf("expected preview playbook at %s: %v", playbookPath, err)
+	}
+
+	closeResp, err := server.CloseEphemeralResource(context.Background(), &tfprotov6.CloseEphemeralResourceRequest{
+		TypeName: "souschef_migration",
+		Private:  openResp.Private,
+	})
+	if err != nil || len(closeResp.Diagnostics) > 0 {
+		t.Fatalf("unexpected Close failure: %v %v", err, closeResp.Diagnostics)
+	}
+	if _, err := os.Stat(playbookPath); !os.IsNotExist(err) {
+		t.Fatalf("expected preview playbook to be removed on Close, got %v", err)
+	}
+}
+
+func TestMigrationEphemeralResourceOpenFailureRemovesPreviewDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TMPDIR", tmpDir)
+	t.Setenv("SOUSCHEF_TEST_FAIL", "convert-recipe")
+	server, objectType := newEphemeralTestServer(t)
+
+	openResp, err := server.OpenEphemeralResource(context.Background(), &tfprotov6.OpenEphemeralResourceRequest{
+		TypeName: "souschef_migration",
+		Config: ephemeralMigrationConfig(t, objectType, map[string]tftypes.Value{
+			"cookbook_path": tftypes.NewValue(tftypes.String, testTmpCookbook),
+		}),
+	})
+	if err != nil || len(openResp.Diagnostics) == 0 {
+		t.Fatalf("expected Open to fail, got %v %v", err, openResp.Diagnostics)
+	}
+
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to list %s: %v", tmpDir, err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected preview directory to be removed, found %s", entries[0].Name())
+	}
+}
+
+func TestMigrationEphemeralResourceValidateConfig(t *testing.T) {
+	server, objectType := newEphemeralTestServer(t)
+
+	resp, err := server.ValidateEphemeralResourceConfig(context.Background(), &tfprotov6.ValidateEphemeralResourceConfigRequest{
+		TypeName: "souschef_migration",
+		Config: ephemeralMigrationConfig(t, objectType, map[string]tftypes.Value{
+			"cookbook_path": tftypes.NewValue(tftypes.String, testTmpCookbook),
+			"output_syntax": tftypes.NewValue(tftypes.String, "toml"),
+		}),
+	})
+	if err != nil || len(resp.Diagnostics) == 0 {
+		t.Fatalf("expected a diagnostic for invalid output_syntax, got %v %v", err, resp.Diagnostics)
+	}
+}
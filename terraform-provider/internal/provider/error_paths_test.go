@@ -29,15 +29,12 @@ func TestProviderConfigureWithConfig(t *testing.T) {
 	p.Schema(context.Background(), schemaReq, schemaResp)
 
 	// Create a config value
+	configType := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
 	configValue := tftypes.NewValue(
-		tftypes.Object{
-			AttributeTypes: map[string]tftypes.Type{
-				"souschef_path": tftypes.String,
-			},
-		},
-		map[string]tftypes.Value{
+		configType,
+		nullFilledValues(configType.AttributeTypes, map[string]tftypes.Value{
 			"souschef_path": tftypes.NewValue(tftypes.String, "/custom/path/souschef"),
-		},
+		}),
 	)
 
 	// Create config
@@ -87,15 +84,12 @@ func TestProviderConfigureWithDefault(t *testing.T) {
 	p.Schema(context.Background(), schemaReq, schemaResp)
 
 	// Create a config value with null souschef_path (to test default)
+	configType := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
 	configValue := tftypes.NewValue(
-		tftypes.Object{
-			AttributeTypes: map[string]tftypes.Type{
-				"souschef_path": tftypes.String,
-			},
-		},
-		map[string]tftypes.Value{
+		configType,
+		nullFilledValues(configType.AttributeTypes, map[string]tftypes.Value{
 			"souschef_path": tftypes.NewValue(tftypes.String, nil), // null value
-		},
+		}),
 	)
 
 	config := tfsdk.Config{
@@ -155,6 +149,7 @@ func TestDataSourceAssessmentWithConfig(t *testing.T) {
 				"resource_count":  tftypes.Number,
 				"estimated_hours": tftypes.Number,
 				"recommendations": tftypes.String,
+				"timeouts":        tftypes.Object{AttributeTypes: map[string]tftypes.Type{"read": tftypes.String}},
 			},
 		},
 		map[string]tftypes.Value{
@@ -165,6 +160,7 @@ func TestDataSourceAssessmentWithConfig(t *testing.T) {
 			"resource_count":  tftypes.NewValue(tftypes.Number, nil),
 			"estimated_hours": tftypes.NewValue(tftypes.Number, nil),
 			"recommendations": tftypes.NewValue(tftypes.String, nil),
+			"timeouts":        tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{"read": tftypes.String}}, nil),
 		},
 	)
 
@@ -220,23 +216,16 @@ func TestResourceMigrationCreateWithConfig(t *testing.T) {
 	// Create a plan value
 	planValue := tftypes.NewValue(
 		tftypes.Object{
-			AttributeTypes: map[string]tftypes.Type{
-				"id":               tftypes.String,
-				"cookbook_path":    tftypes.String,
-				"output_path":      tftypes.String,
-				"recipe_name":      tftypes.String,
-				"cookbook_name":    tftypes.String,
-				"playbook_content": tftypes.String,
-			},
+			AttributeTypes: migrationAttributeTypes,
 		},
-		map[string]tftypes.Value{
+		nullFilledValues(migrationAttributeTypes, map[string]tftypes.Value{
 			"id":               tftypes.NewValue(tftypes.String, nil),
 			"cookbook_path":    tftypes.NewValue(tftypes.String, cookbookPath),
 			"output_path":      tftypes.NewValue(tftypes.String, outputPath),
 			"recipe_name":      tftypes.NewValue(tftypes.String, "default"),
 			"cookbook_name":    tftypes.NewValue(tftypes.String, nil),
 			"playbook_content": tftypes.NewValue(tftypes.String, nil),
-		},
+		}),
 	)
 
 	plan := tfsdk.Plan{
@@ -281,23 +270,16 @@ func TestResourceMigrationReadNonexistentFile(t *testing.T) {
 	// Create a state value for a resource
 	stateValue := tftypes.NewValue(
 		tftypes.Object{
-			AttributeTypes: map[string]tftypes.Type{
-				"id":               tftypes.String,
-				"cookbook_path":    tftypes.String,
-				"output_path":      tftypes.String,
-				"recipe_name":      tftypes.String,
-				"cookbook_name":    tftypes.String,
-				"playbook_content": tftypes.String,
-			},
+			AttributeTypes: migrationAttributeTypes,
 		},
-		map[string]tftypes.Value{
+		nullFilledValues(migrationAttributeTypes, map[string]tftypes.Value{
 			"id":               tftypes.NewValue(tftypes.String, testIDValue),
 			"cookbook_path":    tftypes.NewValue(tftypes.String, tmpDir),
 			"output_path":      tftypes.NewValue(tftypes.String, tmpDir),
 			"recipe_name":      tftypes.NewValue(tftypes.String, "default"),
 			"cookbook_name":    tftypes.NewValue(tftypes.String, "test"),
 			"playbook_content": tftypes.NewValue(tftypes.String, "content"),
-		},
+		}),
 	)
 
 	state := tfsdk.State{
@@ -338,44 +320,30 @@ func TestResourceMigrationUpdate(t *testing.T) {
 	// Create plan and state values
 	stateValue := tftypes.NewValue(
 		tftypes.Object{
-			AttributeTypes: map[string]tftypes.Type{
-				"id":               tftypes.String,
-				"cookbook_path":    tftypes.String,
-				"output_path":      tftypes.String,
-				"recipe_name":      tftypes.String,
-				"cookbook_name":    tftypes.String,
-				"playbook_content": tftypes.String,
-			},
+			AttributeTypes: migrationAttributeTypes,
 		},
-		map[string]tftypes.Value{
+		nullFilledValues(migrationAttributeTypes, map[string]tftypes.Value{
 			"id":               tftypes.NewValue(tftypes.String, "old-id"),
 			"cookbook_path":    tftypes.NewValue(tftypes.String, tmpDir),
 			"output_path":      tftypes.NewValue(tftypes.String, tmpDir),
 			"recipe_name":      tftypes.NewValue(tftypes.String, "default"),
 			"cookbook_name":    tftypes.NewValue(tftypes.String, "test"),
 			"playbook_content": tftypes.NewValue(tftypes.String, "old"),
-		},
+		}),
 	)
 
 	planValue := tftypes.NewValue(
 		tftypes.Object{
-			AttributeTypes: map[string]tftypes.Type{
-				"id":               tftypes.String,
-				"cookbook_path":    tftypes.String,
-				"output_path":      tftypes.String,
-				"recipe_name":      tftypes.String,
-				"cookbook_name":    tftypes.String,
-				"playbook_content": tftypes.String,
-			},
+			AttributeTypes: migrationAttributeTypes,
 		},
-		map[string]tftypes.Value{
+		nullFilledValues(migrationAttributeTypes, map[string]tftypes.Value{
 			"id":               tftypes.NewValue(tftypes.String, "old-id"),
 			"cookbook_path":    tftypes.NewValue(tftypes.String, tmpDir),
 			"output_path":      tftypes.NewValue(tftypes.String, tmpDir+"/new"),
 			"recipe_name":      tftypes.NewValue(tftypes.String, "newrecipe"),
 			"cookbook_name":    tftypes.NewValue(tftypes.String, nil),
 			"playbook_content": tftypes.NewValue(tftypes.String, nil),
-		},
+		}),
 	)
 
 	req := resource.UpdateRequest{
@@ -430,23 +398,16 @@ func TestResourceMigrationDelete(t *testing.T) {
 	// Create state value
 	stateValue := tftypes.NewValue(
 		tftypes.Object{
-			AttributeTypes: map[string]tftypes.Type{
-				"id":               tftypes.String,
-				"cookbook_path":    tftypes.String,
-				"output_path":      tftypes.String,
-				"recipe_name":      tftypes.String,
-				"cookbook_name":    tftypes.String,
-				"playbook_content": tftypes.String,
-			},
+			AttributeTypes: migrationAttributeTypes,
 		},
-		map[string]tftypes.Value{
+		nullFilledValues(migrationAttributeTypes, map[string]tftypes.Value{
 			"id":               tftypes.NewValue(tftypes.String, testIDValue),
 			"cookbook_path":    tftypes.NewValue(tftypes.String, tmpDir),
 			"output_path":      tftypes.NewValue(tftypes.String, outputPath),
 			"recipe_name":      tftypes.NewValue(tftypes.String, "default"),
 			"cookbook_name":    tftypes.NewValue(tftypes.String, "test"),
 			"playbook_content": tftypes.NewValue(tftypes.String, "content"),
-		},
+		}),
 	)
 
 	req := resource.DeleteRequest{
@@ -498,6 +459,7 @@ func TestDataSourceCostEstimateReadWithConfig(t *testing.T) {
 				"estimated_cost_usd":     tftypes.Number,
 				"total_project_cost_usd": tftypes.Number,
 				"recommendations":        tftypes.String,
+				"timeouts":               tftypes.Object{AttributeTypes: map[string]tftypes.Type{"read": tftypes.String}},
 			},
 		},
 		map[string]tftypes.Value{
@@ -512,6 +474,7 @@ func TestDataSourceCostEstimateReadWithConfig(t *testing.T) {
 			"estimated_cost_usd":     tftypes.NewValue(tftypes.Number, nil),
 			"total_project_cost_usd": tftypes.NewValue(tftypes.Number, nil),
 			"recommendations":        tftypes.NewValue(tftypes.String, nil),
+			"timeouts":               tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{"read": tftypes.String}}, nil),
 		},
 	)
 
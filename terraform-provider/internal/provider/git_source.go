@@ -0,0 +1,196 @@
+// Package provider contains helpers for fetching cookbooks from Git
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// gitCommand is the git executable used to fetch cookbooks.
+const gitCommand = "git"
+
+// gitRepoName returns the directory name a clone of gitURL is checked out to,
+// so the cookbook name derived from the path matches the repository name.
+func gitRepoName(gitURL string) string {
+	name := strings.TrimRight(gitURL, "/")
+	if idx := strings.LastIndexAny(name, "/:"); idx >= 0 {
+		name = name[idx+1:]
+	}
+	name = strings.TrimSuffix(name, ".git")
+	if name == "" {
+		return "cookbook"
+	}
+	return name
+}
+
+// runGit executes git with the given arguments and returns its trimmed output.
+func runGit(ctx context.Context, args ...string) (string, error) {
+	cmd := execCommandContext(ctx, gitCommand, args...)
+	tflog.Debug(ctx, "Executing git", map[string]interface{}{
+		"command": cmd.String(),
+	})
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w\n%s", strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// cloneGitCookbook shallow-clones gitURL at gitRef (a branch or tag; the
+// default branch when empty) into a temporary directory. It returns the clone
+// path, the checked out commit SHA and a cleanup function removing the clone.
+func cloneGitCookbook(ctx context.Context, gitURL, gitRef string) (string, string, func(), error) {
+	tempDir, err := osMkdirTemp("", "souschef-git-")
+	if err != nil {
+		return "", "", nil, fmt.Errorf("could not create temporary directory: %w", err)
+	}
+	cleanup := func() {
+		if err := osRemoveAll(tempDir); err != nil {
+			tflog.Warn(ctx, "Failed to remove cookbook clone", map[string]interface{}{
+				"path":  tempDir,
+				"error": err.Error(),
+			})
+		}
+	}
+
+	clonePath := filepath.Join(tempDir, gitRepoName(gitURL))
+	commit, err := shallowCloneGit(ctx, gitURL, gitRef, clonePath)
+	if err != nil {
+		cleanup()
+		return "", "", nil, err
+	}
+
+	return clonePath, commit, cleanup, nil
+}
+
+// shallowCloneGit clones gitURL at gitRef (the default branch when empty)
+// into clonePath and returns the checked out commit SHA.
+func shallowCloneGit(ctx context.Context, gitURL, gitRef, clonePath string) (string, error) {
+	args := []string{"clone", "--depth", "1"}
+	if gitRef != "" {
+		args = append(args, "--branch", gitRef)
+	}
+	args = append(args, "--", gitURL, clonePath)
+	if _, err := runGit(ctx, args...); err != nil {
+		return "", err
+	}
+	return runGit(ctx, "-C", clonePath, "rev-parse", "HEAD")
+}
+
+// gitCacheLocks holds a *sync.Mutex per clone cache directory, serialising
+// access to each cache entry so concurrent reads of the same repository do
+// not replace a clone while it is in use. Reads of other repositories and
+// refs do not wait on it.
+var gitCacheLocks sync.Map
+
+// gitCacheLock returns the mutex guarding the cache entry in cacheDir.
+func gitCacheLock(cacheDir string) *sync.Mutex {
+	mu, _ := gitCacheLocks.LoadOrStore(cacheDir, &sync.Mutex{})
+	return mu.(*sync.Mutex)
+}
+
+// gitCacheDir returns the directory below the user cache directory that
+// clones of gitURL at gitRef are cached in, keyed by both so every URL and
+// ref pair gets its own clone.
+func gitCacheDir(gitURL, gitRef string) (string, error) {
+	cacheDir, err := osUserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("could not find the user cache directory: %w", err)
+	}
+	key := contentSHA256([]byte(gitURL + "\x00" + gitRef))[:16]
+	return filepath.Join(cacheDir, "souschef", "git", key), nil
+}
+
+// cachedGitCookbook returns a clone of gitURL at gitRef in the user cache
+// directory and its checked out commit SHA. An existing clone is reused while
+// gitRef still points to its commit, or when the remote cannot be reached;
+// otherwise it is replaced by a fresh shallow clone.
+func cachedGitCookbook(ctx context.Context, gitURL, gitRef string) (string, string, error) {
+	cacheDir, err := gitCacheDir(gitURL, gitRef)
+	if err != nil {
+		return "", "", err
+	}
+	clonePath := filepath.Join(cacheDir, gitRepoName(gitURL))
+
+	mu := gitCacheLock(cacheDir)
+	mu.Lock()
+	defer mu.Unlock()
+
+	// Only trust a directory that is itself a clone, not one inside some
+	// other repository that git would find by walking up
+	if _, err := osStat(filepath.Join(clonePath, ".git")); err == nil {
+		if commit, err := runGit(ctx, "-C", clonePath, "rev-parse", "HEAD"); err == nil {
+			remote, err := resolveGitRef(ctx, gitURL, gitRef)
+			if err != nil {
+				tflog.Warn(ctx, "Could not check cookbook remote; using cached clone", map[string]interface{}{
+					"path":  clonePath,
+					"error": err.Error(),
+				})
+				return clonePath, commit, nil
+			}
+			if remote == commit {
+				return clonePath, commit, nil
+			}
+		}
+	}
+
+	// Clone next to the cache entry and move it into place, so a failed
+	// clone leaves the previous one intact
+	if err := osMkdirAll(cacheDir, 0755); err != nil {
+		return "", "", fmt.Errorf("could not create cache directory: %w", err)
+	}
+	tempDir, err := osMkdirTemp(cacheDir, "clone-")
+	if err != nil {
+		return "", "", fmt.Errorf("could not create temporary directory: %w", err)
+	}
+	defer func() { _ = osRemoveAll(tempDir) }()
+
+	tempClone := filepath.Join(tempDir, gitRepoName(gitURL))
+	commit, err := shallowCloneGit(ctx, gitURL, gitRef, tempClone)
+	if err != nil {
+		return "", "", err
+	}
+	if err := osRemoveAll(clonePath); err != nil {
+		return "", "", fmt.Errorf("could not remove stale clone: %w", err)
+	}
+	if err := osRename(tempClone, clonePath); err != nil {
+		return "", "", fmt.Errorf("could not move clone into the cache: %w", err)
+	}
+	return clonePath, commit, nil
+}
+
+// resolveGitRef returns the commit SHA gitRef (or HEAD when empty) currently
+// points to in the remote repository, peeling annotated tags to their commit.
+func resolveGitRef(ctx context.Context, gitURL, gitRef string) (string, error) {
+	if gitRef == "" {
+		gitRef = "HEAD"
+	}
+
+	output, err := runGit(ctx, "ls-remote", "--", gitURL, gitRef, gitRef+"^{}")
+	if err != nil {
+		return "", err
+	}
+
+	commit := ""
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		if strings.HasSuffix(fields[1], "^{}") {
+			return fields[0], nil
+		}
+		if commit == "" {
+			commit = fields[0]
+		}
+	}
+	if commit == "" {
+		return "", fmt.Errorf("ref %q not found in %s", gitRef, gitURL)
+	}
+	return commit, nil
+}
@@ -0,0 +1,200 @@
+// Package provider contains unit tests for the Git cookbook source helpers.
+package provider
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testGitBranch = "main"
+
+// gitTestRepo is a local bare repository fixture with a working copy used to push commits.
+type gitTestRepo struct {
+	url     string
+	workDir string
+}
+
+// runTestGit runs git in dir and returns its trimmed output, failing the test on error.
+func runTestGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	args = append([]string{"-c", "user.name=souschef", "-c", "user.email=souschef@example.com", "-c", "init.defaultBranch=" + testGitBranch}, args...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// newGitCookbookRepo creates a bare repository named web-cookbook.git containing a cookbook.
+func newGitCookbookRepo(t *testing.T) *gitTestRepo {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	bareDir := filepath.Join(root, "web-cookbook.git")
+	workDir := filepath.Join(root, "work")
+	runTestGit(t, root, "init", "--bare", bareDir)
+	runTestGit(t, root, "init", workDir)
+
+	repo := &gitTestRepo{url: "file://" + bareDir, workDir: workDir}
+	repo.commit(t, "metadata.rb", "name 'web'\n")
+	runTestGit(t, workDir, "remote", "add", "origin", bareDir)
+	runTestGit(t, workDir, "push", "origin", testGitBranch)
+	runTestGit(t, bareDir, "symbolic-ref", "HEAD", "refs/heads/"+testGitBranch)
+	return repo
+}
+
+// commit writes a file in the working copy, commits it and returns the new commit SHA.
+func (r *gitTestRepo) commit(t *testing.T, name, content string) string {
+	t.Helper()
+
+	if err := os.WriteFile(filepath.Join(r.workDir, name), []byte(content), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	runTestGit(t, r.workDir, "add", name)
+	runTestGit(t, r.workDir, "commit", "-m", "update "+name)
+	return runTestGit(t, r.workDir, "rev-parse", "HEAD")
+}
+
+// push pushes the working copy branch to the bare repository.
+func (r *gitTestRepo) push(t *testing.T) {
+	t.Helper()
+	runTestGit(t, r.workDir, "push", "origin", testGitBranch)
+}
+
+func TestGitRepoName(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/example/nginx-cookbook.git": "nginx-cookbook",
+		"git@github.com:example/nginx.git":              "nginx",
+		"file:///srv/git/base/":                         "base",
+		"":                                              "cookbook",
+	}
+	for gitURL, want := range tests {
+		if got := gitRepoName(gitURL); got != want {
+			t.Errorf("gitRepoName(%q) = %q, want %q", gitURL, got, want)
+		}
+	}
+}
+
+func TestCloneGitCookbook(t *testing.T) {
+	repo := newGitCookbookRepo(t)
+	want := runTestGit(t, repo.workDir, "rev-parse", "HEAD")
+
+	clonePath, commit, cleanup, err := cloneGitCookbook(context.Background(), repo.url, testGitBranch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if filepath.Base(clonePath) != "web-cookbook" {
+		t.Fatalf("expected clone directory named after the repository, got %s", clonePath)
+	}
+	if commit != want {
+		t.Fatalf("expected commit %s, got %s", want, commit)
+	}
+	if _, err := os.Stat(filepath.Join(clonePath, "metadata.rb")); err != nil {
+		t.Fatalf("expected cookbook in clone: %v", err)
+	}
+
+	cleanup()
+	if _, err := os.Stat(clonePath); !os.IsNotExist(err) {
+		t.Fatalf("expected clone to be removed, got %v", err)
+	}
+}
+
+func TestCloneGitCookbookMissingRef(t *testing.T) {
+	repo := newGitCookbookRepo(t)
+
+	if _, _, _, err := cloneGitCookbook(context.Background(), repo.url, "does-not-exist"); err == nil {
+		t.Fatal("expected error for missing ref")
+	}
+}
+
+func TestResolveGitRef(t *testing.T) {
+	repo := newGitCookbookRepo(t)
+	first := runTestGit(t, repo.workDir, "rev-parse", "HEAD")
+	runTestGit(t, repo.workDir, "tag", "-a", "v1.0.0", "-m", "release")
+	runTestGit(t, repo.workDir, "push", "origin", "v1.0.0")
+	second := repo.commit(t, "README.md", "docs\n")
+	repo.push(t)
+
+	tests := map[string]string{
+		"":            second,
+		testGitBranch: second,
+		"v1.0.0":      first,
+	}
+	for ref, want := range tests {
+		got, err := resolveGitRef(context.Background(), repo.url, ref)
+		if err != nil {
+			t.Fatalf("unexpected error resolving %q: %v", ref, err)
+		}
+		if got != want {
+			t.Errorf("resolveGitRef(%q) = %s, want %s", ref, got, want)
+		}
+	}
+
+	if _, err := resolveGitRef(context.Background(), repo.url, "missing"); err == nil {
+		t.Fatal("expected error for missing ref")
+	}
+}
+
+func TestCachedGitCookbookUnreachableRemote(t *testing.T) {
+	repo := newGitCookbookRepo(t)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	clonePath, commit, err := cachedGitCookbook(context.Background(), repo.url, testGitBranch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// With the remote gone the cached clone is still used
+	if err := os.RemoveAll(strings.TrimPrefix(repo.url, "file://")); err != nil {
+		t.Fatalf("failed to remove repository: %v", err)
+	}
+	cachedPath, cachedCommit, err := cachedGitCookbook(context.Background(), repo.url, testGitBranch)
+	if err != nil || cachedPath != clonePath || cachedCommit != commit {
+		t.Fatalf("expected the cached clone %s at %s, got %s at %s, %v", clonePath, commit, cachedPath, cachedCommit, err)
+	}
+}
+
+func TestCachedGitCookbookLocksPerRepository(t *testing.T) {
+	repo := newGitCookbookRepo(t)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	// A read holding another repository's cache entry does not block this one
+	busyDir, err := gitCacheDir("https://example.com/busy.git", testGitBranch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	busy := gitCacheLock(busyDir)
+	busy.Lock()
+	defer busy.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := cachedGitCookbook(context.Background(), repo.url, testGitBranch)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected the read to proceed while another repository's entry is locked")
+	}
+
+	if gitCacheLock(busyDir) != busy {
+		t.Fatal("expected the same lock for the same cache entry")
+	}
+}
@@ -39,13 +39,56 @@ const fakeSousChefScript = "#!/bin/sh\n" +
 	"set -e\n" +
 	"cmd=\"$1\"\n" +
 	"shift\n" +
+	"for a in \"$@\"; do [ \"$a\" = \"--dry-run\" ] && dry=1; done\n" +
+	"if [ -n \"$dry\" ]; then\n" +
+	"  tmp=$(mktemp -d)\n" +
+	"  n=$#\n" +
+	"  while [ $n -gt 0 ]; do\n" +
+	"    a=\"$1\"; shift; n=$((n-1))\n" +
+	"    case \"$a\" in\n" +
+	"      --dry-run) ;;\n" +
+	"      --output-path) set -- \"$@\" \"$a\" \"$tmp\"; shift; n=$((n-1)) ;;\n" +
+	"      *) set -- \"$@\" \"$a\" ;;\n" +
+	"    esac\n" +
+	"  done\n" +
+	"  \"$0\" \"$cmd\" \"$@\" > /dev/null\n" +
+	"  find \"$tmp\" -type f -exec cat {} +\n" +
+	"  rm -rf \"$tmp\"\n" +
+	"  exit 0\n" +
+	"fi\n" +
+	"if [ -n \"$SOUSCHEF_TEST_RENAME\" ]; then\n" +
+	"  case \"$cmd\" in\n" +
+	"    \"${SOUSCHEF_TEST_RENAME%%:*}\") echo \"unknown command: $cmd\" >&2; exit 2 ;;\n" +
+	"    \"${SOUSCHEF_TEST_RENAME#*:}\") cmd=\"${SOUSCHEF_TEST_RENAME%%:*}\" ;;\n" +
+	"  esac\n" +
+	"fi\n" +
+	"if [ -n \"$SOUSCHEF_TEST_WARN\" ]; then\n" +
+	"  echo \"$SOUSCHEF_TEST_WARN\" >&2\n" +
+	"fi\n" +
+	"if [ -n \"$SOUSCHEF_TEST_FLAKY\" ] && [ ! -f \"$SOUSCHEF_TEST_FLAKY\" ]; then\n" +
+	"  touch \"$SOUSCHEF_TEST_FLAKY\"\n" +
+	"  echo \"$SOUSCHEF_TEST_FLAKY_STDERR\" >&2\n" +
+	"  exit 1\n" +
+	"fi\n" +
+	"if [ -n \"$SOUSCHEF_TEST_COUNT\" ]; then\n" +
+	"  echo \"$cmd\" >> \"$SOUSCHEF_TEST_COUNT\"\n" +
+	"  sleep 1\n" +
+	"fi\n" +
 	"case \"$cmd\" in\n" +
 	"  convert-recipe)\n" +
 	scriptWhileArgsLoop +
 	scriptCaseFirstArg +
 	scriptOutputPathArg +
 	"        --recipe-name) recipe=\"$2\"; shift 2 ;;\n" +
-	"        --cookbook-path) shift 2 ;;\n" +
+	"        --recipe-names) recipes=\"$2\"; shift 2 ;;\n" +
+	"        --merge) merge=1; shift ;;\n" +
+	"        --output-file) outfile=\"$2\"; shift 2 ;;\n" +
+	"        --cookbook-path) cookbook=\"$2\"; shift 2 ;;\n" +
+	"        --recipes-subdir) subdir=\"$2\"; shift 2 ;;\n" +
+	"        --variable-rename-map) renames=\"$2\"; shift 2 ;;\n" +
+	"        --output-syntax) syntax=\"$2\"; shift 2 ;;\n" +
+	"        --ansible-version) ansible=\"$2\"; shift 2 ;;\n" +
+	"        --layout) layout=\"$2\"; shift 2 ;;\n" +
 	scriptDefaultShift +
 	scriptCaseEnd +
 	scriptLoopDone +
@@ -53,12 +96,52 @@ const fakeSousChefScript = "#!/bin/sh\n" +
 	scriptForcedError +
 	scriptExitFailure +
 	scriptIfEnd +
+	"    if [ -n \"$SOUSCHEF_TEST_FAIL_RECIPE\" ] && [ \"$SOUSCHEF_TEST_FAIL_RECIPE\" = \"$recipe\" ]; then\n" +
+	scriptForcedError +
+	scriptExitFailure +
+	scriptIfEnd +
+	"    if [ -n \"$merge\" ]; then\n" +
+	"      mkdir -p \"$out\"\n" +
+	"      for r in $(echo \"$recipes\" | tr ',' ' '); do echo \"recipe: $r\"; done > \"$out/$outfile\"\n" +
+	scriptExitSuccess +
+	scriptIfEnd +
+	"    if [ -n \"$subdir\" ] && [ ! -f \"$cookbook/$subdir/$recipe.rb\" ]; then\n" +
+	"      echo \"recipe $recipe not found in $cookbook/$subdir\" >&2\n" +
+	scriptExitFailure +
+	scriptIfEnd +
 	"    if [ \"$SOUSCHEF_TEST_SKIP_WRITE\" = \"convert-recipe\" ]; then\n" +
 	scriptExitSuccess +
 	scriptIfEnd +
 	scriptMakeOutputPath +
-	"    echo \"recipe: $recipe\" > \"$out/$recipe.yml\"\n" +
-	"    if [ \"$SOUSCHEF_TEST_CHMOD\" = \"convert-recipe\" ]; then\n" +
+	"    if [ \"$layout\" = \"role\" ]; then\n" +
+	"      role=\"$out/roles/$recipe\"\n" +
+	"      mkdir -p \"$role/tasks\" \"$role/defaults\" \"$role/handlers\"\n" +
+	"      printf -- '- name: Converted from recipe %s\\n  ansible.builtin.debug:\\n    msg: %s\\n' \"$recipe\" \"$recipe\" > \"$role/tasks/main.yml\"\n" +
+	"      echo \"---\" > \"$role/defaults/main.yml\"\n" +
+	"      echo \"---\" > \"$role/handlers/main.yml\"\n" +
+	"      printf -- '- hosts: all\\n  roles:\\n    - %s\\n' \"$recipe\" | tee \"$out/$recipe.yml\"\n" +
+	scriptExitSuccess +
+	scriptIfEnd +
+	"    if [ \"$syntax\" = \"json\" ]; then\n" +
+	"      echo \"{\\\"recipe\\\": \\\"$recipe\\\"}\" | tee \"$out/$recipe.json\"\n" +
+	scriptExitSuccess +
+	scriptIfEnd +
+	"    echo \"recipe: $recipe\" | tee \"$out/$recipe.yml\"\n" +
+	"    if [ -n \"$renames\" ]; then\n" +
+	"      tr ',' '\\n' < \"$renames\" | sed -n 's/.*:\"\\([^\"]*\\)\".*/\\1: \"{{ \\1 }}\"/p' | tee -a \"$out/$recipe.yml\"\n" +
+	scriptIfEnd +
+	"    if [ -n \"$ansible\" ]; then\n" +
+	"      echo \"ansible_version: $ansible\" | tee -a \"$out/$recipe.yml\"\n" +
+	scriptIfEnd +
+	"    if [ -n \"$SOUSCHEF_TEST_PAD_BYTES\" ]; then\n" +
+	"      head -c \"$SOUSCHEF_TEST_PAD_BYTES\" /dev/zero | tr '\\0' '#' >> \"$out/$recipe.yml\"\n" +
+	scriptIfEnd +
+	"    if [ -n \"$SOUSCHEF_TEST_INVALID_UTF8\" ]; then\n" +
+	"      printf '\\377\\376binary\\n' >> \"$out/$recipe.yml\"\n" +
+	scriptIfEnd +
+	"    if [ -n \"$SOUSCHEF_TEST_YAML_EXT\" ]; then\n" +
+	"      mv \"$out/$recipe.yml\" \"$out/$recipe.yaml\"\n" +
+	scriptIfEnd + "    if [ \"$SOUSCHEF_TEST_CHMOD\" = \"convert-recipe\" ]; then\n" +
 	"      chmod 000 \"$out/$recipe.yml\"\n" +
 	scriptIfEnd +
 	scriptCaseClauseEnd +
@@ -67,7 +150,7 @@ const fakeSousChefScript = "#!/bin/sh\n" +
 	scriptCaseFirstArg +
 	scriptOutputPathArg +
 	"        --plan-path) shift 2 ;;\n" +
-	"        --base-image) shift 2 ;;\n" +
+	"        --base-image) base=\"$2\"; shift 2 ;;\n" +
 	scriptDefaultShift +
 	scriptCaseEnd +
 	scriptLoopDone +
@@ -79,17 +162,216 @@ const fakeSousChefScript = "#!/bin/sh\n" +
 	scriptExitSuccess +
 	scriptIfEnd +
 	scriptMakeOutputPath +
-	"    echo \"FROM ubuntu:latest\" > \"$out/Dockerfile\"\n" +
+	"    echo \"FROM ${base:-${SOUSCHEF_TEST_DEFAULT_BASE_IMAGE:-ubuntu:latest}}\" > \"$out/Dockerfile\"\n" +
 	"    if [ \"$SOUSCHEF_TEST_CHMOD\" = \"convert-habitat\" ]; then\n" +
 	"      chmod 000 \"$out/Dockerfile\"\n" +
 	scriptIfEnd +
 	scriptCaseClauseEnd +
+	"  convert-kitchen)\n" +
+	scriptWhileArgsLoop +
+	scriptCaseFirstArg +
+	scriptOutputPathArg +
+	"        --kitchen-path) shift 2 ;;\n" +
+	scriptDefaultShift +
+	scriptCaseEnd +
+	scriptLoopDone +
+	"    if [ \"$SOUSCHEF_TEST_FAIL\" = \"convert-kitchen\" ]; then\n" +
+	scriptForcedError +
+	scriptExitFailure +
+	scriptIfEnd +
+	scriptMakeOutputPath +
+	"    printf 'driver:\\n  name: docker\\nplatforms:\\n  - name: ubuntu-22.04\\n' > \"$out/molecule.yml\"\n" +
+	scriptCaseClauseEnd +
+	"  convert-metadata)\n" +
+	scriptWhileArgsLoop +
+	scriptCaseFirstArg +
+	scriptOutputPathArg +
+	"        --metadata-path) metadata=\"$2\"; shift 2 ;;\n" +
+	scriptDefaultShift +
+	scriptCaseEnd +
+	scriptLoopDone +
+	"    if [ \"$SOUSCHEF_TEST_FAIL\" = \"convert-metadata\" ]; then\n" +
+	scriptForcedError +
+	scriptExitFailure +
+	scriptIfEnd +
+	"    mkdir -p \"$out/meta\"\n" +
+	"    {\n" +
+	"      printf 'galaxy_info:\\n  author: converted\\ndependencies:\\n'\n" +
+	"      sed -n \"s/^depends '\\([^']*\\)'.*/  - role: \\1/p\" \"$metadata\"\n" +
+	"    } > \"$out/meta/main.yml\"\n" +
+	scriptCaseClauseEnd +
+	"  convert-ohai)\n" +
+	scriptWhileArgsLoop +
+	scriptCaseFirstArg +
+	scriptOutputPathArg +
+	"        --plugin-path) plugin=\"$2\"; shift 2 ;;\n" +
+	scriptDefaultShift +
+	scriptCaseEnd +
+	scriptLoopDone +
+	"    if [ \"$SOUSCHEF_TEST_FAIL\" = \"convert-ohai\" ]; then\n" +
+	scriptForcedError +
+	scriptExitFailure +
+	scriptIfEnd +
+	scriptMakeOutputPath +
+	"    fact=\"$out/$(basename \"$plugin\" .rb).fact\"\n" +
+	"    printf '#!/bin/sh\\necho \"{\\\\\"plugin\\\\\": \\\\\"%s\\\\\"}\"\\n' \"$(basename \"$plugin\" .rb)\" > \"$fact\"\n" +
+	"    chmod 755 \"$fact\"\n" +
+	scriptCaseClauseEnd +
+	"  convert-template)\n" +
+	scriptWhileArgsLoop +
+	scriptCaseFirstArg +
+	scriptOutputPathArg +
+	"        --template-path) template=\"$2\"; shift 2 ;;\n" +
+	scriptDefaultShift +
+	scriptCaseEnd +
+	scriptLoopDone +
+	"    if [ \"$SOUSCHEF_TEST_FAIL\" = \"convert-template\" ]; then\n" +
+	scriptForcedError +
+	scriptExitFailure +
+	scriptIfEnd +
+	scriptMakeOutputPath +
+	"    sed 's/<%= *@\\([a-z_]*\\) *%>/{{ \\1 }}/g' \"$template\" > \"$out/$(basename \"$template\" .erb).j2\"\n" +
+	scriptCaseClauseEnd +
+	"  convert-compliance)\n" +
+	scriptWhileArgsLoop +
+	scriptCaseFirstArg +
+	scriptOutputPathArg +
+	"        --compliance-path) scan=\"$2\"; shift 2 ;;\n" +
+	"        --format) format=\"$2\"; shift 2 ;;\n" +
+	scriptDefaultShift +
+	scriptCaseEnd +
+	scriptLoopDone +
+	"    if [ \"$SOUSCHEF_TEST_FAIL\" = \"convert-compliance\" ]; then\n" +
+	scriptForcedError +
+	scriptExitFailure +
+	scriptIfEnd +
+	scriptMakeOutputPath +
+	"    name=$(basename \"$scan\"); name=${name%.*}\n" +
+	"    if [ \"$format\" = \"gitlab_ci\" ]; then\n" +
+	"      printf 'compliance:%s:\\n  script: inspec exec %s\\n' \"$name\" \"$name\" > \"$out/$name.gitlab-ci.yml\"\n" +
+	"    else\n" +
+	"      printf 'name: compliance %s\\njobs:\\n  scan:\\n    steps:\\n      - run: inspec exec %s\\n' \"$name\" \"$name\" > \"$out/$name.yml\"\n" +
+	scriptIfEnd +
+	scriptCaseClauseEnd +
+	"  convert-chefspec)\n" +
+	scriptWhileArgsLoop +
+	scriptCaseFirstArg +
+	scriptOutputPathArg +
+	"        --spec-path) spec=\"$2\"; shift 2 ;;\n" +
+	"        --format) format=\"$2\"; shift 2 ;;\n" +
+	scriptDefaultShift +
+	scriptCaseEnd +
+	scriptLoopDone +
+	"    if [ \"$SOUSCHEF_TEST_FAIL\" = \"convert-chefspec\" ]; then\n" +
+	scriptForcedError +
+	scriptExitFailure +
+	scriptIfEnd +
+	scriptMakeOutputPath +
+	"    if [ \"$format\" = \"molecule\" ]; then\n" +
+	"      printf -- '- name: Verify\\n  hosts: all\\n  tasks: []\\n' > \"$out/verify.yml\"\n" +
+	"    else\n" +
+	"      printf 'def test_converted(host):\\n    assert host.package(\"nginx\").is_installed\\n' > \"$out/test_$(basename \"$spec\" _spec.rb).py\"\n" +
+	"    fi\n" +
+	scriptCaseClauseEnd +
+	"  convert-files)\n" +
+	scriptWhileArgsLoop +
+	scriptCaseFirstArg +
+	scriptOutputPathArg +
+	"        --files-path) files=\"$2\"; shift 2 ;;\n" +
+	scriptDefaultShift +
+	scriptCaseEnd +
+	scriptLoopDone +
+	"    if [ \"$SOUSCHEF_TEST_FAIL\" = \"convert-files\" ]; then\n" +
+	scriptForcedError +
+	scriptExitFailure +
+	scriptIfEnd +
+	scriptMakeOutputPath +
+	"    cp -R \"$files/.\" \"$out/\"\n" +
+	scriptCaseClauseEnd +
+	"  convert-node)\n" +
+	scriptWhileArgsLoop +
+	scriptCaseFirstArg +
+	scriptOutputPathArg +
+	"        --node-path) node=\"$2\"; shift 2 ;;\n" +
+	scriptDefaultShift +
+	scriptCaseEnd +
+	scriptLoopDone +
+	"    if [ \"$SOUSCHEF_TEST_FAIL\" = \"convert-node\" ]; then\n" +
+	scriptForcedError +
+	scriptExitFailure +
+	scriptIfEnd +
+	scriptMakeOutputPath +
+	"    name=$(basename \"$node\" .json)\n" +
+	"    printf 'chef_environment: production\\nnginx_port: 8080\\n' > \"$out/$name.yml\"\n" +
+	scriptCaseClauseEnd +
+	"  convert-databag)\n" +
+	scriptWhileArgsLoop +
+	scriptCaseFirstArg +
+	scriptOutputPathArg +
+	"        --databag-path) bag=\"$2\"; shift 2 ;;\n" +
+	"        --secret-key) key=\"$2\"; shift 2 ;;\n" +
+	scriptDefaultShift +
+	scriptCaseEnd +
+	scriptLoopDone +
+	"    if [ \"$SOUSCHEF_TEST_FAIL\" = \"convert-databag\" ]; then\n" +
+	scriptForcedError +
+	scriptExitFailure +
+	scriptIfEnd +
+	scriptMakeOutputPath +
+	"    for item in \"$bag\"/*.json; do\n" +
+	"      name=$(basename \"$item\" .json)\n" +
+	"      if grep -q encrypted_data \"$item\"; then\n" +
+	"        if [ -z \"$key\" ]; then\n" +
+	"          echo \"item $name is encrypted and no --secret-key was given\" >&2\n" +
+	"          exit 1\n" +
+	"        fi\n" +
+	"        printf 'id: %s\\npassword: decrypted-with-%s\\n' \"$name\" \"$(basename \"$key\")\" > \"$out/$name.yml\"\n" +
+	"      else\n" +
+	"        printf 'id: %s\\n' \"$name\" > \"$out/$name.yml\"\n" +
+	"      fi\n" +
+	"    done\n" +
+	scriptCaseClauseEnd +
+	"  convert-search)\n" +
+	scriptWhileArgsLoop +
+	scriptCaseFirstArg +
+	scriptOutputPathArg +
+	"        --recipe-name) recipe=\"$2\"; shift 2 ;;\n" +
+	"        --cookbook-path) shift 2 ;;\n" +
+	scriptDefaultShift +
+	scriptCaseEnd +
+	scriptLoopDone +
+	"    if [ \"$SOUSCHEF_TEST_FAIL\" = \"convert-search\" ]; then\n" +
+	scriptForcedError +
+	scriptExitFailure +
+	scriptIfEnd +
+	scriptMakeOutputPath +
+	"    printf 'plugin: constructed\\ngroups:\\n  web: role_web is defined\\n' > \"$out/${recipe}_inventory.yml\"\n" +
+	scriptCaseClauseEnd +
+	"  convert-all)\n" +
+	scriptWhileArgsLoop +
+	scriptCaseFirstArg +
+	scriptOutputPathArg +
+	"        --cookbook-path) shift 2 ;;\n" +
+	scriptDefaultShift +
+	scriptCaseEnd +
+	scriptLoopDone +
+	"    if [ \"$SOUSCHEF_TEST_FAIL\" = \"convert-all\" ]; then\n" +
+	scriptForcedError +
+	scriptExitFailure +
+	scriptIfEnd +
+	"    mkdir -p \"$out/playbooks\" \"$out/vars\" \"$out/templates/sites\"\n" +
+	"    printf -- '- hosts: all\\n' > \"$out/playbooks/default.yml\"\n" +
+	"    printf -- '- hosts: web\\n' > \"$out/playbooks/server.yml\"\n" +
+	"    printf 'nginx_port: 80\\n' > \"$out/vars/default.yml\"\n" +
+	"    printf 'worker_processes {{ nginx_workers }};\\n' > \"$out/templates/nginx.conf.j2\"\n" +
+	"    printf 'server_name {{ server_name }};\\n' > \"$out/templates/sites/default.conf.j2\"\n" +
+	scriptCaseClauseEnd +
 	"  convert-inspec)\n" +
 	scriptWhileArgsLoop +
 	scriptCaseFirstArg +
 	scriptOutputPathArg +
 	"        --format) format=\"$2\"; shift 2 ;;\n" +
-	"        --profile-path) shift 2 ;;\n" +
+	"        --profile-path) profile=\"$2\"; shift 2 ;;\n" +
 	scriptDefaultShift +
 	scriptCaseEnd +
 	scriptLoopDone +
@@ -97,6 +379,10 @@ const fakeSousChefScript = "#!/bin/sh\n" +
 	scriptForcedError +
 	scriptExitFailure +
 	scriptIfEnd +
+	"    if [ -n \"$SOUSCHEF_TEST_FAIL_PROFILE\" ] && [ \"$(basename \"$profile\")\" = \"$SOUSCHEF_TEST_FAIL_PROFILE\" ]; then\n" +
+	scriptForcedError +
+	scriptExitFailure +
+	scriptIfEnd +
 	"    if [ \"$SOUSCHEF_TEST_SKIP_WRITE\" = \"convert-inspec\" ]; then\n" +
 	scriptExitSuccess +
 	scriptIfEnd +
@@ -109,11 +395,23 @@ const fakeSousChefScript = "#!/bin/sh\n" +
 	"    esac\n" +
 	scriptMakeOutputPath +
 	"    echo \"test content\" > \"$out/$filename\"\n" +
+	"    if [ -n \"$SOUSCHEF_TEST_CONTROLS\" ]; then\n" +
+	"      echo \"$SOUSCHEF_TEST_CONTROLS\" > \"$out/.controls.json\"\n" +
+	scriptIfEnd +
 	"    if [ \"$SOUSCHEF_TEST_CHMOD\" = \"convert-inspec\" ]; then\n" +
 	"      chmod 000 \"$out/$filename\"\n" +
 	scriptIfEnd +
 	scriptCaseClauseEnd +
 	"  assess-cookbook)\n" +
+	scriptWhileArgsLoop +
+	scriptCaseFirstArg +
+	"        --cookbook-path) cookbook=\"$2\"; shift 2 ;;\n" +
+	scriptDefaultShift +
+	scriptCaseEnd +
+	scriptLoopDone +
+	"    if [ -n \"$SOUSCHEF_TEST_SLEEP\" ]; then\n" +
+	"      sleep \"$SOUSCHEF_TEST_SLEEP\"\n" +
+	scriptIfEnd +
 	"    if [ \"$SOUSCHEF_TEST_FAIL\" = \"assess-cookbook\" ]; then\n" +
 	scriptForcedError +
 	scriptExitFailure +
@@ -122,8 +420,126 @@ const fakeSousChefScript = "#!/bin/sh\n" +
 	"      echo \"{bad json\"\n" +
 	scriptExitSuccess +
 	scriptIfEnd +
+	"    if [ -f \"$cookbook/assessment.json\" ]; then\n" +
+	"      cat \"$cookbook/assessment.json\"\n" +
+	scriptExitSuccess +
+	scriptIfEnd +
 	"    echo '{\"complexity\":\"Low\",\"recipe_count\":2,\"resource_count\":5,\"estimated_hours\":3.5,\"recommendations\":\"ok\"}'\n" +
 	scriptCaseClauseEnd +
+	"  deps)\n" +
+	scriptWhileArgsLoop +
+	scriptCaseFirstArg +
+	"        --cookbook-path) cookbook=\"$2\"; shift 2 ;;\n" +
+	scriptDefaultShift +
+	scriptCaseEnd +
+	scriptLoopDone +
+	"    if [ \"$SOUSCHEF_TEST_FAIL\" = \"deps\" ]; then\n" +
+	scriptForcedError +
+	scriptExitFailure +
+	scriptIfEnd +
+	"    if [ -f \"$cookbook/deps.json\" ]; then\n" +
+	"      cat \"$cookbook/deps.json\"\n" +
+	scriptExitSuccess +
+	scriptIfEnd +
+	"    echo '{\"dependencies\":{}}'\n" +
+	scriptCaseClauseEnd +
+	"  berksfile-info)\n" +
+	scriptWhileArgsLoop +
+	scriptCaseFirstArg +
+	"        --path) berksfile=\"$2\"; shift 2 ;;\n" +
+	scriptDefaultShift +
+	scriptCaseEnd +
+	scriptLoopDone +
+	"    if [ \"$SOUSCHEF_TEST_FAIL\" = \"berksfile-info\" ]; then\n" +
+	scriptForcedError +
+	scriptExitFailure +
+	scriptIfEnd +
+	"    cat \"$(dirname \"$berksfile\")/berksfile.json\"\n" +
+	scriptCaseClauseEnd +
+	"  role-info)\n" +
+	scriptWhileArgsLoop +
+	scriptCaseFirstArg +
+	"        --role-path) role=\"$2\"; shift 2 ;;\n" +
+	scriptDefaultShift +
+	scriptCaseEnd +
+	scriptLoopDone +
+	"    if [ \"$SOUSCHEF_TEST_FAIL\" = \"role-info\" ]; then\n" +
+	scriptForcedError +
+	scriptExitFailure +
+	scriptIfEnd +
+	"    case \"$role\" in\n" +
+	"      *.json) cat \"$role\" ;;\n" +
+	"      *.rb)\n" +
+	"        name=$(sed -n 's/^name \"\\(.*\\)\"$/\\1/p' \"$role\")\n" +
+	"        runlist=$(sed -n 's/^run_list //p' \"$role\")\n" +
+	"        defaults=$(sed -n 's/^default_attributes //p' \"$role\" | sed 's/=>/:/g')\n" +
+	"        echo \"{\\\"name\\\":\\\"$name\\\",\\\"run_list\\\":[$runlist],\\\"default_attributes\\\":{$defaults}}\"\n" +
+	"        ;;\n" +
+	"      *) echo \"unsupported role format: $role\" >&2; exit 1 ;;\n" +
+	"    esac\n" +
+	scriptCaseClauseEnd +
+	"  inspec-info)\n" +
+	scriptWhileArgsLoop +
+	scriptCaseFirstArg +
+	"        --profile-path) profile=\"$2\"; shift 2 ;;\n" +
+	scriptDefaultShift +
+	scriptCaseEnd +
+	scriptLoopDone +
+	"    if [ \"$SOUSCHEF_TEST_FAIL\" = \"inspec-info\" ]; then\n" +
+	scriptForcedError +
+	scriptExitFailure +
+	scriptIfEnd +
+	"    name=$(sed -n \"s/^name: *//p\" \"$profile/inspec.yml\")\n" +
+	"    version=$(sed -n \"s/^version: *//p\" \"$profile/inspec.yml\")\n" +
+	"    ids=$(cat \"$profile\"/controls/*.rb 2>/dev/null | sed -n \"s/^ *control [\\\"']\\([^\\\"']*\\)[\\\"'].*/{\\\"id\\\":\\\"\\1\\\"}/p\" | paste -sd, -)\n" +
+	"    echo \"{\\\"name\\\":\\\"$name\\\",\\\"version\\\":\\\"$version\\\",\\\"controls\\\":[$ids]}\"\n" +
+	scriptCaseClauseEnd +
+	"  recipe-info)\n" +
+	scriptWhileArgsLoop +
+	scriptCaseFirstArg +
+	"        --cookbook-path) cookbook=\"$2\"; shift 2 ;;\n" +
+	"        --recipe-name) recipe=\"$2\"; shift 2 ;;\n" +
+	scriptDefaultShift +
+	scriptCaseEnd +
+	scriptLoopDone +
+	"    if [ \"$SOUSCHEF_TEST_FAIL\" = \"recipe-info\" ]; then\n" +
+	scriptForcedError +
+	scriptExitFailure +
+	scriptIfEnd +
+	"    resources=$(sed -n \"s/^\\([a-z_]*\\) [\\\"']\\([^\\\"']*\\)[\\\"'].*/{\\\"type\\\":\\\"\\1\\\",\\\"name\\\":\\\"\\2\\\"}/p\" \"$cookbook/recipes/$recipe.rb\" | paste -sd, -)\n" +
+	"    echo \"{\\\"resources\\\":[$resources]}\"\n" +
+	scriptCaseClauseEnd +
+	"  env)\n" +
+	"    if [ $# -gt 0 ]; then\n" +
+	"      echo \"$1=$(printenv \"$1\" || true)\"\n" +
+	scriptExitSuccess +
+	scriptIfEnd +
+	"    echo \"NO_COLOR=$NO_COLOR TERM=$TERM\"\n" +
+	scriptCaseClauseEnd +
+	"  validate)\n" +
+	scriptWhileArgsLoop +
+	scriptCaseFirstArg +
+	"        --cookbook-path) cookbook=\"$2\"; shift 2 ;;\n" +
+	scriptDefaultShift +
+	scriptCaseEnd +
+	scriptLoopDone +
+	"    if [ \"$SOUSCHEF_TEST_FAIL\" = \"validate\" ]; then\n" +
+	scriptForcedError +
+	scriptExitFailure +
+	scriptIfEnd +
+	"    if [ -f \"$cookbook/validation.json\" ]; then\n" +
+	"      cat \"$cookbook/validation.json\"\n" +
+	scriptExitSuccess +
+	scriptIfEnd +
+	"    echo '{\"valid\":true,\"unsupported_features\":[],\"warnings\":[]}'\n" +
+	scriptCaseClauseEnd +
+	"  --version)\n" +
+	"    if [ \"$SOUSCHEF_TEST_FAIL\" = \"--version\" ]; then\n" +
+	"      echo \"error: no such option: --version\" >&2\n" +
+	"      exit 2\n" +
+	scriptIfEnd +
+	"    echo \"souschef 3.2.1\"\n" +
+	scriptCaseClauseEnd +
 	"  *)\n" +
 	"    echo \"unknown command\" >&2\n" +
 	scriptExitFailure +
@@ -243,6 +659,15 @@ func newDataSourceConfig(t *testing.T, schema datasourceschema.Schema, val inter
 	}
 }
 
+func newResourceConfig(t *testing.T, schema resourceschema.Schema, val interface{}) tfsdk.Config {
+	t.Helper()
+
+	return tfsdk.Config{
+		Schema: schema,
+		Raw:    newConfigValue(t, schema.Type(), val),
+	}
+}
+
 func newConfigValue(t *testing.T, schemaType attr.Type, val interface{}) tftypes.Value {
 	t.Helper()
 
@@ -280,6 +705,16 @@ func withOsRemove(t *testing.T, fn func(string) error) {
 	})
 }
 
+// withOsRename temporarily overrides the osRename function for testing.
+func withOsRename(t *testing.T, fn func(string, string) error) {
+	t.Helper()
+	original := osRename
+	osRename = fn
+	t.Cleanup(func() {
+		osRename = original
+	})
+}
+
 // withOsReadFile temporarily overrides the osReadFile function for testing.
 func withOsReadFile(t *testing.T, fn func(string) ([]byte, error)) {
 	t.Helper()
@@ -346,10 +781,12 @@ func newBatchMigrationTestFixture(t *testing.T) (*batchMigrationResource, resour
 		RecipeNames: []types.String{
 			types.StringValue("default"),
 		},
-		ID:            types.StringNull(),
-		CookbookName:  types.StringNull(),
-		PlaybookCount: types.Int64Null(),
-		Playbooks:     types.MapNull(types.StringType),
+		ID:                  types.StringNull(),
+		CookbookName:        types.StringNull(),
+		PlaybookCount:       types.Int64Null(),
+		Playbooks:           types.MapNull(types.StringType),
+		RecipeStatus:        types.MapNull(types.StringType),
+		ResolvedRecipeNames: types.ListNull(types.StringType),
 	})
 
 	return r, schema, plan
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// assertImportMatchesRead imports id and refreshes the imported state,
+// failing when the refresh changes any attribute.
+func assertImportMatchesRead(t *testing.T, r resource.ResourceWithImportState, id string) {
+	t.Helper()
+
+	schema := newResourceSchema(t, r)
+	importResp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, importResp.Diagnostics)
+	}
+
+	readResp := &resource.ReadResponse{State: importResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: importResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+
+	if !readResp.State.Raw.Equal(importResp.State.Raw) {
+		t.Fatalf("expected Read to leave imported state unchanged\nimported: %s\nread:     %s", importResp.State.Raw, readResp.State.Raw)
+	}
+}
+
+func TestMigrationImportMatchesRead(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t), MaxContentBytes: defaultMaxContentBytes}}
+	outputDir := t.TempDir()
+	cookbookPath := filepath.Join(t.TempDir(), "web")
+	if err := os.MkdirAll(cookbookPath, testDirPermissions); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+	if err := os.WriteFile(filepath.Join(cookbookPath, "metadata.rb"), []byte("name 'web'\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "default.yml"), []byte("- hosts: all\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+
+	assertImportMatchesRead(t, r, cookbookPath+"|"+outputDir+"|default")
+
+	// Imported state records the hashes a fresh apply would
+	schema := newResourceSchema(t, r)
+	importResp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: cookbookPath + "|" + outputDir + "|default"}, importResp)
+	var state migrationResourceModel
+	if diags := importResp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.ContentSHA256.ValueString() != contentSHA256([]byte("- hosts: all\n")) {
+		t.Errorf("unexpected content_sha256 %q", state.ContentSHA256.ValueString())
+	}
+	if state.SourceHash.IsNull() || state.SourceHash != sourceHashValue(cookbookPath) {
+		t.Errorf("expected source_hash of the cookbook, got %v", state.SourceHash)
+	}
+}
+
+func TestMigrationImportMatchesReadTruncated(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t), MaxContentBytes: 4}}
+	outputDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outputDir, "default.yml"), []byte("- hosts: all\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+
+	assertImportMatchesRead(t, r, t.TempDir()+"|"+outputDir+"|default")
+}
+
+func TestBatchMigrationImportMatchesRead(t *testing.T) {
+	r := &batchMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	outputDir := t.TempDir()
+	for _, name := range []string{"default", "web"} {
+		if err := os.WriteFile(filepath.Join(outputDir, name+".yml"), []byte("recipe: "+name+"\n"), testFilePermissions); err != nil {
+			t.Fatalf(testFailedToWriteFile, err)
+		}
+	}
+
+	assertImportMatchesRead(t, r, t.TempDir()+"|"+outputDir+"|default,web")
+}
+
+func TestHabitatMigrationImportMatchesRead(t *testing.T) {
+	r := &habitatMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	outputDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outputDir, "Dockerfile"), []byte("FROM debian:12\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+
+	planPath := filepath.Join(t.TempDir(), "nginx", "plan.sh")
+	if err := os.MkdirAll(filepath.Dir(planPath), testDirPermissions); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+	if err := os.WriteFile(planPath, []byte("pkg_name=nginx\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+
+	assertImportMatchesRead(t, r, planPath+"|"+outputDir)
+}
+
+func TestInSpecMigrationImportMatchesRead(t *testing.T) {
+	r := &inspecMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	outputDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outputDir, inspecTestFilename("testinfra")), []byte("def test_nginx(host):\n    pass\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+
+	assertImportMatchesRead(t, r, t.TempDir()+"|"+outputDir+"|testinfra")
+}
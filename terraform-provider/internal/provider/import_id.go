@@ -0,0 +1,67 @@
+// Package provider contains the import ID parsing shared by resources
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// importIDParts splits an import ID into its positional parts. Besides the
+// pipe-delimited form, a JSON object keyed by attribute name is accepted, e.g.
+// {"cookbook_path": "...", "output_path": "...", "recipe_name": "default"},
+// where fields names the attribute at each position. List values are joined
+// with commas, booleans become "true" or "false", and omitted trailing fields are dropped so optional parts
+// behave as they do in the pipe-delimited form.
+func importIDParts(id string, fields ...string) ([]string, error) {
+	if !strings.HasPrefix(strings.TrimSpace(id), "{") {
+		return strings.Split(id, "|"), nil
+	}
+
+	var spec map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(id), &spec); err != nil {
+		return nil, fmt.Errorf("could not parse JSON import ID: %w", err)
+	}
+
+	positions := make(map[string]int, len(fields))
+	for i, field := range fields {
+		positions[field] = i
+	}
+
+	parts := make([]string, len(fields))
+	for key, raw := range spec {
+		position, ok := positions[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q in JSON import ID; expected one of: %s", key, strings.Join(fields, ", "))
+		}
+
+		value, err := importIDValue(raw)
+		if err != nil {
+			return nil, fmt.Errorf("field %q in JSON import ID must be a string, a boolean or a list of strings", key)
+		}
+		parts[position] = value
+	}
+
+	for len(parts) > 0 && parts[len(parts)-1] == "" {
+		parts = parts[:len(parts)-1]
+	}
+	return parts, nil
+}
+
+// importIDValue returns a JSON import ID value in its pipe-delimited form.
+func importIDValue(raw json.RawMessage) (string, error) {
+	var value string
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return value, nil
+	}
+	var flag bool
+	if err := json.Unmarshal(raw, &flag); err == nil {
+		return strconv.FormatBool(flag), nil
+	}
+	var values []string
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return "", err
+	}
+	return strings.Join(values, ","), nil
+}
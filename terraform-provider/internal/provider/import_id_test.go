@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestImportIDParts(t *testing.T) {
+	fields := []string{"cookbook_path", "output_path", "recipe_names", "output_syntax"}
+
+	tests := map[string]struct {
+		id      string
+		want    []string
+		wantErr bool
+	}{
+		"pipe delimited": {
+			id:   "/cookbooks/web|/out|default",
+			want: []string{"/cookbooks/web", "/out", "default"},
+		},
+		"JSON": {
+			id:   `{"output_path": "/out", "cookbook_path": "/cookbooks/web", "recipe_names": "default"}`,
+			want: []string{"/cookbooks/web", "/out", "default"},
+		},
+		"JSON list value": {
+			id:   `{"cookbook_path": "/cookbooks/web", "output_path": "/out", "recipe_names": ["default", "server"]}`,
+			want: []string{"/cookbooks/web", "/out", "default,server"},
+		},
+		"JSON optional field": {
+			id:   `{"cookbook_path": "/cookbooks/web", "output_path": "/out", "recipe_names": "default", "output_syntax": "json"}`,
+			want: []string{"/cookbooks/web", "/out", "default", "json"},
+		},
+		"JSON boolean value": {
+			id:   `{"cookbook_path": "/cookbooks/web", "output_path": "/out", "recipe_names": "default", "output_syntax": false}`,
+			want: []string{"/cookbooks/web", "/out", "default", "false"},
+		},
+		"JSON missing middle field": {
+			id:   `{"cookbook_path": "/cookbooks/web", "recipe_names": "default"}`,
+			want: []string{"/cookbooks/web", "", "default"},
+		},
+		"JSON unknown field": {
+			id:      `{"cookbook_path": "/cookbooks/web", "recipe": "default"}`,
+			wantErr: true,
+		},
+		"JSON wrong type": {
+			id:      `{"cookbook_path": 42}`,
+			wantErr: true,
+		},
+		"malformed JSON": {
+			id:      `{"cookbook_path": `,
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := importIDParts(tt.id, fields...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("importIDParts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("importIDParts() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -56,6 +56,71 @@ func TestAllDataSourceConfigures(t *testing.T) {
 			providerData: 123,
 			expectError:  true,
 		},
+		// Diff DataSource Tests
+		{
+			name:         "DiffConfigureNilClient",
+			ds:           &diffDataSource{},
+			providerData: nil,
+			expectError:  false,
+		},
+		{
+			name:         "DiffConfigureInvalidType",
+			ds:           &diffDataSource{},
+			providerData: "invalid",
+			expectError:  true,
+		},
+		// Lint DataSource Tests
+		{
+			name:         "LintConfigureNilClient",
+			ds:           &lintDataSource{},
+			providerData: nil,
+			expectError:  false,
+		},
+		{
+			name:         "LintConfigureInvalidType",
+			ds:           &lintDataSource{},
+			providerData: "invalid",
+			expectError:  true,
+		},
+		// Coverage Report DataSource Tests
+		{
+			name:         "CoverageReportConfigureNilClient",
+			ds:           &coverageReportDataSource{},
+			providerData: nil,
+			expectError:  false,
+		},
+		{
+			name:         "CoverageReportConfigureInvalidType",
+			ds:           &coverageReportDataSource{},
+			providerData: "invalid",
+			expectError:  true,
+		},
+		// Recipe List DataSource Tests
+		{
+			name:         "RecipeListConfigureNilClient",
+			ds:           &recipeListDataSource{},
+			providerData: nil,
+			expectError:  false,
+		},
+		{
+			name:         "RecipeListConfigureInvalidType",
+			ds:           &recipeListDataSource{},
+			providerData: "invalid",
+			expectError:  true,
+		},
+		// Validate DataSource Tests
+		{
+			name:         "ValidateConfigureNilClient",
+			ds:           &validateDataSource{},
+			providerData: nil,
+			expectError:  false,
+		},
+		{
+			name:         "ValidateConfigureInvalidType",
+			ds:           &validateDataSource{},
+			providerData: "invalid",
+			expectError:  true,
+		},
 	}
 
 	for _, tt := range tests {
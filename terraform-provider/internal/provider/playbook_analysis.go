@@ -0,0 +1,193 @@
+// Package provider contains helpers for analysing generated Ansible content
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// envLookupPattern matches lookup('env', 'NAME') and query("env", "NAME") calls.
+var envLookupPattern = regexp.MustCompile(`\b(?:lookup|query)\(\s*['"]env['"]\s*,\s*['"]([^'"]+)['"]`)
+
+// parseReferencedEnvVars returns the sorted, de-duplicated environment variable
+// names referenced through env lookups in the given playbook content.
+func parseReferencedEnvVars(content string) []string {
+	seen := make(map[string]bool)
+	envVars := make([]string, 0)
+	for _, match := range envLookupPattern.FindAllStringSubmatch(content, -1) {
+		name := match[1]
+		if !seen[name] {
+			seen[name] = true
+			envVars = append(envVars, name)
+		}
+	}
+	sort.Strings(envVars)
+	return envVars
+}
+
+// templateVariablePattern matches the variable a Jinja2 `{{ }}` expression
+// starts with, e.g. nginx in {{ nginx.port | default(80) }}.
+var templateVariablePattern = regexp.MustCompile(`\{\{-?\s*([A-Za-z_][A-Za-z0-9_]*)`)
+
+// parseTemplateVariables returns the sorted, de-duplicated variable names
+// referenced in the `{{ }}` expressions of the given Jinja2 template content.
+func parseTemplateVariables(content string) []string {
+	seen := make(map[string]bool)
+	variables := make([]string, 0)
+	for _, match := range templateVariablePattern.FindAllStringSubmatch(content, -1) {
+		name := match[1]
+		if !seen[name] {
+			seen[name] = true
+			variables = append(variables, name)
+		}
+	}
+	sort.Strings(variables)
+	return variables
+}
+
+// defaultDestructivePatterns are the shell commands flagged by the destructive
+// content check when no destructive_patterns are configured.
+var defaultDestructivePatterns = []string{
+	`\brm\s+-[a-zA-Z]*(?:r[a-zA-Z]*f|f[a-zA-Z]*r)`,
+	`\bmkfs(?:\.\w+)?\s`,
+	`\bdd\s+.*\bof=/dev/`,
+	`\bchmod\s+-R\s+0?777\s+/(?:\s|$)`,
+	`>\s*/dev/[sh]d[a-z]`,
+	`:\(\)\s*\{\s*:\|:&\s*\};:`,
+}
+
+// compileDestructivePatterns compiles the given regular expressions, falling
+// back to defaultDestructivePatterns when none are provided.
+func compileDestructivePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	if len(patterns) == 0 {
+		patterns = defaultDestructivePatterns
+	}
+
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid destructive pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// findDestructiveLines returns a "line N: text" entry for every line of content
+// matching at least one of the patterns.
+func findDestructiveLines(content string, patterns []*regexp.Regexp) []string {
+	matches := make([]string, 0)
+	for i, line := range strings.Split(content, "\n") {
+		for _, re := range patterns {
+			if re.MatchString(line) {
+				matches = append(matches, fmt.Sprintf("line %d: %s", i+1, strings.TrimSpace(line)))
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// yamlKeyPattern matches a mapping key, optionally introduced by a list item dash.
+var yamlKeyPattern = regexp.MustCompile(`^(\s*)(-\s+)?([A-Za-z_][\w.]*):(?:\s|$)`)
+
+// taskListKeys are the keys whose list items are tasks.
+var taskListKeys = map[string]bool{
+	"tasks": true, "pre_tasks": true, "post_tasks": true, "handlers": true,
+	"block": true, "rescue": true, "always": true,
+}
+
+// playbookKeywords are play and task keywords that are never module names.
+var playbookKeywords = map[string]bool{
+	"name": true, "hosts": true, "tasks": true, "pre_tasks": true, "post_tasks": true,
+	"handlers": true, "roles": true, "gather_facts": true, "vars": true, "vars_files": true,
+	"vars_prompt": true, "serial": true, "strategy": true, "order": true, "port": true,
+	"max_fail_percentage": true, "block": true, "rescue": true, "always": true,
+	"when": true, "register": true, "become": true, "become_user": true, "become_method": true,
+	"tags": true, "notify": true, "listen": true, "loop": true, "loop_control": true,
+	"args": true, "ignore_errors": true, "ignore_unreachable": true, "changed_when": true,
+	"failed_when": true, "delegate_to": true, "delegate_facts": true, "environment": true,
+	"no_log": true, "until": true, "retries": true, "delay": true, "run_once": true,
+	"check_mode": true, "diff": true, "connection": true, "remote_user": true,
+	"any_errors_fatal": true, "throttle": true, "timeout": true, "debugger": true,
+	"collections": true, "module_defaults": true,
+}
+
+// moduleCountItem tracks a YAML list item while scanning for task modules.
+type moduleCountItem struct {
+	keyCol  int
+	isTask  bool
+	counted bool
+}
+
+// yamlKey records a mapping key and the column it starts at.
+type yamlKey struct {
+	col  int
+	name string
+}
+
+// parseModuleCounts returns how many tasks in the given playbook content use
+// each Ansible module. A task's module is its first key that is not a play or
+// task keyword; only list items under task lists (or at the top level) count.
+func parseModuleCounts(content string) map[string]int {
+	counts := make(map[string]int)
+	var keys []yamlKey
+	var items []moduleCountItem
+
+	for _, line := range strings.Split(content, "\n") {
+		match := yamlKeyPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		keyCol := len(match[1]) + len(match[2])
+		key := match[3]
+
+		// Keys and items at or right of this line's start belong to earlier siblings
+		startCol := keyCol
+		if match[2] != "" {
+			startCol = len(match[1])
+		}
+		for len(keys) > 0 && keys[len(keys)-1].col >= startCol {
+			keys = keys[:len(keys)-1]
+		}
+		for len(items) > 0 && items[len(items)-1].keyCol > keyCol {
+			items = items[:len(items)-1]
+		}
+
+		if match[2] != "" {
+			if len(items) > 0 && items[len(items)-1].keyCol == keyCol {
+				items = items[:len(items)-1]
+			}
+			isTask := len(keys) == 0 || taskListKeys[keys[len(keys)-1].name]
+			items = append(items, moduleCountItem{keyCol: keyCol, isTask: isTask})
+		}
+		keys = append(keys, yamlKey{col: keyCol, name: key})
+
+		if len(items) == 0 {
+			continue
+		}
+		item := &items[len(items)-1]
+		if item.keyCol != keyCol || !item.isTask || item.counted {
+			continue
+		}
+		if playbookKeywords[key] || strings.HasPrefix(key, "with_") {
+			continue
+		}
+		counts[key]++
+		item.counted = true
+	}
+
+	return counts
+}
+
+// playbookReferencesVariable reports whether the Ansible variable name appears
+// as a whole word in the playbook content.
+func playbookReferencesVariable(content, name string) bool {
+	if name == "" {
+		return false
+	}
+	return regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`).MatchString(content)
+}
@@ -0,0 +1,138 @@
+// Package provider contains unit tests for generated content analysis helpers.
+package provider
+
+import (
+	"testing"
+)
+
+func TestParseReferencedEnvVars(t *testing.T) {
+	content := `- name: Configure app
+  hosts: all
+  tasks:
+    - name: Write config
+      template:
+        src: app.conf.j2
+        dest: "{{ lookup('env', 'APP_HOME') }}/app.conf"
+    - name: Set token
+      set_fact:
+        token: '{{ lookup("env", "API_TOKEN") }}'
+        home: "{{ query('env', 'APP_HOME') }}"
+`
+
+	verifyStringSliceResult(t, parseReferencedEnvVars(content), []string{"API_TOKEN", "APP_HOME"})
+}
+
+func TestParseReferencedEnvVarsNone(t *testing.T) {
+	got := parseReferencedEnvVars("- name: test\n  debug:\n    msg: \"{{ lookup('file', 'motd') }}\"\n")
+	if len(got) != 0 {
+		t.Fatalf("expected no env vars, got %v", got)
+	}
+}
+
+func TestParseTemplateVariables(t *testing.T) {
+	content := `worker_processes {{ nginx_workers }};
+server {
+  listen {{nginx.port | default(80)}};
+  server_name {{- server_name -}};
+  {% if ssl_enabled %}ssl_certificate {{ nginx.cert }};{% endif %}
+}
+`
+
+	verifyStringSliceResult(t, parseTemplateVariables(content), []string{"nginx", "nginx_workers", "server_name"})
+}
+
+func TestFindDestructiveLines(t *testing.T) {
+	patterns, err := compileDestructivePatterns(nil)
+	if err != nil {
+		t.Fatalf("failed to compile default patterns: %v", err)
+	}
+
+	content := `- name: Cleanup
+  hosts: all
+  tasks:
+    - name: Remove data
+      command: rm -rf /var/lib/app
+    - name: Show status
+      command: systemctl status app
+`
+
+	verifyStringSliceResult(t, findDestructiveLines(content, patterns), []string{"line 5: command: rm -rf /var/lib/app"})
+}
+
+func TestFindDestructiveLinesSafeContent(t *testing.T) {
+	patterns, err := compileDestructivePatterns(nil)
+	if err != nil {
+		t.Fatalf("failed to compile default patterns: %v", err)
+	}
+
+	got := findDestructiveLines("- name: Remove temp file\n  file:\n    path: /tmp/app.lock\n    state: absent\n", patterns)
+	if len(got) != 0 {
+		t.Fatalf("expected no destructive lines, got %v", got)
+	}
+}
+
+func TestCompileDestructivePatternsInvalid(t *testing.T) {
+	if _, err := compileDestructivePatterns([]string{"("}); err == nil {
+		t.Fatal("expected error for invalid pattern")
+	}
+}
+
+func TestParseModuleCounts(t *testing.T) {
+	content := `---
+- name: Configure web
+  hosts: all
+  become: true
+  tasks:
+    - name: Install nginx
+      ansible.builtin.package:
+        name: nginx
+        state: present
+    - name: Install tools
+      ansible.builtin.package:
+        name: "{{ item }}"
+      loop:
+        - curl
+        - git
+    - name: Render users
+      template:
+        src: users.j2
+        dest: /etc/users
+      with_items:
+        - name: alice
+          state: present
+    - name: Guarded
+      block:
+        - name: Start nginx
+          service:
+            name: nginx
+            state: started
+      when: enable_nginx
+  handlers:
+    - name: restart nginx
+      service:
+        name: nginx
+        state: restarted
+`
+
+	got := parseModuleCounts(content)
+	want := map[string]int{
+		"ansible.builtin.package": 2,
+		"template":                1,
+		"service":                 2,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for module, count := range want {
+		if got[module] != count {
+			t.Fatalf("expected %s count %d, got %v", module, count, got)
+		}
+	}
+}
+
+func TestParseModuleCountsTaskFile(t *testing.T) {
+	got := parseModuleCounts("- name: a\n  debug:\n    msg: hi\n- debug: msg=bye\n")
+	if len(got) != 1 || got["debug"] != 2 {
+		t.Fatalf("expected debug count 2, got %v", got)
+	}
+}
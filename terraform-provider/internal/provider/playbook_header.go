@@ -0,0 +1,50 @@
+// Package provider contains helpers for adding a provenance header to generated playbooks
+package provider
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// defaultHeaderTemplate is the header written when add_header is set
+	// without a header_template.
+	defaultHeaderTemplate = "Generated by SousChef from cookbook {cookbook} recipe {recipe} on {date}"
+	// headerDateFormat is the layout {date} is rendered with.
+	headerDateFormat = "2006-01-02"
+)
+
+// headerDatePlaceholder stands in for {date} while the pattern matching an
+// existing header is built.
+const headerDatePlaceholder = "\x00date\x00"
+
+// renderPlaybookHeader renders headerTemplate as a block of YAML comment
+// lines, replacing {cookbook}, {recipe} and {date}.
+func renderPlaybookHeader(headerTemplate, cookbookName, recipeName, date string) string {
+	if headerTemplate == "" {
+		headerTemplate = defaultHeaderTemplate
+	}
+	text := strings.NewReplacer("{cookbook}", cookbookName, "{recipe}", recipeName, "{date}", date).
+		Replace(strings.TrimRight(headerTemplate, "\n"))
+
+	var header strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		header.WriteString(strings.TrimRight("# "+line, " ") + "\n")
+	}
+	return header.String()
+}
+
+// playbookHeaderPattern matches a header rendered from headerTemplate for the
+// same cookbook and recipe on any date, at the start of a playbook.
+func playbookHeaderPattern(headerTemplate, cookbookName, recipeName string) *regexp.Regexp {
+	rendered := regexp.QuoteMeta(renderPlaybookHeader(headerTemplate, cookbookName, recipeName, headerDatePlaceholder))
+	return regexp.MustCompile(`\A` + strings.ReplaceAll(rendered, headerDatePlaceholder, `\d{4}-\d{2}-\d{2}`))
+}
+
+// addPlaybookHeader prepends the header rendered for date to content. A
+// header already at the start of content, e.g. when the CLI left an existing
+// playbook in place, is replaced rather than repeated.
+func addPlaybookHeader(content []byte, headerTemplate, cookbookName, recipeName, date string) []byte {
+	body := playbookHeaderPattern(headerTemplate, cookbookName, recipeName).ReplaceAll(content, nil)
+	return append([]byte(renderPlaybookHeader(headerTemplate, cookbookName, recipeName, date)), body...)
+}
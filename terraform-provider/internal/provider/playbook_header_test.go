@@ -0,0 +1,47 @@
+// Package provider contains unit tests for the generated playbook header helpers.
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderPlaybookHeader(t *testing.T) {
+	tests := map[string]struct {
+		template string
+		want     string
+	}{
+		"default":    {want: "# Generated by SousChef from cookbook nginx recipe default on 2024-05-01\n"},
+		"multi-line": {template: "Managed by Terraform\n\nSource: {cookbook}::{recipe} ({date})\n", want: "# Managed by Terraform\n#\n# Source: nginx::default (2024-05-01)\n"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := renderPlaybookHeader(tt.template, "nginx", "default", "2024-05-01"); got != tt.want {
+				t.Fatalf("renderPlaybookHeader() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddPlaybookHeader(t *testing.T) {
+	playbook := []byte("---\n- hosts: all\n")
+	for _, template := range []string{"", "Source: {cookbook}::{recipe}\nDate: {date}"} {
+		// Re-adding a header, even one from an earlier date, keeps a single header
+		content := addPlaybookHeader(playbook, template, "nginx", "default", "2024-05-01")
+		content = addPlaybookHeader(content, template, "nginx", "default", "2024-05-01")
+		content = addPlaybookHeader(content, template, "nginx", "default", "2024-06-30")
+
+		want := renderPlaybookHeader(template, "nginx", "default", "2024-06-30") + string(playbook)
+		if string(content) != want {
+			t.Fatalf("template %q: expected %q, got %q", template, want, content)
+		}
+	}
+
+	// A comment that only resembles the header is kept
+	comment := []byte("# Generated by SousChef from cookbook apache recipe default on 2024-05-01\n- hosts: all\n")
+	content := addPlaybookHeader(comment, "", "nginx", "default", "2024-05-01")
+	if !strings.HasSuffix(string(content), string(comment)) {
+		t.Fatalf("expected unrelated comment to be kept, got %q", content)
+	}
+}
@@ -3,20 +3,72 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/sync/semaphore"
 )
 
 // Ensure the implementation satisfies the expected interfaces
 var (
-	_ provider.Provider = &SousChefProvider{}
+	_ provider.Provider                       = &SousChefProvider{}
+	_ provider.ProviderWithEphemeralResources = &SousChefProvider{}
 )
 
+const (
+	// defaultMaxContentBytes is the max_content_bytes used when it is not configured.
+	defaultMaxContentBytes = 1 << 20
+
+	// idStrategyName derives resource IDs from cookbook and recipe names only.
+	idStrategyName = "name"
+	// idStrategyHash adds a short hash of the absolute source path to resource IDs.
+	idStrategyHash = "hash"
+
+	// missingArtifactRemove drops a resource from state when its generated file is gone.
+	missingArtifactRemove = "remove"
+	// missingArtifactError fails Read when a resource's generated file is gone.
+	missingArtifactError = "error"
+)
+
+// knownSubcommands are the logical SousChef CLI subcommands the provider runs,
+// which command_overrides may map to the names a fork of the CLI uses.
+var knownSubcommands = []string{
+	"assess-cookbook",
+	"berksfile-info",
+	"convert-all",
+	"convert-chefspec",
+	"convert-compliance",
+	"convert-databag",
+	"convert-files",
+	"convert-habitat",
+	"convert-inspec",
+	"convert-kitchen",
+	"convert-metadata",
+	"convert-node",
+	"convert-ohai",
+	"convert-recipe",
+	"convert-search",
+	"convert-template",
+	"deps",
+	"inspec-info",
+	"recipe-info",
+	"role-info",
+	"validate",
+}
+
 // SousChefProvider defines the provider implementation.
 type SousChefProvider struct {
 	// version is set to the provider version on release
@@ -25,7 +77,22 @@ type SousChefProvider struct {
 
 // SousChefProviderModel describes the provider data model.
 type SousChefProviderModel struct {
-	SousChefPath types.String `tfsdk:"souschef_path"`
+	SousChefPath            types.String `tfsdk:"souschef_path"`
+	AllowNestedOutput       types.Bool   `tfsdk:"allow_nested_output"`
+	OutputRoot              types.String `tfsdk:"output_root"`
+	PreserveCLIColor        types.Bool   `tfsdk:"preserve_cli_color"`
+	MaxContentBytes         types.Int64  `tfsdk:"max_content_bytes"`
+	IDStrategy              types.String `tfsdk:"id_strategy"`
+	CLIEnv                  types.Map    `tfsdk:"cli_env"`
+	MissingArtifactBehavior types.String `tfsdk:"missing_artifact_behavior"`
+	CommandOverrides        types.Map    `tfsdk:"command_overrides"`
+	SurfaceCLIWarnings      types.Bool   `tfsdk:"surface_cli_warnings"`
+	ReportPath              types.String `tfsdk:"report_path"`
+	DryRun                  types.Bool   `tfsdk:"dry_run"`
+	RetryableErrors         types.List   `tfsdk:"retryable_errors"`
+	NormalizePaths          types.Bool   `tfsdk:"normalize_paths"`
+	FollowSymlinks          types.Bool   `tfsdk:"follow_symlinks"`
+	MaxConcurrentCLI        types.Int64  `tfsdk:"max_concurrent_cli"`
 }
 
 // New is a helper function to simplify provider server setup.
@@ -52,6 +119,70 @@ func (p *SousChefProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 				Description: "Path to the SousChef CLI executable. Defaults to 'souschef' in PATH.",
 				Optional:    true,
 			},
+			"allow_nested_output": schema.BoolAttribute{
+				Description: "Downgrade the output_path inside cookbook_path check from an error to a warning. Defaults to false.",
+				Optional:    true,
+			},
+			"output_root": schema.StringAttribute{
+				Description: "Existing directory that relative resource output_path values are resolved against. Absolute output_path values are used as-is.",
+				Optional:    true,
+			},
+			"preserve_cli_color": schema.BoolAttribute{
+				Description: "Let the SousChef CLI emit ANSI colour codes. By default it is run with NO_COLOR=1 and TERM=dumb so diagnostics stay readable. Defaults to false.",
+				Optional:    true,
+			},
+			"max_content_bytes": schema.Int64Attribute{
+				Description: "Largest generated file, in bytes, stored in full in resource state. Larger files are still written to disk but only their SHA-256 is stored. Defaults to 1048576 (1 MiB).",
+				Optional:    true,
+			},
+			"id_strategy": schema.StringAttribute{
+				Description: "How resource IDs are derived: 'name' uses cookbook and recipe names, 'hash' appends a short SHA-256 of the absolute source path so same-named cookbooks at different paths get distinct IDs. Defaults to 'name'.",
+				Optional:    true,
+			},
+			"cli_env": schema.MapAttribute{
+				Description: "Environment variables set for every SousChef CLI invocation on top of the provider's own environment, e.g. CHEF_LICENSE = \"accept\". Values must not contain newlines.",
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+			},
+			"missing_artifact_behavior": schema.StringAttribute{
+				Description: "What refresh does when a resource's generated files no longer exist: 'remove' drops the resource from state so the next apply recreates it, 'error' fails the refresh so the deletion can be investigated. Defaults to 'remove'.",
+				Optional:    true,
+			},
+			"command_overrides": schema.MapAttribute{
+				Description: "Subcommand names for forks of the SousChef CLI that renamed them, keyed by the subcommand the provider would otherwise run, e.g. {\"convert-recipe\" = \"recipe-convert\"}. Subcommands not listed keep their names.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"surface_cli_warnings": schema.BoolAttribute{
+				Description: "Report anything the SousChef CLI writes to stderr on a successful run as a Terraform warning, e.g. constructs it converted on a best-effort basis. Defaults to true.",
+				Optional:    true,
+			},
+			"report_path": schema.StringAttribute{
+				Description: "Path of a JSON report, written when the current Terraform operation ends, listing each SousChef CLI run's resource ID, subcommand, source and output paths, success and duration.",
+				Optional:    true,
+			},
+			"dry_run": schema.BoolAttribute{
+				Description: "Preview conversions without touching disk: every resource create and update passes --dry-run to the SousChef CLI and takes its computed attributes from the CLI's stdout, refresh keeps resources whose files do not exist, and destroy deletes nothing. Defaults to false.",
+				Optional:    true,
+			},
+			"retryable_errors": schema.ListAttribute{
+				Description: "Regular expressions matched against the stderr of a failed SousChef CLI run; the run is retried, up to 3 attempts in total, only when one matches, e.g. \"temporary failure in name resolution\". By default failed runs are not retried.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"normalize_paths": schema.BoolAttribute{
+				Description: "Record each resource's resolved_output_path as an absolute path, so refresh finds the generated files whatever directory Terraform runs in. output_path and cookbook_path are kept as configured. Defaults to true.",
+				Optional:    true,
+			},
+			"follow_symlinks": schema.BoolAttribute{
+				Description: "Resolve symlinks in cookbook_path before deriving cookbook_name and the resource ID, so a symlinked cookbook is named after the directory it points to rather than the link. The CLI is still given cookbook_path as configured. Defaults to true.",
+				Optional:    true,
+			},
+			"max_concurrent_cli": schema.Int64Attribute{
+				Description: "Most SousChef CLI processes run at once across all resources and data sources; further invocations wait for a free slot. Bounds memory use when many migrations are applied together. Defaults to the number of CPUs.",
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -80,6 +211,15 @@ func (p *SousChefProvider) Configure(ctx context.Context, req provider.Configure
 
 	// Validate configuration values
 	validateAndReportConfigValue(config.SousChefPath, path.Root("souschef_path"), resp)
+	validateOutputRoot(config.OutputRoot, resp)
+	validateMaxContentBytes(config.MaxContentBytes, resp)
+	validateMaxConcurrentCLI(config.MaxConcurrentCLI, resp)
+	validateIDStrategy(config.IDStrategy, resp)
+	validateMissingArtifactBehavior(config.MissingArtifactBehavior, resp)
+	validateReportPath(config.ReportPath, resp)
+	cliEnv := cliEnvFromConfig(ctx, config.CLIEnv, resp)
+	commandOverrides := commandOverridesFromConfig(ctx, config.CommandOverrides, resp)
+	retryableErrors := retryableErrorsFromConfig(ctx, config.RetryableErrors, resp)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -91,18 +231,459 @@ func (p *SousChefProvider) Configure(ctx context.Context, req provider.Configure
 		sousChefPath = config.SousChefPath.ValueString()
 	}
 
+	maxContentBytes := int64(defaultMaxContentBytes)
+	if !config.MaxContentBytes.IsNull() {
+		maxContentBytes = config.MaxContentBytes.ValueInt64()
+	}
+
+	maxConcurrentCLI := int64(runtime.NumCPU())
+	if !config.MaxConcurrentCLI.IsNull() {
+		maxConcurrentCLI = config.MaxConcurrentCLI.ValueInt64()
+	}
+
 	// Create client data that resources can use
 	client := &SousChefClient{
-		Path: sousChefPath,
+		Path:                    sousChefPath,
+		AllowNestedOutput:       config.AllowNestedOutput.ValueBool(),
+		OutputRoot:              config.OutputRoot.ValueString(),
+		PreserveCLIColor:        config.PreserveCLIColor.ValueBool(),
+		MaxContentBytes:         maxContentBytes,
+		IDStrategy:              idStrategyName,
+		CLIEnv:                  cliEnv,
+		CommandOverrides:        commandOverrides,
+		MissingArtifactBehavior: missingArtifactRemove,
+		ProviderVersion:         p.version,
+		SuppressCLIWarnings:     !config.SurfaceCLIWarnings.IsNull() && !config.SurfaceCLIWarnings.ValueBool(),
+		ReportPath:              config.ReportPath.ValueString(),
+		DryRun:                  config.DryRun.ValueBool(),
+		RetryableErrors:         retryableErrors,
+		KeepRelativePaths:       !config.NormalizePaths.IsNull() && !config.NormalizePaths.ValueBool(),
+		KeepSymlinks:            !config.FollowSymlinks.IsNull() && !config.FollowSymlinks.ValueBool(),
+		MaxConcurrentCLI:        maxConcurrentCLI,
+		CLISlots:                semaphore.NewWeighted(maxConcurrentCLI),
+	}
+	if !config.IDStrategy.IsNull() {
+		client.IDStrategy = config.IDStrategy.ValueString()
+	}
+	if !config.MissingArtifactBehavior.IsNull() {
+		client.MissingArtifactBehavior = config.MissingArtifactBehavior.ValueString()
 	}
 
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+}
+
+// validateOutputRoot checks output_root, when set, is a known existing directory.
+func validateOutputRoot(value types.String, resp *provider.ConfigureResponse) {
+	if value.IsNull() {
+		return
+	}
+	if value.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("output_root"),
+			"Unknown Output Root",
+			"The provider cannot resolve output paths as there is an unknown configuration value for output_root.",
+		)
+		return
+	}
+
+	info, err := osStat(value.ValueString())
+	if err != nil || !info.IsDir() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("output_root"),
+			"Invalid Output Root",
+			fmt.Sprintf("output_root must be an existing directory: %s", value.ValueString()),
+		)
+	}
+}
+
+// validateMaxContentBytes checks max_content_bytes, when set, is a known positive value.
+func validateMaxContentBytes(value types.Int64, resp *provider.ConfigureResponse) {
+	if value.IsNull() {
+		return
+	}
+	if value.IsUnknown() || value.ValueInt64() <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_content_bytes"),
+			"Invalid Max Content Bytes",
+			"max_content_bytes must be a known value greater than zero.",
+		)
+	}
+}
+
+// validateMaxConcurrentCLI checks max_concurrent_cli, when set, is a known positive value.
+func validateMaxConcurrentCLI(value types.Int64, resp *provider.ConfigureResponse) {
+	if value.IsNull() {
+		return
+	}
+	if value.IsUnknown() || value.ValueInt64() <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_cli"),
+			"Invalid Max Concurrent CLI",
+			"max_concurrent_cli must be a known value greater than zero.",
+		)
+	}
+}
+
+// validateIDStrategy checks id_strategy, when set, is a known supported strategy.
+func validateIDStrategy(value types.String, resp *provider.ConfigureResponse) {
+	if value.IsNull() {
+		return
+	}
+	if value.IsUnknown() || (value.ValueString() != idStrategyName && value.ValueString() != idStrategyHash) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id_strategy"),
+			"Invalid ID Strategy",
+			fmt.Sprintf("id_strategy must be %q or %q.", idStrategyName, idStrategyHash),
+		)
+	}
+}
+
+// validateMissingArtifactBehavior checks missing_artifact_behavior, when set, is a known supported behavior.
+func validateMissingArtifactBehavior(value types.String, resp *provider.ConfigureResponse) {
+	if value.IsNull() {
+		return
+	}
+	if value.IsUnknown() || (value.ValueString() != missingArtifactRemove && value.ValueString() != missingArtifactError) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("missing_artifact_behavior"),
+			"Invalid Missing Artifact Behavior",
+			fmt.Sprintf("missing_artifact_behavior must be %q or %q.", missingArtifactRemove, missingArtifactError),
+		)
+	}
+}
+
+// validateReportPath checks report_path, when set, is known and its
+// directory exists.
+func validateReportPath(value types.String, resp *provider.ConfigureResponse) {
+	if value.IsNull() {
+		return
+	}
+	if value.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("report_path"),
+			"Unknown Report Path",
+			"The provider cannot write the report as there is an unknown configuration value for report_path.",
+		)
+		return
+	}
+
+	if value.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("report_path"),
+			"Invalid Report Path",
+			"report_path must not be empty.",
+		)
+		return
+	}
+	reportDir := filepath.Dir(value.ValueString())
+	if info, err := osStat(reportDir); err != nil || !info.IsDir() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("report_path"),
+			"Invalid Report Path",
+			fmt.Sprintf("report_path must be a file in an existing directory; %q is not a directory.", reportDir),
+		)
+	}
+}
+
+// cliEnvFromConfig returns the cli_env entries, checking every name is
+// non-empty without '=' and no value contains a newline.
+func cliEnvFromConfig(ctx context.Context, value types.Map, resp *provider.ConfigureResponse) map[string]string {
+	if value.IsNull() {
+		return nil
+	}
+	if value.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("cli_env"),
+			"Unknown CLI Environment",
+			"The provider cannot run the SousChef CLI as there is an unknown configuration value for cli_env.",
+		)
+		return nil
+	}
+
+	env := make(map[string]string)
+	resp.Diagnostics.Append(value.ElementsAs(ctx, &env, false)...)
+	for _, name := range sortedKeys(env) {
+		if name == "" || strings.Contains(name, "=") {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("cli_env"),
+				"Invalid CLI Environment",
+				fmt.Sprintf("cli_env variable names must be non-empty and must not contain '=', got %q.", name),
+			)
+		}
+		if strings.ContainsAny(env[name], "\r\n") {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("cli_env").AtMapKey(name),
+				"Invalid CLI Environment",
+				fmt.Sprintf("The value of cli_env variable %s must not contain newlines.", name),
+			)
+		}
+	}
+	return env
+}
+
+// commandOverridesFromConfig returns the command_overrides entries, checking
+// every key is a known subcommand and every value is a non-empty name.
+func commandOverridesFromConfig(ctx context.Context, value types.Map, resp *provider.ConfigureResponse) map[string]string {
+	if value.IsNull() {
+		return nil
+	}
+	if value.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("command_overrides"),
+			"Unknown Command Overrides",
+			"The provider cannot run the SousChef CLI as there is an unknown configuration value for command_overrides.",
+		)
+		return nil
+	}
+
+	overrides := make(map[string]string)
+	resp.Diagnostics.Append(value.ElementsAs(ctx, &overrides, false)...)
+	for _, name := range sortedKeys(overrides) {
+		if !slices.Contains(knownSubcommands, name) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("command_overrides"),
+				"Invalid Command Overrides",
+				fmt.Sprintf("command_overrides key %q is not a SousChef subcommand the provider runs; expected one of: %s.", name, strings.Join(knownSubcommands, ", ")),
+			)
+		}
+		if overrides[name] == "" || strings.ContainsAny(overrides[name], " \t\r\n") {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("command_overrides").AtMapKey(name),
+				"Invalid Command Overrides",
+				fmt.Sprintf("The override for %s must be a single non-empty subcommand name.", name),
+			)
+		}
+	}
+	return overrides
+}
+
+// retryableErrorsFromConfig compiles the retryable_errors patterns, so an
+// invalid pattern fails Configure rather than the first failed CLI run.
+func retryableErrorsFromConfig(ctx context.Context, value types.List, resp *provider.ConfigureResponse) []*regexp.Regexp {
+	if value.IsNull() {
+		return nil
+	}
+	if value.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retryable_errors"),
+			"Unknown Retryable Errors",
+			"The provider cannot run the SousChef CLI as there is an unknown configuration value for retryable_errors.",
+		)
+		return nil
+	}
+
+	var patterns []string
+	resp.Diagnostics.Append(value.ElementsAs(ctx, &patterns, false)...)
+	retryable := make([]*regexp.Regexp, 0, len(patterns))
+	for i, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retryable_errors").AtListIndex(i),
+				"Invalid Retryable Errors",
+				fmt.Sprintf("retryable_errors pattern %q is not a valid regular expression: %s", pattern, err),
+			)
+			continue
+		}
+		retryable = append(retryable, re)
+	}
+	return retryable
 }
 
 // SousChefClient is a simple client that wraps CLI calls
 type SousChefClient struct {
-	Path string
+	Path                    string
+	AllowNestedOutput       bool
+	OutputRoot              string
+	PreserveCLIColor        bool
+	MaxContentBytes         int64
+	IDStrategy              string
+	CLIEnv                  map[string]string
+	MissingArtifactBehavior string
+	ProviderVersion         string
+	CommandOverrides        map[string]string
+	// SuppressCLIWarnings is set when surface_cli_warnings is false, so a
+	// zero-value client surfaces warnings like the provider default.
+	SuppressCLIWarnings bool
+	// ReportPath is where CLI runs are reported as JSON; empty disables the report.
+	ReportPath string
+	// DryRun passes --dry-run to every conversion and keeps resources from
+	// writing, reading back or deleting anything on disk.
+	DryRun bool
+	// RetryableErrors are the compiled retryable_errors patterns; a failed CLI
+	// run is retried only when its stderr matches one.
+	RetryableErrors []*regexp.Regexp
+	// KeepRelativePaths is set when normalize_paths is false, so a zero-value
+	// client normalizes paths like the provider default.
+	KeepRelativePaths bool
+	// KeepSymlinks is set when follow_symlinks is false, so a zero-value
+	// client names cookbooks after their real directories.
+	KeepSymlinks bool
+	// MaxConcurrentCLI is the configured max_concurrent_cli.
+	MaxConcurrentCLI int64
+	// CLISlots bounds how many CLI processes run at once across every
+	// resource and data source sharing the client; nil means no limit.
+	CLISlots *semaphore.Weighted
+}
+
+// acquireCLISlot waits for a free CLI slot and returns the function that
+// releases it.
+func (c *SousChefClient) acquireCLISlot(ctx context.Context) (func(), error) {
+	if c == nil || c.CLISlots == nil {
+		return func() {}, nil
+	}
+	if err := c.CLISlots.Acquire(ctx, 1); err != nil {
+		return nil, err
+	}
+	return func() { c.CLISlots.Release(1) }, nil
+}
+
+// retryable reports whether a failed CLI run with the given stderr should
+// be retried.
+func (c *SousChefClient) retryable(stderr string) bool {
+	if c == nil {
+		return false
+	}
+	for _, re := range c.RetryableErrors {
+		if re.MatchString(stderr) {
+			return true
+		}
+	}
+	return false
+}
+
+// isDryRun reports whether the provider dry_run attribute is set.
+func (c *SousChefClient) isDryRun() bool {
+	return c != nil && c.DryRun
+}
+
+// dryRunArgs returns args with --dry-run appended in dry-run mode.
+func (c *SousChefClient) dryRunArgs(args []string) []string {
+	if !c.isDryRun() {
+		return args
+	}
+	return append(args, "--dry-run")
+}
+
+// surfacesCLIWarnings reports whether stderr output of successful CLI runs
+// is reported as warnings.
+func (c *SousChefClient) surfacesCLIWarnings() bool {
+	return c == nil || !c.SuppressCLIWarnings
+}
+
+// subcommand returns the CLI subcommand to run for the logical subcommand
+// name, applying command_overrides.
+func (c *SousChefClient) subcommand(name string) string {
+	if c == nil {
+		return name
+	}
+	if override, ok := c.CommandOverrides[name]; ok {
+		return override
+	}
+	return name
+}
+
+// contentLimit returns the largest generated file stored in full in state,
+// falling back to defaultMaxContentBytes when the client has no limit set.
+func (c *SousChefClient) contentLimit() int64 {
+	if c == nil || c.MaxContentBytes <= 0 {
+		return defaultMaxContentBytes
+	}
+	return c.MaxContentBytes
+}
+
+// storedContent returns the value to store in state for generated content.
+// Content over the client's limit is replaced by a notice carrying its
+// SHA-256 hash, and the second return value reports the truncation.
+func (c *SousChefClient) storedContent(content []byte) (string, bool) {
+	limit := c.contentLimit()
+	if int64(len(content)) <= limit {
+		return string(content), false
+	}
+	return fmt.Sprintf("# Content truncated: %d bytes exceeds max_content_bytes (%d). SHA-256: %s\n",
+		len(content), limit, contentSHA256(content)), true
+}
+
+// command builds a SousChef CLI invocation. Unless PreserveCLIColor is set,
+// colour output is disabled so ANSI codes do not end up in diagnostics.
+// CLIEnv entries are added last so they take precedence.
+func (c *SousChefClient) command(ctx context.Context, args ...string) *exec.Cmd {
+	cmd := execCommandContext(ctx, c.Path, args...)
+	if c.PreserveCLIColor && len(c.CLIEnv) == 0 {
+		return cmd
+	}
+
+	env := os.Environ()
+	if !c.PreserveCLIColor {
+		env = append(env, "NO_COLOR=1", "TERM=dumb")
+	}
+	for _, name := range sortedKeys(c.CLIEnv) {
+		env = append(env, name+"="+c.CLIEnv[name])
+	}
+	cmd.Env = env
+	return cmd
+}
+
+// resolveOutputPath joins a relative outputPath onto the provider's
+// output_root, when one is configured, and makes the result absolute unless
+// normalize_paths is false, so it does not depend on Terraform's working
+// directory.
+func (c *SousChefClient) resolveOutputPath(outputPath string) string {
+	if c != nil && c.OutputRoot != "" && !filepath.IsAbs(outputPath) {
+		outputPath = filepath.Join(c.OutputRoot, outputPath)
+	}
+	if outputPath == "" || (c != nil && c.KeepRelativePaths) {
+		return outputPath
+	}
+	if absPath, err := filepath.Abs(outputPath); err == nil {
+		return absPath
+	}
+	return outputPath
+}
+
+// cookbookName returns the name of the cookbook at cookbookPath: the base
+// name of the directory it resolves to, or of cookbookPath itself when
+// follow_symlinks is false or the path cannot be resolved.
+func (c *SousChefClient) cookbookName(cookbookPath string) string {
+	if c == nil || !c.KeepSymlinks {
+		if realPath, err := filepath.EvalSymlinks(cookbookPath); err == nil {
+			cookbookPath = realPath
+		}
+	}
+	return filepath.Base(cookbookPath)
+}
+
+// resourceID returns the ID of a resource whose name-based ID is nameID and
+// whose source cookbook, plan, profile or file is at sourcePath. Under the
+// hash strategy a short SHA-256 of the absolute sourcePath and nameID is
+// appended, so same-named sources at different paths get distinct IDs.
+func (c *SousChefClient) resourceID(nameID, sourcePath string) string {
+	if c == nil || c.IDStrategy != idStrategyHash {
+		return nameID
+	}
+	if absPath, err := filepath.Abs(sourcePath); err == nil {
+		sourcePath = absPath
+	}
+	return nameID + "-" + contentSHA256([]byte(sourcePath + "\x00" + nameID))[:12]
+}
+
+// gitResourceID is resourceID for a cookbook cloned from gitURL at gitRef.
+// The clone lives in a new temporary directory on every apply, so the hash
+// strategy hashes the URL and ref instead of the clone's path.
+func (c *SousChefClient) gitResourceID(nameID, gitURL, gitRef string) string {
+	if c == nil || c.IDStrategy != idStrategyHash {
+		return nameID
+	}
+	return nameID + "-" + contentSHA256([]byte(gitURL + "\x00" + gitRef + "\x00" + nameID))[:12]
+}
+
+// EphemeralResources defines the ephemeral resources implemented in the provider.
+func (p *SousChefProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewMigrationEphemeralResource,
+	}
 }
 
 // DataSources defines the data sources implemented in the provider.
@@ -110,6 +691,22 @@ func (p *SousChefProvider) DataSources(_ context.Context) []func() datasource.Da
 	return []func() datasource.DataSource{
 		NewAssessmentDataSource,
 		NewCostEstimateDataSource,
+		NewDiffDataSource,
+		NewLintDataSource,
+		NewCoverageReportDataSource,
+		NewRecipeListDataSource,
+		NewValidateDataSource,
+		NewDependenciesDataSource,
+		NewRoleDataSource,
+		NewInSpecProfileDataSource,
+		NewBatchCostEstimateDataSource,
+		NewVersionDataSource,
+		NewMigrationPlanDataSource,
+		NewBerksfileDataSource,
+		NewEncryptedDatabagDataSource,
+		NewGitCookbookDataSource,
+		NewRecipeDataSource,
+		NewConvertTemplateDataSource,
 	}
 }
 
@@ -120,5 +717,19 @@ func (p *SousChefProvider) Resources(_ context.Context) []func() resource.Resour
 		NewBatchMigrationResource,
 		NewHabitatMigrationResource,
 		NewInSpecMigrationResource,
+		NewBatchHabitatMigrationResource,
+		NewKitchenMigrationResource,
+		NewSearchMigrationResource,
+		NewConvertAllResource,
+		NewBatchInSpecMigrationResource,
+		NewFileMigrationResource,
+		NewNodeMigrationResource,
+		NewDatabagMigrationResource,
+		NewChefSpecMigrationResource,
+		NewMetadataMigrationResource,
+		NewOhaiMigrationResource,
+		NewComplianceMigrationResource,
+		NewCookbookValidationResource,
+		NewAssessmentResource,
 	}
 }
@@ -57,12 +57,12 @@ func TestProviderFactoryComplete(t *testing.T) {
 	resources := provider.Resources(context.Background())
 	dataSources := provider.DataSources(context.Background())
 
-	if len(resources) != 4 {
-		t.Errorf("Expected 4 resources, got %d", len(resources))
+	if len(resources) != 18 {
+		t.Errorf("Expected 18 resources, got %d", len(resources))
 	}
 
-	if len(dataSources) != 2 {
-		t.Errorf("Expected 2 data sources, got %d", len(dataSources))
+	if len(dataSources) != 18 {
+		t.Errorf("Expected 18 data sources, got %d", len(dataSources))
 	}
 
 	// Verify each resource factory works
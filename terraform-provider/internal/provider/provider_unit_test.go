@@ -5,12 +5,18 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func TestSousChefProviderNew(t *testing.T) {
@@ -246,6 +252,104 @@ func TestNewInSpecMigrationResource(t *testing.T) {
 	}
 }
 
+func TestNewBatchHabitatMigrationResource(t *testing.T) {
+	r := NewBatchHabitatMigrationResource()
+	if r == nil {
+		t.Fatal("expected non-nil batch habitat migration resource")
+	}
+}
+
+func TestNewKitchenMigrationResource(t *testing.T) {
+	r := NewKitchenMigrationResource()
+	if r == nil {
+		t.Fatal("expected non-nil kitchen migration resource")
+	}
+}
+
+func TestNewSearchMigrationResource(t *testing.T) {
+	r := NewSearchMigrationResource()
+	if r == nil {
+		t.Fatal("expected non-nil search migration resource")
+	}
+}
+
+func TestNewConvertAllResource(t *testing.T) {
+	r := NewConvertAllResource()
+	if r == nil {
+		t.Fatal("expected non-nil convert all resource")
+	}
+}
+
+func TestNewBatchInSpecMigrationResource(t *testing.T) {
+	r := NewBatchInSpecMigrationResource()
+	if r == nil {
+		t.Fatal("expected non-nil batch InSpec migration resource")
+	}
+}
+
+func TestNewFileMigrationResource(t *testing.T) {
+	r := NewFileMigrationResource()
+	if r == nil {
+		t.Fatal("expected non-nil file migration resource")
+	}
+}
+
+func TestNewNodeMigrationResource(t *testing.T) {
+	r := NewNodeMigrationResource()
+	if r == nil {
+		t.Fatal("expected non-nil node migration resource")
+	}
+}
+
+func TestNewDatabagMigrationResource(t *testing.T) {
+	r := NewDatabagMigrationResource()
+	if r == nil {
+		t.Fatal("expected non-nil data bag migration resource")
+	}
+}
+
+func TestNewChefSpecMigrationResource(t *testing.T) {
+	r := NewChefSpecMigrationResource()
+	if r == nil {
+		t.Fatal("expected non-nil ChefSpec migration resource")
+	}
+}
+
+func TestNewMetadataMigrationResource(t *testing.T) {
+	r := NewMetadataMigrationResource()
+	if r == nil {
+		t.Fatal("expected non-nil metadata migration resource")
+	}
+}
+
+func TestNewOhaiMigrationResource(t *testing.T) {
+	r := NewOhaiMigrationResource()
+	if r == nil {
+		t.Fatal("expected non-nil Ohai migration resource")
+	}
+}
+
+func TestNewComplianceMigrationResource(t *testing.T) {
+	r := NewComplianceMigrationResource()
+	if r == nil {
+		t.Fatal("expected non-nil compliance migration resource")
+	}
+}
+
+func TestNewCookbookValidationResource(t *testing.T) {
+	r := NewCookbookValidationResource()
+	if r == nil {
+		t.Fatal("expected non-nil cookbook validation resource")
+	}
+}
+
+func TestNewAssessmentResource(t *testing.T) {
+	r := NewAssessmentResource()
+	if r == nil {
+		t.Fatal("expected non-nil assessment resource")
+	}
+}
+
 func TestNewAssessmentDataSource(t *testing.T) {
 	ds := NewAssessmentDataSource()
 	if ds == nil {
@@ -260,6 +364,62 @@ func TestNewCostEstimateDataSource(t *testing.T) {
 	}
 }
 
+func TestNewBatchCostEstimateDataSource(t *testing.T) {
+	ds := NewBatchCostEstimateDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil batch cost estimate data source")
+	}
+}
+
+func TestNewVersionDataSource(t *testing.T) {
+	ds := NewVersionDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil version data source")
+	}
+}
+
+func TestNewMigrationPlanDataSource(t *testing.T) {
+	ds := NewMigrationPlanDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil migration plan data source")
+	}
+}
+
+func TestNewBerksfileDataSource(t *testing.T) {
+	ds := NewBerksfileDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil Berksfile data source")
+	}
+}
+
+func TestNewEncryptedDatabagDataSource(t *testing.T) {
+	ds := NewEncryptedDatabagDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil encrypted data bag data source")
+	}
+}
+
+func TestNewGitCookbookDataSource(t *testing.T) {
+	ds := NewGitCookbookDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil Git cookbook data source")
+	}
+}
+
+func TestNewRecipeDataSource(t *testing.T) {
+	ds := NewRecipeDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil recipe data source")
+	}
+}
+
+func TestNewConvertTemplateDataSource(t *testing.T) {
+	ds := NewConvertTemplateDataSource()
+	if ds == nil {
+		t.Fatal("expected non-nil template conversion data source")
+	}
+}
+
 func TestMigrationResourceSchema(t *testing.T) {
 	r := &migrationResource{}
 	req := resource.SchemaRequest{}
@@ -352,3 +512,569 @@ func TestAccPreCheckDefaultPath(t *testing.T) {
 	// testAccPreCheck should not panic
 	testAccPreCheck(t)
 }
+
+func TestProviderConfigureOutputRoot(t *testing.T) {
+	p := &SousChefProvider{}
+	schema := newProviderSchema(t, p)
+
+	root := t.TempDir()
+	notDir := filepath.Join(root, "file.txt")
+	if err := os.WriteFile(notDir, []byte("x"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+
+	tests := []struct {
+		name       string
+		outputRoot types.String
+		wantErr    bool
+	}{
+		{name: "unset", outputRoot: types.StringNull()},
+		{name: "existing directory", outputRoot: types.StringValue(root)},
+		{name: "missing directory", outputRoot: types.StringValue(filepath.Join(root, "missing")), wantErr: true},
+		{name: "regular file", outputRoot: types.StringValue(notDir), wantErr: true},
+		{name: "unknown", outputRoot: types.StringUnknown(), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newProviderConfig(t, schema, SousChefProviderModel{OutputRoot: tt.outputRoot, CLIEnv: types.MapNull(types.StringType), CommandOverrides: types.MapNull(types.StringType), RetryableErrors: types.ListNull(types.StringType)})
+			resp := &provider.ConfigureResponse{}
+			p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, resp.Diagnostics)
+			}
+			if tt.wantErr {
+				return
+			}
+			client, ok := resp.ResourceData.(*SousChefClient)
+			if !ok || client.OutputRoot != tt.outputRoot.ValueString() {
+				t.Fatalf("expected output root %q, got %#v", tt.outputRoot.ValueString(), resp.ResourceData)
+			}
+		})
+	}
+}
+
+func TestProviderConfigurePreserveCLIColor(t *testing.T) {
+	p := &SousChefProvider{}
+	schema := newProviderSchema(t, p)
+
+	for _, preserve := range []types.Bool{types.BoolNull(), types.BoolValue(true)} {
+		config := newProviderConfig(t, schema, SousChefProviderModel{PreserveCLIColor: preserve, CLIEnv: types.MapNull(types.StringType), CommandOverrides: types.MapNull(types.StringType), RetryableErrors: types.ListNull(types.StringType)})
+		resp := &provider.ConfigureResponse{}
+		p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+		}
+		client, ok := resp.ResourceData.(*SousChefClient)
+		if !ok || client.PreserveCLIColor != preserve.ValueBool() {
+			t.Fatalf("expected preserve_cli_color %v, got %#v", preserve.ValueBool(), resp.ResourceData)
+		}
+	}
+}
+
+func TestProviderConfigureSurfaceCLIWarnings(t *testing.T) {
+	p := &SousChefProvider{}
+	schema := newProviderSchema(t, p)
+
+	for surface, wantSuppressed := range map[types.Bool]bool{types.BoolNull(): false, types.BoolValue(true): false, types.BoolValue(false): true} {
+		config := newProviderConfig(t, schema, SousChefProviderModel{SurfaceCLIWarnings: surface, CLIEnv: types.MapNull(types.StringType), CommandOverrides: types.MapNull(types.StringType), RetryableErrors: types.ListNull(types.StringType)})
+		resp := &provider.ConfigureResponse{}
+		p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+		}
+		client, ok := resp.ResourceData.(*SousChefClient)
+		if !ok || client.SuppressCLIWarnings != wantSuppressed {
+			t.Fatalf("surface_cli_warnings %v: expected SuppressCLIWarnings %v, got %#v", surface, wantSuppressed, resp.ResourceData)
+		}
+	}
+}
+
+func TestProviderConfigureReportPath(t *testing.T) {
+	p := &SousChefProvider{}
+	schema := newProviderSchema(t, p)
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+
+	tests := map[string]struct {
+		reportPath types.String
+		want       string
+		wantErr    bool
+	}{
+		"unset":             {reportPath: types.StringNull()},
+		"set":               {reportPath: types.StringValue(reportPath), want: reportPath},
+		"empty":             {reportPath: types.StringValue(""), wantErr: true},
+		"missing directory": {reportPath: types.StringValue(filepath.Join(t.TempDir(), "missing", "report.json")), wantErr: true},
+		"unknown":           {reportPath: types.StringUnknown(), wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := newProviderConfig(t, schema, SousChefProviderModel{ReportPath: tt.reportPath, CLIEnv: types.MapNull(types.StringType), CommandOverrides: types.MapNull(types.StringType), RetryableErrors: types.ListNull(types.StringType)})
+			resp := &provider.ConfigureResponse{}
+			p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, resp.Diagnostics)
+			}
+			if client, ok := resp.ResourceData.(*SousChefClient); !tt.wantErr && (!ok || client.ReportPath != tt.want) {
+				t.Fatalf("expected ReportPath %q, got %#v", tt.want, resp.ResourceData)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureDryRun(t *testing.T) {
+	p := &SousChefProvider{}
+	schema := newProviderSchema(t, p)
+
+	for _, dryRun := range []types.Bool{types.BoolNull(), types.BoolValue(false), types.BoolValue(true)} {
+		config := newProviderConfig(t, schema, SousChefProviderModel{DryRun: dryRun, CLIEnv: types.MapNull(types.StringType), CommandOverrides: types.MapNull(types.StringType), RetryableErrors: types.ListNull(types.StringType)})
+		resp := &provider.ConfigureResponse{}
+		p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+		}
+		if client, ok := resp.ResourceData.(*SousChefClient); !ok || client.DryRun != dryRun.ValueBool() {
+			t.Fatalf("expected DryRun %t, got %#v", dryRun.ValueBool(), resp.ResourceData)
+		}
+	}
+}
+
+func TestProviderConfigureNormalizePaths(t *testing.T) {
+	p := &SousChefProvider{}
+	schema := newProviderSchema(t, p)
+
+	for normalize, wantKeep := range map[types.Bool]bool{types.BoolNull(): false, types.BoolValue(true): false, types.BoolValue(false): true} {
+		config := newProviderConfig(t, schema, SousChefProviderModel{NormalizePaths: normalize, CLIEnv: types.MapNull(types.StringType), CommandOverrides: types.MapNull(types.StringType), RetryableErrors: types.ListNull(types.StringType)})
+		resp := &provider.ConfigureResponse{}
+		p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+		}
+		if client, ok := resp.ResourceData.(*SousChefClient); !ok || client.KeepRelativePaths != wantKeep {
+			t.Fatalf("normalize_paths %v: expected KeepRelativePaths %t, got %#v", normalize, wantKeep, resp.ResourceData)
+		}
+	}
+}
+
+func TestProviderConfigureFollowSymlinks(t *testing.T) {
+	p := &SousChefProvider{}
+	schema := newProviderSchema(t, p)
+
+	for follow, wantKeep := range map[types.Bool]bool{types.BoolNull(): false, types.BoolValue(true): false, types.BoolValue(false): true} {
+		config := newProviderConfig(t, schema, SousChefProviderModel{FollowSymlinks: follow, CLIEnv: types.MapNull(types.StringType), CommandOverrides: types.MapNull(types.StringType), RetryableErrors: types.ListNull(types.StringType)})
+		resp := &provider.ConfigureResponse{}
+		p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+		}
+		if client, ok := resp.ResourceData.(*SousChefClient); !ok || client.KeepSymlinks != wantKeep {
+			t.Fatalf("follow_symlinks %v: expected KeepSymlinks %t, got %#v", follow, wantKeep, resp.ResourceData)
+		}
+	}
+}
+
+func TestProviderConfigureRetryableErrors(t *testing.T) {
+	p := &SousChefProvider{}
+	schema := newProviderSchema(t, p)
+
+	tests := map[string]struct {
+		patterns types.List
+		want     int
+		wantErr  bool
+	}{
+		"unset":   {patterns: types.ListNull(types.StringType)},
+		"valid":   {patterns: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("temporary failure"), types.StringValue("(?i)timed? ?out")}), want: 2},
+		"invalid": {patterns: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("temporary failure"), types.StringValue("(unclosed")}), wantErr: true},
+		"unknown": {patterns: types.ListUnknown(types.StringType), wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := newProviderConfig(t, schema, SousChefProviderModel{RetryableErrors: tt.patterns, CLIEnv: types.MapNull(types.StringType), CommandOverrides: types.MapNull(types.StringType)})
+			resp := &provider.ConfigureResponse{}
+			p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, resp.Diagnostics)
+			}
+			if client, ok := resp.ResourceData.(*SousChefClient); !tt.wantErr && (!ok || len(client.RetryableErrors) != tt.want) {
+				t.Fatalf("expected %d compiled patterns, got %#v", tt.want, resp.ResourceData)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureMaxContentBytes(t *testing.T) {
+	p := &SousChefProvider{}
+	schema := newProviderSchema(t, p)
+
+	tests := []struct {
+		name            string
+		maxContentBytes types.Int64
+		want            int64
+		wantErr         bool
+	}{
+		{name: "unset", maxContentBytes: types.Int64Null(), want: defaultMaxContentBytes},
+		{name: "set", maxContentBytes: types.Int64Value(4096), want: 4096},
+		{name: "zero", maxContentBytes: types.Int64Value(0), wantErr: true},
+		{name: "unknown", maxContentBytes: types.Int64Unknown(), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newProviderConfig(t, schema, SousChefProviderModel{MaxContentBytes: tt.maxContentBytes, CLIEnv: types.MapNull(types.StringType), CommandOverrides: types.MapNull(types.StringType), RetryableErrors: types.ListNull(types.StringType)})
+			resp := &provider.ConfigureResponse{}
+			p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, resp.Diagnostics)
+			}
+			if tt.wantErr {
+				return
+			}
+			client, ok := resp.ResourceData.(*SousChefClient)
+			if !ok || client.MaxContentBytes != tt.want {
+				t.Fatalf("expected max_content_bytes %d, got %#v", tt.want, resp.ResourceData)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureMaxConcurrentCLI(t *testing.T) {
+	p := &SousChefProvider{}
+	schema := newProviderSchema(t, p)
+
+	tests := []struct {
+		name             string
+		maxConcurrentCLI types.Int64
+		want             int64
+		wantErr          bool
+	}{
+		{name: "unset", maxConcurrentCLI: types.Int64Null(), want: int64(runtime.NumCPU())},
+		{name: "set", maxConcurrentCLI: types.Int64Value(2), want: 2},
+		{name: "zero", maxConcurrentCLI: types.Int64Value(0), wantErr: true},
+		{name: "unknown", maxConcurrentCLI: types.Int64Unknown(), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newProviderConfig(t, schema, SousChefProviderModel{MaxConcurrentCLI: tt.maxConcurrentCLI, CLIEnv: types.MapNull(types.StringType), CommandOverrides: types.MapNull(types.StringType), RetryableErrors: types.ListNull(types.StringType)})
+			resp := &provider.ConfigureResponse{}
+			p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, resp.Diagnostics)
+			}
+			if tt.wantErr {
+				return
+			}
+			client, ok := resp.ResourceData.(*SousChefClient)
+			if !ok || client.MaxConcurrentCLI != tt.want || client.CLISlots == nil {
+				t.Fatalf("expected max_concurrent_cli %d, got %#v", tt.want, resp.ResourceData)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureIDStrategy(t *testing.T) {
+	p := &SousChefProvider{}
+	schema := newProviderSchema(t, p)
+
+	tests := []struct {
+		name       string
+		idStrategy types.String
+		want       string
+		wantErr    bool
+	}{
+		{name: "unset", idStrategy: types.StringNull(), want: idStrategyName},
+		{name: "name", idStrategy: types.StringValue(idStrategyName), want: idStrategyName},
+		{name: "hash", idStrategy: types.StringValue(idStrategyHash), want: idStrategyHash},
+		{name: "invalid", idStrategy: types.StringValue("uuid"), wantErr: true},
+		{name: "unknown", idStrategy: types.StringUnknown(), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newProviderConfig(t, schema, SousChefProviderModel{IDStrategy: tt.idStrategy, CLIEnv: types.MapNull(types.StringType), CommandOverrides: types.MapNull(types.StringType), RetryableErrors: types.ListNull(types.StringType)})
+			resp := &provider.ConfigureResponse{}
+			p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, resp.Diagnostics)
+			}
+			if tt.wantErr {
+				return
+			}
+			client, ok := resp.ResourceData.(*SousChefClient)
+			if !ok || client.IDStrategy != tt.want {
+				t.Fatalf("expected id_strategy %q, got %#v", tt.want, resp.ResourceData)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureMissingArtifactBehavior(t *testing.T) {
+	p := &SousChefProvider{}
+	schema := newProviderSchema(t, p)
+
+	tests := []struct {
+		name     string
+		behavior types.String
+		want     string
+		wantErr  bool
+	}{
+		{name: "unset", behavior: types.StringNull(), want: missingArtifactRemove},
+		{name: "remove", behavior: types.StringValue(missingArtifactRemove), want: missingArtifactRemove},
+		{name: "error", behavior: types.StringValue(missingArtifactError), want: missingArtifactError},
+		{name: "invalid", behavior: types.StringValue("ignore"), wantErr: true},
+		{name: "unknown", behavior: types.StringUnknown(), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newProviderConfig(t, schema, SousChefProviderModel{MissingArtifactBehavior: tt.behavior, CLIEnv: types.MapNull(types.StringType), CommandOverrides: types.MapNull(types.StringType), RetryableErrors: types.ListNull(types.StringType)})
+			resp := &provider.ConfigureResponse{}
+			p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, resp.Diagnostics)
+			}
+			if tt.wantErr {
+				return
+			}
+			client, ok := resp.ResourceData.(*SousChefClient)
+			if !ok || client.MissingArtifactBehavior != tt.want {
+				t.Fatalf("expected missing_artifact_behavior %q, got %#v", tt.want, resp.ResourceData)
+			}
+		})
+	}
+}
+
+func TestSousChefClientResourceID(t *testing.T) {
+	hashClient := &SousChefClient{IDStrategy: idStrategyHash}
+
+	if got := (&SousChefClient{IDStrategy: idStrategyName}).resourceID("nginx-default", "/a/nginx"); got != "nginx-default" {
+		t.Errorf("expected name strategy to keep the name ID, got %q", got)
+	}
+	if got := (*SousChefClient)(nil).resourceID("nginx-default", "/a/nginx"); got != "nginx-default" {
+		t.Errorf("expected nil client to keep the name ID, got %q", got)
+	}
+
+	first := hashClient.resourceID("nginx-default", "/a/nginx")
+	if !strings.HasPrefix(first, "nginx-default-") || len(first) != len("nginx-default-")+12 {
+		t.Errorf("expected name ID with a 12 character hash suffix, got %q", first)
+	}
+	if again := hashClient.resourceID("nginx-default", "/a/nginx"); again != first {
+		t.Errorf("expected a deterministic ID, got %q and %q", first, again)
+	}
+	if other := hashClient.resourceID("nginx-default", "/b/nginx"); other == first {
+		t.Errorf("expected distinct IDs for different paths, both got %q", first)
+	}
+	if other := hashClient.resourceID("nginx-server", "/a/nginx"); other == first {
+		t.Errorf("expected distinct IDs for different recipes, both got %q", first)
+	}
+}
+
+func TestSousChefClientGitResourceID(t *testing.T) {
+	hashClient := &SousChefClient{IDStrategy: idStrategyHash}
+
+	if got := (&SousChefClient{IDStrategy: idStrategyName}).gitResourceID("web-default", "https://example.com/web.git", "main"); got != "web-default" {
+		t.Errorf("expected name strategy to keep the name ID, got %q", got)
+	}
+
+	first := hashClient.gitResourceID("web-default", "https://example.com/web.git", "main")
+	if !strings.HasPrefix(first, "web-default-") || len(first) != len("web-default-")+12 {
+		t.Errorf("expected name ID with a 12 character hash suffix, got %q", first)
+	}
+	if again := hashClient.gitResourceID("web-default", "https://example.com/web.git", "main"); again != first {
+		t.Errorf("expected a deterministic ID, got %q and %q", first, again)
+	}
+	if other := hashClient.gitResourceID("web-default", "https://example.com/web.git", "v2"); other == first {
+		t.Errorf("expected distinct IDs for different refs, both got %q", first)
+	}
+}
+
+func TestProviderConfigureCLIEnv(t *testing.T) {
+	p := &SousChefProvider{}
+	schema := newProviderSchema(t, p)
+
+	if !schema.Attributes["cli_env"].IsSensitive() {
+		t.Fatal("expected cli_env to be marked sensitive")
+	}
+
+	tests := []struct {
+		name    string
+		cliEnv  types.Map
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "unset", cliEnv: types.MapNull(types.StringType)},
+		{name: "set", cliEnv: stringMapValue(map[string]string{"CHEF_LICENSE": "accept"}), want: map[string]string{"CHEF_LICENSE": "accept"}},
+		{name: "empty name", cliEnv: stringMapValue(map[string]string{"": "accept"}), wantErr: true},
+		{name: "name with equals", cliEnv: stringMapValue(map[string]string{"A=B": "accept"}), wantErr: true},
+		{name: "newline in value", cliEnv: stringMapValue(map[string]string{"CHEF_LICENSE": "accept\nFOO=bar"}), wantErr: true},
+		{name: "unknown", cliEnv: types.MapUnknown(types.StringType), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newProviderConfig(t, schema, SousChefProviderModel{CLIEnv: tt.cliEnv, CommandOverrides: types.MapNull(types.StringType), RetryableErrors: types.ListNull(types.StringType)})
+			resp := &provider.ConfigureResponse{}
+			p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, resp.Diagnostics)
+			}
+			if tt.wantErr {
+				return
+			}
+			client, ok := resp.ResourceData.(*SousChefClient)
+			if !ok || !reflect.DeepEqual(client.CLIEnv, tt.want) {
+				t.Fatalf("expected cli_env %v, got %#v", tt.want, resp.ResourceData)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureCommandOverrides(t *testing.T) {
+	p := &SousChefProvider{}
+	schema := newProviderSchema(t, p)
+
+	tests := []struct {
+		name      string
+		overrides types.Map
+		want      map[string]string
+		wantErr   bool
+	}{
+		{name: "unset", overrides: types.MapNull(types.StringType)},
+		{name: "set", overrides: stringMapValue(map[string]string{"convert-recipe": "recipe-convert"}), want: map[string]string{"convert-recipe": "recipe-convert"}},
+		{name: "recipe-info", overrides: stringMapValue(map[string]string{"recipe-info": "info-recipe"}), want: map[string]string{"recipe-info": "info-recipe"}},
+		{name: "convert-template", overrides: stringMapValue(map[string]string{"convert-template": "template-convert"}), want: map[string]string{"convert-template": "template-convert"}},
+		{name: "unknown subcommand", overrides: stringMapValue(map[string]string{"convert-cookbook": "cookbook-convert"}), wantErr: true},
+		{name: "empty name", overrides: stringMapValue(map[string]string{"convert-recipe": ""}), wantErr: true},
+		{name: "name with spaces", overrides: stringMapValue(map[string]string{"convert-recipe": "recipe convert"}), wantErr: true},
+		{name: "unknown", overrides: types.MapUnknown(types.StringType), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newProviderConfig(t, schema, SousChefProviderModel{CLIEnv: types.MapNull(types.StringType), CommandOverrides: tt.overrides, RetryableErrors: types.ListNull(types.StringType)})
+			resp := &provider.ConfigureResponse{}
+			p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, resp.Diagnostics)
+			}
+			if tt.wantErr {
+				return
+			}
+			client, ok := resp.ResourceData.(*SousChefClient)
+			if !ok || !reflect.DeepEqual(client.CommandOverrides, tt.want) {
+				t.Fatalf("expected command_overrides %v, got %#v", tt.want, resp.ResourceData)
+			}
+		})
+	}
+}
+
+func TestCommandOverridesRenameSubcommand(t *testing.T) {
+	t.Setenv("SOUSCHEF_TEST_RENAME", "convert-recipe:recipe-convert")
+	outputDir := t.TempDir()
+
+	// The forked CLI rejects the upstream name
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, roleMigrationModel(outputDir, types.StringNull()))}, resp)
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "unknown command: convert-recipe") {
+		t.Fatalf("expected the upstream subcommand to be rejected, got %v", resp.Diagnostics)
+	}
+
+	r.client.CommandOverrides = map[string]string{"convert-recipe": "recipe-convert"}
+	state := createMigration(t, r, roleMigrationModel(outputDir, types.StringNull()))
+	if !strings.Contains(state.PlaybookContent.ValueString(), "recipe: default") {
+		t.Fatalf("unexpected playbook content %q", state.PlaybookContent.ValueString())
+	}
+}
+
+func TestSousChefClientSubcommand(t *testing.T) {
+	client := &SousChefClient{CommandOverrides: map[string]string{"convert-recipe": "recipe-convert"}}
+	if got := client.subcommand("convert-recipe"); got != "recipe-convert" {
+		t.Errorf("expected override, got %q", got)
+	}
+	if got := client.subcommand("deps"); got != "deps" {
+		t.Errorf("expected unlisted subcommand to keep its name, got %q", got)
+	}
+	if got := (*SousChefClient)(nil).subcommand("deps"); got != "deps" {
+		t.Errorf("expected nil client to keep the name, got %q", got)
+	}
+}
+
+// stringMapValue converts a Go map to a types.Map of strings.
+func stringMapValue(values map[string]string) types.Map {
+	elements := make(map[string]attr.Value, len(values))
+	for key, value := range values {
+		elements[key] = types.StringValue(value)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
+
+func TestSousChefClientResolveOutputPath(t *testing.T) {
+	client := &SousChefClient{OutputRoot: "/srv/ansible"}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		client     *SousChefClient
+		outputPath string
+		want       string
+	}{
+		{name: "relative joined onto root", client: client, outputPath: "web/playbooks", want: "/srv/ansible/web/playbooks"},
+		{name: "absolute unchanged", client: client, outputPath: "/opt/playbooks", want: "/opt/playbooks"},
+		{name: "no root", client: &SousChefClient{}, outputPath: "playbooks", want: filepath.Join(cwd, "playbooks")},
+		{name: "nil client", client: nil, outputPath: "playbooks", want: filepath.Join(cwd, "playbooks")},
+		{name: "cleaned", client: &SousChefClient{}, outputPath: "./web/../playbooks", want: filepath.Join(cwd, "playbooks")},
+		{name: "normalize_paths false", client: &SousChefClient{KeepRelativePaths: true}, outputPath: "playbooks", want: "playbooks"},
+		{name: "normalize_paths false with root", client: &SousChefClient{OutputRoot: "/srv/ansible", KeepRelativePaths: true}, outputPath: "playbooks", want: "/srv/ansible/playbooks"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.client.resolveOutputPath(tt.outputPath); got != tt.want {
+				t.Fatalf("resolveOutputPath(%q) = %q, want %q", tt.outputPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSousChefClientCookbookName(t *testing.T) {
+	cookbookDir := filepath.Join(t.TempDir(), "nginx")
+	if err := os.Mkdir(cookbookDir, 0o755); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+	link := filepath.Join(t.TempDir(), "current")
+	if err := os.Symlink(cookbookDir, link); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		client       *SousChefClient
+		cookbookPath string
+		want         string
+	}{
+		{name: "directory", client: &SousChefClient{}, cookbookPath: cookbookDir, want: "nginx"},
+		{name: "symlink followed", client: &SousChefClient{}, cookbookPath: link, want: "nginx"},
+		{name: "nil client follows", client: nil, cookbookPath: link, want: "nginx"},
+		{name: "follow_symlinks false", client: &SousChefClient{KeepSymlinks: true}, cookbookPath: link, want: "current"},
+		{name: "missing path", client: &SousChefClient{}, cookbookPath: testTmpCookbook, want: "cookbook"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.client.cookbookName(tt.cookbookPath); got != tt.want {
+				t.Fatalf("cookbookName(%q) = %q, want %q", tt.cookbookPath, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Package provider contains the JSON report of SousChef CLI runs
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// reportEntry records the outcome of one SousChef CLI run.
+type reportEntry struct {
+	// ResourceID is the ID of the resource whose conversion ran the command,
+	// empty for data sources.
+	ResourceID string `json:"resource_id,omitempty"`
+	// Subcommand is the souschef subcommand that was run, e.g. convert-recipe.
+	Subcommand string `json:"subcommand"`
+	// Source is the cookbook, profile or file that was converted, taken
+	// from the first --*-path argument other than --output-path.
+	Source string `json:"source,omitempty"`
+	// OutputPath is the --output-path argument, when the command has one.
+	OutputPath string `json:"output_path,omitempty"`
+	// Success reports whether the command exited successfully.
+	Success bool `json:"success"`
+	// Error is the failure message when Success is false.
+	Error string `json:"error,omitempty"`
+	// StartedAt is the RFC 3339 time the command started.
+	StartedAt string `json:"started_at"`
+	// DurationMS is how long the command ran, in milliseconds.
+	DurationMS int64 `json:"duration_ms"`
+}
+
+// report is the JSON document written to report_path.
+type report struct {
+	ProviderVersion string        `json:"provider_version,omitempty"`
+	Entries         []reportEntry `json:"entries"`
+}
+
+// cliReports accumulates the CLI runs of the current Terraform operation,
+// keyed by report_path, so provider aliases sharing a path share a report.
+// Every operation runs in a new provider process, so each report starts
+// empty and replaces the previous operation's file when FlushReports writes
+// it.
+var cliReports = struct {
+	sync.Mutex
+	byPath map[string]*report
+}{byPath: make(map[string]*report)}
+
+// reportResourceIDKey is the context key under which a resource passes its
+// ID to the CLI runs it makes.
+type reportResourceIDKey struct{}
+
+// withReportResourceID returns a copy of ctx whose CLI runs are reported
+// against the resource with the given ID.
+func withReportResourceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, reportResourceIDKey{}, id)
+}
+
+// reportResourceID returns the resource ID set on ctx by
+// withReportResourceID, or "" when there is none.
+func reportResourceID(ctx context.Context) string {
+	id, _ := ctx.Value(reportResourceIDKey{}).(string)
+	return id
+}
+
+// newReportEntry describes a CLI run of args for the resource with the given
+// ID that started at start.
+func newReportEntry(resourceID string, args []string, start time.Time, err error) reportEntry {
+	entry := reportEntry{
+		ResourceID: resourceID,
+		StartedAt:  start.UTC().Format(time.RFC3339),
+		DurationMS: time.Since(start).Milliseconds(),
+		Success:    err == nil,
+	}
+	if len(args) > 0 {
+		entry.Subcommand = args[0]
+	}
+	for i := 1; i+1 < len(args); i++ {
+		switch {
+		case args[i] == "--output-path":
+			entry.OutputPath = args[i+1]
+		case entry.Source == "" && strings.HasPrefix(args[i], "--") && strings.HasSuffix(args[i], "-path"):
+			entry.Source = args[i+1]
+		}
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	return entry
+}
+
+// recordCLIRun adds entry to the report at the client's report_path. It is
+// a no-op when report_path is not set.
+func recordCLIRun(client *SousChefClient, entry reportEntry) {
+	if client == nil || client.ReportPath == "" {
+		return
+	}
+
+	cliReports.Lock()
+	defer cliReports.Unlock()
+	r, ok := cliReports.byPath[client.ReportPath]
+	if !ok {
+		r = &report{ProviderVersion: client.ProviderVersion}
+		cliReports.byPath[client.ReportPath] = r
+	}
+	r.Entries = append(r.Entries, entry)
+}
+
+// FlushReports writes each report_path's report of the current operation,
+// atomically so a reader never sees a partial file. main calls it once the
+// provider server has stopped, which Terraform requests at the end of every
+// operation. Paths without any CLI runs are left untouched.
+func FlushReports() error {
+	cliReports.Lock()
+	defer cliReports.Unlock()
+
+	var errs []error
+	for reportPath, r := range cliReports.byPath {
+		data, err := json.MarshalIndent(r, "", "  ")
+		if err == nil {
+			err = writeFileAtomic(reportPath, append(data, '\n'), 0644)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not write SousChef report to %s: %w", reportPath, err))
+		}
+	}
+	cliReports.byPath = make(map[string]*report)
+	return errors.Join(errs...)
+}
@@ -0,0 +1,124 @@
+// Package provider contains unit tests for the CLI run report.
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNewReportEntry(t *testing.T) {
+	entry := newReportEntry("web-default", []string{"convert-recipe", "--cookbook-path", "/cookbooks/web", "--recipe-name", "default", "--output-path", "/playbooks"}, time.Now(), nil)
+	if entry.ResourceID != "web-default" || entry.Subcommand != "convert-recipe" || entry.Source != "/cookbooks/web" || entry.OutputPath != "/playbooks" || !entry.Success || entry.Error != "" {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+
+	entry = newReportEntry("", []string{"validate", "--cookbook-path", "/cookbooks/web"}, time.Now(), errors.New("souschef validate exited with code 1"))
+	if entry.Success || entry.Error == "" || entry.OutputPath != "" {
+		t.Fatalf("unexpected failed entry: %+v", entry)
+	}
+}
+
+func TestReportRecordsConversions(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	client := &SousChefClient{Path: newFakeSousChef(t), ReportPath: reportPath, ProviderVersion: "1.2.3"}
+	outputDir := t.TempDir()
+
+	ohai := &ohaiMigrationResource{client: client}
+	ohaiSchema := newResourceSchema(t, ohai)
+	ohaiResp := &resource.CreateResponse{State: tfsdk.State{Schema: ohaiSchema}}
+	ohai.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, ohaiSchema, ohaiMigrationResourceModel{
+		PluginPath: types.StringValue(testOhaiPluginPath),
+		OutputPath: types.StringValue(outputDir),
+	})}, ohaiResp)
+	var ohaiState ohaiMigrationResourceModel
+	ohaiResp.State.Get(context.Background(), &ohaiState)
+
+	compliance := &complianceMigrationResource{client: client}
+	complianceSchema := newResourceSchema(t, compliance)
+	compliance.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, complianceSchema, complianceMigrationResourceModel{
+		CompliancePath: types.StringValue(testCompliancePath),
+		OutputPath:     types.StringValue(outputDir),
+	})}, &resource.CreateResponse{State: tfsdk.State{Schema: complianceSchema}})
+
+	t.Setenv("SOUSCHEF_TEST_FAIL", "convert-metadata")
+	metadata := &metadataMigrationResource{client: client}
+	metadataSchema := newResourceSchema(t, metadata)
+	metadata.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, metadataSchema, metadataMigrationResourceModel{
+		MetadataPath: types.StringValue(testMetadataPath),
+		OutputPath:   types.StringValue(outputDir),
+		Dependencies: types.ListUnknown(types.StringType),
+	})}, &resource.CreateResponse{State: tfsdk.State{Schema: metadataSchema}})
+
+	// The report is only written once the operation ends
+	if _, err := os.Stat(reportPath); !os.IsNotExist(err) {
+		t.Fatalf("expected no report before it is flushed, got %v", err)
+	}
+	if err := FlushReports(); err != nil {
+		t.Fatalf("unexpected error flushing reports: %v", err)
+	}
+
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	var got report
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("failed to parse report: %v", err)
+	}
+	if got.ProviderVersion != "1.2.3" || len(got.Entries) != 3 {
+		t.Fatalf("unexpected report: %s", data)
+	}
+
+	// A failed conversion is still reported against its resource
+	metadataContent, err := os.ReadFile(testMetadataPath)
+	if err != nil {
+		t.Fatalf("failed to read metadata: %v", err)
+	}
+	roleName, _ := parseCookbookMetadata(testMetadataPath, string(metadataContent))
+	want := []struct {
+		resourceID string
+		subcommand string
+		source     string
+		success    bool
+	}{
+		{ohaiState.ID.ValueString(), "convert-ohai", testOhaiPluginPath, true},
+		{client.resourceID(fmt.Sprintf(complianceIDFormat, complianceScanName(testCompliancePath), complianceOutputFormat(types.StringNull())), testCompliancePath), "convert-compliance", testCompliancePath, true},
+		{client.resourceID(fmt.Sprintf(metadataIDFormat, roleName), testMetadataPath), "convert-metadata", testMetadataPath, false},
+	}
+	for i, w := range want {
+		entry := got.Entries[i]
+		if entry.ResourceID != w.resourceID || entry.Subcommand != w.subcommand || entry.Source != w.source || entry.Success != w.success || entry.OutputPath != outputDir {
+			t.Errorf("entry %d: expected %+v, got %+v", i, w, entry)
+		}
+	}
+}
+
+func TestRecordCLIRunWithoutReportPath(t *testing.T) {
+	recordCLIRun(&SousChefClient{}, reportEntry{Subcommand: "validate"})
+	recordCLIRun(nil, reportEntry{Subcommand: "validate"})
+	if len(cliReports.byPath) != 0 {
+		t.Fatalf("expected nothing recorded without report_path, got %v", cliReports.byPath)
+	}
+}
+
+func TestFlushReportsWriteError(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "missing", "report.json")
+	recordCLIRun(&SousChefClient{ReportPath: reportPath}, reportEntry{Subcommand: "validate"})
+	if err := FlushReports(); err == nil || !strings.Contains(err.Error(), reportPath) {
+		t.Fatalf("expected an error naming %s, got %v", reportPath, err)
+	}
+	if err := FlushReports(); err != nil {
+		t.Fatalf("expected flushed reports to be cleared, got %v", err)
+	}
+}
@@ -0,0 +1,169 @@
+// Package provider implements the SousChef Terraform provider resources
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const assessmentIDFormat = "assessment-%s"
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource = &assessmentResource{}
+)
+
+// NewAssessmentResource creates a new assessment resource
+func NewAssessmentResource() resource.Resource {
+	return &assessmentResource{}
+}
+
+// assessmentResource is the resource implementation. Unlike the
+// souschef_assessment data source, which is re-read on every plan, it keeps
+// the assessment taken at apply time, and refresh reports when the cookbook's
+// complexity has since changed.
+type assessmentResource struct {
+	client *SousChefClient
+}
+
+// assessmentResourceModel describes the resource data model
+type assessmentResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	CookbookPath  types.String `tfsdk:"cookbook_path"`
+	Complexity    types.String `tfsdk:"complexity"`
+	ResourceCount types.Int64  `tfsdk:"resource_count"`
+	AssessedAt    types.String `tfsdk:"assessed_at"`
+}
+
+// Metadata returns the resource type name
+func (r *assessmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_assessment"
+}
+
+// Schema defines the schema for the resource
+func (r *assessmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Snapshots a cookbook's migration assessment at apply time. Refresh re-assesses the cookbook and reports a changed complexity or resource count as drift, so a cookbook growing more complex shows up in `terraform plan`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier for the assessment",
+			},
+			"cookbook_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the Chef cookbook directory",
+			},
+			"complexity": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Migration complexity level (Low/Medium/High)",
+			},
+			"resource_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Total Chef resources across all recipes",
+			},
+			"assessed_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "RFC 3339 timestamp of the assessment that created or last updated the resource",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *assessmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = configureResource(req, resp)
+}
+
+// Create assesses the cookbook and sets the initial Terraform state
+func (r *assessmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan assessmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.executeAssessment(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read re-assesses the cookbook. A changed complexity or resource count is
+// written to state, so Terraform reports it as changed outside Terraform,
+// and a warning names the change; assessed_at keeps the snapshot's time.
+func (r *assessmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state assessmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cookbookPath := state.CookbookPath.ValueString()
+	assessment, ok := assessCookbook(ctx, r.client, cookbookPath, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	if assessment.Complexity != state.Complexity.ValueString() {
+		resp.Diagnostics.AddWarning(
+			"Cookbook complexity changed",
+			fmt.Sprintf("%s was assessed as %s complexity at %s and is now %s.",
+				cookbookPath, state.Complexity.ValueString(), state.AssessedAt.ValueString(), assessment.Complexity),
+		)
+	}
+	state.Complexity = types.StringValue(assessment.Complexity)
+	state.ResourceCount = types.Int64Value(assessment.ResourceCount)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update re-assesses the cookbook and sets the updated Terraform state on success
+func (r *assessmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan assessmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.executeAssessment(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the resource from Terraform state; there are no files to clean up
+func (r *assessmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// executeAssessment is a helper that encapsulates the common logic for Create and Update.
+// It assesses the cookbook and records the result with the time it was taken.
+func (r *assessmentResource) executeAssessment(ctx context.Context, model *assessmentResourceModel, diagnostics *diag.Diagnostics) {
+	cookbookPath := model.CookbookPath.ValueString()
+	id := r.client.resourceID(fmt.Sprintf(assessmentIDFormat, r.client.cookbookName(cookbookPath)), cookbookPath)
+
+	assessment, ok := assessCookbook(withReportResourceID(ctx, id), r.client, cookbookPath, diagnostics)
+	if !ok {
+		return
+	}
+
+	model.ID = types.StringValue(id)
+	model.Complexity = types.StringValue(assessment.Complexity)
+	model.ResourceCount = types.Int64Value(assessment.ResourceCount)
+	model.AssessedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,81 @@
+// Package provider contains unit tests for the assessment resource.
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAssessmentResourceLifecycle(t *testing.T) {
+	r := &assessmentResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	cookbookPath := filepath.Join(t.TempDir(), "web")
+	if err := os.Mkdir(cookbookPath, testDirPermissions); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+	writeAssessment := func(assessment string) {
+		if err := os.WriteFile(filepath.Join(cookbookPath, "assessment.json"), []byte(assessment), testFilePermissions); err != nil {
+			t.Fatalf(testFailedToWriteFile, err)
+		}
+	}
+	writeAssessment(`{"complexity":"Low","resource_count":4}`)
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, assessmentResourceModel{
+		CookbookPath: types.StringValue(cookbookPath),
+	})}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+
+	var created assessmentResourceModel
+	if diags := createResp.State.Get(context.Background(), &created); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if created.ID.ValueString() != "assessment-web" || created.Complexity.ValueString() != "Low" || created.ResourceCount.ValueInt64() != 4 {
+		t.Fatalf("unexpected state: %+v", created)
+	}
+	if _, err := time.Parse(time.RFC3339, created.AssessedAt.ValueString()); err != nil {
+		t.Fatalf("expected an RFC 3339 assessed_at, got %q: %v", created.AssessedAt.ValueString(), err)
+	}
+
+	// Read leaves an unchanged assessment alone
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() || readResp.Diagnostics.WarningsCount() != 0 {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.Equal(createResp.State.Raw) {
+		t.Fatal("expected an unchanged assessment to leave state as created")
+	}
+
+	// A cookbook that grew more complex shows up as a diff against the snapshot
+	writeAssessment(`{"complexity":"High","resource_count":40}`)
+	readResp = &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() || readResp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected a complexity change warning, got %v", readResp.Diagnostics)
+	}
+	var refreshed assessmentResourceModel
+	if diags := readResp.State.Get(context.Background(), &refreshed); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if refreshed.Complexity.ValueString() != "High" || refreshed.ResourceCount.ValueInt64() != 40 || refreshed.AssessedAt != created.AssessedAt {
+		t.Fatalf("expected the new assessment with the snapshot time kept, got %+v", refreshed)
+	}
+
+	// Read surfaces CLI failures
+	t.Setenv("SOUSCHEF_TEST_FAIL", "assess-cookbook")
+	readResp = &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if !readResp.Diagnostics.HasError() {
+		t.Fatal("expected error when the assessment fails")
+	}
+}
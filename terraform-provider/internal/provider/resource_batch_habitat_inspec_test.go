@@ -134,10 +134,12 @@ func TestBatchMigrationResourceCreateUpdateReadDelete(t *testing.T) {
 			types.StringValue("default"),
 			types.StringValue("install"),
 		},
-		ID:            types.StringNull(),
-		CookbookName:  types.StringNull(),
-		PlaybookCount: types.Int64Null(),
-		Playbooks:     types.MapNull(types.StringType),
+		ID:                  types.StringNull(),
+		CookbookName:        types.StringNull(),
+		PlaybookCount:       types.Int64Null(),
+		Playbooks:           types.MapNull(types.StringType),
+		RecipeStatus:        types.MapNull(types.StringType),
+		ResolvedRecipeNames: types.ListNull(types.StringType),
 	})
 
 	// Create and Update phases
@@ -146,12 +148,14 @@ func TestBatchMigrationResourceCreateUpdateReadDelete(t *testing.T) {
 
 	// Read phase
 	state := newState(t, schema, batchMigrationResourceModel{
-		OutputPath:    types.StringValue(outputDir),
-		RecipeNames:   []types.String{types.StringValue("default")},
-		ID:            types.StringNull(),
-		CookbookName:  types.StringNull(),
-		PlaybookCount: types.Int64Null(),
-		Playbooks:     types.MapNull(types.StringType),
+		OutputPath:          types.StringValue(outputDir),
+		RecipeNames:         []types.String{types.StringValue("default")},
+		ID:                  types.StringNull(),
+		CookbookName:        types.StringNull(),
+		PlaybookCount:       types.Int64Null(),
+		Playbooks:           types.MapNull(types.StringType),
+		RecipeStatus:        types.MapNull(types.StringType),
+		ResolvedRecipeNames: types.ListNull(types.StringType),
 	})
 	testResourceReadExistingPhase(t, r, schema, state)
 
@@ -186,12 +190,14 @@ func TestBatchMigrationResourceErrors(t *testing.T) {
 
 	outputDir := t.TempDir()
 	state := newState(t, schema, batchMigrationResourceModel{
-		OutputPath:    types.StringValue(outputDir),
-		RecipeNames:   []types.String{types.StringValue("missing")},
-		ID:            types.StringNull(),
-		CookbookName:  types.StringNull(),
-		PlaybookCount: types.Int64Null(),
-		Playbooks:     types.MapNull(types.StringType),
+		OutputPath:          types.StringValue(outputDir),
+		RecipeNames:         []types.String{types.StringValue("missing")},
+		ID:                  types.StringNull(),
+		CookbookName:        types.StringNull(),
+		PlaybookCount:       types.Int64Null(),
+		Playbooks:           types.MapNull(types.StringType),
+		RecipeStatus:        types.MapNull(types.StringType),
+		ResolvedRecipeNames: types.ListNull(types.StringType),
 	})
 	readResp := &resource.ReadResponse{State: tfsdk.State{Schema: schema}}
 	r.Read(context.Background(), resource.ReadRequest{State: state}, readResp)
@@ -282,18 +288,20 @@ func TestHabitatAndInSpecResourceCoverage(t *testing.T) {
 			},
 			createResourceFn: func(t *testing.T, res resource.Resource, schema resourceschema.Schema, setupPath string, outputDir string) tfsdk.Plan {
 				return newPlan(t, schema, inspecMigrationResourceModel{
-					ProfilePath:  types.StringValue(setupPath),
-					OutputPath:   types.StringValue(outputDir),
-					OutputFormat: types.StringValue("testinfra"),
-					ID:           types.StringNull(),
-					ProfileName:  types.StringNull(),
-					TestContent:  types.StringNull(),
+					ProfilePath:       types.StringValue(setupPath),
+					OutputPath:        types.StringValue(outputDir),
+					OutputFormat:      types.StringValue("testinfra"),
+					ID:                types.StringNull(),
+					ProfileName:       types.StringNull(),
+					TestContent:       types.StringNull(),
+					ConvertedControls: types.ListNull(types.StringType),
 				})
 			},
 			createStateFn: func(t *testing.T, res resource.Resource, schema resourceschema.Schema, outputDir string) tfsdk.State {
 				return newState(t, schema, inspecMigrationResourceModel{
-					OutputPath:   types.StringValue(outputDir),
-					OutputFormat: types.StringValue("testinfra"),
+					OutputPath:        types.StringValue(outputDir),
+					OutputFormat:      types.StringValue("testinfra"),
+					ConvertedControls: types.ListNull(types.StringType),
 				})
 			},
 			outputFile: testinfraFilename,
@@ -378,12 +386,13 @@ func TestHabitatAndInSpecResourceErrors(t *testing.T) {
 			},
 			setupPlan: func(t *testing.T, schema resourceschema.Schema, path string) tfsdk.Plan {
 				return newPlan(t, schema, inspecMigrationResourceModel{
-					ProfilePath:  types.StringValue(path),
-					OutputPath:   types.StringValue(t.TempDir()),
-					OutputFormat: types.StringValue("serverspec"),
-					ID:           types.StringNull(),
-					ProfileName:  types.StringNull(),
-					TestContent:  types.StringNull(),
+					ProfilePath:       types.StringValue(path),
+					OutputPath:        types.StringValue(t.TempDir()),
+					OutputFormat:      types.StringValue("serverspec"),
+					ID:                types.StringNull(),
+					ProfileName:       types.StringNull(),
+					TestContent:       types.StringNull(),
+					ConvertedControls: types.ListNull(types.StringType),
 				})
 			},
 			convertCommand: testConvertInSpec,
@@ -0,0 +1,401 @@
+// Package provider implements the SousChef Terraform provider resources
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	habitatPlanFilename       = "plan.sh"
+	batchHabitatIDFormat      = "%s-habitat-batch"
+	batchHabitatImportIDUsage = "Import ID must be in format: plans_root|output_path or plans_root|output_path|base_image"
+)
+
+// discoverHabitatPlans walks plansRoot for plan.sh files and returns their
+// paths keyed by package name (the plan's parent directory name).
+func discoverHabitatPlans(plansRoot string) (map[string]string, error) {
+	plans := make(map[string]string)
+	err := filepath.WalkDir(plansRoot, func(planPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || entry.Name() != habitatPlanFilename {
+			return nil
+		}
+
+		packageName := filepath.Base(filepath.Dir(planPath))
+		if existing, ok := plans[packageName]; ok {
+			return fmt.Errorf("package %s is defined by both %s and %s", packageName, existing, planPath)
+		}
+		plans[packageName] = planPath
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(plans) == 0 {
+		return nil, fmt.Errorf("no %s files found under %s", habitatPlanFilename, plansRoot)
+	}
+	return plans, nil
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// batchHabitatDockerfilePath returns the Dockerfile path for packageName.
+func batchHabitatDockerfilePath(outputPath, packageName string) string {
+	return filepath.Join(outputPath, packageName, "Dockerfile")
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource                = &batchHabitatMigrationResource{}
+	_ resource.ResourceWithImportState = &batchHabitatMigrationResource{}
+)
+
+// NewBatchHabitatMigrationResource creates a new batch Habitat migration resource
+func NewBatchHabitatMigrationResource() resource.Resource {
+	return &batchHabitatMigrationResource{}
+}
+
+// batchHabitatMigrationResource is the resource implementation
+type batchHabitatMigrationResource struct {
+	client *SousChefClient
+}
+
+// batchHabitatMigrationResourceModel describes the resource data model
+type batchHabitatMigrationResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	PlansRoot          types.String `tfsdk:"plans_root"`
+	OutputPath         types.String `tfsdk:"output_path"`
+	CreateOutputDir    types.Bool   `tfsdk:"create_output_dir"`
+	PruneEmptyDir      types.Bool   `tfsdk:"prune_empty_dir"`
+	RetainOnDelete     types.Bool   `tfsdk:"retain_on_delete"`
+	ResolvedOutputPath types.String `tfsdk:"resolved_output_path"`
+	BaseImage          types.String `tfsdk:"base_image"`
+	PlanCount          types.Int64  `tfsdk:"plan_count"`
+	Dockerfiles        types.Map    `tfsdk:"dockerfiles"`
+}
+
+// Metadata returns the resource type name
+func (r *batchHabitatMigrationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_batch_habitat_migration"
+}
+
+// Schema defines the schema for the resource
+func (r *batchHabitatMigrationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages conversion of every Chef Habitat plan under a directory to Dockerfiles.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier for the batch Habitat migration",
+			},
+			"plans_root": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Directory searched recursively for Habitat `plan.sh` files",
+			},
+			"output_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Directory where a subdirectory containing a Dockerfile is written for each package",
+			},
+			"create_output_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Create `output_path` when it does not exist (default: true). When false the directory must already exist, e.g. when it is pre-created with specific ownership and permissions",
+			},
+			"prune_empty_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove the output directory on destroy when no other files remain in it (default: false)",
+			},
+			"retain_on_delete": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Leave the generated files in place on destroy and only remove the resource from state, e.g. once they are committed to a repository (default: false)",
+			},
+			"resolved_output_path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Directory the Dockerfiles are written to: `output_path` joined onto the provider `output_root` when `output_path` is relative",
+			},
+			"base_image": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Base Docker image to use for every package (default: ubuntu:latest)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"plan_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of plans converted",
+			},
+			"dockerfiles": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Map of package names to Dockerfile content",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *batchHabitatMigrationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = configureResource(req, resp)
+}
+
+// executeBatchHabitatConversion converts every plan under plans_root into
+// output_path/<package>/Dockerfile and updates the model state.
+func (r *batchHabitatMigrationResource) executeBatchHabitatConversion(ctx context.Context, model *batchHabitatMigrationResourceModel, diagnostics *diag.Diagnostics) {
+	plansRoot := model.PlansRoot.ValueString()
+	id := r.client.resourceID(fmt.Sprintf(batchHabitatIDFormat, filepath.Base(plansRoot)), plansRoot)
+	ctx = withReportResourceID(ctx, id)
+	outputPath := r.client.resolveOutputPath(model.OutputPath.ValueString())
+	baseImage := defaultBaseImage
+	if !model.BaseImage.IsNull() && !model.BaseImage.IsUnknown() && model.BaseImage.ValueString() != "" {
+		baseImage = model.BaseImage.ValueString()
+	}
+
+	plans, err := discoverHabitatPlans(plansRoot)
+	if err != nil {
+		diagnostics.AddError(
+			"Error discovering Habitat plans",
+			fmt.Sprintf("Could not find plans under %s: %s", plansRoot, err),
+		)
+		return
+	}
+
+	// Check the output directory, creating it unless create_output_dir is false;
+	// per-item subdirectories are always created
+	if !r.client.prepareOutputDirectory(model.CreateOutputDir, outputPath, diagnostics) {
+		return
+	}
+
+	dockerfiles := make(map[string]string)
+	for _, packageName := range sortedKeys(plans) {
+		packageOutput := filepath.Join(outputPath, packageName)
+		if !r.client.createOutputDirectory(packageOutput, diagnostics) {
+			return
+		}
+
+		args := []string{"convert-habitat", "--plan-path", plans[packageName], "--output-path", packageOutput, "--base-image", baseImage}
+		content, ok := executeConversion(ctx, r.client, args, batchHabitatDockerfilePath(outputPath, packageName), errReadingDockerfile, diagnostics)
+		if !ok {
+			return
+		}
+		dockerfiles[packageName] = content
+	}
+
+	// Convert Dockerfiles map to types.Map
+	dockerfilesMap, mapDiags := typesMapValueFrom(ctx, types.StringType, dockerfiles)
+	diagnostics.Append(mapDiags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	model.ID = types.StringValue(id)
+	model.ResolvedOutputPath = types.StringValue(outputPath)
+	model.BaseImage = types.StringValue(baseImage)
+	model.PlanCount = types.Int64Value(int64(len(dockerfiles)))
+	model.Dockerfiles = dockerfilesMap
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *batchHabitatMigrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan batchHabitatMigrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing := snapshotOutputs(r.client.resolveOutputPath(plan.OutputPath.ValueString()))
+
+	// Execute conversion and set state
+	r.executeBatchHabitatConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Don't leave untracked Dockerfiles behind if the apply was interrupted
+	generated := make([]string, 0, len(plan.Dockerfiles.Elements()))
+	for packageName := range plan.Dockerfiles.Elements() {
+		generated = append(generated, batchHabitatDockerfilePath(plan.ResolvedOutputPath.ValueString(), packageName))
+	}
+	if cleanupIfCanceled(ctx, &resp.Diagnostics, existing, generated...) {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *batchHabitatMigrationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state batchHabitatMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+
+	// Check if any Dockerfile exists
+	dockerfiles := make(map[string]string)
+	for packageName := range state.Dockerfiles.Elements() {
+		dockerfilePath := batchHabitatDockerfilePath(outputPath, packageName)
+		content, err := readFileWithRetry(ctx, dockerfilePath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				errReadingDockerfile,
+				fmt.Sprintf("Could not read file %s: %s", dockerfilePath, err),
+			)
+			return
+		}
+		dockerfiles[packageName] = string(content)
+	}
+
+	if len(dockerfiles) == 0 {
+		r.client.handleMissingArtifact(ctx, "any Dockerfiles in "+outputPath, &resp.State, &resp.Diagnostics)
+		return
+	}
+
+	// Update state with current content
+	dockerfilesMap, mapDiags := typesMapValueFrom(ctx, types.StringType, dockerfiles)
+	resp.Diagnostics.Append(mapDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Dockerfiles = dockerfilesMap
+	state.PlanCount = types.Int64Value(int64(len(dockerfiles)))
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *batchHabitatMigrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan batchHabitatMigrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Execute conversion and set state
+	r.executeBatchHabitatConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *batchHabitatMigrationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state batchHabitatMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	if r.client.retainGeneratedFiles(state.RetainOnDelete, outputPath, &resp.Diagnostics) {
+		return
+	}
+
+	// Delete generated Dockerfiles, then the package directories they leave behind
+	for packageName := range state.Dockerfiles.Elements() {
+		deleteGeneratedFile(batchHabitatDockerfilePath(outputPath, packageName), "Dockerfile", &resp.Diagnostics)
+		pruneEmptyDir(state.PruneEmptyDir, filepath.Join(outputPath, packageName), &resp.Diagnostics)
+	}
+	pruneEmptyDir(state.PruneEmptyDir, outputPath, &resp.Diagnostics)
+}
+
+// ImportState imports an existing resource into Terraform
+func (r *batchHabitatMigrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format: plans_root|output_path|base_image (base_image is optional)
+	parts, err := importIDParts(req.ID, "plans_root", "output_path", "base_image")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+	if len(parts) < 2 || len(parts) > 3 {
+		resp.Diagnostics.AddError("Invalid import ID", batchHabitatImportIDUsage)
+		return
+	}
+
+	plansRoot := parts[0]
+	configuredOutputPath := parts[1]
+	outputPath := r.client.resolveOutputPath(configuredOutputPath)
+	baseImage := defaultBaseImage
+	if len(parts) == 3 && parts[2] != "" {
+		baseImage = parts[2]
+	}
+
+	plans, err := discoverHabitatPlans(plansRoot)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error discovering Habitat plans",
+			fmt.Sprintf("Could not find plans under %s: %s", plansRoot, err),
+		)
+		return
+	}
+
+	// Read all Dockerfiles and validate they exist
+	dockerfiles := make(map[string]string)
+	for packageName := range plans {
+		dockerfilePath := batchHabitatDockerfilePath(outputPath, packageName)
+		if !checkFileExists(dockerfilePath, "Dockerfile", &resp.Diagnostics) {
+			return
+		}
+
+		content := readGeneratedFile(dockerfilePath, errReadingDockerfile, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		dockerfiles[packageName] = content
+	}
+
+	// Convert Dockerfiles map to types.Map
+	dockerfilesMap, mapDiags := typesMapValueFrom(ctx, types.StringType, dockerfiles)
+	resp.Diagnostics.Append(mapDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("plans_root"), plansRoot)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_path"), configuredOutputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resolved_output_path"), outputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("base_image"), baseImage)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("plan_count"), int64(len(dockerfiles)))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dockerfiles"), dockerfilesMap)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), r.client.resourceID(fmt.Sprintf(batchHabitatIDFormat, filepath.Base(plansRoot)), plansRoot))...)
+}
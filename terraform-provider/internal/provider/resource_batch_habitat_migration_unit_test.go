@@ -0,0 +1,190 @@
+// Package provider contains unit tests for the batch Habitat migration resource.
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newHabitatPlansFixture creates a plans root containing nginx and redis plans.
+func newHabitatPlansFixture(t *testing.T) string {
+	t.Helper()
+
+	plansRoot := t.TempDir()
+	for _, planDir := range []string{"nginx", filepath.Join("data", "redis")} {
+		dir := filepath.Join(plansRoot, planDir)
+		if err := os.MkdirAll(dir, testDirPermissions); err != nil {
+			t.Fatalf(testFailedToCreateDirectory, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, habitatPlanFilename), []byte("pkg_name="+filepath.Base(dir)+"\n"), testFilePermissions); err != nil {
+			t.Fatalf(testFailedToWriteFile, err)
+		}
+	}
+	return plansRoot
+}
+
+// createBatchHabitatMigration runs Create against the fake CLI and returns
+// the resulting state.
+func createBatchHabitatMigration(t *testing.T, plansRoot, outputPath string) (*batchHabitatMigrationResource, batchHabitatMigrationResourceModel, tfsdk.State) {
+	t.Helper()
+
+	r := &batchHabitatMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	plan := newPlan(t, schema, batchHabitatMigrationResourceModel{
+		PlansRoot:   types.StringValue(plansRoot),
+		OutputPath:  types.StringValue(outputPath),
+		Dockerfiles: types.MapUnknown(types.StringType),
+	})
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var state batchHabitatMigrationResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	return r, state, resp.State
+}
+
+func TestDiscoverHabitatPlans(t *testing.T) {
+	plans, err := discoverHabitatPlans(newHabitatPlansFixture(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	verifyStringSliceResult(t, sortedKeys(plans), []string{"nginx", "redis"})
+
+	if _, err := discoverHabitatPlans(t.TempDir()); err == nil {
+		t.Fatal("expected error when no plans exist")
+	}
+}
+
+func TestDiscoverHabitatPlansDuplicatePackage(t *testing.T) {
+	plansRoot := newHabitatPlansFixture(t)
+	duplicate := filepath.Join(plansRoot, "legacy", "nginx")
+	if err := os.MkdirAll(duplicate, testDirPermissions); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+	if err := os.WriteFile(filepath.Join(duplicate, habitatPlanFilename), []byte("pkg_name=nginx\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+
+	if _, err := discoverHabitatPlans(plansRoot); err == nil {
+		t.Fatal("expected error for duplicate package name")
+	}
+}
+
+func TestBatchHabitatMigrationCreate(t *testing.T) {
+	outputPath := t.TempDir()
+	_, state, _ := createBatchHabitatMigration(t, newHabitatPlansFixture(t), outputPath)
+
+	if state.PlanCount.ValueInt64() != 2 {
+		t.Fatalf("expected plan_count 2, got %d", state.PlanCount.ValueInt64())
+	}
+	if state.BaseImage.ValueString() != defaultBaseImage {
+		t.Fatalf("expected default base image, got %s", state.BaseImage.ValueString())
+	}
+
+	dockerfiles := make(map[string]string)
+	state.Dockerfiles.ElementsAs(context.Background(), &dockerfiles, false)
+	for _, packageName := range []string{"nginx", "redis"} {
+		if dockerfiles[packageName] == "" {
+			t.Fatalf("expected Dockerfile content for %s, got %v", packageName, dockerfiles)
+		}
+		if _, err := os.Stat(filepath.Join(outputPath, packageName, "Dockerfile")); err != nil {
+			t.Fatalf("expected Dockerfile for %s: %v", packageName, err)
+		}
+	}
+}
+
+func TestBatchHabitatMigrationCreateNoPlans(t *testing.T) {
+	r := &batchHabitatMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	plan := newPlan(t, schema, batchHabitatMigrationResourceModel{
+		PlansRoot:   types.StringValue(t.TempDir()),
+		OutputPath:  types.StringValue(t.TempDir()),
+		Dockerfiles: types.MapUnknown(types.StringType),
+	})
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when plans_root has no plans")
+	}
+}
+
+func TestBatchHabitatMigrationReadAndDelete(t *testing.T) {
+	outputPath := t.TempDir()
+	r, _, state := createBatchHabitatMigration(t, newHabitatPlansFixture(t), outputPath)
+
+	if err := os.Remove(filepath.Join(outputPath, "redis", "Dockerfile")); err != nil {
+		t.Fatalf("failed to remove Dockerfile: %v", err)
+	}
+
+	readResp := &resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+
+	var refreshed batchHabitatMigrationResourceModel
+	if diags := readResp.State.Get(context.Background(), &refreshed); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if refreshed.PlanCount.ValueInt64() != 1 {
+		t.Fatalf("expected plan_count 1 after removing a Dockerfile, got %d", refreshed.PlanCount.ValueInt64())
+	}
+
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+	}
+	if _, err := os.Stat(filepath.Join(outputPath, "nginx", "Dockerfile")); !os.IsNotExist(err) {
+		t.Fatalf("expected Dockerfile to be deleted, got %v", err)
+	}
+
+	readResp = &resource.ReadResponse{State: readResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: readResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Fatal("expected resource to be removed when no Dockerfiles exist")
+	}
+}
+
+func TestBatchHabitatMigrationImportState(t *testing.T) {
+	plansRoot := newHabitatPlansFixture(t)
+	outputPath := t.TempDir()
+	r, _, _ := createBatchHabitatMigration(t, plansRoot, outputPath)
+	schema := newResourceSchema(t, r)
+
+	resp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: plansRoot + "|" + outputPath + "|alpine:3"}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var state batchHabitatMigrationResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.PlanCount.ValueInt64() != 2 || state.BaseImage.ValueString() != "alpine:3" {
+		t.Fatalf("unexpected imported state: %+v", state)
+	}
+
+	for _, id := range []string{plansRoot, plansRoot + "|" + t.TempDir()} {
+		resp = &resource.ImportStateResponse{State: newEmptyState(schema)}
+		r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, resp)
+		if !resp.Diagnostics.HasError() {
+			t.Fatalf("expected error importing %q", id)
+		}
+	}
+}
@@ -0,0 +1,393 @@
+// Package provider implements the SousChef Terraform provider resources
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	batchInspecIDFormat      = "%s-inspec-batch"
+	batchInspecImportIDUsage = "Import ID must be in format: profiles_root|output_path|output_format"
+)
+
+// discoverInSpecProfiles returns the immediate subdirectories of profilesRoot
+// that contain an inspec.yml, keyed by directory name.
+func discoverInSpecProfiles(profilesRoot string) (map[string]string, error) {
+	entries, err := osReadDir(profilesRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make(map[string]string)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		profilePath := filepath.Join(profilesRoot, entry.Name())
+		if _, err := osStat(filepath.Join(profilePath, inspecProfileMetadataFile)); err == nil {
+			profiles[entry.Name()] = profilePath
+		}
+	}
+
+	if len(profiles) == 0 {
+		return nil, fmt.Errorf("no subdirectories containing %s found under %s", inspecProfileMetadataFile, profilesRoot)
+	}
+	return profiles, nil
+}
+
+// batchInspecTestPath returns the converted test file path for profileName.
+func batchInspecTestPath(outputPath, profileName, outputFormat string) string {
+	return filepath.Join(outputPath, profileName, inspecTestFilename(outputFormat))
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource                = &batchInspecMigrationResource{}
+	_ resource.ResourceWithImportState = &batchInspecMigrationResource{}
+)
+
+// NewBatchInSpecMigrationResource creates a new batch InSpec migration resource
+func NewBatchInSpecMigrationResource() resource.Resource {
+	return &batchInspecMigrationResource{}
+}
+
+// batchInspecMigrationResource is the resource implementation
+type batchInspecMigrationResource struct {
+	client *SousChefClient
+}
+
+// batchInspecMigrationResourceModel describes the resource data model
+type batchInspecMigrationResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	ProfilesRoot       types.String `tfsdk:"profiles_root"`
+	OutputPath         types.String `tfsdk:"output_path"`
+	CreateOutputDir    types.Bool   `tfsdk:"create_output_dir"`
+	PruneEmptyDir      types.Bool   `tfsdk:"prune_empty_dir"`
+	RetainOnDelete     types.Bool   `tfsdk:"retain_on_delete"`
+	ResolvedOutputPath types.String `tfsdk:"resolved_output_path"`
+	OutputFormat       types.String `tfsdk:"output_format"`
+	ProfileCount       types.Int64  `tfsdk:"profile_count"`
+	Tests              types.Map    `tfsdk:"tests"`
+}
+
+// Metadata returns the resource type name
+func (r *batchInspecMigrationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_batch_inspec_migration"
+}
+
+// Schema defines the schema for the resource
+func (r *batchInspecMigrationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages conversion of every InSpec profile in a directory to a test framework.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier for the batch InSpec migration",
+			},
+			"profiles_root": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Directory whose immediate subdirectories containing `inspec.yml` are converted",
+			},
+			"output_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Directory where a subdirectory containing the converted tests is written for each profile",
+			},
+			"create_output_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Create `output_path` when it does not exist (default: true). When false the directory must already exist, e.g. when it is pre-created with specific ownership and permissions",
+			},
+			"prune_empty_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove the output directory on destroy when no other files remain in it (default: false)",
+			},
+			"retain_on_delete": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Leave the generated files in place on destroy and only remove the resource from state, e.g. once they are committed to a repository (default: false)",
+			},
+			"resolved_output_path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Directory the tests are written to: `output_path` joined onto the provider `output_root` when `output_path` is relative",
+			},
+			"output_format": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Output test framework format for every profile (testinfra, serverspec, goss, or ansible)",
+			},
+			"profile_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of profiles converted",
+			},
+			"tests": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Map of profile names to converted test content",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *batchInspecMigrationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = configureResource(req, resp)
+}
+
+// executeBatchInSpecConversion converts every profile under profiles_root into
+// output_path/<profile>/ and updates the model state.
+func (r *batchInspecMigrationResource) executeBatchInSpecConversion(ctx context.Context, model *batchInspecMigrationResourceModel, diagnostics *diag.Diagnostics) {
+	profilesRoot := model.ProfilesRoot.ValueString()
+	id := r.client.resourceID(fmt.Sprintf(batchInspecIDFormat, filepath.Base(profilesRoot)), profilesRoot)
+	ctx = withReportResourceID(ctx, id)
+	outputPath := r.client.resolveOutputPath(model.OutputPath.ValueString())
+	outputFormat := model.OutputFormat.ValueString()
+
+	profiles, err := discoverInSpecProfiles(profilesRoot)
+	if err != nil {
+		diagnostics.AddError(
+			"Error discovering InSpec profiles",
+			fmt.Sprintf("Could not find profiles under %s: %s", profilesRoot, err),
+		)
+		return
+	}
+
+	// Check the output directory, creating it unless create_output_dir is false;
+	// per-item subdirectories are always created
+	if !r.client.prepareOutputDirectory(model.CreateOutputDir, outputPath, diagnostics) {
+		return
+	}
+
+	tests := make(map[string]string)
+	for _, profileName := range sortedKeys(profiles) {
+		profileOutput := filepath.Join(outputPath, profileName)
+		if !r.client.createOutputDirectory(profileOutput, diagnostics) {
+			return
+		}
+
+		args := []string{"convert-inspec", "--profile-path", profiles[profileName], "--output-path", profileOutput, "--format", outputFormat}
+		output, err := runCLI(ctx, r.client, diagnostics, r.client.dryRunArgs(args)...)
+		if err != nil {
+			failure := diagnosticFromError(err)
+			diagnostics.AddError(
+				failure.Summary(),
+				fmt.Sprintf("Converting profile %s failed. %s", profileName, failure.Detail()),
+			)
+			return
+		}
+
+		if r.client.isDryRun() {
+			tests[profileName] = string(output)
+			continue
+		}
+		content := readGeneratedFile(batchInspecTestPath(outputPath, profileName, outputFormat), errReadingTestFile, diagnostics)
+		if diagnostics.HasError() {
+			return
+		}
+		tests[profileName] = content
+	}
+
+	// Convert tests map to types.Map
+	testsMap, mapDiags := typesMapValueFrom(ctx, types.StringType, tests)
+	diagnostics.Append(mapDiags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	model.ID = types.StringValue(id)
+	model.ResolvedOutputPath = types.StringValue(outputPath)
+	model.ProfileCount = types.Int64Value(int64(len(tests)))
+	model.Tests = testsMap
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *batchInspecMigrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan batchInspecMigrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing := snapshotOutputs(r.client.resolveOutputPath(plan.OutputPath.ValueString()))
+
+	// Execute conversion and set state
+	r.executeBatchInSpecConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Don't leave untracked tests behind if the apply was interrupted
+	generated := make([]string, 0, len(plan.Tests.Elements()))
+	for profileName := range plan.Tests.Elements() {
+		generated = append(generated, batchInspecTestPath(plan.ResolvedOutputPath.ValueString(), profileName, plan.OutputFormat.ValueString()))
+	}
+	if cleanupIfCanceled(ctx, &resp.Diagnostics, existing, generated...) {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *batchInspecMigrationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state batchInspecMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+
+	// Check if any test file exists
+	tests := make(map[string]string)
+	for profileName := range state.Tests.Elements() {
+		testPath := batchInspecTestPath(outputPath, profileName, state.OutputFormat.ValueString())
+		content, err := readFileWithRetry(ctx, testPath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				errReadingTestFile,
+				fmt.Sprintf("Could not read file %s: %s", testPath, err),
+			)
+			return
+		}
+		tests[profileName] = string(content)
+	}
+
+	if len(tests) == 0 {
+		r.client.handleMissingArtifact(ctx, "any test files in "+outputPath, &resp.State, &resp.Diagnostics)
+		return
+	}
+
+	// Update state with current content
+	testsMap, mapDiags := typesMapValueFrom(ctx, types.StringType, tests)
+	resp.Diagnostics.Append(mapDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Tests = testsMap
+	state.ProfileCount = types.Int64Value(int64(len(tests)))
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *batchInspecMigrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state batchInspecMigrationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Execute conversion and set state
+	r.executeBatchInSpecConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A new output format writes a differently named file; remove the old ones
+	if state.OutputFormat.ValueString() != plan.OutputFormat.ValueString() && !r.client.isDryRun() {
+		previousOutput := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+		for profileName := range state.Tests.Elements() {
+			deleteGeneratedFile(batchInspecTestPath(previousOutput, profileName, state.OutputFormat.ValueString()), "test file", &resp.Diagnostics)
+		}
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *batchInspecMigrationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state batchInspecMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	if r.client.retainGeneratedFiles(state.RetainOnDelete, outputPath, &resp.Diagnostics) {
+		return
+	}
+
+	// Delete generated tests, then the profile directories they leave behind
+	for profileName := range state.Tests.Elements() {
+		deleteGeneratedFile(batchInspecTestPath(outputPath, profileName, state.OutputFormat.ValueString()), "test file", &resp.Diagnostics)
+		pruneEmptyDir(state.PruneEmptyDir, filepath.Join(outputPath, profileName), &resp.Diagnostics)
+	}
+	pruneEmptyDir(state.PruneEmptyDir, outputPath, &resp.Diagnostics)
+}
+
+// ImportState imports an existing resource into Terraform
+func (r *batchInspecMigrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format: profiles_root|output_path|output_format
+	parts, err := importIDParts(req.ID, "profiles_root", "output_path", "output_format")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+	if len(parts) != 3 {
+		resp.Diagnostics.AddError("Invalid import ID", batchInspecImportIDUsage)
+		return
+	}
+
+	profilesRoot := parts[0]
+	configuredOutputPath := parts[1]
+	outputPath := r.client.resolveOutputPath(configuredOutputPath)
+	outputFormat := parts[2]
+
+	profiles, err := discoverInSpecProfiles(profilesRoot)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error discovering InSpec profiles",
+			fmt.Sprintf("Could not find profiles under %s: %s", profilesRoot, err),
+		)
+		return
+	}
+
+	// Read all test files and validate they exist
+	tests := make(map[string]string)
+	for profileName := range profiles {
+		testPath := batchInspecTestPath(outputPath, profileName, outputFormat)
+		if !checkFileExists(testPath, "Test file", &resp.Diagnostics) {
+			return
+		}
+
+		content := readGeneratedFile(testPath, errReadingTestFile, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		tests[profileName] = content
+	}
+
+	// Convert tests map to types.Map
+	testsMap, mapDiags := typesMapValueFrom(ctx, types.StringType, tests)
+	resp.Diagnostics.Append(mapDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("profiles_root"), profilesRoot)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_path"), configuredOutputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resolved_output_path"), outputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_format"), outputFormat)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("profile_count"), int64(len(tests)))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tests"), testsMap)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), r.client.resourceID(fmt.Sprintf(batchInspecIDFormat, filepath.Base(profilesRoot)), profilesRoot))...)
+}
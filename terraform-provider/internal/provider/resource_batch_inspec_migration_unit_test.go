@@ -0,0 +1,192 @@
+// Package provider contains unit tests for the batch InSpec migration resource.
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newInSpecProfilesFixture creates a profiles root containing linux and ssh
+// profiles, plus a directory without inspec.yml that must be skipped.
+func newInSpecProfilesFixture(t *testing.T) string {
+	t.Helper()
+
+	profilesRoot := t.TempDir()
+	for _, profileName := range []string{"linux", "ssh"} {
+		writeInSpecFixtureFile(t, filepath.Join(profilesRoot, profileName, inspecProfileMetadataFile), "name: "+profileName+"\nversion: 1.0.0\n")
+		writeInSpecFixtureFile(t, filepath.Join(profilesRoot, profileName, "controls", "default.rb"), "control '"+profileName+"-01' do\nend\n")
+	}
+	writeInSpecFixtureFile(t, filepath.Join(profilesRoot, "notes", "README.md"), "not a profile\n")
+	return profilesRoot
+}
+
+// writeInSpecFixtureFile writes content to filePath, creating parent directories.
+func writeInSpecFixtureFile(t *testing.T, filePath, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(filePath), testDirPermissions); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+	if err := os.WriteFile(filePath, []byte(content), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+}
+
+// createBatchInSpecMigration runs Create against the fake CLI and returns the
+// resource and the create response.
+func createBatchInSpecMigration(t *testing.T, profilesRoot, outputPath, outputFormat string) (*batchInspecMigrationResource, *resource.CreateResponse) {
+	t.Helper()
+
+	r := &batchInspecMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	plan := newPlan(t, schema, batchInspecMigrationResourceModel{
+		ProfilesRoot: types.StringValue(profilesRoot),
+		OutputPath:   types.StringValue(outputPath),
+		OutputFormat: types.StringValue(outputFormat),
+		Tests:        types.MapUnknown(types.StringType),
+	})
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan}, resp)
+	return r, resp
+}
+
+func TestDiscoverInSpecProfiles(t *testing.T) {
+	profilesRoot := newInSpecProfilesFixture(t)
+	profiles, err := discoverInSpecProfiles(profilesRoot)
+	if err != nil {
+		t.Fatalf(unexpectedError, err)
+	}
+	if len(profiles) != 2 || profiles["linux"] != filepath.Join(profilesRoot, "linux") || profiles["ssh"] == "" {
+		t.Fatalf("expected linux and ssh profiles, got %v", profiles)
+	}
+
+	if _, err := discoverInSpecProfiles(t.TempDir()); err == nil {
+		t.Fatal("expected error when no profiles are found")
+	}
+	if _, err := discoverInSpecProfiles(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatal("expected error for a missing profiles_root")
+	}
+}
+
+func TestBatchInSpecMigrationCreate(t *testing.T) {
+	outputPath := t.TempDir()
+	_, resp := createBatchInSpecMigration(t, newInSpecProfilesFixture(t), outputPath, "goss")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var state batchInspecMigrationResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.ProfileCount.ValueInt64() != 2 {
+		t.Fatalf("expected profile_count 2, got %d", state.ProfileCount.ValueInt64())
+	}
+
+	tests := make(map[string]string)
+	state.Tests.ElementsAs(context.Background(), &tests, false)
+	for _, profileName := range []string{"linux", "ssh"} {
+		if tests[profileName] == "" {
+			t.Fatalf("expected test content for %s, got %v", profileName, tests)
+		}
+		if _, err := os.Stat(filepath.Join(outputPath, profileName, gossFilename)); err != nil {
+			t.Fatalf("expected goss tests for %s: %v", profileName, err)
+		}
+	}
+}
+
+func TestBatchInSpecMigrationCreateProfileFailure(t *testing.T) {
+	t.Setenv("SOUSCHEF_TEST_FAIL_PROFILE", "ssh")
+	_, resp := createBatchInSpecMigration(t, newInSpecProfilesFixture(t), t.TempDir(), "goss")
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when a profile fails to convert")
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "profile ssh") || !strings.Contains(detail, "forced error") {
+		t.Fatalf("expected the failing profile and CLI output in the diagnostic, got %q", detail)
+	}
+}
+
+func TestBatchInSpecMigrationReadUpdateAndDelete(t *testing.T) {
+	outputPath := t.TempDir()
+	r, createResp := createBatchInSpecMigration(t, newInSpecProfilesFixture(t), outputPath, "goss")
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+	schema := newResourceSchema(t, r)
+
+	// Switching format removes the previous format's files
+	var state batchInspecMigrationResourceModel
+	createResp.State.Get(context.Background(), &state)
+	planned := state
+	planned.OutputFormat = types.StringValue("testinfra")
+	updateResp := &resource.UpdateResponse{State: tfsdk.State{Schema: schema}}
+	r.Update(context.Background(), resource.UpdateRequest{Plan: newPlan(t, schema, planned), State: createResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, updateResp.Diagnostics)
+	}
+	if _, err := os.Stat(filepath.Join(outputPath, "linux", gossFilename)); !os.IsNotExist(err) {
+		t.Fatalf("expected goss tests to be removed after the format change, got %v", err)
+	}
+
+	if err := os.Remove(filepath.Join(outputPath, "ssh", testinfraFilename)); err != nil {
+		t.Fatalf("failed to remove tests: %v", err)
+	}
+	readResp := &resource.ReadResponse{State: updateResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: updateResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	var refreshed batchInspecMigrationResourceModel
+	readResp.State.Get(context.Background(), &refreshed)
+	if refreshed.ProfileCount.ValueInt64() != 1 {
+		t.Fatalf("expected profile_count 1 after removing a test file, got %d", refreshed.ProfileCount.ValueInt64())
+	}
+
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+	}
+	if _, err := os.Stat(filepath.Join(outputPath, "linux", testinfraFilename)); !os.IsNotExist(err) {
+		t.Fatalf("expected tests to be deleted, got %v", err)
+	}
+}
+
+func TestBatchInSpecMigrationImportState(t *testing.T) {
+	profilesRoot := newInSpecProfilesFixture(t)
+	outputPath := t.TempDir()
+	r, createResp := createBatchInSpecMigration(t, profilesRoot, outputPath, "ansible")
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+	schema := newResourceSchema(t, r)
+
+	resp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: profilesRoot + "|" + outputPath + "|ansible"}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var state batchInspecMigrationResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.ProfileCount.ValueInt64() != 2 || state.OutputFormat.ValueString() != "ansible" {
+		t.Fatalf("unexpected imported state: %+v", state)
+	}
+
+	for _, id := range []string{profilesRoot + "|" + outputPath, profilesRoot + "|" + outputPath + "|goss"} {
+		resp = &resource.ImportStateResponse{State: newEmptyState(schema)}
+		r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, resp)
+		if !resp.Diagnostics.HasError() {
+			t.Fatalf("expected error importing %q", id)
+		}
+	}
+}
@@ -4,19 +4,35 @@ package provider
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 const (
 	errorReadingBatchPlaybook = "Error reading playbook"
 	batchMigrationIDFormat    = "%s-batch"
+	batchImportAllRecipes     = "*"
+	batchRecipeStatusOK       = "ok"
+	batchRecipeStatusFailed   = "failed"
+	batchRecipePlaceholder    = "{recipe}"
+	batchPlaybookHashPrefix   = "sha256:"
+	maxBatchConversionWorkers = 4
+	batchImportIDFormatHelp   = "Import ID must be in format: cookbook_path|output_path|recipe1,recipe2,recipe3 " +
+		"or cookbook_path|output_path|* to import every playbook in output_path, " +
+		"optionally followed by |output_path_template and |store_content"
 )
 
 func parseBatchRecipeNames(recipeNamesStr string) ([]string, error) {
@@ -41,10 +57,198 @@ func parseBatchRecipeNames(recipeNamesStr string) ([]string, error) {
 	return recipeNames, nil
 }
 
+// readRecipeNamesFile reads newline-delimited recipe names from filePath,
+// skipping blank lines and lines starting with #.
+func readRecipeNamesFile(filePath string) ([]string, error) {
+	content, err := osReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	recipeNames := make([]string, 0)
+	for _, line := range strings.Split(string(content), "\n") {
+		name := strings.TrimSpace(line)
+		if name == "" || strings.HasPrefix(name, "#") {
+			continue
+		}
+		recipeNames = append(recipeNames, name)
+	}
+
+	if len(recipeNames) == 0 {
+		return nil, fmt.Errorf("no recipe names found in %s", filePath)
+	}
+	return recipeNames, nil
+}
+
+// resolveBatchRecipeNames merges the explicit recipe_names with those read
+// from recipe_names_file, keeping the first occurrence of each name.
+func resolveBatchRecipeNames(model batchMigrationResourceModel) ([]string, error) {
+	recipeNames := stringSliceFromTypesList(model.RecipeNames)
+	if !model.RecipeNamesFile.IsNull() && model.RecipeNamesFile.ValueString() != "" {
+		fileNames, err := readRecipeNamesFile(model.RecipeNamesFile.ValueString())
+		if err != nil {
+			return nil, err
+		}
+		recipeNames = append(recipeNames, fileNames...)
+	}
+
+	seen := make(map[string]bool, len(recipeNames))
+	resolved := make([]string, 0, len(recipeNames))
+	for _, name := range recipeNames {
+		if !seen[name] {
+			seen[name] = true
+			resolved = append(resolved, name)
+		}
+	}
+
+	if len(resolved) == 0 {
+		return nil, fmt.Errorf("recipe names are required")
+	}
+	return resolved, nil
+}
+
+// firstDuplicateRecipeName returns the first name that occurs more than once
+// in recipeNames.
+func firstDuplicateRecipeName(recipeNames []string) (string, bool) {
+	seen := make(map[string]bool, len(recipeNames))
+	for _, name := range recipeNames {
+		if seen[name] {
+			return name, true
+		}
+		seen[name] = true
+	}
+	return "", false
+}
+
+// knownListStrings returns the known string elements of list.
+func knownListStrings(list types.List) []string {
+	values := make([]string, 0, len(list.Elements()))
+	for _, element := range list.Elements() {
+		if value, ok := element.(types.String); ok && !value.IsUnknown() && !value.IsNull() {
+			values = append(values, value.ValueString())
+		}
+	}
+	return values
+}
+
+// stateRecipeNames returns the recipe names recorded in state, falling back
+// to recipe_names for state written before resolved_recipe_names existed.
+func stateRecipeNames(state batchMigrationResourceModel) []string {
+	if state.ResolvedRecipeNames.IsNull() || state.ResolvedRecipeNames.IsUnknown() {
+		return stringSliceFromTypesList(state.RecipeNames)
+	}
+
+	recipeNames := make([]string, 0, len(state.ResolvedRecipeNames.Elements()))
+	for _, element := range state.ResolvedRecipeNames.Elements() {
+		if name, ok := element.(types.String); ok {
+			recipeNames = append(recipeNames, name.ValueString())
+		}
+	}
+	return recipeNames
+}
+
+// batchPlaybookPath returns where the playbook for recipeName is written:
+// outputTemplate with {recipe} interpolated when set, otherwise
+// <recipe>.yml, relative to outputPath.
+func batchPlaybookPath(outputPath string, outputTemplate types.String, recipeName string) string {
+	if outputTemplate.IsNull() || outputTemplate.IsUnknown() || outputTemplate.ValueString() == "" {
+		return filepath.Join(outputPath, recipeName+".yml")
+	}
+	return filepath.Join(outputPath, strings.ReplaceAll(outputTemplate.ValueString(), batchRecipePlaceholder, recipeName))
+}
+
+// validateOutputPathTemplate checks that a template yields a distinct
+// relative path for every recipe.
+func validateOutputPathTemplate(outputTemplate string) error {
+	if !strings.Contains(outputTemplate, batchRecipePlaceholder) {
+		return fmt.Errorf("template must contain %s so that recipes do not overwrite each other", batchRecipePlaceholder)
+	}
+	if filepath.IsAbs(outputTemplate) {
+		return fmt.Errorf("template must be relative to output_path")
+	}
+	for _, part := range strings.Split(filepath.ToSlash(outputTemplate), "/") {
+		if part == ".." {
+			return fmt.Errorf("template must not leave output_path")
+		}
+	}
+	return nil
+}
+
+// batchPlaybookEntries returns the playbooks map to store in state: each
+// playbook's content, or with store_content = false its "sha256:<hash>", so
+// large batches keep state small while Read still detects drift.
+func batchPlaybookEntries(storeContent types.Bool, playbooks map[string]string) map[string]string {
+	if storeContent.IsNull() || storeContent.IsUnknown() || storeContent.ValueBool() {
+		return playbooks
+	}
+	hashes := make(map[string]string, len(playbooks))
+	for recipeName, content := range playbooks {
+		hashes[recipeName] = batchPlaybookEntry(storeContent, content)
+	}
+	return hashes
+}
+
+// batchPlaybookEntry returns a single playbook's stored value: its content,
+// or with store_content = false its "sha256:<hash>".
+func batchPlaybookEntry(storeContent types.Bool, content string) string {
+	if storeContent.IsNull() || storeContent.IsUnknown() || storeContent.ValueBool() {
+		return content
+	}
+	return batchPlaybookHashPrefix + contentSHA256([]byte(content))
+}
+
+// mergedPlaybookPath returns the path of the single playbook written when
+// merge is true.
+func mergedPlaybookPath(outputPath string, outputFilename types.String) string {
+	return filepath.Join(outputPath, outputFilename.ValueString())
+}
+
+// discoverBatchRecipeNames infers recipe names from the playbooks in
+// outputPath that match outputTemplate, or the *.yml playbooks when it is
+// unset, returned in path order.
+func discoverBatchRecipeNames(outputPath string, outputTemplate types.String) ([]string, error) {
+	if outputTemplate.IsNull() || outputTemplate.IsUnknown() || outputTemplate.ValueString() == "" {
+		outputTemplate = types.StringValue(batchRecipePlaceholder + ".yml")
+	}
+	template := outputTemplate.ValueString()
+	matches, err := filepath.Glob(filepath.Join(outputPath, strings.ReplaceAll(template, batchRecipePlaceholder, "*")))
+	if err != nil {
+		return nil, err
+	}
+
+	// The name runs from the template's text before its first {recipe} to
+	// the text after it; a match is kept only if the name maps back to it
+	prefix, rest, _ := strings.Cut(filepath.Join(outputPath, template), batchRecipePlaceholder)
+	literal, _, repeated := strings.Cut(rest, batchRecipePlaceholder)
+	recipeNames := make([]string, 0)
+	for _, match := range matches {
+		recipeName := strings.TrimPrefix(match, prefix)
+		if !repeated {
+			recipeName = strings.TrimSuffix(recipeName, literal)
+		} else if i := strings.Index(recipeName, literal); literal != "" && i >= 0 {
+			recipeName = recipeName[:i]
+		}
+		if recipeName == "" || batchPlaybookPath(outputPath, outputTemplate, recipeName) != match {
+			continue
+		}
+		if info, err := osStat(match); err != nil || info.IsDir() {
+			continue
+		}
+		recipeNames = append(recipeNames, recipeName)
+	}
+
+	if len(recipeNames) == 0 {
+		return nil, fmt.Errorf("no playbooks found in %s", outputPath)
+	}
+
+	return recipeNames, nil
+}
+
 // Ensure the implementation satisfies the expected interfaces
 var (
-	_ resource.Resource                = &batchMigrationResource{}
-	_ resource.ResourceWithImportState = &batchMigrationResource{}
+	_ resource.Resource                   = &batchMigrationResource{}
+	_ resource.ResourceWithImportState    = &batchMigrationResource{}
+	_ resource.ResourceWithValidateConfig = &batchMigrationResource{}
 )
 
 // NewBatchMigrationResource creates a new batch migration resource
@@ -59,13 +263,27 @@ type batchMigrationResource struct {
 
 // batchMigrationResourceModel describes the resource data model
 type batchMigrationResourceModel struct {
-	ID            types.String   `tfsdk:"id"`
-	CookbookPath  types.String   `tfsdk:"cookbook_path"`
-	OutputPath    types.String   `tfsdk:"output_path"`
-	RecipeNames   []types.String `tfsdk:"recipe_names"`
-	CookbookName  types.String   `tfsdk:"cookbook_name"`
-	PlaybookCount types.Int64    `tfsdk:"playbook_count"`
-	Playbooks     types.Map      `tfsdk:"playbooks"`
+	ID                  types.String   `tfsdk:"id"`
+	CookbookPath        types.String   `tfsdk:"cookbook_path"`
+	OutputPath          types.String   `tfsdk:"output_path"`
+	OutputPathTemplate  types.String   `tfsdk:"output_path_template"`
+	CreateOutputDir     types.Bool     `tfsdk:"create_output_dir"`
+	PruneEmptyDir       types.Bool     `tfsdk:"prune_empty_dir"`
+	RetainOnDelete      types.Bool     `tfsdk:"retain_on_delete"`
+	ResolvedOutputPath  types.String   `tfsdk:"resolved_output_path"`
+	RecipeNames         []types.String `tfsdk:"recipe_names"`
+	RecipeNamesFile     types.String   `tfsdk:"recipe_names_file"`
+	ResolvedRecipeNames types.List     `tfsdk:"resolved_recipe_names"`
+	ContinueOnError     types.Bool     `tfsdk:"continue_on_error"`
+	RecipesSubdir       types.String   `tfsdk:"recipes_subdir"`
+	StoreContent        types.Bool     `tfsdk:"store_content"`
+	Merge               types.Bool     `tfsdk:"merge"`
+	OutputFilename      types.String   `tfsdk:"output_filename"`
+	CookbookName        types.String   `tfsdk:"cookbook_name"`
+	PlaybookCount       types.Int64    `tfsdk:"playbook_count"`
+	Playbooks           types.Map      `tfsdk:"playbooks"`
+	PlaybookContent     types.String   `tfsdk:"playbook_content"`
+	RecipeStatus        types.Map      `tfsdk:"recipe_status"`
 }
 
 // Metadata returns the resource type name
@@ -91,10 +309,59 @@ func (r *batchMigrationResource) Schema(ctx context.Context, req resource.Schema
 				Required:            true,
 				MarkdownDescription: "Directory where Ansible playbooks will be written",
 			},
+			"output_path_template": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path of each playbook relative to `output_path`, with `{recipe}` replaced by the recipe name, e.g. `{recipe}/main.yml`. Must contain `{recipe}` (default: `{recipe}.yml`)",
+			},
+			"create_output_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Create `output_path` when it does not exist (default: true). When false the directory must already exist, e.g. when it is pre-created with specific ownership and permissions",
+			},
+			"prune_empty_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove the output directory on destroy when no other files remain in it (default: false)",
+			},
+			"retain_on_delete": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Leave the generated files in place on destroy and only remove the resource from state, e.g. once they are committed to a repository (default: false)",
+			},
+			"resolved_output_path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Directory the playbooks are written to: `output_path` joined onto the provider `output_root` when `output_path` is relative",
+			},
 			"recipe_names": schema.ListAttribute{
-				Required:            true,
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "List of recipe names to convert. At least one of `recipe_names` or `recipe_names_file` is required",
+			},
+			"recipe_names_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Newline-delimited file of recipe names to convert, merged after `recipe_names`. Blank lines and lines starting with `#` are ignored",
+			},
+			"resolved_recipe_names": schema.ListAttribute{
+				Computed:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "List of recipe names to convert",
+				MarkdownDescription: "Recipe names converted: `recipe_names` followed by those read from `recipe_names_file`, without duplicates",
+			},
+			"continue_on_error": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Record recipes that fail to convert in `recipe_status` and convert the rest instead of aborting. The resource only errors if every recipe fails (default: false)",
+			},
+			"recipes_subdir": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Directory holding the cookbook's recipes, relative to the cookbook, for nested cookbooks or Policyfile layouts (default: `recipes`)",
+			},
+			"store_content": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Store each playbook's content in `playbooks`. Set to false for large batches to store `sha256:<hash>` of each playbook instead, keeping state small while still detecting drift (default: true)",
+			},
+			"merge": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Convert all recipes into a single playbook named `output_filename` instead of one playbook per recipe. The playbook is stored in `playbook_content` and `playbooks` is left unset. Requires `output_filename` and cannot be combined with `output_path_template` or `continue_on_error` (default: false)",
+			},
+			"output_filename": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "File name, within `output_path`, of the merged playbook written when `merge` is true, e.g. `site.yml`",
 			},
 			"cookbook_name": schema.StringAttribute{
 				Computed:            true,
@@ -107,7 +374,16 @@ func (r *batchMigrationResource) Schema(ctx context.Context, req resource.Schema
 			"playbooks": schema.MapAttribute{
 				Computed:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "Map of recipe names to playbook content",
+				MarkdownDescription: "Map of recipe names to playbook content, or to `sha256:<hash>` of it when `store_content` is false. Unset when `merge` is true",
+			},
+			"playbook_content": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Content of the merged playbook, or `sha256:<hash>` of it when `store_content` is false. Only set when `merge` is true",
+			},
+			"recipe_status": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Map of recipe names to conversion status (`ok` or `failed`)",
 			},
 		},
 	}
@@ -118,24 +394,332 @@ func (r *batchMigrationResource) Configure(ctx context.Context, req resource.Con
 	r.client = configureResource(req, resp)
 }
 
-// executeBatchConversion converts Chef recipes to Ansible playbooks
-func (r *batchMigrationResource) executeBatchConversion(ctx context.Context, cookbookPath string, outputPath string, recipeNames []string, diags *diag.Diagnostics) map[string]string {
+// ValidateConfig rejects an output_path nested inside cookbook_path, an
+// output_path_template without {recipe}, duplicate recipe_names and an
+// incomplete merge configuration, and requires recipe_names or a readable,
+// non-empty recipe_names_file.
+func (r *batchMigrationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateOutputNotNested(ctx, req.Config, r.client, &resp.Diagnostics)
+	validateRecipesSubdir(ctx, req.Config, &resp.Diagnostics)
+	validateBatchMergeConfig(ctx, req.Config, &resp.Diagnostics)
+
+	var outputTemplate types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("output_path_template"), &outputTemplate)...)
+	if !outputTemplate.IsNull() && !outputTemplate.IsUnknown() {
+		if err := validateOutputPathTemplate(outputTemplate.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("output_path_template"),
+				"Invalid output path template",
+				err.Error(),
+			)
+		}
+	}
+
+	var recipeNames types.List
+	var recipeNamesFile types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("recipe_names"), &recipeNames)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("recipe_names_file"), &recipeNamesFile)...)
+	if resp.Diagnostics.HasError() || recipeNames.IsUnknown() || recipeNamesFile.IsUnknown() {
+		return
+	}
+
+	if duplicate, ok := firstDuplicateRecipeName(knownListStrings(recipeNames)); ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("recipe_names"),
+			"Duplicate recipe name",
+			fmt.Sprintf("Recipe %q is listed more than once in recipe_names.", duplicate),
+		)
+	}
+
+	if recipeNamesFile.IsNull() {
+		if recipeNames.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("recipe_names"),
+				"Missing recipe names",
+				"At least one of recipe_names or recipe_names_file must be set.",
+			)
+		}
+		return
+	}
+
+	if _, err := readRecipeNamesFile(recipeNamesFile.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("recipe_names_file"),
+			"Invalid recipe names file",
+			fmt.Sprintf("Could not read recipe names: %s", err),
+		)
+	}
+}
+
+// validateBatchMergeConfig requires a plain output_filename when merge is
+// true, and rejects the per-recipe options a single merged playbook cannot
+// honour.
+func validateBatchMergeConfig(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var merge types.Bool
+	var outputFilename types.String
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("merge"), &merge)...)
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("output_filename"), &outputFilename)...)
+	if diagnostics.HasError() || !merge.ValueBool() {
+		return
+	}
+
+	if outputFilename.IsNull() {
+		diagnostics.AddAttributeError(
+			path.Root("output_filename"),
+			"Missing output filename",
+			"output_filename must be set when merge is true.",
+		)
+	} else if !outputFilename.IsUnknown() {
+		if name := outputFilename.ValueString(); name == "" || name == "." || name == ".." || filepath.Base(name) != name {
+			diagnostics.AddAttributeError(
+				path.Root("output_filename"),
+				"Invalid output filename",
+				fmt.Sprintf("output_filename must be a file name within output_path, got %q.", name),
+			)
+		}
+	}
+
+	var outputTemplate types.String
+	var continueOnError types.Bool
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("output_path_template"), &outputTemplate)...)
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("continue_on_error"), &continueOnError)...)
+	if !outputTemplate.IsNull() {
+		addMergeConflictError("output_path_template", diagnostics)
+	}
+	if !continueOnError.IsNull() {
+		addMergeConflictError("continue_on_error", diagnostics)
+	}
+}
+
+// addMergeConflictError reports an attribute that cannot be combined with merge.
+func addMergeConflictError(name string, diagnostics *diag.Diagnostics) {
+	diagnostics.AddAttributeError(
+		path.Root(name),
+		"Conflicting merge option",
+		fmt.Sprintf("%s cannot be set when merge is true, as all recipes are converted into a single playbook.", name),
+	)
+}
+
+// resolvePlanRecipeNames resolves the plan's recipe names and records them in
+// resolved_recipe_names.
+func resolvePlanRecipeNames(plan *batchMigrationResourceModel, diags *diag.Diagnostics) []string {
+	recipeNames, err := resolveBatchRecipeNames(*plan)
+	if err != nil {
+		diags.AddError(
+			"Error resolving recipe names",
+			fmt.Sprintf("Could not determine recipes to convert: %s", err),
+		)
+		return nil
+	}
+	plan.ResolvedRecipeNames = typesListFromStringSlice(recipeNames)
+	return recipeNames
+}
+
+// convertBatchRecipe converts a single recipe into playbookPath and returns
+// its playbook content.
+func (r *batchMigrationResource) convertBatchRecipe(ctx context.Context, cookbookPath string, recipesSubdir types.String, playbookPath, recipeName string, diags *diag.Diagnostics) string {
+	playbookDir := filepath.Dir(playbookPath)
+	if !r.client.createOutputDirectory(playbookDir, diags) {
+		return ""
+	}
+
+	args := []string{"convert-recipe", "--cookbook-path", cookbookPath, "--recipe-name", recipeName, "--output-path", playbookDir}
+	args = append(args, recipesSubdirArgs(recipesSubdir)...)
+	generatedPath := filepath.Join(playbookDir, recipeName+".yml")
+	content, ok := executeConversion(ctx, r.client, args, generatedPath, errorReadingBatchPlaybook, diags)
+	if !ok {
+		return ""
+	}
+
+	// Move the generated playbook into place when output_path_template names it differently
+	if generatedPath != playbookPath && !r.client.isDryRun() {
+		if err := osRename(generatedPath, playbookPath); err != nil {
+			diags.AddError(
+				"Error renaming playbook",
+				fmt.Sprintf("Could not rename %s to %s: %s", generatedPath, playbookPath, err),
+			)
+			return ""
+		}
+	}
+
+	return content
+}
+
+// logBatchRecipeDone logs the outcome and duration of the n-th of total
+// recipe conversions so that TF_LOG=INFO shows batch progress.
+func logBatchRecipeDone(ctx context.Context, n, total int, recipeName string, elapsed time.Duration, ok bool) {
+	status := batchRecipeStatusOK
+	if !ok {
+		status = batchRecipeStatusFailed
+	}
+	tflog.Info(ctx, fmt.Sprintf("converted %d/%d: %s", n, total, recipeName), map[string]interface{}{
+		"recipe":  recipeName,
+		"status":  status,
+		"elapsed": elapsed.String(),
+	})
+}
+
+// batchRecipeResult is the outcome of converting one recipe of a batch.
+// Skipped results belong to recipes that were never started because an
+// earlier failure aborted the batch.
+type batchRecipeResult struct {
+	content string
+	diags   diag.Diagnostics
+	skipped bool
+}
+
+// convertBatchRecipes converts recipeNames with up to maxBatchConversionWorkers
+// CLI invocations at a time. Each worker writes only its recipe's slot of the
+// returned slice, so results line up with recipeNames however the conversions
+// interleave. Unless continueOnError is set, recipes not yet started when one
+// fails are skipped.
+func (r *batchMigrationResource) convertBatchRecipes(ctx context.Context, cookbookPath string, recipesSubdir types.String, outputPath string, outputTemplate types.String, recipeNames []string, continueOnError bool) []batchRecipeResult {
+	results := make([]batchRecipeResult, len(recipeNames))
+	indexes := make(chan int)
+	var failed atomic.Bool
+	var wg sync.WaitGroup
+	for range min(maxBatchConversionWorkers, len(recipeNames)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				recipeName := recipeNames[i]
+				if failed.Load() && !continueOnError {
+					results[i].skipped = true
+					continue
+				}
+
+				tflog.Info(ctx, fmt.Sprintf("converting %d/%d: %s", i+1, len(recipeNames), recipeName))
+				start := time.Now()
+				results[i].content = r.convertBatchRecipe(ctx, cookbookPath, recipesSubdir, batchPlaybookPath(outputPath, outputTemplate, recipeName), recipeName, &results[i].diags)
+				logBatchRecipeDone(ctx, i+1, len(recipeNames), recipeName, time.Since(start), !results[i].diags.HasError())
+				if results[i].diags.HasError() {
+					failed.Store(true)
+				}
+			}
+		}()
+	}
+	for i := range recipeNames {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// mergeBatchResults collects per-recipe results into the playbooks and status
+// maps in recipe order, so diagnostics and state never depend on which
+// conversion finished first. By default the first failure aborts the batch;
+// with continueOnError, failures are reported as warnings and recorded in the
+// status map, and the batch only fails if no recipe converts.
+func mergeBatchResults(recipeNames []string, results []batchRecipeResult, continueOnError bool, diags *diag.Diagnostics) (map[string]string, map[string]string) {
 	playbooks := make(map[string]string)
-	for _, recipeName := range recipeNames {
-		args := []string{"convert-recipe", "--cookbook-path", cookbookPath, "--recipe-name", recipeName, "--output-path", outputPath}
-		if _, ok := executeSousChefCommand(ctx, r.client.Path, args, "Error converting recipe", diags); !ok {
-			return nil
+	statuses := make(map[string]string)
+	for i, recipeName := range recipeNames {
+		result := results[i]
+		if result.skipped {
+			continue
+		}
+		if !result.diags.HasError() {
+			diags.Append(result.diags...)
+			playbooks[recipeName] = result.content
+			statuses[recipeName] = batchRecipeStatusOK
+			continue
 		}
 
-		playbookPath := filepath.Join(outputPath, recipeName+".yml")
-		content := readGeneratedFile(playbookPath, errorReadingBatchPlaybook, diags)
-		if diags.HasError() {
-			return nil
+		if !continueOnError {
+			diags.Append(result.diags...)
+			return nil, nil
 		}
 
-		playbooks[recipeName] = content
+		statuses[recipeName] = batchRecipeStatusFailed
+		for _, d := range result.diags {
+			diags.AddWarning(
+				fmt.Sprintf("Recipe %s failed to convert", recipeName),
+				fmt.Sprintf("%s: %s", d.Summary(), d.Detail()),
+			)
+		}
+	}
+
+	if len(playbooks) == 0 && len(recipeNames) > 0 {
+		diags.AddError(
+			"Error converting recipes",
+			fmt.Sprintf("None of the %d recipes could be converted", len(recipeNames)),
+		)
+		return nil, nil
+	}
+	if err := checkBatchResults(recipeNames, playbooks, statuses); err != nil {
+		diags.AddError("Inconsistent batch conversion result", err.Error())
+		return nil, nil
+	}
+	return playbooks, statuses
+}
+
+// checkBatchResults guards against a merge that lost or invented recipes:
+// every requested recipe must have a status, and exactly the recipes marked
+// ok must have a playbook.
+func checkBatchResults(recipeNames []string, playbooks, statuses map[string]string) error {
+	if len(statuses) != len(recipeNames) {
+		return fmt.Errorf("expected a status for each of the %d requested recipes, got %d", len(recipeNames), len(statuses))
+	}
+	converted := 0
+	for _, recipeName := range recipeNames {
+		status, ok := statuses[recipeName]
+		if !ok {
+			return fmt.Errorf("recipe %s has no conversion status", recipeName)
+		}
+		_, hasPlaybook := playbooks[recipeName]
+		if hasPlaybook != (status == batchRecipeStatusOK) {
+			return fmt.Errorf("recipe %s has status %q but playbook present is %t", recipeName, status, hasPlaybook)
+		}
+		if hasPlaybook {
+			converted++
+		}
+	}
+	if converted != len(playbooks) {
+		return fmt.Errorf("expected %d playbooks, got %d", converted, len(playbooks))
+	}
+	return nil
+}
+
+// executeMergedConversion converts all recipes into the single playbook named
+// by output_filename and records it in the model. The recipes share the
+// outcome of one CLI invocation, so each is recorded as ok.
+func (r *batchMigrationResource) executeMergedConversion(ctx context.Context, model *batchMigrationResourceModel, cookbookPath, outputPath string, recipeNames []string, diags *diag.Diagnostics) {
+	args := []string{"convert-recipe",
+		"--cookbook-path", cookbookPath,
+		"--recipe-names", strings.Join(recipeNames, ","),
+		"--output-path", outputPath,
+		"--merge",
+		"--output-file", model.OutputFilename.ValueString(),
+	}
+	args = append(args, recipesSubdirArgs(model.RecipesSubdir)...)
+	content, ok := executeConversion(ctx, r.client, args, mergedPlaybookPath(outputPath, model.OutputFilename), errorReadingBatchPlaybook, diags)
+	if !ok {
+		return
 	}
-	return playbooks
+
+	statuses := make(map[string]string, len(recipeNames))
+	for _, recipeName := range recipeNames {
+		statuses[recipeName] = batchRecipeStatusOK
+	}
+	statusMap, mapDiags := typesMapValueFrom(ctx, types.StringType, statuses)
+	diags.Append(mapDiags...)
+	if diags.HasError() {
+		return
+	}
+
+	model.Playbooks = types.MapNull(types.StringType)
+	model.PlaybookContent = types.StringValue(batchPlaybookEntry(model.StoreContent, content))
+	model.PlaybookCount = types.Int64Value(1)
+	model.RecipeStatus = statusMap
+}
+
+// executeBatchConversion converts Chef recipes to Ansible playbooks and
+// returns the playbooks alongside each recipe's status.
+func (r *batchMigrationResource) executeBatchConversion(ctx context.Context, cookbookPath string, recipesSubdir types.String, outputPath string, outputTemplate types.String, recipeNames []string, continueOnError bool, diags *diag.Diagnostics) (map[string]string, map[string]string) {
+	results := r.convertBatchRecipes(ctx, cookbookPath, recipesSubdir, outputPath, outputTemplate, recipeNames, continueOnError)
+	return mergeBatchResults(recipeNames, results, continueOnError, diags)
 }
 
 // Create creates the resource and sets the initial Terraform state
@@ -148,35 +732,63 @@ func (r *batchMigrationResource) Create(ctx context.Context, req resource.Create
 	}
 
 	cookbookPath := plan.CookbookPath.ValueString()
-	outputPath := plan.OutputPath.ValueString()
-	recipeNames := stringSliceFromTypesList(plan.RecipeNames)
+	outputPath := r.client.resolveOutputPath(plan.OutputPath.ValueString())
+	plan.ResolvedOutputPath = types.StringValue(outputPath)
+	cookbookName := r.client.cookbookName(cookbookPath)
+	plan.ID = types.StringValue(r.client.resourceID(fmt.Sprintf(batchMigrationIDFormat, cookbookName), cookbookPath))
+	plan.CookbookName = types.StringValue(cookbookName)
+	ctx = withReportResourceID(ctx, plan.ID.ValueString())
+	recipeNames := resolvePlanRecipeNames(&plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Create output directory
-	if !createOutputDirectory(outputPath, &resp.Diagnostics) {
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, outputPath, &resp.Diagnostics) {
+		return
+	}
+	existing := snapshotOutputs(outputPath)
+
+	// Convert all recipes into one playbook when merge is set
+	if plan.Merge.ValueBool() {
+		r.executeMergedConversion(ctx, &plan, cookbookPath, outputPath, recipeNames, &resp.Diagnostics)
+		if cleanupIfCanceled(ctx, &resp.Diagnostics, existing, mergedPlaybookPath(outputPath, plan.OutputFilename)) || resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 		return
 	}
 
 	// Convert recipes to playbooks
-	playbooks := r.executeBatchConversion(ctx, cookbookPath, outputPath, recipeNames, &resp.Diagnostics)
+	playbooks, statuses := r.executeBatchConversion(ctx, cookbookPath, plan.RecipesSubdir, outputPath, plan.OutputPathTemplate, recipeNames, plan.ContinueOnError.ValueBool(), &resp.Diagnostics)
+
+	// Don't leave untracked playbooks behind if the apply was interrupted,
+	// including those converted before a mid-batch cancellation
+	generated := make([]string, len(recipeNames))
+	for i, recipeName := range recipeNames {
+		generated[i] = batchPlaybookPath(outputPath, plan.OutputPathTemplate, recipeName)
+	}
+	if cleanupIfCanceled(ctx, &resp.Diagnostics, existing, generated...) {
+		return
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Extract cookbook name from path
-	cookbookName := filepath.Base(cookbookPath)
-
-	// Convert playbooks map to types.Map
-	playbooksMap, mapDiags := typesMapValueFrom(ctx, types.StringType, playbooks)
+	// Convert playbooks and statuses to types.Map
+	playbooksMap, mapDiags := typesMapValueFrom(ctx, types.StringType, batchPlaybookEntries(plan.StoreContent, playbooks))
+	resp.Diagnostics.Append(mapDiags...)
+	statusMap, mapDiags := typesMapValueFrom(ctx, types.StringType, statuses)
 	resp.Diagnostics.Append(mapDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Set state
-	plan.ID = types.StringValue(fmt.Sprintf(batchMigrationIDFormat, cookbookName))
-	plan.CookbookName = types.StringValue(cookbookName)
 	plan.PlaybookCount = types.Int64Value(int64(len(playbooks)))
 	plan.Playbooks = playbooksMap
+	plan.PlaybookContent = types.StringNull()
+	plan.RecipeStatus = statusMap
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -191,31 +803,58 @@ func (r *batchMigrationResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	outputPath := state.OutputPath.ValueString()
-	recipeNames := stringSliceFromTypesList(state.RecipeNames)
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	recipeNames := stateRecipeNames(state)
+
+	// A merged batch has a single playbook to refresh
+	if state.Merge.ValueBool() {
+		playbookPath := mergedPlaybookPath(outputPath, state.OutputFilename)
+		if !readFileAndSetState(
+			ctx,
+			playbookPath,
+			"playbook_content",
+			func(content string) {
+				state.PlaybookContent = types.StringValue(batchPlaybookEntry(state.StoreContent, content))
+			},
+			errorReadingBatchPlaybook,
+			&resp.Diagnostics,
+			r.client.missingArtifactHandler("merged playbook "+playbookPath, &resp.State, &resp.Diagnostics),
+		) {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+		return
+	}
 
 	// Check if any playbook exists
 	anyExists := false
 	playbooks := make(map[string]string)
 	for _, recipeName := range recipeNames {
-		playbookPath := filepath.Join(outputPath, recipeName+".yml")
+		playbookPath := batchPlaybookPath(outputPath, state.OutputPathTemplate, recipeName)
 		if _, err := osStat(playbookPath); err == nil {
-			anyExists = true
-			content := readGeneratedFile(playbookPath, errorReadingBatchPlaybook, &resp.Diagnostics)
-			if resp.Diagnostics.HasError() {
+			content, err := readFileWithRetry(ctx, playbookPath)
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				resp.Diagnostics.AddError(
+					errorReadingBatchPlaybook,
+					fmt.Sprintf("Could not read file %s: %s", playbookPath, err),
+				)
 				return
 			}
-			playbooks[recipeName] = content
+			anyExists = true
+			playbooks[recipeName] = string(content)
 		}
 	}
 
 	if !anyExists {
-		resp.State.RemoveResource(ctx)
+		r.client.handleMissingArtifact(ctx, "any playbooks in "+outputPath, &resp.State, &resp.Diagnostics)
 		return
 	}
 
-	// Update state with current content
-	playbooksMap, mapDiags := typesMapValueFrom(ctx, types.StringType, playbooks)
+	// Update state with current content, or its hash when store_content is false
+	playbooksMap, mapDiags := typesMapValueFrom(ctx, types.StringType, batchPlaybookEntries(state.StoreContent, playbooks))
 	resp.Diagnostics.Append(mapDiags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -238,27 +877,51 @@ func (r *batchMigrationResource) Update(ctx context.Context, req resource.Update
 	}
 
 	cookbookPath := plan.CookbookPath.ValueString()
-	outputPath := plan.OutputPath.ValueString()
-	recipeNames := stringSliceFromTypesList(plan.RecipeNames)
+	outputPath := r.client.resolveOutputPath(plan.OutputPath.ValueString())
+	plan.ResolvedOutputPath = types.StringValue(outputPath)
+	cookbookName := r.client.cookbookName(cookbookPath)
+	plan.ID = types.StringValue(r.client.resourceID(fmt.Sprintf(batchMigrationIDFormat, cookbookName), cookbookPath))
+	plan.CookbookName = types.StringValue(cookbookName)
+	ctx = withReportResourceID(ctx, plan.ID.ValueString())
+	recipeNames := resolvePlanRecipeNames(&plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check the output directory, creating it unless create_output_dir is false
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, outputPath, &resp.Diagnostics) {
+		return
+	}
+
+	// Convert all recipes into one playbook when merge is set
+	if plan.Merge.ValueBool() {
+		r.executeMergedConversion(ctx, &plan, cookbookPath, outputPath, recipeNames, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
 
 	// Convert recipes to playbooks
-	playbooks := r.executeBatchConversion(ctx, cookbookPath, outputPath, recipeNames, &resp.Diagnostics)
+	playbooks, statuses := r.executeBatchConversion(ctx, cookbookPath, plan.RecipesSubdir, outputPath, plan.OutputPathTemplate, recipeNames, plan.ContinueOnError.ValueBool(), &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Convert playbooks map to types.Map
-	playbooksMap, mapDiags := typesMapValueFrom(ctx, types.StringType, playbooks)
+	// Convert playbooks and statuses to types.Map
+	playbooksMap, mapDiags := typesMapValueFrom(ctx, types.StringType, batchPlaybookEntries(plan.StoreContent, playbooks))
+	resp.Diagnostics.Append(mapDiags...)
+	statusMap, mapDiags := typesMapValueFrom(ctx, types.StringType, statuses)
 	resp.Diagnostics.Append(mapDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	cookbookName := filepath.Base(cookbookPath)
-	plan.ID = types.StringValue(fmt.Sprintf(batchMigrationIDFormat, cookbookName))
-	plan.CookbookName = types.StringValue(cookbookName)
 	plan.Playbooks = playbooksMap
+	plan.PlaybookContent = types.StringNull()
 	plan.PlaybookCount = types.Int64Value(int64(len(playbooks)))
+	plan.RecipeStatus = statusMap
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -273,37 +936,87 @@ func (r *batchMigrationResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
-	outputPath := state.OutputPath.ValueString()
-	recipeNames := stringSliceFromTypesList(state.RecipeNames)
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	if r.client.retainGeneratedFiles(state.RetainOnDelete, outputPath, &resp.Diagnostics) {
+		return
+	}
+	if state.Merge.ValueBool() {
+		deleteGeneratedFile(mergedPlaybookPath(outputPath, state.OutputFilename), "merged playbook", &resp.Diagnostics)
+		pruneEmptyDir(state.PruneEmptyDir, outputPath, &resp.Diagnostics)
+		return
+	}
+	recipeNames := stateRecipeNames(state)
 
-	// Delete generated playbooks
+	// Delete generated playbooks, and any per-recipe directories the
+	// output_path_template created
 	for _, recipeName := range recipeNames {
-		playbookPath := filepath.Join(outputPath, recipeName+".yml")
+		playbookPath := batchPlaybookPath(outputPath, state.OutputPathTemplate, recipeName)
 		deleteGeneratedFile(playbookPath, "playbook", &resp.Diagnostics)
+		if playbookDir := filepath.Dir(playbookPath); playbookDir != filepath.Clean(outputPath) {
+			pruneEmptyDir(state.PruneEmptyDir, playbookDir, &resp.Diagnostics)
+		}
 	}
+	pruneEmptyDir(state.PruneEmptyDir, outputPath, &resp.Diagnostics)
 }
 
 // ImportState imports an existing resource into Terraform
 func (r *batchMigrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Import ID format: cookbook_path|output_path|recipe1,recipe2,recipe3
-	parts := strings.Split(req.ID, "|")
-	if len(parts) != 3 {
+	// or cookbook_path|output_path|* to infer recipes from output_path,
+	// optionally followed by |output_path_template and |store_content
+	parts, err := importIDParts(req.ID, "cookbook_path", "output_path", "recipe_names", "output_path_template", "store_content")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+	if len(parts) < 3 || len(parts) > 5 {
 		resp.Diagnostics.AddError(
 			"Invalid import ID",
-			"Import ID must be in format: cookbook_path|output_path|recipe1,recipe2,recipe3",
+			batchImportIDFormatHelp,
 		)
 		return
 	}
 
 	cookbookPath := parts[0]
-	outputPath := parts[1]
+	configuredOutputPath := parts[1]
+	outputPath := r.client.resolveOutputPath(configuredOutputPath)
 	recipeNamesStr := parts[2]
+	outputTemplate := types.StringNull()
+	if len(parts) >= 4 && parts[3] != "" {
+		if err := validateOutputPathTemplate(parts[3]); err != nil {
+			resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("Invalid output_path_template: %s", err))
+			return
+		}
+		outputTemplate = types.StringValue(parts[3])
+	}
+	storeContent := types.BoolNull()
+	if len(parts) == 5 && parts[4] != "" {
+		store, err := strconv.ParseBool(parts[4])
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("store_content must be true or false, got %q", parts[4]))
+			return
+		}
+		storeContent = types.BoolValue(store)
+	}
 
 	// Validate that the cookbook directory exists
 	if !checkFileExists(cookbookPath, "Cookbook", &resp.Diagnostics) {
 		return
 	}
 
+	// Infer recipe names from existing playbooks when importing the whole directory
+	if strings.TrimSpace(recipeNamesStr) == batchImportAllRecipes {
+		discovered, err := discoverBatchRecipeNames(outputPath, outputTemplate)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error discovering playbooks",
+				fmt.Sprintf("Could not infer recipe names from %s: %s", outputPath, err),
+			)
+			return
+		}
+		recipeNamesStr = strings.Join(discovered, ",")
+	}
+
 	// Parse recipe names
 	recipeNames, err := parseBatchRecipeNames(recipeNamesStr)
 	if err != nil {
@@ -316,8 +1029,9 @@ func (r *batchMigrationResource) ImportState(ctx context.Context, req resource.I
 
 	// Read all playbooks and validate they exist
 	playbooks := make(map[string]string)
+	statuses := make(map[string]string)
 	for _, recipeName := range recipeNames {
-		playbookPath := filepath.Join(outputPath, recipeName+".yml")
+		playbookPath := batchPlaybookPath(outputPath, outputTemplate, recipeName)
 		if !checkFileExists(playbookPath, "Playbook", &resp.Diagnostics) {
 			return
 		}
@@ -328,10 +1042,11 @@ func (r *batchMigrationResource) ImportState(ctx context.Context, req resource.I
 		}
 
 		playbooks[recipeName] = content
+		statuses[recipeName] = batchRecipeStatusOK
 	}
 
 	// Extract cookbook name from path
-	cookbookName := filepath.Base(cookbookPath)
+	cookbookName := r.client.cookbookName(cookbookPath)
 
 	// Convert recipe names to types
 	recipeNamesTypes := make([]types.String, len(recipeNames))
@@ -339,19 +1054,30 @@ func (r *batchMigrationResource) ImportState(ctx context.Context, req resource.I
 		recipeNamesTypes[i] = types.StringValue(name)
 	}
 
-	// Convert playbooks map to types.Map
-	playbooksMap, mapDiags := typesMapValueFrom(ctx, types.StringType, playbooks)
+	// Convert playbooks and statuses to types.Map, storing hashes as Create
+	// does when store_content is false
+	playbooksMap, mapDiags := typesMapValueFrom(ctx, types.StringType, batchPlaybookEntries(storeContent, playbooks))
+	resp.Diagnostics.Append(mapDiags...)
+	statusMap, mapDiags := typesMapValueFrom(ctx, types.StringType, statuses)
 	resp.Diagnostics.Append(mapDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Set state
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cookbook_path"), cookbookPath)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_path"), outputPath)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("recipe_names"), recipeNamesTypes)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cookbook_name"), cookbookName)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("playbook_count"), int64(len(playbooks)))...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("playbooks"), playbooksMap)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), fmt.Sprintf(batchMigrationIDFormat, cookbookName))...)
+	// Set state in one call, as Create does, so a failed import sets nothing
+	model := batchMigrationResourceModel{
+		ID:                  types.StringValue(r.client.resourceID(fmt.Sprintf(batchMigrationIDFormat, cookbookName), cookbookPath)),
+		CookbookPath:        types.StringValue(cookbookPath),
+		OutputPath:          types.StringValue(configuredOutputPath),
+		OutputPathTemplate:  outputTemplate,
+		ResolvedOutputPath:  types.StringValue(outputPath),
+		RecipeNames:         recipeNamesTypes,
+		ResolvedRecipeNames: typesListFromStringSlice(recipeNames),
+		CookbookName:        types.StringValue(cookbookName),
+		PlaybookCount:       types.Int64Value(int64(len(playbooks))),
+		Playbooks:           playbooksMap,
+		RecipeStatus:        statusMap,
+		StoreContent:        storeContent,
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
 }
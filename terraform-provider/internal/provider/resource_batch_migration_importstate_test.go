@@ -7,8 +7,11 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -216,3 +219,150 @@ func TestBatchMigrationImportStateMultipleRecipes(t *testing.T) {
 		t.Errorf("Expected playbook_count to be 2, got %d", model.PlaybookCount.ValueInt64())
 	}
 }
+
+// TestBatchMigrationImportStateAtomic tests that a failed map conversion leaves no attributes set
+func TestBatchMigrationImportStateAtomic(t *testing.T) {
+	cookbookDir := t.TempDir()
+	outputDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outputDir, "default.yml"), []byte("---\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+
+	// Fail only the recipe_status conversion, after playbooks converted successfully
+	calls := 0
+	withTypesMapValueFrom(t, func(ctx context.Context, elementType attr.Type, elements any) (types.Map, diag.Diagnostics) {
+		calls++
+		if calls == 2 {
+			var diags diag.Diagnostics
+			diags.AddError("conversion failed", "forced")
+			return types.MapNull(elementType), diags
+		}
+		return types.MapValueFrom(ctx, elementType, elements)
+	})
+
+	r := &batchMigrationResource{client: &SousChefClient{Path: "souschef"}}
+	schema := newResourceSchema(t, r)
+	resp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: cookbookDir + "|" + outputDir + "|default"}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error from failed map conversion")
+	}
+
+	if !resp.State.Raw.IsNull() {
+		var model batchMigrationResourceModel
+		resp.State.Get(context.Background(), &model)
+		if !model.ID.IsNull() || !model.CookbookPath.IsNull() || !model.Playbooks.IsNull() {
+			t.Fatalf("expected no attributes to be set, got %+v", model)
+		}
+	}
+}
+
+// TestBatchMigrationImportStateOutputOptions tests that output_path_template and
+// store_content in the import ID are applied as Create applies them
+func TestBatchMigrationImportStateOutputOptions(t *testing.T) {
+	r := &batchMigrationResource{client: &SousChefClient{Path: "souschef"}}
+	content := "---\n- name: default playbook\n"
+
+	tests := map[string]struct {
+		files        []string
+		idSuffix     string
+		wantTemplate types.String
+		wantStore    types.Bool
+		wantEntry    string
+	}{
+		"template": {
+			files:        []string{"default/main.yml"},
+			idSuffix:     "default|{recipe}/main.yml",
+			wantTemplate: types.StringValue("{recipe}/main.yml"),
+			wantStore:    types.BoolNull(),
+			wantEntry:    content,
+		},
+		"yaml template": {
+			files:        []string{"default.yaml"},
+			idSuffix:     "default|{recipe}.yaml",
+			wantTemplate: types.StringValue("{recipe}.yaml"),
+			wantStore:    types.BoolNull(),
+			wantEntry:    content,
+		},
+		"discovered with template": {
+			files:        []string{"default/main.yml", "notes.txt"},
+			idSuffix:     "*|{recipe}/main.yml",
+			wantTemplate: types.StringValue("{recipe}/main.yml"),
+			wantStore:    types.BoolNull(),
+			wantEntry:    content,
+		},
+		"store_content false": {
+			files:        []string{"default.yml"},
+			idSuffix:     "default||false",
+			wantTemplate: types.StringNull(),
+			wantStore:    types.BoolValue(false),
+			wantEntry:    batchPlaybookHashPrefix + contentSHA256([]byte(content)),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cookbookDir := t.TempDir()
+			outputDir := t.TempDir()
+			for _, file := range tt.files {
+				playbookPath := filepath.Join(outputDir, file)
+				if err := os.MkdirAll(filepath.Dir(playbookPath), testDirPermissions); err != nil {
+					t.Fatalf(testFailedToCreateDirectory, err)
+				}
+				if err := os.WriteFile(playbookPath, []byte(content), testFilePermissions); err != nil {
+					t.Fatalf(testFailedToWriteFile, err)
+				}
+			}
+
+			resp := &resource.ImportStateResponse{State: newEmptyState(newResourceSchema(t, r))}
+			r.ImportState(context.Background(), resource.ImportStateRequest{
+				ID: fmt.Sprintf("%s|%s|%s", cookbookDir, outputDir, tt.idSuffix),
+			}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+			}
+
+			var model batchMigrationResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+			if !model.OutputPathTemplate.Equal(tt.wantTemplate) || !model.StoreContent.Equal(tt.wantStore) {
+				t.Fatalf("expected output_path_template %s and store_content %s, got %s and %s",
+					tt.wantTemplate, tt.wantStore, model.OutputPathTemplate, model.StoreContent)
+			}
+			want := types.MapValueMust(types.StringType, map[string]attr.Value{"default": types.StringValue(tt.wantEntry)})
+			if !model.Playbooks.Equal(want) {
+				t.Fatalf("expected playbooks %s, got %s", want, model.Playbooks)
+			}
+		})
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		cookbookDir := t.TempDir()
+		outputDir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(outputDir, "default.yml"), []byte(content), testFilePermissions); err != nil {
+			t.Fatalf(testFailedToWriteFile, err)
+		}
+		resp := &resource.ImportStateResponse{State: newEmptyState(newResourceSchema(t, r))}
+		r.ImportState(context.Background(), resource.ImportStateRequest{
+			ID: fmt.Sprintf(`{"cookbook_path": %q, "output_path": %q, "recipe_names": ["default"], "store_content": false}`, cookbookDir, outputDir),
+		}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+		}
+		var model batchMigrationResourceModel
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &model)...)
+		if !model.StoreContent.Equal(types.BoolValue(false)) {
+			t.Fatalf("expected store_content false, got %s", model.StoreContent)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, idSuffix := range []string{"default|main.yml", "default||maybe"} {
+			resp := &resource.ImportStateResponse{State: newEmptyState(newResourceSchema(t, r))}
+			r.ImportState(context.Background(), resource.ImportStateRequest{
+				ID: fmt.Sprintf("%s|%s|%s", t.TempDir(), t.TempDir(), idSuffix),
+			}, resp)
+			if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != invalidImportIDMsg {
+				t.Fatalf("expected an invalid import ID error for %q, got %v", idSuffix, resp.Diagnostics)
+			}
+		}
+	})
+}
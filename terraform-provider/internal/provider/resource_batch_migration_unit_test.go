@@ -0,0 +1,808 @@
+// Package provider contains unit tests for the batch migration resource.
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestBatchMigrationResourceValidateConfig(t *testing.T) {
+	r := &batchMigrationResource{client: &SousChefClient{Path: "souschef"}}
+	schema := newResourceSchema(t, r)
+	cookbookDir := t.TempDir()
+
+	config := newResourceConfig(t, schema, batchMigrationResourceModel{
+		CookbookPath:        types.StringValue(cookbookDir),
+		OutputPath:          types.StringValue(filepath.Join(cookbookDir, "playbooks")),
+		RecipeNames:         []types.String{types.StringValue("default")},
+		Playbooks:           types.MapNull(types.StringType),
+		RecipeStatus:        types.MapNull(types.StringType),
+		ResolvedRecipeNames: types.ListNull(types.StringType),
+	})
+	resp := &resource.ValidateConfigResponse{}
+	r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for output_path nested in cookbook_path")
+	}
+
+	r.client.AllowNestedOutput = true
+	resp = &resource.ValidateConfigResponse{}
+	r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning when nested output is allowed, got %v", resp.Diagnostics)
+	}
+}
+
+func TestBatchMigrationImportStateDirectory(t *testing.T) {
+	r := &batchMigrationResource{}
+	schema := newResourceSchema(t, r)
+
+	cookbookDir := t.TempDir()
+	outputDir := t.TempDir()
+	for _, name := range []string{"install.yml", "default.yml", "configure.yml", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(outputDir, name), []byte("content"), testFilePermissions); err != nil {
+			t.Fatalf(testFailedToWritePlaybook, err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(outputDir, "roles.yml"), testDirPermissions); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+
+	resp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: cookbookDir + "|" + outputDir + "|*"}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var state batchMigrationResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+
+	got := stringSliceFromTypesList(state.RecipeNames)
+	want := []string{"configure", "default", "install"}
+	verifyStringSliceResult(t, got, want)
+	if state.PlaybookCount.ValueInt64() != int64(len(want)) {
+		t.Fatalf("expected playbook_count %d, got %d", len(want), state.PlaybookCount.ValueInt64())
+	}
+}
+
+func TestBatchMigrationImportStateEmptyDirectory(t *testing.T) {
+	r := &batchMigrationResource{}
+	schema := newResourceSchema(t, r)
+
+	resp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: t.TempDir() + "|" + t.TempDir() + "|*"}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected diagnostics when output_path has no playbooks")
+	}
+}
+
+// createBatchMigration runs Create for recipeNames with the recipe named
+// "broken" forced to fail in the fake CLI.
+func createBatchMigration(t *testing.T, continueOnError types.Bool, recipeNames ...string) *resource.CreateResponse {
+	t.Helper()
+	return createBatchMigrationWithContext(context.Background(), t, continueOnError, recipeNames...)
+}
+
+// createBatchMigrationWithContext is createBatchMigration with a caller-supplied
+// context, e.g. one carrying a test logger.
+func createBatchMigrationWithContext(ctx context.Context, t *testing.T, continueOnError types.Bool, recipeNames ...string) *resource.CreateResponse {
+	t.Helper()
+	t.Setenv("SOUSCHEF_TEST_FAIL_RECIPE", "broken")
+
+	r := &batchMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+
+	names := make([]types.String, len(recipeNames))
+	for i, name := range recipeNames {
+		names[i] = types.StringValue(name)
+	}
+	plan := newPlan(t, schema, batchMigrationResourceModel{
+		CookbookPath:        types.StringValue(testTmpCookbook),
+		OutputPath:          types.StringValue(t.TempDir()),
+		RecipeNames:         names,
+		ContinueOnError:     continueOnError,
+		Playbooks:           types.MapUnknown(types.StringType),
+		RecipeStatus:        types.MapUnknown(types.StringType),
+		ResolvedRecipeNames: types.ListUnknown(types.StringType),
+	})
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	return resp
+}
+
+func TestBatchMigrationCreateFailFastByDefault(t *testing.T) {
+	resp := createBatchMigration(t, types.BoolNull(), "default", "broken")
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected the failing recipe to abort the batch")
+	}
+}
+
+func TestBatchMigrationCreateContinueOnError(t *testing.T) {
+	resp := createBatchMigration(t, types.BoolValue(true), "default", "broken")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() == 0 {
+		t.Fatal("expected a warning for the failed recipe")
+	}
+
+	var state batchMigrationResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+
+	statuses := make(map[string]string)
+	state.RecipeStatus.ElementsAs(context.Background(), &statuses, false)
+	if statuses["default"] != batchRecipeStatusOK || statuses["broken"] != batchRecipeStatusFailed {
+		t.Fatalf("unexpected recipe_status: %v", statuses)
+	}
+	if state.PlaybookCount.ValueInt64() != 1 {
+		t.Fatalf("expected 1 playbook, got %d", state.PlaybookCount.ValueInt64())
+	}
+	if _, ok := state.Playbooks.Elements()["broken"]; ok {
+		t.Fatal("expected no playbook for the failed recipe")
+	}
+}
+
+func TestBatchMigrationCreateContinueOnErrorAllFailed(t *testing.T) {
+	resp := createBatchMigration(t, types.BoolValue(true), "broken")
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error when no recipe converts")
+	}
+}
+
+func TestBatchMigrationCreateLogsProgress(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	resp := createBatchMigrationWithContext(ctx, t, types.BoolValue(true), "default", "broken", "web")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("failed to decode log output: %v", err)
+	}
+
+	messages := make(map[string]map[string]interface{})
+	for _, entry := range entries {
+		if entry["@level"] == "info" {
+			messages[entry["@message"].(string)] = entry
+		}
+	}
+
+	for i, recipe := range []string{"default", "broken", "web"} {
+		if _, ok := messages[fmt.Sprintf("converting %d/3: %s", i+1, recipe)]; !ok {
+			t.Errorf("expected a progress line for %s, got %v", recipe, entries)
+		}
+		done, ok := messages[fmt.Sprintf("converted %d/3: %s", i+1, recipe)]
+		if !ok {
+			t.Errorf("expected a completion line for %s, got %v", recipe, entries)
+			continue
+		}
+		if done["elapsed"] == nil || done["elapsed"] == "" {
+			t.Errorf("expected elapsed time for %s, got %v", recipe, done)
+		}
+	}
+	if got := messages["converted 2/3: broken"]["status"]; got != batchRecipeStatusFailed {
+		t.Errorf("expected failed status for broken recipe, got %v", got)
+	}
+}
+
+// writeRecipeNamesFile writes content to a recipe names file and returns its path.
+func writeRecipeNamesFile(t *testing.T, content string) string {
+	t.Helper()
+
+	filePath := filepath.Join(t.TempDir(), "recipes.txt")
+	if err := os.WriteFile(filePath, []byte(content), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	return filePath
+}
+
+func TestReadRecipeNamesFile(t *testing.T) {
+	filePath := writeRecipeNamesFile(t, "# recipes exported by the inventory tool\ndefault\n\n  web  \n# database\ndatabase\n")
+	got, err := readRecipeNamesFile(filePath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	verifyStringSliceResult(t, got, []string{"default", "web", "database"})
+
+	if _, err := readRecipeNamesFile(writeRecipeNamesFile(t, "# nothing yet\n\n")); err == nil {
+		t.Fatal("expected error for file without recipe names")
+	}
+	if _, err := readRecipeNamesFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Fatal("expected error for missing file")
+	}
+}
+
+func TestBatchMigrationResourceValidateConfigRecipeNames(t *testing.T) {
+	r := &batchMigrationResource{client: &SousChefClient{Path: "souschef"}}
+	schema := newResourceSchema(t, r)
+
+	tests := map[string]struct {
+		recipeNames     []types.String
+		recipeNamesFile types.String
+		wantError       bool
+	}{
+		"recipe_names only":  {recipeNames: []types.String{types.StringValue("default")}},
+		"file only":          {recipeNamesFile: types.StringValue(writeRecipeNamesFile(t, "default\n"))},
+		"neither":            {wantError: true},
+		"empty file":         {recipeNamesFile: types.StringValue(writeRecipeNamesFile(t, "# none\n")), wantError: true},
+		"missing file":       {recipeNamesFile: types.StringValue(filepath.Join(t.TempDir(), "missing.txt")), wantError: true},
+		"unknown file value": {recipeNamesFile: types.StringUnknown()},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := newResourceConfig(t, schema, batchMigrationResourceModel{
+				CookbookPath:        types.StringValue(t.TempDir()),
+				OutputPath:          types.StringValue(t.TempDir()),
+				RecipeNames:         tt.recipeNames,
+				RecipeNamesFile:     tt.recipeNamesFile,
+				ResolvedRecipeNames: types.ListNull(types.StringType),
+				Playbooks:           types.MapNull(types.StringType),
+				RecipeStatus:        types.MapNull(types.StringType),
+			})
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Fatalf("expected error %t, got %v", tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestBatchMigrationCreateRecipeNamesFile(t *testing.T) {
+	r := &batchMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputPath := t.TempDir()
+
+	plan := newPlan(t, schema, batchMigrationResourceModel{
+		CookbookPath:        types.StringValue(testTmpCookbook),
+		OutputPath:          types.StringValue(outputPath),
+		RecipeNames:         []types.String{types.StringValue("default")},
+		RecipeNamesFile:     types.StringValue(writeRecipeNamesFile(t, "# generated\n\nweb\ndefault\n\n# db next\ndatabase\n")),
+		ResolvedRecipeNames: types.ListUnknown(types.StringType),
+		Playbooks:           types.MapUnknown(types.StringType),
+		RecipeStatus:        types.MapUnknown(types.StringType),
+	})
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var state batchMigrationResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	want := []string{"default", "web", "database"}
+	verifyStringSliceResult(t, stateRecipeNames(state), want)
+	if state.PlaybookCount.ValueInt64() != int64(len(want)) {
+		t.Fatalf("expected %d playbooks, got %d", len(want), state.PlaybookCount.ValueInt64())
+	}
+	for _, recipeName := range want {
+		if _, err := os.Stat(filepath.Join(outputPath, recipeName+".yml")); err != nil {
+			t.Fatalf("expected playbook for %s: %v", recipeName, err)
+		}
+	}
+
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: resp.State}, deleteResp)
+	if _, err := os.Stat(filepath.Join(outputPath, "database.yml")); !os.IsNotExist(err) {
+		t.Fatalf("expected playbook from recipe_names_file to be deleted, got %v", err)
+	}
+}
+
+func TestValidateOutputPathTemplate(t *testing.T) {
+	tests := map[string]bool{
+		"{recipe}/main.yml":             true,
+		"playbooks/{recipe}.yaml":       true,
+		"main.yml":                      false,
+		"/srv/{recipe}/main.yml":        false,
+		"../{recipe}/main.yml":          false,
+		"roles/../../{recipe}.yml":      false,
+		"{recipe}/tasks/main.yml":       true,
+		"{recipe}/..{recipe}.yml":       true,
+		"nested/{recipe}/../x/{recipe}": false,
+	}
+	for template, valid := range tests {
+		if err := validateOutputPathTemplate(template); (err == nil) != valid {
+			t.Errorf("validateOutputPathTemplate(%q) = %v, want valid=%v", template, err, valid)
+		}
+	}
+}
+
+func TestBatchMigrationOutputPathTemplate(t *testing.T) {
+	r := &batchMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputDir := t.TempDir()
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, batchMigrationResourceModel{
+		CookbookPath:        types.StringValue(testTmpCookbook),
+		OutputPath:          types.StringValue(outputDir),
+		OutputPathTemplate:  types.StringValue("{recipe}/main.yml"),
+		PruneEmptyDir:       types.BoolValue(true),
+		RecipeNames:         []types.String{types.StringValue("default"), types.StringValue("server")},
+		Playbooks:           types.MapUnknown(types.StringType),
+		RecipeStatus:        types.MapUnknown(types.StringType),
+		ResolvedRecipeNames: types.ListUnknown(types.StringType),
+	})}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+
+	for _, recipeName := range []string{"default", "server"} {
+		if _, err := os.Stat(filepath.Join(outputDir, recipeName, "main.yml")); err != nil {
+			t.Fatalf("expected nested playbook for %s: %v", recipeName, err)
+		}
+		if _, err := os.Stat(filepath.Join(outputDir, recipeName, recipeName+".yml")); !os.IsNotExist(err) {
+			t.Fatalf("expected %s.yml to be renamed, got %v", recipeName, err)
+		}
+	}
+
+	// Read finds the nested playbooks
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() || readResp.State.Raw.IsNull() {
+		t.Fatalf("expected Read to keep the resource, got %v", readResp.Diagnostics)
+	}
+	var state batchMigrationResourceModel
+	readResp.State.Get(context.Background(), &state)
+	if state.PlaybookCount.ValueInt64() != 2 {
+		t.Fatalf("expected 2 playbooks, got %d", state.PlaybookCount.ValueInt64())
+	}
+
+	// Delete removes the playbooks and prunes the per-recipe directories
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+	}
+	if _, err := os.Stat(outputDir); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be pruned, got %v", outputDir, err)
+	}
+}
+
+func TestBatchMigrationValidateConfigOutputPathTemplate(t *testing.T) {
+	r := &batchMigrationResource{client: &SousChefClient{Path: "souschef"}}
+	schema := newResourceSchema(t, r)
+
+	config := newResourceConfig(t, schema, batchMigrationResourceModel{
+		CookbookPath:        types.StringValue(t.TempDir()),
+		OutputPath:          types.StringValue(t.TempDir()),
+		OutputPathTemplate:  types.StringValue("main.yml"),
+		RecipeNames:         []types.String{types.StringValue("default")},
+		Playbooks:           types.MapNull(types.StringType),
+		RecipeStatus:        types.MapNull(types.StringType),
+		ResolvedRecipeNames: types.ListNull(types.StringType),
+	})
+	resp := &resource.ValidateConfigResponse{}
+	r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for a template without {recipe}")
+	}
+}
+
+func TestBatchMigrationValidateConfigDuplicateRecipeNames(t *testing.T) {
+	r := &batchMigrationResource{client: &SousChefClient{Path: "souschef"}}
+	schema := newResourceSchema(t, r)
+
+	tests := map[string]struct {
+		recipeNames []string
+		wantErr     bool
+	}{
+		"duplicate": {recipeNames: []string{"default", "default"}, wantErr: true},
+		"unique":    {recipeNames: []string{"default", "server"}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			names := make([]types.String, len(tt.recipeNames))
+			for i, recipeName := range tt.recipeNames {
+				names[i] = types.StringValue(recipeName)
+			}
+			config := newResourceConfig(t, schema, batchMigrationResourceModel{
+				CookbookPath:        types.StringValue(t.TempDir()),
+				OutputPath:          types.StringValue(t.TempDir()),
+				RecipeNames:         names,
+				Playbooks:           types.MapNull(types.StringType),
+				RecipeStatus:        types.MapNull(types.StringType),
+				ResolvedRecipeNames: types.ListNull(types.StringType),
+			})
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, resp.Diagnostics)
+			}
+			if tt.wantErr && !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), `"default"`) {
+				t.Fatalf("expected the duplicate name in the diagnostic, got %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestBatchMigrationCreateRecipesSubdir(t *testing.T) {
+	r := &batchMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputPath := t.TempDir()
+
+	plan := newPlan(t, schema, batchMigrationResourceModel{
+		CookbookPath:        types.StringValue(newPolicyfileCookbook(t, "default", "web")),
+		OutputPath:          types.StringValue(outputPath),
+		RecipesSubdir:       types.StringValue("policy/recipes"),
+		RecipeNames:         []types.String{types.StringValue("default"), types.StringValue("web")},
+		ResolvedRecipeNames: types.ListUnknown(types.StringType),
+		Playbooks:           types.MapUnknown(types.StringType),
+		RecipeStatus:        types.MapUnknown(types.StringType),
+	})
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+	for _, recipeName := range []string{"default", "web"} {
+		if _, err := os.Stat(filepath.Join(outputPath, recipeName+".yml")); err != nil {
+			t.Fatalf("expected playbook for %s: %v", recipeName, err)
+		}
+	}
+
+	config := newResourceConfig(t, schema, batchMigrationResourceModel{
+		CookbookPath:        types.StringValue(t.TempDir()),
+		OutputPath:          types.StringValue(t.TempDir()),
+		RecipesSubdir:       types.StringValue("../recipes"),
+		RecipeNames:         []types.String{types.StringValue("default")},
+		Playbooks:           types.MapNull(types.StringType),
+		RecipeStatus:        types.MapNull(types.StringType),
+		ResolvedRecipeNames: types.ListNull(types.StringType),
+	})
+	validateResp := &resource.ValidateConfigResponse{}
+	r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, validateResp)
+	if !validateResp.Diagnostics.HasError() {
+		t.Fatal("expected error for recipes_subdir outside the cookbook")
+	}
+}
+
+func TestBatchMigrationStoreContent(t *testing.T) {
+	t.Setenv("SOUSCHEF_TEST_PAD_BYTES", "4096")
+	r := &batchMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+
+	create := func(t *testing.T, outputPath string, storeContent types.Bool) tfsdk.State {
+		t.Helper()
+		resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+		r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, batchMigrationResourceModel{
+			CookbookPath:        types.StringValue(testTmpCookbook),
+			OutputPath:          types.StringValue(outputPath),
+			StoreContent:        storeContent,
+			RecipeNames:         []types.String{types.StringValue("default"), types.StringValue("web")},
+			ResolvedRecipeNames: types.ListUnknown(types.StringType),
+			Playbooks:           types.MapUnknown(types.StringType),
+			RecipeStatus:        types.MapUnknown(types.StringType),
+		})}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+		}
+		return resp.State
+	}
+	playbooks := func(t *testing.T, state tfsdk.State) map[string]string {
+		t.Helper()
+		var model batchMigrationResourceModel
+		if diags := state.Get(context.Background(), &model); diags.HasError() {
+			t.Fatalf("failed to read state: %v", diags)
+		}
+		values := map[string]string{}
+		model.Playbooks.ElementsAs(context.Background(), &values, false)
+		return values
+	}
+
+	contentState := create(t, t.TempDir(), types.BoolNull())
+	hashOutput := t.TempDir()
+	hashState := create(t, hashOutput, types.BoolValue(false))
+
+	// Without content, state holds a fixed-size hash per playbook
+	if len(hashState.Raw.String()) >= len(contentState.Raw.String())/4 {
+		t.Fatalf("expected a much smaller state without content: %d vs %d bytes", len(hashState.Raw.String()), len(contentState.Raw.String()))
+	}
+	stored := playbooks(t, hashState)
+	content, err := os.ReadFile(filepath.Join(hashOutput, "web.yml"))
+	if err != nil {
+		t.Fatalf("failed to read playbook: %v", err)
+	}
+	if want := batchPlaybookHashPrefix + contentSHA256(content); stored["web"] != want {
+		t.Fatalf("expected playbooks[web] = %q, got %q", want, stored["web"])
+	}
+	if strings.Contains(playbooks(t, contentState)["web"], batchPlaybookHashPrefix) {
+		t.Fatal("expected content to be stored by default")
+	}
+
+	// Read replaces the hash of an edited playbook so drift is still detected
+	edited := "recipe: web\nedited: true\n"
+	if err := os.WriteFile(filepath.Join(hashOutput, "web.yml"), []byte(edited), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	readResp := &resource.ReadResponse{State: hashState}
+	r.Read(context.Background(), resource.ReadRequest{State: hashState}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	refreshed := playbooks(t, readResp.State)
+	if refreshed["web"] != batchPlaybookHashPrefix+contentSHA256([]byte(edited)) {
+		t.Fatalf("expected Read to store the edited playbook's hash, got %q", refreshed["web"])
+	}
+	if refreshed["default"] != stored["default"] {
+		t.Fatalf("expected unchanged playbook to keep its hash, got %q", refreshed["default"])
+	}
+}
+
+func TestBatchMigrationCreateManyRecipes(t *testing.T) {
+	recipeNames := make([]string, 3*maxBatchConversionWorkers+1)
+	for i := range recipeNames {
+		recipeNames[i] = fmt.Sprintf("recipe_%02d", i)
+	}
+	resp := createBatchMigration(t, types.BoolNull(), recipeNames...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var state batchMigrationResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	playbooks := make(map[string]string)
+	state.Playbooks.ElementsAs(context.Background(), &playbooks, false)
+	if len(playbooks) != len(recipeNames) {
+		t.Fatalf("expected %d playbooks, got %v", len(recipeNames), playbooks)
+	}
+	for _, recipeName := range recipeNames {
+		if got := strings.TrimSpace(playbooks[recipeName]); got != "recipe: "+recipeName {
+			t.Errorf("unexpected playbook for %s: %q", recipeName, got)
+		}
+	}
+}
+
+func TestMergeBatchResults(t *testing.T) {
+	recipeNames := []string{"default", "broken", "web"}
+	failure := diag.Diagnostics{diag.NewErrorDiagnostic("Error converting recipe", "broken")}
+	results := []batchRecipeResult{
+		{content: "recipe: default"},
+		{diags: failure},
+		{content: "recipe: web"},
+	}
+
+	t.Run("continue on error", func(t *testing.T) {
+		var diags diag.Diagnostics
+		playbooks, statuses := mergeBatchResults(recipeNames, results, true, &diags)
+		if diags.HasError() || diags.WarningsCount() != 1 {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		want := map[string]string{"default": "recipe: default", "web": "recipe: web"}
+		if fmt.Sprint(playbooks) != fmt.Sprint(want) {
+			t.Fatalf("unexpected playbooks: %v", playbooks)
+		}
+		if statuses["broken"] != batchRecipeStatusFailed || len(statuses) != len(recipeNames) {
+			t.Fatalf("unexpected statuses: %v", statuses)
+		}
+	})
+
+	t.Run("fail fast with skipped recipes", func(t *testing.T) {
+		var diags diag.Diagnostics
+		skipped := []batchRecipeResult{results[0], results[1], {skipped: true}}
+		if playbooks, _ := mergeBatchResults(recipeNames, skipped, false, &diags); playbooks != nil || !diags.HasError() {
+			t.Fatalf("expected the batch to fail, got %v %v", playbooks, diags)
+		}
+	})
+
+	t.Run("skipped recipe under continue on error", func(t *testing.T) {
+		var diags diag.Diagnostics
+		skipped := []batchRecipeResult{results[0], results[1], {skipped: true}}
+		if playbooks, _ := mergeBatchResults(recipeNames, skipped, true, &diags); playbooks != nil || !diags.HasError() {
+			t.Fatalf("expected the inconsistency guard to fail, got %v %v", playbooks, diags)
+		}
+	})
+}
+
+func TestCheckBatchResults(t *testing.T) {
+	recipeNames := []string{"default", "web"}
+	tests := map[string]struct {
+		playbooks map[string]string
+		statuses  map[string]string
+		wantError bool
+	}{
+		"consistent": {
+			playbooks: map[string]string{"default": "a"},
+			statuses:  map[string]string{"default": batchRecipeStatusOK, "web": batchRecipeStatusFailed},
+		},
+		"missing status": {
+			playbooks: map[string]string{"default": "a"},
+			statuses:  map[string]string{"default": batchRecipeStatusOK},
+			wantError: true,
+		},
+		"unrequested recipe": {
+			playbooks: map[string]string{"default": "a", "web": "b"},
+			statuses:  map[string]string{"default": batchRecipeStatusOK, "other": batchRecipeStatusOK},
+			wantError: true,
+		},
+		"playbook for failed recipe": {
+			playbooks: map[string]string{"default": "a", "web": "b"},
+			statuses:  map[string]string{"default": batchRecipeStatusOK, "web": batchRecipeStatusFailed},
+			wantError: true,
+		},
+		"extra playbook": {
+			playbooks: map[string]string{"default": "a", "other": "b"},
+			statuses:  map[string]string{"default": batchRecipeStatusOK, "web": batchRecipeStatusFailed},
+			wantError: true,
+		},
+	}
+	for name, tt := range tests {
+		if err := checkBatchResults(recipeNames, tt.playbooks, tt.statuses); (err != nil) != tt.wantError {
+			t.Errorf("%s: expected error %v, got %v", name, tt.wantError, err)
+		}
+	}
+}
+
+func TestBatchMigrationValidateConfigMerge(t *testing.T) {
+	r := &batchMigrationResource{client: &SousChefClient{Path: "souschef"}}
+	schema := newResourceSchema(t, r)
+
+	tests := map[string]struct {
+		model   batchMigrationResourceModel
+		wantErr bool
+	}{
+		"valid":                {model: batchMigrationResourceModel{OutputFilename: types.StringValue("site.yml")}},
+		"missing filename":     {wantErr: true},
+		"filename with slash":  {model: batchMigrationResourceModel{OutputFilename: types.StringValue("plays/site.yml")}, wantErr: true},
+		"with continue":        {model: batchMigrationResourceModel{OutputFilename: types.StringValue("site.yml"), ContinueOnError: types.BoolValue(true)}, wantErr: true},
+		"with output template": {model: batchMigrationResourceModel{OutputFilename: types.StringValue("site.yml"), OutputPathTemplate: types.StringValue("{recipe}.yml")}, wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			model := tt.model
+			model.CookbookPath = types.StringValue(t.TempDir())
+			model.OutputPath = types.StringValue(t.TempDir())
+			model.RecipeNames = []types.String{types.StringValue("default")}
+			model.Merge = types.BoolValue(true)
+			model.Playbooks = types.MapNull(types.StringType)
+			model.RecipeStatus = types.MapNull(types.StringType)
+			model.ResolvedRecipeNames = types.ListNull(types.StringType)
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: newResourceConfig(t, schema, model)}, resp)
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestBatchMigrationMerge(t *testing.T) {
+	r := &batchMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputDir := t.TempDir()
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, batchMigrationResourceModel{
+		CookbookPath:        types.StringValue(testTmpCookbook),
+		OutputPath:          types.StringValue(outputDir),
+		RecipeNames:         []types.String{types.StringValue("default"), types.StringValue("web")},
+		Merge:               types.BoolValue(true),
+		OutputFilename:      types.StringValue("site.yml"),
+		Playbooks:           types.MapUnknown(types.StringType),
+		RecipeStatus:        types.MapUnknown(types.StringType),
+		ResolvedRecipeNames: types.ListUnknown(types.StringType),
+	})}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+
+	var state batchMigrationResourceModel
+	if diags := createResp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.PlaybookContent.ValueString() != "recipe: default\nrecipe: web\n" || state.PlaybookCount.ValueInt64() != 1 || !state.Playbooks.IsNull() {
+		t.Fatalf("unexpected merged state: %+v", state)
+	}
+	statuses := make(map[string]string)
+	state.RecipeStatus.ElementsAs(context.Background(), &statuses, false)
+	if statuses["default"] != batchRecipeStatusOK || statuses["web"] != batchRecipeStatusOK {
+		t.Fatalf("unexpected recipe_status %v", statuses)
+	}
+
+	// Read picks up edits to the merged playbook
+	playbookPath := filepath.Join(outputDir, "site.yml")
+	if err := os.WriteFile(playbookPath, []byte("edited\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	var refreshed batchMigrationResourceModel
+	readResp.State.Get(context.Background(), &refreshed)
+	if refreshed.PlaybookContent.ValueString() != "edited\n" {
+		t.Fatalf("expected Read to refresh playbook_content, got %q", refreshed.PlaybookContent.ValueString())
+	}
+
+	// Delete removes the merged playbook, after which Read drops the resource
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+	}
+	if _, err := os.Stat(playbookPath); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be deleted, got %v", playbookPath, err)
+	}
+	r.Read(context.Background(), resource.ReadRequest{State: readResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Fatal("expected resource to be removed when the merged playbook is missing")
+	}
+}
+
+func TestBatchMigrationDryRun(t *testing.T) {
+	r := &batchMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t), DryRun: true}}
+	schema := newResourceSchema(t, r)
+
+	for _, merge := range []bool{false, true} {
+		outputDir := filepath.Join(t.TempDir(), "out")
+		createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+		r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, batchMigrationResourceModel{
+			CookbookPath:        types.StringValue(testTmpCookbook),
+			OutputPath:          types.StringValue(outputDir),
+			RecipeNames:         []types.String{types.StringValue("default"), types.StringValue("web")},
+			Merge:               types.BoolValue(merge),
+			OutputFilename:      types.StringValue("site.yml"),
+			Playbooks:           types.MapUnknown(types.StringType),
+			RecipeStatus:        types.MapUnknown(types.StringType),
+			ResolvedRecipeNames: types.ListUnknown(types.StringType),
+		})}, createResp)
+		if createResp.Diagnostics.HasError() {
+			t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+		}
+		if _, err := os.Stat(outputDir); !os.IsNotExist(err) {
+			t.Fatalf("merge=%t: expected nothing written to %s in dry-run mode, got %v", merge, outputDir, err)
+		}
+
+		var state batchMigrationResourceModel
+		createResp.State.Get(context.Background(), &state)
+		if merge {
+			if state.PlaybookContent.ValueString() != "recipe: default\nrecipe: web\n" {
+				t.Fatalf("expected the merged playbook from stdout, got %q", state.PlaybookContent.ValueString())
+			}
+			continue
+		}
+		playbooks := make(map[string]string)
+		state.Playbooks.ElementsAs(context.Background(), &playbooks, false)
+		if playbooks["default"] != "recipe: default\n" || playbooks["web"] != "recipe: web\n" {
+			t.Fatalf("expected playbooks from stdout, got %v", playbooks)
+		}
+
+		// Read keeps the resource and Delete succeeds without touching disk
+		readResp := &resource.ReadResponse{State: createResp.State}
+		r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+		if readResp.Diagnostics.HasError() || readResp.State.Raw.IsNull() {
+			t.Fatalf("expected Read to keep the resource, got %v", readResp.Diagnostics)
+		}
+		deleteResp := &resource.DeleteResponse{}
+		r.Delete(context.Background(), resource.DeleteRequest{State: createResp.State}, deleteResp)
+		if deleteResp.Diagnostics.HasError() {
+			t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+		}
+	}
+}
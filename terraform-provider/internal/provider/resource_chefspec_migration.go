@@ -0,0 +1,366 @@
+// Package provider implements the SousChef Terraform provider resources
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	chefspecFormatPytest   = "pytest"
+	chefspecFormatMolecule = "molecule"
+	chefspecSpecSuffix     = "_spec.rb"
+	chefspecVerifyFilename = "verify.yml"
+	chefspecIDFormat       = "chefspec-%s-%s"
+	errReadingChefSpecTest = "Error reading converted ChefSpec tests"
+)
+
+// chefspecExamplePattern matches a ChefSpec example, either a block
+// (`it 'installs nginx' do`) or a one-liner (`it { is_expected.to ... }`).
+var chefspecExamplePattern = regexp.MustCompile(`^\s*it[\s({'"]`)
+
+// countChefSpecExamples returns the number of `it` examples in a ChefSpec file;
+// each becomes one generated assertion.
+func countChefSpecExamples(content string) int {
+	count := 0
+	for _, line := range strings.Split(content, "\n") {
+		if chefspecExamplePattern.MatchString(line) {
+			count++
+		}
+	}
+	return count
+}
+
+// chefspecOutputFormat returns the configured output format, defaulting to pytest.
+func chefspecOutputFormat(outputFormat types.String) string {
+	if outputFormat.IsNull() || outputFormat.IsUnknown() || outputFormat.ValueString() == "" {
+		return chefspecFormatPytest
+	}
+	return outputFormat.ValueString()
+}
+
+// chefspecSpecName returns the spec name used for the generated file and the
+// resource ID, e.g. "web" for web_spec.rb.
+func chefspecSpecName(specPath string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(filepath.Base(specPath), chefspecSpecSuffix), filepath.Ext(specPath))
+}
+
+// chefspecTestFilename returns the file the CLI writes for outputFormat:
+// test_<spec>.py for pytest, or the Molecule verifier playbook verify.yml.
+func chefspecTestFilename(specPath, outputFormat string) string {
+	if outputFormat == chefspecFormatMolecule {
+		return chefspecVerifyFilename
+	}
+	return "test_" + chefspecSpecName(specPath) + ".py"
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource                   = &chefspecMigrationResource{}
+	_ resource.ResourceWithImportState    = &chefspecMigrationResource{}
+	_ resource.ResourceWithValidateConfig = &chefspecMigrationResource{}
+)
+
+// NewChefSpecMigrationResource creates a new ChefSpec migration resource
+func NewChefSpecMigrationResource() resource.Resource {
+	return &chefspecMigrationResource{}
+}
+
+// chefspecMigrationResource is the resource implementation
+type chefspecMigrationResource struct {
+	client *SousChefClient
+}
+
+// chefspecMigrationResourceModel describes the resource data model
+type chefspecMigrationResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	SpecPath           types.String `tfsdk:"spec_path"`
+	OutputPath         types.String `tfsdk:"output_path"`
+	OutputFormat       types.String `tfsdk:"output_format"`
+	CreateOutputDir    types.Bool   `tfsdk:"create_output_dir"`
+	PruneEmptyDir      types.Bool   `tfsdk:"prune_empty_dir"`
+	RetainOnDelete     types.Bool   `tfsdk:"retain_on_delete"`
+	ResolvedOutputPath types.String `tfsdk:"resolved_output_path"`
+	ExampleCount       types.Int64  `tfsdk:"example_count"`
+	TestContent        types.String `tfsdk:"test_content"`
+}
+
+// Metadata returns the resource type name
+func (r *chefspecMigrationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_chefspec_migration"
+}
+
+// Schema defines the schema for the resource
+func (r *chefspecMigrationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages conversion of ChefSpec unit tests to pytest tests or Molecule verifier assertions.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier for the ChefSpec migration",
+			},
+			"spec_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the ChefSpec file, e.g. `spec/default_spec.rb`",
+			},
+			"output_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Directory where the converted tests will be written",
+			},
+			"output_format": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Output format: `pytest` writes `test_<spec>.py`, `molecule` writes a `verify.yml` playbook (default: pytest)",
+			},
+			"create_output_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Create `output_path` when it does not exist (default: true). When false the directory must already exist, e.g. when it is pre-created with specific ownership and permissions",
+			},
+			"prune_empty_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove the output directory on destroy when no other files remain in it (default: false)",
+			},
+			"retain_on_delete": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Leave the generated files in place on destroy and only remove the resource from state, e.g. once they are committed to a repository (default: false)",
+			},
+			"resolved_output_path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Directory the tests are written to: `output_path` joined onto the provider `output_root` when `output_path` is relative",
+			},
+			"example_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of ChefSpec `it` examples converted",
+			},
+			"test_content": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Generated test content",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *chefspecMigrationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = configureResource(req, resp)
+}
+
+// ValidateConfig checks output_format is "pytest" or "molecule"
+func (r *chefspecMigrationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var outputFormat types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("output_format"), &outputFormat)...)
+	if resp.Diagnostics.HasError() || outputFormat.IsNull() || outputFormat.IsUnknown() {
+		return
+	}
+
+	format := outputFormat.ValueString()
+	if format != chefspecFormatPytest && format != chefspecFormatMolecule {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("output_format"),
+			"Invalid output format",
+			fmt.Sprintf("output_format must be %q or %q, got %q", chefspecFormatPytest, chefspecFormatMolecule, format),
+		)
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *chefspecMigrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan chefspecMigrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create output directory
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
+		return
+	}
+	existing := snapshotOutputs(r.client.resolveOutputPath(plan.OutputPath.ValueString()))
+
+	// Execute conversion and set state
+	r.executeChefSpecConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Don't leave an untracked test file behind if the apply was interrupted
+	testFilePath := filepath.Join(plan.ResolvedOutputPath.ValueString(), chefspecTestFilename(plan.SpecPath.ValueString(), chefspecOutputFormat(plan.OutputFormat)))
+	if cleanupIfCanceled(ctx, &resp.Diagnostics, existing, testFilePath) {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *chefspecMigrationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state chefspecMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	testFilePath := filepath.Join(outputPath, chefspecTestFilename(state.SpecPath.ValueString(), chefspecOutputFormat(state.OutputFormat)))
+
+	// Check if file exists and read content
+	if !readFileAndSetState(
+		ctx,
+		testFilePath,
+		"test_content",
+		func(content string) { state.TestContent = types.StringValue(content) },
+		errReadingChefSpecTest,
+		&resp.Diagnostics,
+		r.client.missingArtifactHandler("converted ChefSpec tests "+testFilePath, &resp.State, &resp.Diagnostics),
+	) {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *chefspecMigrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan chefspecMigrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check the output directory, creating it unless create_output_dir is false
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
+		return
+	}
+
+	// Execute conversion and set state
+	r.executeChefSpecConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *chefspecMigrationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state chefspecMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	if r.client.retainGeneratedFiles(state.RetainOnDelete, outputPath, &resp.Diagnostics) {
+		return
+	}
+	testFilePath := filepath.Join(outputPath, chefspecTestFilename(state.SpecPath.ValueString(), chefspecOutputFormat(state.OutputFormat)))
+	deleteGeneratedFile(testFilePath, "converted ChefSpec tests", &resp.Diagnostics)
+	pruneEmptyDir(state.PruneEmptyDir, outputPath, &resp.Diagnostics)
+}
+
+// executeChefSpecConversion is a helper that encapsulates the common logic for Create and Update.
+// It executes the ChefSpec conversion, reads the output, and updates the model state.
+func (r *chefspecMigrationResource) executeChefSpecConversion(ctx context.Context, model *chefspecMigrationResourceModel, diagnostics *diag.Diagnostics) {
+	specPath := model.SpecPath.ValueString()
+	outputPath := r.client.resolveOutputPath(model.OutputPath.ValueString())
+	outputFormat := chefspecOutputFormat(model.OutputFormat)
+	id := r.client.resourceID(fmt.Sprintf(chefspecIDFormat, chefspecSpecName(specPath), outputFormat), specPath)
+	ctx = withReportResourceID(ctx, id)
+
+	specContent := readGeneratedFile(specPath, "Error reading ChefSpec file", diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+
+	// Call souschef CLI to convert the ChefSpec file
+	args := []string{"convert-chefspec", "--spec-path", specPath, "--output-path", outputPath, "--format", outputFormat}
+	content, ok := executeConversion(ctx, r.client, args, filepath.Join(outputPath, chefspecTestFilename(specPath, outputFormat)), errReadingChefSpecTest, diagnostics)
+	if !ok {
+		return
+	}
+
+	// Set state
+	model.ID = types.StringValue(id)
+	model.ResolvedOutputPath = types.StringValue(outputPath)
+	model.ExampleCount = types.Int64Value(int64(countChefSpecExamples(specContent)))
+	model.TestContent = types.StringValue(content)
+}
+
+// ImportState imports an existing resource into Terraform
+func (r *chefspecMigrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format: spec_path|output_path|format (format is optional)
+	parts, err := importIDParts(req.ID, "spec_path", "output_path", "format")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+	if len(parts) < 2 || len(parts) > 3 {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID must be in format: spec_path|output_path or spec_path|output_path|format",
+		)
+		return
+	}
+
+	specPath := parts[0]
+	configuredOutputPath := parts[1]
+	outputPath := r.client.resolveOutputPath(configuredOutputPath)
+	configuredFormat := types.StringNull()
+	if len(parts) == 3 && parts[2] != "" {
+		configuredFormat = types.StringValue(parts[2])
+	}
+	outputFormat := chefspecOutputFormat(configuredFormat)
+	if outputFormat != chefspecFormatPytest && outputFormat != chefspecFormatMolecule {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("format must be %q or %q, got %q", chefspecFormatPytest, chefspecFormatMolecule, outputFormat),
+		)
+		return
+	}
+
+	// Validate that the ChefSpec file exists
+	if !checkFileExists(specPath, "ChefSpec file", &resp.Diagnostics) {
+		return
+	}
+	specContent := readGeneratedFile(specPath, "Error reading ChefSpec file", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check if the converted tests exist
+	testFilePath := filepath.Join(outputPath, chefspecTestFilename(specPath, outputFormat))
+	if !checkFileExists(testFilePath, "Converted ChefSpec tests", &resp.Diagnostics) {
+		return
+	}
+
+	// Read test content
+	content := readGeneratedFile(testFilePath, errReadingChefSpecTest, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("spec_path"), specPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_path"), configuredOutputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_format"), configuredFormat)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resolved_output_path"), outputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("example_count"), int64(countChefSpecExamples(specContent)))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("test_content"), content)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), r.client.resourceID(fmt.Sprintf(chefspecIDFormat, chefspecSpecName(specPath), outputFormat), specPath))...)
+}
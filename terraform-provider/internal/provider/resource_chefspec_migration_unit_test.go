@@ -0,0 +1,212 @@
+// Package provider contains unit tests for the ChefSpec migration resource.
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testChefSpecPath is a ChefSpec file with five examples.
+var testChefSpecPath = filepath.Join(getFixturePath("chefspec"), "web_spec.rb")
+
+func TestCountChefSpecExamples(t *testing.T) {
+	tests := map[string]int{
+		"it 'installs nginx' do\nend\n":              1,
+		"  it { is_expected.to start_service('x') }": 1,
+		"  it(\"works\") do\n  end\n  it \"too\"\n":  2,
+		"describe 'x' do\n  item = 1\n  it_behaves":  0,
+	}
+	for content, want := range tests {
+		if got := countChefSpecExamples(content); got != want {
+			t.Errorf("countChefSpecExamples(%q) = %d, want %d", content, got, want)
+		}
+	}
+}
+
+func TestChefSpecTestFilename(t *testing.T) {
+	if got := chefspecTestFilename("/spec/web_spec.rb", chefspecFormatPytest); got != "test_web.py" {
+		t.Errorf("unexpected pytest filename %q", got)
+	}
+	if got := chefspecTestFilename("/spec/web_spec.rb", chefspecFormatMolecule); got != chefspecVerifyFilename {
+		t.Errorf("unexpected molecule filename %q", got)
+	}
+}
+
+func TestChefSpecMigrationLifecycle(t *testing.T) {
+	r := &chefspecMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputDir := t.TempDir()
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, chefspecMigrationResourceModel{
+		SpecPath:   types.StringValue(testChefSpecPath),
+		OutputPath: types.StringValue(outputDir),
+	})}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+
+	var state chefspecMigrationResourceModel
+	if diags := createResp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.ID.ValueString() != "chefspec-web-pytest" {
+		t.Fatalf("unexpected id %q", state.ID.ValueString())
+	}
+	if state.ExampleCount.ValueInt64() != 5 {
+		t.Fatalf("expected 5 examples, got %d", state.ExampleCount.ValueInt64())
+	}
+	if !strings.Contains(state.TestContent.ValueString(), "def test_") {
+		t.Fatalf("unexpected test_content %q", state.TestContent.ValueString())
+	}
+
+	// Read picks up edits to the generated file
+	testPath := filepath.Join(outputDir, "test_web.py")
+	if err := os.WriteFile(testPath, []byte("def test_edited(host):\n    pass\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	var refreshed chefspecMigrationResourceModel
+	readResp.State.Get(context.Background(), &refreshed)
+	if !strings.Contains(refreshed.TestContent.ValueString(), "test_edited") {
+		t.Fatalf("expected Read to refresh test_content, got %q", refreshed.TestContent.ValueString())
+	}
+
+	// ImportState reconstructs the same resource
+	importResp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: testChefSpecPath + "|" + outputDir}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, importResp.Diagnostics)
+	}
+	var imported chefspecMigrationResourceModel
+	importResp.State.Get(context.Background(), &imported)
+	if imported.ID.ValueString() != state.ID.ValueString() || imported.ExampleCount.ValueInt64() != 5 || !imported.OutputFormat.IsNull() {
+		t.Fatalf("unexpected imported state: %+v", imported)
+	}
+
+	// Delete removes the generated tests, after which Read drops the resource
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+	}
+	if _, err := os.Stat(testPath); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be deleted, got %v", testPath, err)
+	}
+
+	readResp = &resource.ReadResponse{State: readResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: readResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Fatal("expected resource to be removed when the generated tests are missing")
+	}
+}
+
+func TestChefSpecMigrationMoleculeFormat(t *testing.T) {
+	r := &chefspecMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputDir := t.TempDir()
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, chefspecMigrationResourceModel{
+		SpecPath:     types.StringValue(testChefSpecPath),
+		OutputPath:   types.StringValue(outputDir),
+		OutputFormat: types.StringValue(chefspecFormatMolecule),
+	})}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+
+	var state chefspecMigrationResourceModel
+	createResp.State.Get(context.Background(), &state)
+	if state.ID.ValueString() != "chefspec-web-molecule" || !strings.Contains(state.TestContent.ValueString(), "Verify") {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+
+	importResp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: testChefSpecPath + "|" + outputDir + "|molecule"}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, importResp.Diagnostics)
+	}
+	var imported chefspecMigrationResourceModel
+	importResp.State.Get(context.Background(), &imported)
+	if imported.OutputFormat.ValueString() != chefspecFormatMolecule || imported.TestContent.ValueString() != state.TestContent.ValueString() {
+		t.Fatalf("unexpected imported state: %+v", imported)
+	}
+}
+
+func TestChefSpecMigrationValidateConfig(t *testing.T) {
+	r := &chefspecMigrationResource{}
+	schema := newResourceSchema(t, r)
+
+	for format, wantError := range map[string]bool{"pytest": false, "molecule": false, "testinfra": true} {
+		resp := &resource.ValidateConfigResponse{}
+		r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: newResourceConfig(t, schema, chefspecMigrationResourceModel{
+			SpecPath:     types.StringValue(testChefSpecPath),
+			OutputPath:   types.StringValue(t.TempDir()),
+			OutputFormat: types.StringValue(format),
+		})}, resp)
+		if resp.Diagnostics.HasError() != wantError {
+			t.Errorf("output_format %q: expected error %v, got %v", format, wantError, resp.Diagnostics)
+		}
+	}
+}
+
+func TestChefSpecMigrationCreateErrors(t *testing.T) {
+	r := &chefspecMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+
+	tests := map[string]struct {
+		specPath string
+		failCLI  bool
+	}{
+		"missing spec file": {specPath: filepath.Join(t.TempDir(), "web_spec.rb")},
+		"CLI failure":       {specPath: testChefSpecPath, failCLI: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tt.failCLI {
+				t.Setenv("SOUSCHEF_TEST_FAIL", "convert-chefspec")
+			}
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+			r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, chefspecMigrationResourceModel{
+				SpecPath:   types.StringValue(tt.specPath),
+				OutputPath: types.StringValue(t.TempDir()),
+			})}, resp)
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestChefSpecMigrationImportStateErrors(t *testing.T) {
+	r := &chefspecMigrationResource{}
+	schema := newResourceSchema(t, r)
+
+	for _, id := range []string{
+		testChefSpecPath,
+		testChefSpecPath + "|" + t.TempDir() + "|serverspec",
+		filepath.Join(t.TempDir(), "web_spec.rb") + "|" + t.TempDir(),
+		testChefSpecPath + "|" + t.TempDir(),
+	} {
+		resp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+		r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, resp)
+		if !resp.Diagnostics.HasError() {
+			t.Fatalf("expected error importing %q", id)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+// Package provider implements the SousChef Terraform provider resources
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	complianceFormatGitHubActions = "github_actions"
+	complianceFormatGitLabCI      = "gitlab_ci"
+	complianceGitLabCISuffix      = ".gitlab-ci.yml"
+	complianceIDFormat            = "compliance-%s-%s"
+	errReadingComplianceScan      = "Error reading converted compliance scan"
+)
+
+// complianceOutputFormat returns the configured output format, defaulting to github_actions.
+func complianceOutputFormat(outputFormat types.String) string {
+	if outputFormat.IsNull() || outputFormat.IsUnknown() || outputFormat.ValueString() == "" {
+		return complianceFormatGitHubActions
+	}
+	return outputFormat.ValueString()
+}
+
+// complianceScanName returns the scan name used for the generated file and
+// the resource ID, e.g. "linux-baseline" for linux-baseline.json.
+func complianceScanName(compliancePath string) string {
+	return strings.TrimSuffix(filepath.Base(compliancePath), filepath.Ext(compliancePath))
+}
+
+// complianceScanFilename returns the pipeline file the CLI writes for
+// outputFormat: a <scan>.yml workflow for GitHub Actions, or an includable
+// <scan>.gitlab-ci.yml for GitLab CI.
+func complianceScanFilename(compliancePath, outputFormat string) string {
+	if outputFormat == complianceFormatGitLabCI {
+		return complianceScanName(compliancePath) + complianceGitLabCISuffix
+	}
+	return complianceScanName(compliancePath) + ".yml"
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource                   = &complianceMigrationResource{}
+	_ resource.ResourceWithImportState    = &complianceMigrationResource{}
+	_ resource.ResourceWithValidateConfig = &complianceMigrationResource{}
+)
+
+// NewComplianceMigrationResource creates a new Chef Compliance migration resource
+func NewComplianceMigrationResource() resource.Resource {
+	return &complianceMigrationResource{}
+}
+
+// complianceMigrationResource is the resource implementation
+type complianceMigrationResource struct {
+	client *SousChefClient
+}
+
+// complianceMigrationResourceModel describes the resource data model
+type complianceMigrationResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	CompliancePath     types.String `tfsdk:"compliance_path"`
+	OutputPath         types.String `tfsdk:"output_path"`
+	OutputFormat       types.String `tfsdk:"output_format"`
+	CreateOutputDir    types.Bool   `tfsdk:"create_output_dir"`
+	PruneEmptyDir      types.Bool   `tfsdk:"prune_empty_dir"`
+	RetainOnDelete     types.Bool   `tfsdk:"retain_on_delete"`
+	ResolvedOutputPath types.String `tfsdk:"resolved_output_path"`
+	ScanContent        types.String `tfsdk:"scan_content"`
+}
+
+// Metadata returns the resource type name
+func (r *complianceMigrationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_compliance_migration"
+}
+
+// Schema defines the schema for the resource
+func (r *complianceMigrationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages conversion of a Chef Compliance scan configuration to CI pipeline steps.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier for the compliance migration",
+			},
+			"compliance_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the Chef Compliance scan configuration, e.g. `compliance/linux-baseline.json`",
+			},
+			"output_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Directory where the pipeline file will be written",
+			},
+			"output_format": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Output format: `github_actions` writes a `<scan>.yml` workflow, `gitlab_ci` writes a `<scan>.gitlab-ci.yml` include (default: github_actions)",
+			},
+			"create_output_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Create `output_path` when it does not exist (default: true). When false the directory must already exist, e.g. when it is pre-created with specific ownership and permissions",
+			},
+			"prune_empty_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove the output directory on destroy when no other files remain in it (default: false)",
+			},
+			"retain_on_delete": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Leave the generated files in place on destroy and only remove the resource from state, e.g. once they are committed to a repository (default: false)",
+			},
+			"resolved_output_path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Directory the pipeline file is written to: `output_path` joined onto the provider `output_root` when `output_path` is relative",
+			},
+			"scan_content": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Generated pipeline content",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *complianceMigrationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = configureResource(req, resp)
+}
+
+// ValidateConfig checks output_format is "github_actions" or "gitlab_ci"
+func (r *complianceMigrationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var outputFormat types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("output_format"), &outputFormat)...)
+	if resp.Diagnostics.HasError() || outputFormat.IsNull() || outputFormat.IsUnknown() {
+		return
+	}
+
+	format := outputFormat.ValueString()
+	if format != complianceFormatGitHubActions && format != complianceFormatGitLabCI {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("output_format"),
+			"Invalid output format",
+			fmt.Sprintf("output_format must be %q or %q, got %q", complianceFormatGitHubActions, complianceFormatGitLabCI, format),
+		)
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *complianceMigrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan complianceMigrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create output directory
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
+		return
+	}
+	existing := snapshotOutputs(r.client.resolveOutputPath(plan.OutputPath.ValueString()))
+
+	// Execute conversion and set state
+	r.executeComplianceConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Don't leave an untracked pipeline file behind if the apply was interrupted
+	scanPath := filepath.Join(plan.ResolvedOutputPath.ValueString(), complianceScanFilename(plan.CompliancePath.ValueString(), complianceOutputFormat(plan.OutputFormat)))
+	if cleanupIfCanceled(ctx, &resp.Diagnostics, existing, scanPath) {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *complianceMigrationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state complianceMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	scanPath := filepath.Join(outputPath, complianceScanFilename(state.CompliancePath.ValueString(), complianceOutputFormat(state.OutputFormat)))
+
+	// Check if file exists and read content
+	if !readFileAndSetState(
+		ctx,
+		scanPath,
+		"scan_content",
+		func(content string) { state.ScanContent = types.StringValue(content) },
+		errReadingComplianceScan,
+		&resp.Diagnostics,
+		r.client.missingArtifactHandler("converted compliance scan "+scanPath, &resp.State, &resp.Diagnostics),
+	) {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *complianceMigrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan complianceMigrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check the output directory, creating it unless create_output_dir is false
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
+		return
+	}
+
+	// Execute conversion and set state
+	r.executeComplianceConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *complianceMigrationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state complianceMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	if r.client.retainGeneratedFiles(state.RetainOnDelete, outputPath, &resp.Diagnostics) {
+		return
+	}
+	scanPath := filepath.Join(outputPath, complianceScanFilename(state.CompliancePath.ValueString(), complianceOutputFormat(state.OutputFormat)))
+	deleteGeneratedFile(scanPath, "converted compliance scan", &resp.Diagnostics)
+	pruneEmptyDir(state.PruneEmptyDir, outputPath, &resp.Diagnostics)
+}
+
+// executeComplianceConversion is a helper that encapsulates the common logic for Create and Update.
+// It executes the compliance scan conversion, reads the output, and updates the model state.
+func (r *complianceMigrationResource) executeComplianceConversion(ctx context.Context, model *complianceMigrationResourceModel, diagnostics *diag.Diagnostics) {
+	compliancePath := model.CompliancePath.ValueString()
+	outputPath := r.client.resolveOutputPath(model.OutputPath.ValueString())
+	outputFormat := complianceOutputFormat(model.OutputFormat)
+	id := r.client.resourceID(fmt.Sprintf(complianceIDFormat, complianceScanName(compliancePath), outputFormat), compliancePath)
+	ctx = withReportResourceID(ctx, id)
+
+	if !checkFileExists(compliancePath, "Compliance scan configuration", diagnostics) {
+		return
+	}
+
+	// Call souschef CLI to convert the scan configuration
+	args := []string{"convert-compliance", "--compliance-path", compliancePath, "--output-path", outputPath, "--format", outputFormat}
+	content, ok := executeConversion(ctx, r.client, args, filepath.Join(outputPath, complianceScanFilename(compliancePath, outputFormat)), errReadingComplianceScan, diagnostics)
+	if !ok {
+		return
+	}
+
+	// Set state
+	model.ID = types.StringValue(id)
+	model.ResolvedOutputPath = types.StringValue(outputPath)
+	model.ScanContent = types.StringValue(content)
+}
+
+// ImportState imports an existing resource into Terraform
+func (r *complianceMigrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format: compliance_path|output_path|format (format is optional)
+	parts, err := importIDParts(req.ID, "compliance_path", "output_path", "format")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+	if len(parts) < 2 || len(parts) > 3 {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID must be in format: compliance_path|output_path or compliance_path|output_path|format",
+		)
+		return
+	}
+
+	compliancePath := parts[0]
+	configuredOutputPath := parts[1]
+	outputPath := r.client.resolveOutputPath(configuredOutputPath)
+	configuredFormat := types.StringNull()
+	if len(parts) == 3 && parts[2] != "" {
+		configuredFormat = types.StringValue(parts[2])
+	}
+	outputFormat := complianceOutputFormat(configuredFormat)
+	if outputFormat != complianceFormatGitHubActions && outputFormat != complianceFormatGitLabCI {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("format must be %q or %q, got %q", complianceFormatGitHubActions, complianceFormatGitLabCI, outputFormat),
+		)
+		return
+	}
+
+	// Validate that the scan configuration exists
+	if !checkFileExists(compliancePath, "Compliance scan configuration", &resp.Diagnostics) {
+		return
+	}
+
+	// Check if the converted pipeline file exists
+	scanPath := filepath.Join(outputPath, complianceScanFilename(compliancePath, outputFormat))
+	if !checkFileExists(scanPath, "Converted compliance scan", &resp.Diagnostics) {
+		return
+	}
+
+	// Read pipeline content
+	content := readGeneratedFile(scanPath, errReadingComplianceScan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("compliance_path"), compliancePath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_path"), configuredOutputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_format"), configuredFormat)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resolved_output_path"), outputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("scan_content"), content)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), r.client.resourceID(fmt.Sprintf(complianceIDFormat, complianceScanName(compliancePath), outputFormat), compliancePath))...)
+}
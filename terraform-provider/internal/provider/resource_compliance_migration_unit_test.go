@@ -0,0 +1,185 @@
+// Package provider contains unit tests for the compliance migration resource.
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testCompliancePath is a Chef Compliance scan job for two baseline profiles.
+var testCompliancePath = filepath.Join(getFixturePath("compliance"), "linux-baseline.json")
+
+func TestComplianceScanFilename(t *testing.T) {
+	if got := complianceScanFilename("/scans/linux-baseline.json", complianceFormatGitHubActions); got != "linux-baseline.yml" {
+		t.Errorf("unexpected github_actions filename %q", got)
+	}
+	if got := complianceScanFilename("/scans/linux-baseline.json", complianceFormatGitLabCI); got != "linux-baseline.gitlab-ci.yml" {
+		t.Errorf("unexpected gitlab_ci filename %q", got)
+	}
+}
+
+func TestComplianceMigrationLifecycle(t *testing.T) {
+	r := &complianceMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputDir := t.TempDir()
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, complianceMigrationResourceModel{
+		CompliancePath: types.StringValue(testCompliancePath),
+		OutputPath:     types.StringValue(outputDir),
+	})}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+
+	var state complianceMigrationResourceModel
+	if diags := createResp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.ID.ValueString() != "compliance-linux-baseline-github_actions" || !strings.Contains(state.ScanContent.ValueString(), "inspec exec linux-baseline") {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+
+	// Read picks up edits to the generated file
+	scanPath := filepath.Join(outputDir, "linux-baseline.yml")
+	if err := os.WriteFile(scanPath, []byte("name: edited\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	var refreshed complianceMigrationResourceModel
+	readResp.State.Get(context.Background(), &refreshed)
+	if refreshed.ScanContent.ValueString() != "name: edited\n" {
+		t.Fatalf("expected Read to refresh scan_content, got %q", refreshed.ScanContent.ValueString())
+	}
+
+	// ImportState reconstructs the same resource
+	importResp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: testCompliancePath + "|" + outputDir + "|github_actions"}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, importResp.Diagnostics)
+	}
+	var imported complianceMigrationResourceModel
+	importResp.State.Get(context.Background(), &imported)
+	if imported.ID.ValueString() != state.ID.ValueString() || imported.ScanContent.ValueString() != refreshed.ScanContent.ValueString() {
+		t.Fatalf("unexpected imported state: %+v", imported)
+	}
+
+	// Delete removes the pipeline file, after which Read drops the resource
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+	}
+	if _, err := os.Stat(scanPath); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be deleted, got %v", scanPath, err)
+	}
+
+	readResp = &resource.ReadResponse{State: readResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: readResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Fatal("expected resource to be removed when the pipeline file is missing")
+	}
+}
+
+func TestComplianceMigrationGitLabCIFormat(t *testing.T) {
+	r := &complianceMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputDir := t.TempDir()
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, complianceMigrationResourceModel{
+		CompliancePath: types.StringValue(testCompliancePath),
+		OutputPath:     types.StringValue(outputDir),
+		OutputFormat:   types.StringValue(complianceFormatGitLabCI),
+	})}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var state complianceMigrationResourceModel
+	resp.State.Get(context.Background(), &state)
+	if state.ID.ValueString() != "compliance-linux-baseline-gitlab_ci" || !strings.HasPrefix(state.ScanContent.ValueString(), "compliance:linux-baseline:") {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "linux-baseline.gitlab-ci.yml")); err != nil {
+		t.Fatalf("expected GitLab CI file: %v", err)
+	}
+}
+
+func TestComplianceMigrationValidateConfig(t *testing.T) {
+	r := &complianceMigrationResource{}
+	schema := newResourceSchema(t, r)
+
+	for format, wantError := range map[string]bool{"github_actions": false, "gitlab_ci": false, "jenkins": true} {
+		resp := &resource.ValidateConfigResponse{}
+		r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: newResourceConfig(t, schema, complianceMigrationResourceModel{
+			CompliancePath: types.StringValue(testCompliancePath),
+			OutputPath:     types.StringValue(t.TempDir()),
+			OutputFormat:   types.StringValue(format),
+		})}, resp)
+		if resp.Diagnostics.HasError() != wantError {
+			t.Errorf("output_format %q: expected error %v, got %v", format, wantError, resp.Diagnostics)
+		}
+	}
+}
+
+func TestComplianceMigrationCreateErrors(t *testing.T) {
+	r := &complianceMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+
+	tests := map[string]struct {
+		compliancePath string
+		failCLI        bool
+	}{
+		"missing scan configuration": {compliancePath: filepath.Join(t.TempDir(), "linux-baseline.json")},
+		"CLI failure":                {compliancePath: testCompliancePath, failCLI: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tt.failCLI {
+				t.Setenv("SOUSCHEF_TEST_FAIL", "convert-compliance")
+			}
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+			r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, complianceMigrationResourceModel{
+				CompliancePath: types.StringValue(tt.compliancePath),
+				OutputPath:     types.StringValue(t.TempDir()),
+			})}, resp)
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestComplianceMigrationImportStateErrors(t *testing.T) {
+	r := &complianceMigrationResource{}
+	schema := newResourceSchema(t, r)
+
+	for _, id := range []string{
+		testCompliancePath,
+		testCompliancePath + "|" + t.TempDir() + "|github_actions|extra",
+		testCompliancePath + "|" + t.TempDir() + "|jenkins",
+		filepath.Join(t.TempDir(), "linux-baseline.json") + "|" + t.TempDir(),
+		testCompliancePath + "|" + t.TempDir(),
+	} {
+		resp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+		r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, resp)
+		if !resp.Diagnostics.HasError() {
+			t.Fatalf("expected error importing %q", id)
+		}
+	}
+}
@@ -0,0 +1,464 @@
+// Package provider implements the SousChef Terraform provider resources
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	convertAllIDFormat     = "%s-all"
+	convertAllPlaybooksDir = "playbooks"
+	convertAllVarsDir      = "vars"
+	convertAllTemplatesDir = "templates"
+)
+
+// convertAllArtifacts holds the files convert-all generated, keyed by path
+// relative to their artifact directory.
+type convertAllArtifacts struct {
+	Playbooks map[string]string
+	VarsFiles map[string]string
+	Templates map[string]string
+}
+
+// count returns the total number of artifacts.
+func (a convertAllArtifacts) count() int {
+	return len(a.Playbooks) + len(a.VarsFiles) + len(a.Templates)
+}
+
+// paths returns the absolute path of every artifact under outputPath.
+func (a convertAllArtifacts) paths(outputPath string) []string {
+	paths := make([]string, 0, a.count())
+	for dir, files := range map[string]map[string]string{
+		convertAllPlaybooksDir: a.Playbooks,
+		convertAllVarsDir:      a.VarsFiles,
+		convertAllTemplatesDir: a.Templates,
+	} {
+		for _, name := range sortedKeys(files) {
+			paths = append(paths, filepath.Join(outputPath, dir, filepath.FromSlash(name)))
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// readArtifactDir reads every file below dir, keyed by slash-separated path
+// relative to dir. A missing directory yields an empty map.
+func readArtifactDir(dir string) (map[string]string, error) {
+	files := make(map[string]string)
+	err := filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if filePath == dir && os.IsNotExist(err) {
+				return fs.SkipDir
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		content, err := osReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(content)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// collectConvertAllArtifacts reads the playbooks, vars files and templates
+// convert-all wrote below outputPath.
+func collectConvertAllArtifacts(outputPath string) (convertAllArtifacts, error) {
+	var artifacts convertAllArtifacts
+	var err error
+	if artifacts.Playbooks, err = readArtifactDir(filepath.Join(outputPath, convertAllPlaybooksDir)); err != nil {
+		return artifacts, err
+	}
+	if artifacts.VarsFiles, err = readArtifactDir(filepath.Join(outputPath, convertAllVarsDir)); err != nil {
+		return artifacts, err
+	}
+	artifacts.Templates, err = readArtifactDir(filepath.Join(outputPath, convertAllTemplatesDir))
+	return artifacts, err
+}
+
+// stateConvertAllArtifacts returns the artifacts recorded in state.
+func stateConvertAllArtifacts(ctx context.Context, state convertAllResourceModel, diagnostics *diag.Diagnostics) convertAllArtifacts {
+	var artifacts convertAllArtifacts
+	diagnostics.Append(state.Playbooks.ElementsAs(ctx, &artifacts.Playbooks, false)...)
+	diagnostics.Append(state.VarsFiles.ElementsAs(ctx, &artifacts.VarsFiles, false)...)
+	diagnostics.Append(state.Templates.ElementsAs(ctx, &artifacts.Templates, false)...)
+	return artifacts
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource                = &convertAllResource{}
+	_ resource.ResourceWithImportState = &convertAllResource{}
+)
+
+// NewConvertAllResource creates a new whole-cookbook conversion resource
+func NewConvertAllResource() resource.Resource {
+	return &convertAllResource{}
+}
+
+// convertAllResource is the resource implementation
+type convertAllResource struct {
+	client *SousChefClient
+}
+
+// convertAllResourceModel describes the resource data model
+type convertAllResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	CookbookPath       types.String `tfsdk:"cookbook_path"`
+	OutputPath         types.String `tfsdk:"output_path"`
+	CreateOutputDir    types.Bool   `tfsdk:"create_output_dir"`
+	PruneEmptyDir      types.Bool   `tfsdk:"prune_empty_dir"`
+	RetainOnDelete     types.Bool   `tfsdk:"retain_on_delete"`
+	ResolvedOutputPath types.String `tfsdk:"resolved_output_path"`
+	CookbookName       types.String `tfsdk:"cookbook_name"`
+	Playbooks          types.Map    `tfsdk:"playbooks"`
+	VarsFiles          types.Map    `tfsdk:"vars_files"`
+	Templates          types.Map    `tfsdk:"templates"`
+	PlaybookCount      types.Int64  `tfsdk:"playbook_count"`
+	VarsFileCount      types.Int64  `tfsdk:"vars_file_count"`
+	TemplateCount      types.Int64  `tfsdk:"template_count"`
+}
+
+// Metadata returns the resource type name
+func (r *convertAllResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_convert_all"
+}
+
+// Schema defines the schema for the resource
+func (r *convertAllResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Converts an entire Chef cookbook (recipes, attributes, templates and files) in a single operation.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier for the conversion",
+			},
+			"cookbook_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the Chef cookbook directory",
+			},
+			"output_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Directory the converted cookbook is written to, with `playbooks`, `vars` and `templates` subdirectories",
+			},
+			"create_output_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Create `output_path` when it does not exist (default: true). When false the directory must already exist, e.g. when it is pre-created with specific ownership and permissions",
+			},
+			"prune_empty_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove the output directory and its artifact subdirectories on destroy when no other files remain in them (default: false)",
+			},
+			"retain_on_delete": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Leave the generated files in place on destroy and only remove the resource from state, e.g. once they are committed to a repository (default: false)",
+			},
+			"resolved_output_path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Directory the output is written to: `output_path` joined onto the provider `output_root` when `output_path` is relative",
+			},
+			"cookbook_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the cookbook",
+			},
+			"playbooks": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Map of paths relative to `playbooks/` to playbook content",
+			},
+			"vars_files": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Map of paths relative to `vars/` to variables file content, converted from cookbook attributes",
+			},
+			"templates": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Map of paths relative to `templates/` to Jinja2 template content",
+			},
+			"playbook_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of playbooks generated",
+			},
+			"vars_file_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of variables files generated",
+			},
+			"template_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of templates generated",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *convertAllResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = configureResource(req, resp)
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *convertAllResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan convertAllResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath := r.client.resolveOutputPath(plan.OutputPath.ValueString())
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, outputPath, &resp.Diagnostics) {
+		return
+	}
+	existing := snapshotOutputs(outputPath)
+
+	artifacts := r.executeConvertAll(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Don't leave untracked artifacts behind if the apply was interrupted
+	if cleanupIfCanceled(ctx, &resp.Diagnostics, existing, artifacts.paths(outputPath)...) {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *convertAllResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state convertAllResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	artifacts, err := collectConvertAllArtifacts(outputPath)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading converted cookbook",
+			fmt.Sprintf("Could not read artifacts in %s: %s", outputPath, err),
+		)
+		return
+	}
+
+	if artifacts.count() == 0 {
+		r.client.handleMissingArtifact(ctx, "any converted artifacts in "+outputPath, &resp.State, &resp.Diagnostics)
+		return
+	}
+
+	setConvertAllArtifacts(ctx, &state, artifacts, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *convertAllResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan convertAllResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check the output directory, creating it unless create_output_dir is false
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
+		return
+	}
+
+	r.executeConvertAll(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *convertAllResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state convertAllResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	if r.client.retainGeneratedFiles(state.RetainOnDelete, outputPath, &resp.Diagnostics) {
+		return
+	}
+	artifacts := stateConvertAllArtifacts(ctx, state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete generated artifacts, collecting the directories they lived in
+	dirs := map[string]string{
+		filepath.Join(outputPath, convertAllPlaybooksDir): "",
+		filepath.Join(outputPath, convertAllVarsDir):      "",
+		filepath.Join(outputPath, convertAllTemplatesDir): "",
+	}
+	for _, artifactPath := range artifacts.paths(outputPath) {
+		deleteGeneratedFile(artifactPath, "artifact", &resp.Diagnostics)
+		dirs[filepath.Dir(artifactPath)] = ""
+	}
+
+	// Prune the deepest directories first so their parents can empty out
+	sortedDirs := sortedKeys(dirs)
+	for i := len(sortedDirs) - 1; i >= 0; i-- {
+		pruneEmptyDir(state.PruneEmptyDir, sortedDirs[i], &resp.Diagnostics)
+	}
+	pruneEmptyDir(state.PruneEmptyDir, outputPath, &resp.Diagnostics)
+}
+
+// setConvertAllArtifacts records artifacts in the model's maps and counts.
+func setConvertAllArtifacts(ctx context.Context, model *convertAllResourceModel, artifacts convertAllArtifacts, diagnostics *diag.Diagnostics) {
+	playbooksMap, mapDiags := typesMapValueFrom(ctx, types.StringType, artifacts.Playbooks)
+	diagnostics.Append(mapDiags...)
+	varsFilesMap, mapDiags := typesMapValueFrom(ctx, types.StringType, artifacts.VarsFiles)
+	diagnostics.Append(mapDiags...)
+	templatesMap, mapDiags := typesMapValueFrom(ctx, types.StringType, artifacts.Templates)
+	diagnostics.Append(mapDiags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	model.Playbooks = playbooksMap
+	model.VarsFiles = varsFilesMap
+	model.Templates = templatesMap
+	model.PlaybookCount = types.Int64Value(int64(len(artifacts.Playbooks)))
+	model.VarsFileCount = types.Int64Value(int64(len(artifacts.VarsFiles)))
+	model.TemplateCount = types.Int64Value(int64(len(artifacts.Templates)))
+}
+
+// executeConvertAll is a helper that encapsulates the common logic for Create and Update.
+// It runs convert-all, enumerates the generated artifacts, and updates the model state.
+func (r *convertAllResource) executeConvertAll(ctx context.Context, model *convertAllResourceModel, diagnostics *diag.Diagnostics) convertAllArtifacts {
+	cookbookPath := model.CookbookPath.ValueString()
+	outputPath := r.client.resolveOutputPath(model.OutputPath.ValueString())
+	cookbookName := r.client.cookbookName(cookbookPath)
+	id := r.client.resourceID(fmt.Sprintf(convertAllIDFormat, cookbookName), cookbookPath)
+	ctx = withReportResourceID(ctx, id)
+
+	// Call souschef CLI to convert the whole cookbook
+	args := []string{"convert-all", "--cookbook-path", cookbookPath, "--output-path", outputPath}
+	if _, ok := executeSousChefCommand(ctx, r.client, r.client.dryRunArgs(args), diagnostics); !ok {
+		return convertAllArtifacts{}
+	}
+
+	// A dry run writes no artifacts, so there is nothing to collect
+	artifacts := convertAllArtifacts{Playbooks: map[string]string{}, VarsFiles: map[string]string{}, Templates: map[string]string{}}
+	var err error
+	if !r.client.isDryRun() {
+		artifacts, err = collectConvertAllArtifacts(outputPath)
+	}
+	if err != nil {
+		diagnostics.AddError(
+			"Error reading converted cookbook",
+			fmt.Sprintf("Could not read artifacts in %s: %s", outputPath, err),
+		)
+		return artifacts
+	}
+	if artifacts.count() == 0 && !r.client.isDryRun() {
+		diagnostics.AddError(
+			"No artifacts generated",
+			fmt.Sprintf("convert-all wrote no playbooks, vars files or templates to %s", outputPath),
+		)
+		return artifacts
+	}
+
+	model.ID = types.StringValue(id)
+	model.CookbookName = types.StringValue(cookbookName)
+	model.ResolvedOutputPath = types.StringValue(outputPath)
+	setConvertAllArtifacts(ctx, model, artifacts, diagnostics)
+	return artifacts
+}
+
+// ImportState imports an existing resource into Terraform
+func (r *convertAllResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format: cookbook_path|output_path
+	parts, err := importIDParts(req.ID, "cookbook_path", "output_path")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID must be in format: cookbook_path|output_path",
+		)
+		return
+	}
+
+	cookbookPath := parts[0]
+	configuredOutputPath := parts[1]
+	outputPath := r.client.resolveOutputPath(configuredOutputPath)
+
+	// Validate that the cookbook exists
+	if !checkFileExists(cookbookPath, "Cookbook", &resp.Diagnostics) {
+		return
+	}
+
+	artifacts, err := collectConvertAllArtifacts(outputPath)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading converted cookbook",
+			fmt.Sprintf("Could not read artifacts in %s: %s", outputPath, err),
+		)
+		return
+	}
+	if artifacts.count() == 0 {
+		resp.Diagnostics.AddError(
+			"Converted cookbook not found",
+			fmt.Sprintf("No playbooks, vars files or templates found in %s", outputPath),
+		)
+		return
+	}
+
+	cookbookName := r.client.cookbookName(cookbookPath)
+	model := convertAllResourceModel{
+		ID:                 types.StringValue(r.client.resourceID(fmt.Sprintf(convertAllIDFormat, cookbookName), cookbookPath)),
+		CookbookPath:       types.StringValue(cookbookPath),
+		OutputPath:         types.StringValue(configuredOutputPath),
+		PruneEmptyDir:      types.BoolNull(),
+		ResolvedOutputPath: types.StringValue(outputPath),
+		CookbookName:       types.StringValue(cookbookName),
+	}
+	setConvertAllArtifacts(ctx, &model, artifacts, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, model)...)
+}
@@ -0,0 +1,133 @@
+// Package provider contains unit tests for the whole-cookbook conversion resource.
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newConvertAllPlan returns a plan converting cookbookPath into outputPath.
+func newConvertAllPlan(cookbookPath, outputPath string) convertAllResourceModel {
+	return convertAllResourceModel{
+		CookbookPath:  types.StringValue(cookbookPath),
+		OutputPath:    types.StringValue(outputPath),
+		PruneEmptyDir: types.BoolValue(true),
+		Playbooks:     types.MapUnknown(types.StringType),
+		VarsFiles:     types.MapUnknown(types.StringType),
+		Templates:     types.MapUnknown(types.StringType),
+	}
+}
+
+func TestConvertAllLifecycle(t *testing.T) {
+	r := &convertAllResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	cookbookPath := filepath.Join(t.TempDir(), "web")
+	if err := os.Mkdir(cookbookPath, testDirPermissions); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+	outputDir := filepath.Join(t.TempDir(), "web")
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, newConvertAllPlan(cookbookPath, outputDir))}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+
+	var state convertAllResourceModel
+	if diags := createResp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.ID.ValueString() != "web-all" {
+		t.Fatalf("unexpected id %q", state.ID.ValueString())
+	}
+	if state.PlaybookCount.ValueInt64() != 2 || state.VarsFileCount.ValueInt64() != 1 || state.TemplateCount.ValueInt64() != 2 {
+		t.Fatalf("unexpected counts: %d playbooks, %d vars files, %d templates",
+			state.PlaybookCount.ValueInt64(), state.VarsFileCount.ValueInt64(), state.TemplateCount.ValueInt64())
+	}
+	artifacts := stateConvertAllArtifacts(context.Background(), state, &createResp.Diagnostics)
+	if artifacts.Templates["sites/default.conf.j2"] != "server_name {{ server_name }};\n" {
+		t.Fatalf("unexpected templates %v", artifacts.Templates)
+	}
+
+	// Read rebuilds the maps from disk
+	if err := os.Remove(filepath.Join(outputDir, convertAllPlaybooksDir, "server.yml")); err != nil {
+		t.Fatalf("failed to remove playbook: %v", err)
+	}
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	var refreshed convertAllResourceModel
+	readResp.State.Get(context.Background(), &refreshed)
+	if refreshed.PlaybookCount.ValueInt64() != 1 || len(refreshed.Playbooks.Elements()) != 1 {
+		t.Fatalf("expected Read to drop the removed playbook, got %v", refreshed.Playbooks)
+	}
+
+	// ImportState reconstructs the resource from disk
+	importResp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: cookbookPath + "|" + outputDir}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, importResp.Diagnostics)
+	}
+	var imported convertAllResourceModel
+	importResp.State.Get(context.Background(), &imported)
+	if imported.ID.ValueString() != "web-all" || !imported.Templates.Equal(refreshed.Templates) {
+		t.Fatalf("unexpected imported state: %+v", imported)
+	}
+
+	// Delete removes every artifact and prunes the directories
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+	}
+	if _, err := os.Stat(outputDir); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be pruned, got %v", outputDir, err)
+	}
+
+	readResp = &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Fatal("expected resource to be removed when the output directory is empty")
+	}
+}
+
+func TestConvertAllCreateCLIFailure(t *testing.T) {
+	t.Setenv("SOUSCHEF_TEST_FAIL", "convert-all")
+	r := &convertAllResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, newConvertAllPlan(t.TempDir(), t.TempDir()))}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error")
+	}
+}
+
+func TestConvertAllImportStateErrors(t *testing.T) {
+	r := &convertAllResource{}
+	schema := newResourceSchema(t, r)
+	cookbookPath := t.TempDir()
+
+	for _, id := range []string{
+		cookbookPath,
+		filepath.Join(t.TempDir(), "missing") + "|" + t.TempDir(),
+		cookbookPath + "|" + t.TempDir(),
+	} {
+		resp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+		r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, resp)
+		if !resp.Diagnostics.HasError() {
+			t.Fatalf("expected error importing %q", id)
+		}
+	}
+}
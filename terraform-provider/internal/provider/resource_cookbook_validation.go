@@ -0,0 +1,167 @@
+// Package provider implements the SousChef Terraform provider resources
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const cookbookValidationIDFormat = "validation-%s"
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource = &cookbookValidationResource{}
+)
+
+// NewCookbookValidationResource creates a new cookbook validation resource
+func NewCookbookValidationResource() resource.Resource {
+	return &cookbookValidationResource{}
+}
+
+// cookbookValidationResource is the resource implementation. Unlike the
+// souschef_validate data source it fails the apply when the cookbook is not
+// convertible, so resources that depend on it are never created.
+type cookbookValidationResource struct {
+	client *SousChefClient
+}
+
+// cookbookValidationResourceModel describes the resource data model
+type cookbookValidationResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	CookbookPath types.String `tfsdk:"cookbook_path"`
+	Valid        types.Bool   `tfsdk:"valid"`
+	CheckedAt    types.String `tfsdk:"checked_at"`
+}
+
+// Metadata returns the resource type name
+func (r *cookbookValidationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cookbook_validation"
+}
+
+// Schema defines the schema for the resource
+func (r *cookbookValidationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Validates that a cookbook can be converted, failing the apply when it cannot. Reference it with `depends_on` to hold back migration resources until the cookbook passes validation.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier for the cookbook validation",
+			},
+			"cookbook_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the Chef cookbook directory",
+			},
+			"valid": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the cookbook passed validation. Always true once created, since an invalid cookbook fails the apply",
+			},
+			"checked_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "RFC 3339 timestamp of the validation that created or last updated the resource",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *cookbookValidationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = configureResource(req, resp)
+}
+
+// Create validates the cookbook and sets the initial Terraform state
+func (r *cookbookValidationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan cookbookValidationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.executeCookbookValidation(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read re-validates the cookbook. A cookbook that is no longer valid is
+// removed from state, so the next apply recreates the resource and fails.
+func (r *cookbookValidationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state cookbookValidationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cookbookPath := state.CookbookPath.ValueString()
+	validation, ok := validateCookbook(ctx, r.client, cookbookPath, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	if !validation.Valid {
+		resp.Diagnostics.AddWarning(
+			"Cookbook no longer convertible",
+			fmt.Sprintf("%s now uses unsupported features: %s. The validation will be recreated on the next apply.",
+				cookbookPath, strings.Join(validation.UnsupportedFeatures, ", ")),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update re-validates the cookbook and sets the updated Terraform state on success
+func (r *cookbookValidationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan cookbookValidationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.executeCookbookValidation(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the resource from Terraform state; there are no files to clean up
+func (r *cookbookValidationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// executeCookbookValidation is a helper that encapsulates the common logic for Create and Update.
+// It validates the cookbook, erroring when it is not convertible, and updates the model state.
+func (r *cookbookValidationResource) executeCookbookValidation(ctx context.Context, model *cookbookValidationResourceModel, diagnostics *diag.Diagnostics) {
+	cookbookPath := model.CookbookPath.ValueString()
+	id := r.client.resourceID(fmt.Sprintf(cookbookValidationIDFormat, r.client.cookbookName(cookbookPath)), cookbookPath)
+	ctx = withReportResourceID(ctx, id)
+
+	validation, ok := validateCookbook(ctx, r.client, cookbookPath, diagnostics)
+	if !ok {
+		return
+	}
+	if !validation.Valid {
+		addCookbookNotConvertibleError(cookbookPath, validation, diagnostics)
+		return
+	}
+
+	model.ID = types.StringValue(id)
+	model.Valid = types.BoolValue(true)
+	model.CheckedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,122 @@
+// Package provider contains unit tests for the cookbook validation resource.
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCookbookValidationLifecycle(t *testing.T) {
+	r := &cookbookValidationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	cookbookPath := filepath.Join(t.TempDir(), "web")
+	if err := os.Mkdir(cookbookPath, testDirPermissions); err != nil {
+		t.Fatalf("failed to create cookbook: %v", err)
+	}
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, cookbookValidationResourceModel{
+		CookbookPath: types.StringValue(cookbookPath),
+	})}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+
+	var state cookbookValidationResourceModel
+	if diags := createResp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.ID.ValueString() != "validation-web" || !state.Valid.ValueBool() {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+	if _, err := time.Parse(time.RFC3339, state.CheckedAt.ValueString()); err != nil {
+		t.Fatalf("expected an RFC 3339 checked_at, got %q: %v", state.CheckedAt.ValueString(), err)
+	}
+
+	// Read keeps a cookbook that still validates
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() || readResp.State.Raw.IsNull() {
+		t.Fatalf("expected the resource to be kept, got %v", readResp.Diagnostics)
+	}
+
+	// Read drops the resource once the cookbook stops validating
+	if err := os.WriteFile(filepath.Join(cookbookPath, "validation.json"), []byte(testInvalidValidation), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	readResp = &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() || readResp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected the resource to be removed with a warning, got %v", readResp.Diagnostics)
+	}
+}
+
+func TestCookbookValidationSymlinkedCookbookID(t *testing.T) {
+	cookbookDir := filepath.Join(t.TempDir(), "postgresql")
+	if err := os.Mkdir(cookbookDir, testDirPermissions); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+	link := filepath.Join(t.TempDir(), "current")
+	if err := os.Symlink(cookbookDir, link); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+
+	for keepSymlinks, wantID := range map[bool]string{false: "validation-postgresql", true: "validation-current"} {
+		r := &cookbookValidationResource{client: &SousChefClient{Path: newFakeSousChef(t), KeepSymlinks: keepSymlinks}}
+		schema := newResourceSchema(t, r)
+		resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+		r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, cookbookValidationResourceModel{
+			CookbookPath: types.StringValue(link),
+		})}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+		}
+		var state cookbookValidationResourceModel
+		resp.State.Get(context.Background(), &state)
+		if state.ID.ValueString() != wantID {
+			t.Fatalf("KeepSymlinks %t: expected id %q, got %q", keepSymlinks, wantID, state.ID.ValueString())
+		}
+	}
+}
+
+func TestCookbookValidationCreateErrors(t *testing.T) {
+	r := &cookbookValidationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+
+	tests := map[string]struct {
+		cookbookPath string
+		failCLI      bool
+		wantDetail   string
+	}{
+		"invalid cookbook": {cookbookPath: newInvalidCookbookFixture(t), wantDetail: "ruby_block, chef_gem"},
+		"CLI failure":      {cookbookPath: t.TempDir(), failCLI: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tt.failCLI {
+				t.Setenv("SOUSCHEF_TEST_FAIL", "validate")
+			}
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+			r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, cookbookValidationResourceModel{
+				CookbookPath: types.StringValue(tt.cookbookPath),
+			})}, resp)
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), tt.wantDetail) {
+				t.Fatalf("expected %q in %v", tt.wantDetail, resp.Diagnostics)
+			}
+		})
+	}
+}
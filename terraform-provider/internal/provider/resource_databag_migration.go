@@ -0,0 +1,455 @@
+// Package provider implements the SousChef Terraform provider resources
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	errReadingDatabagItem = "Error reading converted data bag item"
+	databagIDFormat       = "databag-%s"
+)
+
+// listDatabagItems returns the sorted names of the item JSON files in the
+// data bag directory, without the extension.
+func listDatabagItems(databagPath string) ([]string, error) {
+	entries, err := osReadDir(databagPath)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		items = append(items, strings.TrimSuffix(entry.Name(), ".json"))
+	}
+	sort.Strings(items)
+	return items, nil
+}
+
+// databagItemPath returns the file the converted item is written to.
+func databagItemPath(outputPath, itemName string) string {
+	return filepath.Join(outputPath, itemName+".yml")
+}
+
+// checkSecretKey verifies the secret key file exists and can be opened,
+// without reading its contents.
+func checkSecretKey(secretKeyPath string, diagnostics *diag.Diagnostics) bool {
+	info, err := osStat(secretKeyPath)
+	if os.IsNotExist(err) {
+		diagnostics.AddAttributeError(
+			path.Root("secret_key_path"),
+			"Secret key not found",
+			fmt.Sprintf("Secret key file does not exist: %s", secretKeyPath),
+		)
+		return false
+	}
+	if err == nil && info.IsDir() {
+		err = fmt.Errorf("is a directory")
+	}
+	if err == nil {
+		var file *os.File
+		if file, err = osOpen(secretKeyPath); err == nil {
+			file.Close()
+		}
+	}
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root("secret_key_path"),
+			"Secret key not readable",
+			fmt.Sprintf("Could not open secret key file %s: %s", secretKeyPath, err),
+		)
+		return false
+	}
+	return true
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource                   = &databagMigrationResource{}
+	_ resource.ResourceWithImportState    = &databagMigrationResource{}
+	_ resource.ResourceWithValidateConfig = &databagMigrationResource{}
+)
+
+// NewDatabagMigrationResource creates a new Chef data bag migration resource
+func NewDatabagMigrationResource() resource.Resource {
+	return &databagMigrationResource{}
+}
+
+// databagMigrationResource is the resource implementation
+type databagMigrationResource struct {
+	client *SousChefClient
+}
+
+// databagMigrationResourceModel describes the resource data model
+type databagMigrationResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	DatabagPath        types.String `tfsdk:"databag_path"`
+	OutputPath         types.String `tfsdk:"output_path"`
+	SecretKeyPath      types.String `tfsdk:"secret_key_path"`
+	CreateOutputDir    types.Bool   `tfsdk:"create_output_dir"`
+	PruneEmptyDir      types.Bool   `tfsdk:"prune_empty_dir"`
+	RetainOnDelete     types.Bool   `tfsdk:"retain_on_delete"`
+	ResolvedOutputPath types.String `tfsdk:"resolved_output_path"`
+	DatabagName        types.String `tfsdk:"databag_name"`
+	ItemCount          types.Int64  `tfsdk:"item_count"`
+	Items              types.Map    `tfsdk:"items"`
+	OutputFileMode     types.String `tfsdk:"output_file_mode"`
+	AppliedFileMode    types.String `tfsdk:"applied_file_mode"`
+}
+
+// Metadata returns the resource type name
+func (r *databagMigrationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databag_migration"
+}
+
+// Schema defines the schema for the resource
+func (r *databagMigrationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages conversion of a Chef data bag, optionally encrypted, to Ansible variable files.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier for the data bag migration",
+			},
+			"databag_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the data bag directory holding one JSON file per item",
+			},
+			"output_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Directory where one `<item>.yml` file per item will be written",
+			},
+			"secret_key_path": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path to the encrypted data bag secret. Only the path is passed to the CLI as `--secret-key`; the key is never read into state",
+			},
+			"create_output_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Create `output_path` when it does not exist (default: true). When false the directory must already exist, e.g. when it is pre-created with specific ownership and permissions",
+			},
+			"prune_empty_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove the output directory on destroy when no other files remain in it (default: false)",
+			},
+			"retain_on_delete": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Leave the generated files in place on destroy and only remove the resource from state, e.g. once they are committed to a repository (default: false)",
+			},
+			"output_file_mode": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Octal permission mode, e.g. `0600`, the item files are changed to after conversion, e.g. to keep decrypted items private. By default the files keep the mode the SousChef CLI creates them with",
+			},
+			"applied_file_mode": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Mode of the item files as four octal digits, checked on refresh; null when `output_file_mode` is unset",
+			},
+			"resolved_output_path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Directory the item files are written to: `output_path` joined onto the provider `output_root` when `output_path` is relative",
+			},
+			"databag_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the data bag (the `databag_path` directory name)",
+			},
+			"item_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of converted items",
+			},
+			"items": schema.MapAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Map of item name to converted content. Sensitive because encrypted items are stored decrypted",
+			},
+		},
+	}
+}
+
+// ValidateConfig checks output_file_mode is an octal permission mode
+func (r *databagMigrationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateOutputFileMode(ctx, req.Config, &resp.Diagnostics)
+}
+
+// Configure adds the provider configured client to the resource
+func (r *databagMigrationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = configureResource(req, resp)
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *databagMigrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan databagMigrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fail before creating any output if the secret key is unusable
+	if secretKeyPath := plan.SecretKeyPath.ValueString(); secretKeyPath != "" && !checkSecretKey(secretKeyPath, &resp.Diagnostics) {
+		return
+	}
+
+	// Create output directory
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
+		return
+	}
+	existing := snapshotOutputs(r.client.resolveOutputPath(plan.OutputPath.ValueString()))
+
+	// Execute conversion and set state
+	r.executeDatabagConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Don't leave untracked decrypted items behind if the apply was interrupted
+	outputPath := plan.ResolvedOutputPath.ValueString()
+	itemPaths := make([]string, 0, len(plan.Items.Elements()))
+	for itemName := range plan.Items.Elements() {
+		itemPaths = append(itemPaths, databagItemPath(outputPath, itemName))
+	}
+	if cleanupIfCanceled(ctx, &resp.Diagnostics, existing, itemPaths...) {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *databagMigrationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state databagMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+
+	// Check which converted items still exist
+	items := make(map[string]string)
+	itemPaths := make([]string, 0, len(state.Items.Elements()))
+	for itemName := range state.Items.Elements() {
+		itemPath := databagItemPath(outputPath, itemName)
+		itemPaths = append(itemPaths, itemPath)
+		content, err := readFileWithRetry(ctx, itemPath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				errReadingDatabagItem,
+				fmt.Sprintf("Could not read file %s: %s", itemPath, err),
+			)
+			return
+		}
+		items[itemName] = string(content)
+	}
+
+	if len(items) == 0 {
+		r.client.handleMissingArtifact(ctx, "any converted data bag items in "+outputPath, &resp.State, &resp.Diagnostics)
+		return
+	}
+
+	// Update state with current content
+	itemsMap, mapDiags := typesMapValueFrom(ctx, types.StringType, items)
+	resp.Diagnostics.Append(mapDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Items = itemsMap
+	state.ItemCount = types.Int64Value(int64(len(items)))
+	refreshOutputFileMode(&state.OutputFileMode, &state.AppliedFileMode, &resp.Diagnostics, itemPaths...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *databagMigrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan databagMigrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if secretKeyPath := plan.SecretKeyPath.ValueString(); secretKeyPath != "" && !checkSecretKey(secretKeyPath, &resp.Diagnostics) {
+		return
+	}
+
+	// Check the output directory, creating it unless create_output_dir is false
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
+		return
+	}
+
+	// Execute conversion and set state
+	r.executeDatabagConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *databagMigrationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state databagMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	if r.client.retainGeneratedFiles(state.RetainOnDelete, outputPath, &resp.Diagnostics) {
+		return
+	}
+	for itemName := range state.Items.Elements() {
+		deleteGeneratedFile(databagItemPath(outputPath, itemName), "data bag item", &resp.Diagnostics)
+	}
+	pruneEmptyDir(state.PruneEmptyDir, outputPath, &resp.Diagnostics)
+}
+
+// executeDatabagConversion is a helper that encapsulates the common logic for Create and Update.
+// It executes the data bag conversion, reads the converted items, and updates the model state.
+func (r *databagMigrationResource) executeDatabagConversion(ctx context.Context, model *databagMigrationResourceModel, diagnostics *diag.Diagnostics) {
+	databagPath := model.DatabagPath.ValueString()
+	outputPath := r.client.resolveOutputPath(model.OutputPath.ValueString())
+	databagName := filepath.Base(databagPath)
+	id := r.client.resourceID(fmt.Sprintf(databagIDFormat, databagName), databagPath)
+	ctx = withReportResourceID(ctx, id)
+
+	itemNames, err := listDatabagItems(databagPath)
+	if err != nil {
+		diagnostics.AddError(
+			"Error reading data bag",
+			fmt.Sprintf("Could not list items in %s: %s", databagPath, err),
+		)
+		return
+	}
+
+	// Call souschef CLI to convert the data bag; the secret key is only ever passed by path
+	args := []string{"convert-databag", "--databag-path", databagPath, "--output-path", outputPath}
+	if secretKeyPath := model.SecretKeyPath.ValueString(); secretKeyPath != "" {
+		args = append(args, "--secret-key", secretKeyPath)
+	}
+	if _, ok := executeSousChefCommand(ctx, r.client, r.client.dryRunArgs(args), diagnostics); !ok {
+		return
+	}
+
+	// Read converted items; a dry run writes none, so items is left empty
+	items := make(map[string]string, len(itemNames))
+	itemPaths := make([]string, 0, len(itemNames))
+	for _, itemName := range itemNames {
+		if r.client.isDryRun() {
+			break
+		}
+		itemPath := databagItemPath(outputPath, itemName)
+		items[itemName] = readGeneratedFile(itemPath, errReadingDatabagItem, diagnostics)
+		if diagnostics.HasError() {
+			return
+		}
+		itemPaths = append(itemPaths, itemPath)
+	}
+	model.AppliedFileMode = r.client.applyOutputFileMode(model.OutputFileMode, diagnostics, itemPaths...)
+	if diagnostics.HasError() {
+		return
+	}
+	itemsMap, mapDiags := typesMapValueFrom(ctx, types.StringType, items)
+	diagnostics.Append(mapDiags...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	model.ID = types.StringValue(id)
+	model.ResolvedOutputPath = types.StringValue(outputPath)
+	model.DatabagName = types.StringValue(databagName)
+	model.ItemCount = types.Int64Value(int64(len(itemNames)))
+	model.Items = itemsMap
+}
+
+// ImportState imports an existing resource into Terraform
+func (r *databagMigrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format: databag_path|output_path
+	parts, err := importIDParts(req.ID, "databag_path", "output_path")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID must be in format: databag_path|output_path",
+		)
+		return
+	}
+
+	databagPath := parts[0]
+	configuredOutputPath := parts[1]
+	outputPath := r.client.resolveOutputPath(configuredOutputPath)
+
+	// Validate that the data bag exists
+	if !checkFileExists(databagPath, "Data bag", &resp.Diagnostics) {
+		return
+	}
+	itemNames, err := listDatabagItems(databagPath)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading data bag",
+			fmt.Sprintf("Could not list items in %s: %s", databagPath, err),
+		)
+		return
+	}
+
+	// Read whichever items have been converted
+	items := make(map[string]string)
+	for _, itemName := range itemNames {
+		itemPath := databagItemPath(outputPath, itemName)
+		if _, err := osStat(itemPath); os.IsNotExist(err) {
+			continue
+		}
+		items[itemName] = readGeneratedFile(itemPath, errReadingDatabagItem, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if len(items) == 0 {
+		resp.Diagnostics.AddError(
+			"Converted data bag items not found",
+			fmt.Sprintf("No converted items exist in output directory: %s", outputPath),
+		)
+		return
+	}
+
+	// Set state
+	databagName := filepath.Base(databagPath)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("databag_path"), databagPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_path"), configuredOutputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resolved_output_path"), outputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("databag_name"), databagName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("item_count"), int64(len(items)))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("items"), items)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), r.client.resourceID(fmt.Sprintf(databagIDFormat, databagName), databagPath))...)
+}
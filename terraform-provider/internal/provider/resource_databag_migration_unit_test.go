@@ -0,0 +1,228 @@
+// Package provider contains unit tests for the Chef data bag migration resource.
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testSecretKeyContent = "super-secret-key-material"
+
+// testDatabagPath returns the path of the encrypted data bag fixture.
+func testDatabagPath() string {
+	return filepath.Join(getFixturePath("data_bags"), "credentials")
+}
+
+// newSecretKey writes a data bag secret into a temporary directory.
+func newSecretKey(t *testing.T) string {
+	t.Helper()
+	keyPath := filepath.Join(t.TempDir(), "encrypted_data_bag_secret")
+	if err := os.WriteFile(keyPath, []byte(testSecretKeyContent), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	return keyPath
+}
+
+func TestDatabagMigrationSchemaItemsSensitive(t *testing.T) {
+	schema := newResourceSchema(t, &databagMigrationResource{})
+	if !schema.Attributes["items"].IsSensitive() {
+		t.Fatal("expected items to be sensitive")
+	}
+}
+
+func TestDatabagMigrationLifecycle(t *testing.T) {
+	r := &databagMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	databagPath := testDatabagPath()
+	keyPath := newSecretKey(t)
+	outputDir := filepath.Join(t.TempDir(), "group_vars")
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, databagMigrationResourceModel{
+		DatabagPath:   types.StringValue(databagPath),
+		OutputPath:    types.StringValue(outputDir),
+		SecretKeyPath: types.StringValue(keyPath),
+		PruneEmptyDir: types.BoolValue(true),
+		Items:         types.MapNull(types.StringType),
+	})}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+
+	var state databagMigrationResourceModel
+	if diags := createResp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.ID.ValueString() != "databag-credentials" || state.DatabagName.ValueString() != "credentials" {
+		t.Fatalf("unexpected id %q and databag_name %q", state.ID.ValueString(), state.DatabagName.ValueString())
+	}
+	if state.ItemCount.ValueInt64() != 2 {
+		t.Fatalf("expected 2 items, got %d", state.ItemCount.ValueInt64())
+	}
+	items := map[string]string{}
+	state.Items.ElementsAs(context.Background(), &items, false)
+	if !strings.Contains(items["database"], "password: decrypted-with-encrypted_data_bag_secret") {
+		t.Fatalf("expected decrypted database item, got %q", items["database"])
+	}
+
+	// Only the key path may reach state, never the key itself
+	if state.SecretKeyPath.ValueString() != keyPath {
+		t.Fatalf("unexpected secret_key_path %q", state.SecretKeyPath.ValueString())
+	}
+	if strings.Contains(createResp.State.Raw.String(), testSecretKeyContent) {
+		t.Fatal("secret key contents leaked into state")
+	}
+
+	// Read picks up edits and drops items whose files were removed
+	if err := os.WriteFile(filepath.Join(outputDir, "api.yml"), []byte("token: rotated\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	if err := os.Remove(filepath.Join(outputDir, "database.yml")); err != nil {
+		t.Fatalf("failed to remove item: %v", err)
+	}
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	var refreshed databagMigrationResourceModel
+	readResp.State.Get(context.Background(), &refreshed)
+	items = map[string]string{}
+	refreshed.Items.ElementsAs(context.Background(), &items, false)
+	if len(items) != 1 || items["api"] != "token: rotated\n" || refreshed.ItemCount.ValueInt64() != 1 {
+		t.Fatalf("expected Read to refresh the remaining item, got %v", items)
+	}
+
+	// ImportState reconstructs the same resource
+	assertImportMatchesRead(t, r, databagPath+"|"+outputDir)
+
+	// Delete removes the item files, after which Read drops the resource
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+	}
+	if _, err := os.Stat(outputDir); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be pruned, got %v", outputDir, err)
+	}
+
+	readResp = &resource.ReadResponse{State: readResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: readResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Fatal("expected resource to be removed when no items remain")
+	}
+}
+
+func TestDatabagMigrationOutputFileMode(t *testing.T) {
+	r := &databagMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputDir := t.TempDir()
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, databagMigrationResourceModel{
+		DatabagPath:    types.StringValue(testDatabagPath()),
+		OutputPath:     types.StringValue(outputDir),
+		SecretKeyPath:  types.StringValue(newSecretKey(t)),
+		OutputFileMode: types.StringValue("0600"),
+		Items:          types.MapNull(types.StringType),
+	})}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var state databagMigrationResourceModel
+	resp.State.Get(context.Background(), &state)
+	if state.AppliedFileMode.ValueString() != "0600" {
+		t.Fatalf("expected applied_file_mode 0600, got %q", state.AppliedFileMode.ValueString())
+	}
+	for _, item := range []string{"api.yml", "database.yml"} {
+		if info, err := os.Stat(filepath.Join(outputDir, item)); err != nil || info.Mode().Perm() != 0o600 {
+			t.Fatalf("expected %s to have mode 0600, got %v", item, err)
+		}
+	}
+}
+
+func TestDatabagMigrationCreateErrors(t *testing.T) {
+	r := &databagMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+
+	tests := map[string]struct {
+		databagPath   string
+		secretKeyPath string
+		failCLI       bool
+		wantSummary   string
+	}{
+		"missing key file": {
+			databagPath:   testDatabagPath(),
+			secretKeyPath: filepath.Join(t.TempDir(), "missing_secret"),
+			wantSummary:   "Secret key not found",
+		},
+		"key is a directory": {
+			databagPath:   testDatabagPath(),
+			secretKeyPath: t.TempDir(),
+			wantSummary:   "Secret key not readable",
+		},
+		"encrypted without key": {databagPath: testDatabagPath()},
+		"missing data bag":      {databagPath: filepath.Join(t.TempDir(), "users"), wantSummary: "Error reading data bag"},
+		"CLI failure":           {databagPath: testDatabagPath(), secretKeyPath: newSecretKey(t), failCLI: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tt.failCLI {
+				t.Setenv("SOUSCHEF_TEST_FAIL", "convert-databag")
+			}
+			outputDir := filepath.Join(t.TempDir(), "out")
+			secretKeyPath := types.StringNull()
+			if tt.secretKeyPath != "" {
+				secretKeyPath = types.StringValue(tt.secretKeyPath)
+			}
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+			r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, databagMigrationResourceModel{
+				DatabagPath:   types.StringValue(tt.databagPath),
+				OutputPath:    types.StringValue(outputDir),
+				SecretKeyPath: secretKeyPath,
+				Items:         types.MapNull(types.StringType),
+			})}, resp)
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected error")
+			}
+			if tt.wantSummary != "" && resp.Diagnostics.Errors()[0].Summary() != tt.wantSummary {
+				t.Fatalf("expected %q, got %v", tt.wantSummary, resp.Diagnostics)
+			}
+			if strings.HasPrefix(tt.wantSummary, "Secret key") {
+				if _, err := os.Stat(outputDir); !os.IsNotExist(err) {
+					t.Fatal("expected no output to be created when the secret key is unusable")
+				}
+			}
+		})
+	}
+}
+
+func TestDatabagMigrationImportStateErrors(t *testing.T) {
+	r := &databagMigrationResource{}
+	schema := newResourceSchema(t, r)
+
+	for name, id := range map[string]string{
+		"malformed":        "only-one-part",
+		"missing data bag": filepath.Join(t.TempDir(), "users") + "|" + t.TempDir(),
+		"not converted":    testDatabagPath() + "|" + t.TempDir(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+			r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, resp)
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,350 @@
+// Package provider implements the SousChef Terraform provider resources
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	errReadingCopiedFiles = "Error reading copied files"
+	fileIDFormat          = "files-%s"
+)
+
+// listCopiedFiles returns every file below dir as sorted, slash-separated
+// paths relative to dir. A missing directory yields an empty list.
+func listCopiedFiles(dir string) ([]string, error) {
+	files := make([]string, 0)
+	err := filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if filePath == dir && os.IsNotExist(err) {
+				return fs.SkipDir
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// fileMigrationID returns the resource ID for the cookbook owning filesPath.
+func fileMigrationID(client *SousChefClient, filesPath string) string {
+	return client.resourceID(fmt.Sprintf(fileIDFormat, filepath.Base(filepath.Dir(filesPath))), filesPath)
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource                = &fileMigrationResource{}
+	_ resource.ResourceWithImportState = &fileMigrationResource{}
+)
+
+// NewFileMigrationResource creates a new cookbook files migration resource
+func NewFileMigrationResource() resource.Resource {
+	return &fileMigrationResource{}
+}
+
+// fileMigrationResource is the resource implementation
+type fileMigrationResource struct {
+	client *SousChefClient
+}
+
+// fileMigrationResourceModel describes the resource data model
+type fileMigrationResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	FilesPath          types.String `tfsdk:"files_path"`
+	OutputPath         types.String `tfsdk:"output_path"`
+	CreateOutputDir    types.Bool   `tfsdk:"create_output_dir"`
+	PruneEmptyDir      types.Bool   `tfsdk:"prune_empty_dir"`
+	RetainOnDelete     types.Bool   `tfsdk:"retain_on_delete"`
+	ResolvedOutputPath types.String `tfsdk:"resolved_output_path"`
+	FileCount          types.Int64  `tfsdk:"file_count"`
+	CopiedFiles        types.List   `tfsdk:"copied_files"`
+}
+
+// Metadata returns the resource type name
+func (r *fileMigrationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_file_migration"
+}
+
+// Schema defines the schema for the resource
+func (r *fileMigrationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages conversion of a Chef cookbook's `files/` static assets into an Ansible role's `files/` directory.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier for the files migration",
+			},
+			"files_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the cookbook's `files` directory",
+			},
+			"output_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Directory where the static files will be written",
+			},
+			"create_output_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Create `output_path` when it does not exist (default: true). When false the directory must already exist, e.g. when it is pre-created with specific ownership and permissions",
+			},
+			"prune_empty_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove the output directory and its subdirectories on destroy when no other files remain in them (default: false)",
+			},
+			"retain_on_delete": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Leave the generated files in place on destroy and only remove the resource from state, e.g. once they are committed to a repository (default: false)",
+			},
+			"resolved_output_path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Directory the files are written to: `output_path` joined onto the provider `output_root` when `output_path` is relative",
+			},
+			"file_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of files in the output directory",
+			},
+			"copied_files": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Paths of the files in the output directory, relative to it and sorted",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *fileMigrationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = configureResource(req, resp)
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *fileMigrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan fileMigrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create output directory
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
+		return
+	}
+	existing := snapshotOutputs(r.client.resolveOutputPath(plan.OutputPath.ValueString()))
+
+	// Execute conversion and set state
+	r.executeFileConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Don't leave untracked files behind if the apply was interrupted
+	outputPath := plan.ResolvedOutputPath.ValueString()
+	copiedPaths := make([]string, 0, len(plan.CopiedFiles.Elements()))
+	for _, name := range knownListStrings(plan.CopiedFiles) {
+		copiedPaths = append(copiedPaths, filepath.Join(outputPath, filepath.FromSlash(name)))
+	}
+	if cleanupIfCanceled(ctx, &resp.Diagnostics, existing, copiedPaths...) {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *fileMigrationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state fileMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	files, err := listCopiedFiles(outputPath)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			errReadingCopiedFiles,
+			fmt.Sprintf("Could not list files in %s: %s", outputPath, err),
+		)
+		return
+	}
+
+	// The files were removed outside Terraform
+	if len(files) == 0 {
+		r.client.handleMissingArtifact(ctx, "any copied files in "+outputPath, &resp.State, &resp.Diagnostics)
+		return
+	}
+
+	state.FileCount = types.Int64Value(int64(len(files)))
+	state.CopiedFiles = typesListFromStringSlice(files)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *fileMigrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan fileMigrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check the output directory, creating it unless create_output_dir is false
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
+		return
+	}
+
+	// Execute conversion and set state
+	r.executeFileConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *fileMigrationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state fileMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	if r.client.retainGeneratedFiles(state.RetainOnDelete, outputPath, &resp.Diagnostics) {
+		return
+	}
+
+	// Delete the copied files, collecting the directories they lived in
+	dirs := make(map[string]string)
+	for _, name := range knownListStrings(state.CopiedFiles) {
+		filePath := filepath.Join(outputPath, filepath.FromSlash(name))
+		deleteGeneratedFile(filePath, "copied file", &resp.Diagnostics)
+		if dir := filepath.Dir(filePath); dir != outputPath {
+			dirs[dir] = ""
+		}
+	}
+
+	// Prune the deepest directories first so their parents can empty out
+	sortedDirs := sortedKeys(dirs)
+	for i := len(sortedDirs) - 1; i >= 0; i-- {
+		pruneEmptyDir(state.PruneEmptyDir, sortedDirs[i], &resp.Diagnostics)
+	}
+	pruneEmptyDir(state.PruneEmptyDir, outputPath, &resp.Diagnostics)
+}
+
+// executeFileConversion is a helper that encapsulates the common logic for Create and Update.
+// It copies the cookbook files, lists the output, and updates the model state.
+func (r *fileMigrationResource) executeFileConversion(ctx context.Context, model *fileMigrationResourceModel, diagnostics *diag.Diagnostics) {
+	filesPath := model.FilesPath.ValueString()
+	outputPath := r.client.resolveOutputPath(model.OutputPath.ValueString())
+	id := fileMigrationID(r.client, filesPath)
+	ctx = withReportResourceID(ctx, id)
+
+	// Call souschef CLI to convert the cookbook files
+	args := []string{"convert-files", "--files-path", filesPath, "--output-path", outputPath}
+	if _, ok := executeSousChefCommand(ctx, r.client, r.client.dryRunArgs(args), diagnostics); !ok {
+		return
+	}
+
+	// A dry run copies nothing, so there are no files to list
+	files := []string{}
+	var err error
+	if !r.client.isDryRun() {
+		files, err = listCopiedFiles(outputPath)
+	}
+	if err != nil {
+		diagnostics.AddError(
+			errReadingCopiedFiles,
+			fmt.Sprintf("Could not list files in %s: %s", outputPath, err),
+		)
+		return
+	}
+
+	// Set state
+	model.ID = types.StringValue(id)
+	model.ResolvedOutputPath = types.StringValue(outputPath)
+	model.FileCount = types.Int64Value(int64(len(files)))
+	model.CopiedFiles = typesListFromStringSlice(files)
+}
+
+// ImportState imports an existing resource into Terraform
+func (r *fileMigrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format: files_path|output_path
+	parts, err := importIDParts(req.ID, "files_path", "output_path")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID must be in format: files_path|output_path",
+		)
+		return
+	}
+
+	filesPath := parts[0]
+	configuredOutputPath := parts[1]
+	outputPath := r.client.resolveOutputPath(configuredOutputPath)
+
+	// Validate that the cookbook files directory exists
+	if !checkFileExists(filesPath, "Files directory", &resp.Diagnostics) {
+		return
+	}
+
+	files, err := listCopiedFiles(outputPath)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			errReadingCopiedFiles,
+			fmt.Sprintf("Could not list files in %s: %s", outputPath, err),
+		)
+		return
+	}
+	if len(files) == 0 {
+		resp.Diagnostics.AddError(
+			"Copied files not found",
+			fmt.Sprintf("No files exist in output directory: %s", outputPath),
+		)
+		return
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("files_path"), filesPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_path"), configuredOutputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resolved_output_path"), outputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("file_count"), int64(len(files)))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("copied_files"), files)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), fileMigrationID(r.client, filesPath))...)
+}
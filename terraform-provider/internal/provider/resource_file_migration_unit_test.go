@@ -0,0 +1,158 @@
+// Package provider contains unit tests for the cookbook files migration resource.
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testSampleCookbookFiles are the static files in the sample cookbook fixture.
+var testSampleCookbookFiles = []string{"default/logrotate/app", "default/motd", "default/scripts/healthcheck.sh"}
+
+// newFileMigrationPlan returns a plan model converting filesPath into outputPath.
+func newFileMigrationPlan(filesPath, outputPath string, prune bool) fileMigrationResourceModel {
+	return fileMigrationResourceModel{
+		FilesPath:     types.StringValue(filesPath),
+		OutputPath:    types.StringValue(outputPath),
+		PruneEmptyDir: types.BoolValue(prune),
+		CopiedFiles:   types.ListUnknown(types.StringType),
+	}
+}
+
+func TestFileMigrationLifecycle(t *testing.T) {
+	r := &fileMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	filesPath := filepath.Join(getFixturePath("sample_cookbook"), "files")
+	outputDir := filepath.Join(t.TempDir(), "files")
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, newFileMigrationPlan(filesPath, outputDir, true))}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+
+	var state fileMigrationResourceModel
+	if diags := createResp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.ID.ValueString() != "files-sample_cookbook" {
+		t.Fatalf("unexpected id %q", state.ID.ValueString())
+	}
+	if state.FileCount.ValueInt64() != 3 {
+		t.Fatalf("expected 3 files, got %d", state.FileCount.ValueInt64())
+	}
+	if got := stringList(t, state.CopiedFiles); !reflect.DeepEqual(got, testSampleCookbookFiles) {
+		t.Fatalf("expected copied_files %v, got %v", testSampleCookbookFiles, got)
+	}
+
+	// Read picks up files added outside Terraform
+	extraPath := filepath.Join(outputDir, "default", "banner")
+	if err := os.WriteFile(extraPath, []byte("welcome\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	var refreshed fileMigrationResourceModel
+	readResp.State.Get(context.Background(), &refreshed)
+	if refreshed.FileCount.ValueInt64() != 4 {
+		t.Fatalf("expected Read to list 4 files, got %v", stringList(t, refreshed.CopiedFiles))
+	}
+
+	// Update copies the files again
+	updateResp := &resource.UpdateResponse{State: readResp.State}
+	r.Update(context.Background(), resource.UpdateRequest{
+		Plan:  newPlan(t, schema, newFileMigrationPlan(filesPath, outputDir, true)),
+		State: readResp.State,
+	}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, updateResp.Diagnostics)
+	}
+
+	// ImportState reconstructs the same resource
+	importResp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: filesPath + "|" + outputDir}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, importResp.Diagnostics)
+	}
+	var imported fileMigrationResourceModel
+	importResp.State.Get(context.Background(), &imported)
+	if imported.ID.ValueString() != state.ID.ValueString() || imported.FileCount.ValueInt64() != 4 {
+		t.Fatalf("unexpected imported state: %+v", imported)
+	}
+
+	// Delete removes every copied file and prunes the emptied directories
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: updateResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+	}
+	if _, err := os.Stat(outputDir); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be removed, got %v", outputDir, err)
+	}
+
+	readResp = &resource.ReadResponse{State: updateResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: updateResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Fatal("expected resource to be removed when the output directory is empty")
+	}
+}
+
+func TestFileMigrationCreateErrors(t *testing.T) {
+	r := &fileMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	filesPath := filepath.Join(getFixturePath("sample_cookbook"), "files")
+
+	tests := map[string]struct {
+		filesPath string
+		failCLI   bool
+	}{
+		"missing files directory": {filesPath: filepath.Join(t.TempDir(), "files")},
+		"CLI failure":             {filesPath: filesPath, failCLI: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tt.failCLI {
+				t.Setenv("SOUSCHEF_TEST_FAIL", "convert-files")
+			}
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+			r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, newFileMigrationPlan(tt.filesPath, t.TempDir(), false))}, resp)
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestFileMigrationImportStateErrors(t *testing.T) {
+	r := &fileMigrationResource{}
+	schema := newResourceSchema(t, r)
+	filesPath := filepath.Join(getFixturePath("sample_cookbook"), "files")
+
+	for _, id := range []string{filesPath, filepath.Join(t.TempDir(), "files") + "|" + t.TempDir(), filesPath + "|" + t.TempDir()} {
+		resp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+		r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, resp)
+		if !resp.Diagnostics.HasError() {
+			t.Fatalf("expected error importing %q", id)
+		}
+	}
+}
+
+func TestListCopiedFilesMissingDirectory(t *testing.T) {
+	files, err := listCopiedFiles(filepath.Join(t.TempDir(), "missing"))
+	if err != nil || len(files) != 0 {
+		t.Fatalf("expected no files and no error, got %v, %v", files, err)
+	}
+}
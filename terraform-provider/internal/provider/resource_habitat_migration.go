@@ -11,8 +11,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -34,12 +32,16 @@ type habitatMigrationResource struct {
 
 // habitatMigrationResourceModel describes the resource data model
 type habitatMigrationResourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	PlanPath          types.String `tfsdk:"plan_path"`
-	OutputPath        types.String `tfsdk:"output_path"`
-	BaseImage         types.String `tfsdk:"base_image"`
-	PackageName       types.String `tfsdk:"package_name"`
-	DockerfileContent types.String `tfsdk:"dockerfile_content"`
+	ID                 types.String `tfsdk:"id"`
+	PlanPath           types.String `tfsdk:"plan_path"`
+	OutputPath         types.String `tfsdk:"output_path"`
+	CreateOutputDir    types.Bool   `tfsdk:"create_output_dir"`
+	PruneEmptyDir      types.Bool   `tfsdk:"prune_empty_dir"`
+	RetainOnDelete     types.Bool   `tfsdk:"retain_on_delete"`
+	ResolvedOutputPath types.String `tfsdk:"resolved_output_path"`
+	BaseImage          types.String `tfsdk:"base_image"`
+	PackageName        types.String `tfsdk:"package_name"`
+	DockerfileContent  types.String `tfsdk:"dockerfile_content"`
 }
 
 const (
@@ -48,6 +50,33 @@ const (
 	habitatIDFormat      = "habitat-%s"
 )
 
+// parseDockerfileBaseImage returns the image named by the first FROM
+// instruction in a Dockerfile, skipping flags such as --platform.
+func parseDockerfileBaseImage(content string) (string, bool) {
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || !strings.EqualFold(fields[0], "FROM") {
+			continue
+		}
+		for _, field := range fields[1:] {
+			if !strings.HasPrefix(field, "--") {
+				return field, true
+			}
+		}
+		return "", false
+	}
+	return "", false
+}
+
+// dockerfileBaseImage returns the Dockerfile's base image, falling back to
+// defaultBaseImage when it has no FROM instruction.
+func dockerfileBaseImage(content string) string {
+	if baseImage, ok := parseDockerfileBaseImage(content); ok {
+		return baseImage
+	}
+	return defaultBaseImage
+}
+
 // Metadata returns the resource type name
 func (r *habitatMigrationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_habitat_migration"
@@ -71,13 +100,26 @@ func (r *habitatMigrationResource) Schema(ctx context.Context, req resource.Sche
 				Required:            true,
 				MarkdownDescription: "Directory where Dockerfile will be written",
 			},
+			"create_output_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Create `output_path` when it does not exist (default: true). When false the directory must already exist, e.g. when it is pre-created with specific ownership and permissions",
+			},
+			"prune_empty_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove the output directory on destroy when no other files remain in it (default: false)",
+			},
+			"retain_on_delete": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Leave the generated files in place on destroy and only remove the resource from state, e.g. once they are committed to a repository (default: false)",
+			},
+			"resolved_output_path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Directory the Dockerfile is written to: `output_path` joined onto the provider `output_root` when `output_path` is relative",
+			},
 			"base_image": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Base Docker image to use (default: ubuntu:latest)",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
-				},
+				MarkdownDescription: "Base Docker image to use. When unset, the SousChef CLI's default is used and recorded from the generated Dockerfile",
 			},
 			"package_name": schema.StringAttribute{
 				Computed:            true,
@@ -106,9 +148,10 @@ func (r *habitatMigrationResource) Create(ctx context.Context, req resource.Crea
 	}
 
 	// Create output directory
-	if !createOutputDirectory(plan.OutputPath.ValueString(), &resp.Diagnostics) {
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
 		return
 	}
+	existing := snapshotOutputs(r.client.resolveOutputPath(plan.OutputPath.ValueString()))
 
 	// Execute conversion and set state
 	r.executeHabitatConversion(ctx, &plan, &resp.Diagnostics)
@@ -116,6 +159,11 @@ func (r *habitatMigrationResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
+	// Don't leave an untracked Dockerfile behind if the apply was interrupted
+	if cleanupIfCanceled(ctx, &resp.Diagnostics, existing, filepath.Join(plan.ResolvedOutputPath.ValueString(), "Dockerfile")) {
+		return
+	}
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
@@ -129,17 +177,23 @@ func (r *habitatMigrationResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
-	dockerfilePath := filepath.Join(state.OutputPath.ValueString(), "Dockerfile")
+	dockerfilePath := filepath.Join(stateOutputPath(state.ResolvedOutputPath, state.OutputPath), "Dockerfile")
 
 	// Check if file exists and read content
 	if !readFileAndSetState(
 		ctx,
 		dockerfilePath,
 		"dockerfile_content",
-		func(content string) { state.DockerfileContent = types.StringValue(content) },
+		func(content string) {
+			state.DockerfileContent = types.StringValue(content)
+			// Surface manual edits to the FROM line as base_image drift
+			if baseImage, ok := parseDockerfileBaseImage(content); ok {
+				state.BaseImage = types.StringValue(baseImage)
+			}
+		},
 		errReadingDockerfile,
 		&resp.Diagnostics,
-		resp.State.RemoveResource,
+		r.client.missingArtifactHandler("Dockerfile "+dockerfilePath, &resp.State, &resp.Diagnostics),
 	) {
 		return
 	}
@@ -157,6 +211,11 @@ func (r *habitatMigrationResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
+	// Check the output directory, creating it unless create_output_dir is false
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
+		return
+	}
+
 	// Execute conversion and set state
 	r.executeHabitatConversion(ctx, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -176,38 +235,43 @@ func (r *habitatMigrationResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
-	dockerfilePath := filepath.Join(state.OutputPath.ValueString(), "Dockerfile")
-	deleteGeneratedFile(dockerfilePath, "Dockerfile", &resp.Diagnostics)
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	if r.client.retainGeneratedFiles(state.RetainOnDelete, outputPath, &resp.Diagnostics) {
+		return
+	}
+	deleteGeneratedFile(filepath.Join(outputPath, "Dockerfile"), "Dockerfile", &resp.Diagnostics)
+	pruneEmptyDir(state.PruneEmptyDir, outputPath, &resp.Diagnostics)
 }
 
 // executeHabitatConversion is a helper that encapsulates the common logic for Create and Update.
 // It executes the habitat conversion, reads the output, and updates the model state.
 func (r *habitatMigrationResource) executeHabitatConversion(ctx context.Context, model *habitatMigrationResourceModel, diagnostics *diag.Diagnostics) {
 	planPath := model.PlanPath.ValueString()
-	outputPath := model.OutputPath.ValueString()
-	baseImage := defaultBaseImage
-	if !model.BaseImage.IsNull() && model.BaseImage.ValueString() != "" {
-		baseImage = model.BaseImage.ValueString()
+	outputPath := r.client.resolveOutputPath(model.OutputPath.ValueString())
+	packageName := filepath.Base(filepath.Dir(planPath))
+	id := r.client.resourceID(fmt.Sprintf(habitatIDFormat, packageName), planPath)
+	ctx = withReportResourceID(ctx, id)
+
+	// Leave an unset base_image to the CLI, whose default may change between
+	// versions, rather than pinning the provider's fallback or a prior state value
+	args := []string{"convert-habitat", "--plan-path", planPath, "--output-path", outputPath}
+	baseImage := model.BaseImage.ValueString()
+	if baseImage != "" {
+		args = append(args, "--base-image", baseImage)
 	}
-
-	// Call souschef CLI to convert Habitat plan
-	args := []string{"convert-habitat", "--plan-path", planPath, "--output-path", outputPath, "--base-image", baseImage}
-	if _, ok := executeSousChefCommand(ctx, r.client.Path, args, "Error converting Habitat plan", diagnostics); !ok {
+	content, ok := executeConversion(ctx, r.client, args, filepath.Join(outputPath, "Dockerfile"), errReadingDockerfile, diagnostics)
+	if !ok {
 		return
 	}
 
-	// Read generated Dockerfile
-	dockerfilePath := filepath.Join(outputPath, "Dockerfile")
-	content := readGeneratedFile(dockerfilePath, errReadingDockerfile, diagnostics)
-	if diagnostics.HasError() {
-		return
+	// Record the image the CLI actually used when none was configured
+	if baseImage == "" {
+		baseImage = dockerfileBaseImage(string(content))
 	}
 
-	// Extract package name from plan path
-	packageName := filepath.Base(filepath.Dir(planPath))
-
 	// Set state
-	model.ID = types.StringValue(fmt.Sprintf(habitatIDFormat, packageName))
+	model.ID = types.StringValue(id)
+	model.ResolvedOutputPath = types.StringValue(outputPath)
 	model.BaseImage = types.StringValue(baseImage)
 	model.PackageName = types.StringValue(packageName)
 	model.DockerfileContent = types.StringValue(string(content))
@@ -216,7 +280,11 @@ func (r *habitatMigrationResource) executeHabitatConversion(ctx context.Context,
 // ImportState imports an existing resource into Terraform
 func (r *habitatMigrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Import ID format: plan_path|output_path|base_image (base_image is optional)
-	parts := strings.Split(req.ID, "|")
+	parts, err := importIDParts(req.ID, "plan_path", "output_path", "base_image")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
 	if len(parts) < 2 || len(parts) > 3 {
 		resp.Diagnostics.AddError(
 			"Invalid import ID",
@@ -226,9 +294,10 @@ func (r *habitatMigrationResource) ImportState(ctx context.Context, req resource
 	}
 
 	planPath := parts[0]
-	outputPath := parts[1]
-	baseImage := defaultBaseImage // default
-	if len(parts) == 3 && parts[2] != "" {
+	configuredOutputPath := parts[1]
+	outputPath := r.client.resolveOutputPath(configuredOutputPath)
+	baseImage := ""
+	if len(parts) == 3 {
 		baseImage = parts[2]
 	}
 
@@ -249,14 +318,20 @@ func (r *habitatMigrationResource) ImportState(ctx context.Context, req resource
 		return
 	}
 
+	// Record the Dockerfile's FROM image, as Read does, when none was given
+	if baseImage == "" {
+		baseImage = dockerfileBaseImage(string(content))
+	}
+
 	// Extract package name from plan path (parent directory name)
 	packageName := filepath.Base(filepath.Dir(planPath))
 
 	// Set state
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("plan_path"), planPath)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_path"), outputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_path"), configuredOutputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resolved_output_path"), outputPath)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("base_image"), baseImage)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("package_name"), packageName)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dockerfile_content"), string(content))...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), fmt.Sprintf(habitatIDFormat, packageName))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), r.client.resourceID(fmt.Sprintf(habitatIDFormat, packageName), planPath))...)
 }
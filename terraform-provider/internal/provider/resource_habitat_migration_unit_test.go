@@ -0,0 +1,122 @@
+// Package provider contains unit tests for the Habitat migration resource.
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	resourceschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseDockerfileBaseImage(t *testing.T) {
+	tests := map[string]string{
+		"FROM ubuntu:22.04\nRUN true\n":                           "ubuntu:22.04",
+		"# syntax=docker/dockerfile:1\nfrom debian:12 AS build\n": "debian:12",
+		"FROM --platform=linux/amd64 alpine:3.20\n":               "alpine:3.20",
+		"FROM golang:1.25 AS build\nFROM scratch\n":               "golang:1.25",
+	}
+	for content, want := range tests {
+		got, ok := parseDockerfileBaseImage(content)
+		if !ok || got != want {
+			t.Errorf("parseDockerfileBaseImage(%q) = %q, %t; want %q", content, got, ok, want)
+		}
+	}
+
+	for _, content := range []string{"", "RUN true\n", "FROM --platform=linux/amd64\n"} {
+		if got, ok := parseDockerfileBaseImage(content); ok {
+			t.Errorf("parseDockerfileBaseImage(%q) = %q, expected no base image", content, got)
+		}
+	}
+}
+
+func TestHabitatMigrationReadDetectsBaseImageDrift(t *testing.T) {
+	r := &habitatMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputDir := t.TempDir()
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, habitatMigrationResourceModel{
+		PlanPath:   types.StringValue(testTmpPlanSh),
+		OutputPath: types.StringValue(outputDir),
+		BaseImage:  types.StringValue("ubuntu:22.04"),
+	})}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+
+	// Edit the FROM line outside Terraform
+	dockerfile := "FROM debian:12\nRUN apt-get update\n"
+	if err := os.WriteFile(filepath.Join(outputDir, "Dockerfile"), []byte(dockerfile), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+
+	var state habitatMigrationResourceModel
+	if diags := readResp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.BaseImage.ValueString() != "debian:12" {
+		t.Fatalf("expected base_image to reflect the edited FROM line, got %q", state.BaseImage.ValueString())
+	}
+	if state.DockerfileContent.ValueString() != dockerfile {
+		t.Fatalf("expected dockerfile_content to be refreshed, got %q", state.DockerfileContent.ValueString())
+	}
+}
+
+func TestHabitatMigrationBaseImageFollowsCLIDefault(t *testing.T) {
+	r := &habitatMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputDir := t.TempDir()
+
+	if attr := schema.Attributes["base_image"]; len(attr.(resourceschema.StringAttribute).PlanModifiers) != 0 {
+		t.Fatal("expected base_image to be recomputed rather than copied from prior state")
+	}
+
+	planModel := habitatMigrationResourceModel{
+		PlanPath:   types.StringValue(testTmpPlanSh),
+		OutputPath: types.StringValue(outputDir),
+		BaseImage:  types.StringUnknown(),
+	}
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, planModel)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+
+	var state habitatMigrationResourceModel
+	createResp.State.Get(context.Background(), &state)
+	if state.BaseImage.ValueString() != "ubuntu:latest" {
+		t.Fatalf("expected the CLI default base image, got %q", state.BaseImage.ValueString())
+	}
+
+	// A newer CLI defaults to a different image
+	t.Setenv("SOUSCHEF_TEST_DEFAULT_BASE_IMAGE", "ubuntu:24.04")
+
+	updateResp := &resource.UpdateResponse{State: createResp.State}
+	r.Update(context.Background(), resource.UpdateRequest{
+		Plan:  newPlan(t, schema, planModel),
+		State: createResp.State,
+	}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, updateResp.Diagnostics)
+	}
+
+	updateResp.State.Get(context.Background(), &state)
+	if state.BaseImage.ValueString() != "ubuntu:24.04" {
+		t.Fatalf("expected base_image to follow the new CLI default, got %q", state.BaseImage.ValueString())
+	}
+	if state.DockerfileContent.ValueString() != "FROM ubuntu:24.04\n" {
+		t.Fatalf("unexpected dockerfile_content %q", state.DockerfileContent.ValueString())
+	}
+}
@@ -3,14 +3,48 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"math/rand/v2"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+const (
+	// readRetryAttempts is the number of times Read tries to read a generated file.
+	readRetryAttempts = 3
+	// readRetryDelay is the base pause between read attempts.
+	readRetryDelay = 100 * time.Millisecond
+	// maxRetryDelay caps the exponential backoff between retries.
+	maxRetryDelay = 2 * time.Second
+	// retryJitterFraction is the maximum relative jitter applied to a backoff.
+	retryJitterFraction = 0.2
+)
+
+// backoffWithJitter returns the pause before retry number attempt (counting
+// from 0): base * 2^attempt, capped at maxRetryDelay, with ±20% jitter so
+// that concurrent applies do not retry in lockstep.
+func backoffWithJitter(attempt int, base time.Duration) time.Duration {
+	delay := maxRetryDelay
+	if attempt >= 0 && attempt < 32 && base<<attempt < maxRetryDelay {
+		delay = base << attempt
+	}
+	jitter := (rand.Float64()*2 - 1) * retryJitterFraction
+	return time.Duration(float64(delay) * (1 + jitter))
+}
+
 // configureResource is a common helper for resource Configure methods.
 // It extracts the SousChefClient from ProviderData and returns it,
 // or adds an error diagnostic if the type is unexpected.
@@ -31,9 +65,18 @@ func configureResource(req resource.ConfigureRequest, resp *resource.ConfigureRe
 	return client
 }
 
-// createOutputDirectory creates a directory with the specified permissions.
+// createOutputDirectory creates a directory with the specified permissions
+// and checks that it is writable, so that a read-only output path is reported
+// before the CLI runs rather than as a failed CLI write.
 // Adds an error diagnostic on failure and returns false if there was an error.
-func createOutputDirectory(outputPath string, diagnostics *diag.Diagnostics) bool {
+// In dry-run mode nothing is created.
+func (c *SousChefClient) createOutputDirectory(outputPath string, diagnostics *diag.Diagnostics) bool {
+	if c.isDryRun() {
+		return true
+	}
+	if !checkOutputPathNotFile(outputPath, diagnostics) {
+		return false
+	}
 	if err := osMkdirAll(outputPath, 0755); err != nil {
 		diagnostics.AddError(
 			"Error creating output directory",
@@ -41,9 +84,120 @@ func createOutputDirectory(outputPath string, diagnostics *diag.Diagnostics) boo
 		)
 		return false
 	}
+	if err := probeWritable(outputPath); err != nil {
+		diagnostics.AddError(
+			"Output path not writable",
+			fmt.Sprintf("output path is not writable: %s: %s", outputPath, err),
+		)
+		return false
+	}
+	return true
+}
+
+// checkOutputPathNotFile adds an error and returns false when outputPath is
+// an existing regular file, which os.MkdirAll would otherwise report as a
+// confusing "not a directory".
+func checkOutputPathNotFile(outputPath string, diagnostics *diag.Diagnostics) bool {
+	info, err := osStat(outputPath)
+	if err != nil || !info.Mode().IsRegular() {
+		return true
+	}
+	diagnostics.AddError(
+		"Output path is a file",
+		fmt.Sprintf("output_path must be a directory, but %s is a file", outputPath),
+	)
+	return false
+}
+
+// prepareOutputDirectory creates outputPath with createOutputDirectory unless
+// create_output_dir is false, in which case the directory must already exist:
+// environments that pre-create and permission output directories would rather
+// fail on a misconfigured path than have the provider create it.
+func (c *SousChefClient) prepareOutputDirectory(createOutputDir types.Bool, outputPath string, diagnostics *diag.Diagnostics) bool {
+	if createOutputDir.IsNull() || createOutputDir.IsUnknown() || createOutputDir.ValueBool() {
+		return c.createOutputDirectory(outputPath, diagnostics)
+	}
+	if c.isDryRun() {
+		return true
+	}
+	if !checkOutputPathNotFile(outputPath, diagnostics) {
+		return false
+	}
+
+	info, err := osStat(outputPath)
+	if err != nil || !info.IsDir() {
+		detail := fmt.Sprintf("Output directory %s does not exist and create_output_dir is false. Create it before applying or unset create_output_dir.", outputPath)
+		if err == nil {
+			detail = fmt.Sprintf("Output path %s is not a directory.", outputPath)
+		} else if !os.IsNotExist(err) {
+			detail = fmt.Sprintf("Could not check output directory %s: %s", outputPath, err)
+		}
+		diagnostics.AddError("Output directory not found", detail)
+		return false
+	}
+	if err := probeWritable(outputPath); err != nil {
+		diagnostics.AddError(
+			"Output path not writable",
+			fmt.Sprintf("output path is not writable: %s: %s", outputPath, err),
+		)
+		return false
+	}
 	return true
 }
 
+// writeFileAtomic writes content to filePath through a temporary file in the
+// same directory that is renamed into place, so readers never observe a
+// partially written file if the provider is interrupted mid-write.
+func writeFileAtomic(filePath string, content []byte, perm os.FileMode) (err error) {
+	tmp, err := osCreateTemp(filepath.Dir(filePath), "."+filepath.Base(filePath)+".tmp-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = osRemove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(content); err != nil {
+		return err
+	}
+	if err = tmp.Chmod(perm); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return osRename(tmp.Name(), filePath)
+}
+
+// probeWritable creates and removes a uniquely named file in dir. Unique
+// names keep concurrent resources sharing an output path from racing.
+func probeWritable(dir string) error {
+	probe, err := osCreateTemp(dir, ".souschef-write-probe-")
+	if err != nil {
+		return err
+	}
+	closeErr := probe.Close()
+	if err := osRemove(probe.Name()); err != nil {
+		return err
+	}
+	return closeErr
+}
+
+// stateOutputPath returns the resolved output path recorded in state, falling
+// back to output_path for state written before resolved_output_path existed.
+func stateOutputPath(resolved, configured types.String) string {
+	if !resolved.IsNull() && !resolved.IsUnknown() && resolved.ValueString() != "" {
+		return resolved.ValueString()
+	}
+	return configured.ValueString()
+}
+
 // readGeneratedFile reads a file and returns its content as a string.
 // Adds an error diagnostic on failure and returns empty string.
 func readGeneratedFile(filePath, errorTitle string, diagnostics *diag.Diagnostics) string {
@@ -58,27 +212,116 @@ func readGeneratedFile(filePath, errorTitle string, diagnostics *diag.Diagnostic
 	return string(content)
 }
 
-// executeSousChefCommand runs a souschef CLI command and returns the output.
+// readFileWithRetry reads a file, retrying transient errors so that Read does
+// not fail on a file the CLI is still writing during a parallel apply.
+// Not-exist errors are returned immediately; retries stop when ctx is done.
+func readFileWithRetry(ctx context.Context, filePath string) ([]byte, error) {
+	var lastErr error
+	for attempt := 1; attempt <= readRetryAttempts; attempt++ {
+		content, err := osReadFile(filePath)
+		if err == nil || os.IsNotExist(err) {
+			return content, err
+		}
+		lastErr = err
+
+		if attempt == readRetryAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, lastErr
+		case <-time.After(backoffWithJitter(attempt-1, readRetryDelay)):
+		}
+	}
+	return nil, lastErr
+}
+
+// executeConversion runs a conversion subcommand that writes a single file to
+// filePath and returns that file's content. In dry-run mode the command is
+// passed --dry-run and the content is taken from its stdout instead, as the
+// CLI writes nothing. Adds an error diagnostic on failure and returns false.
+func executeConversion(
+	ctx context.Context,
+	client *SousChefClient,
+	args []string,
+	filePath, errorTitle string,
+	diagnostics *diag.Diagnostics,
+) (string, bool) {
+	output, ok := executeSousChefCommand(ctx, client, client.dryRunArgs(args), diagnostics)
+	if !ok {
+		return "", false
+	}
+	if client.isDryRun() {
+		return string(output), true
+	}
+	content := readGeneratedFile(filePath, errorTitle, diagnostics)
+	return content, !diagnostics.HasError()
+}
+
+// executeSousChefCommand runs a souschef CLI command and returns its stdout.
 // Adds an error diagnostic on failure and returns false.
 func executeSousChefCommand(
 	ctx context.Context,
-	clientPath string,
+	client *SousChefClient,
 	args []string,
-	errorTitle string,
 	diagnostics *diag.Diagnostics,
 ) ([]byte, bool) {
-	cmd := execCommandContext(ctx, clientPath, args...)
-	output, err := cmd.CombinedOutput()
+	output, err := runCLI(ctx, client, diagnostics, args...)
 	if err != nil {
-		diagnostics.AddError(
-			errorTitle,
-			fmt.Sprintf("Command failed: %s\nOutput: %s", err, string(output)),
-		)
+		diagnostics.Append(diagnosticFromError(err))
 		return output, false
 	}
 	return output, true
 }
 
+// outputSnapshot is the set of paths that existed below a Create's output
+// directories before it ran the conversion.
+type outputSnapshot map[string]bool
+
+// snapshotOutputs records every path below roots, so cleanupIfCanceled can
+// tell output a Create wrote from output that predates it. Roots that do not
+// exist contribute nothing.
+func snapshotOutputs(roots ...string) outputSnapshot {
+	existing := outputSnapshot{}
+	for _, root := range roots {
+		_ = filepath.WalkDir(root, func(entryPath string, _ fs.DirEntry, err error) error {
+			if err == nil {
+				existing[filepath.Clean(entryPath)] = true
+			}
+			return nil
+		})
+	}
+	return existing
+}
+
+// cleanupIfCanceled removes the files a Create just generated when ctx was
+// canceled before the state was committed, so an interrupted apply does not
+// leave untracked output behind. Paths in existing were there before the
+// Create ran and are kept. It reports whether the context was canceled, in
+// which case the caller must not set state.
+func cleanupIfCanceled(ctx context.Context, diagnostics *diag.Diagnostics, existing outputSnapshot, paths ...string) bool {
+	if ctx.Err() == nil {
+		return false
+	}
+
+	for _, generatedPath := range paths {
+		if existing[filepath.Clean(generatedPath)] {
+			continue
+		}
+		if err := osRemoveAll(generatedPath); err != nil {
+			diagnostics.AddWarning(
+				"Error removing generated output",
+				fmt.Sprintf("Could not remove %s after the operation was interrupted: %s", generatedPath, err),
+			)
+		}
+	}
+	diagnostics.AddError(
+		"Operation interrupted",
+		fmt.Sprintf("The context was canceled before state was saved (%s); generated output was removed.", ctx.Err()),
+	)
+	return true
+}
+
 // deleteGeneratedFile deletes a file and adds a warning if deletion fails
 // (but not if the file doesn't exist).
 func deleteGeneratedFile(filePath, fileType string, diagnostics *diag.Diagnostics) {
@@ -90,6 +333,131 @@ func deleteGeneratedFile(filePath, fileType string, diagnostics *diag.Diagnostic
 	}
 }
 
+// parseOutputFileMode parses an output_file_mode octal permission string
+// such as "0600" or "640".
+func parseOutputFileMode(mode string) (os.FileMode, error) {
+	value, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil || value > uint64(os.ModePerm) {
+		return 0, fmt.Errorf("output_file_mode must be an octal permission mode such as \"0600\", got %q", mode)
+	}
+	return os.FileMode(value), nil
+}
+
+// formatFileMode formats the permission bits of mode as four octal digits,
+// e.g. "0600".
+func formatFileMode(mode os.FileMode) string {
+	return fmt.Sprintf("%04o", mode.Perm())
+}
+
+// validateOutputFileMode checks output_file_mode parses as an octal
+// permission mode.
+func validateOutputFileMode(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var outputFileMode types.String
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("output_file_mode"), &outputFileMode)...)
+	if diagnostics.HasError() || outputFileMode.IsNull() || outputFileMode.IsUnknown() {
+		return
+	}
+
+	if _, err := parseOutputFileMode(outputFileMode.ValueString()); err != nil {
+		diagnostics.AddAttributeError(path.Root("output_file_mode"), "Invalid output file mode", err.Error())
+	}
+}
+
+// applyOutputFileMode changes the mode of the generated files to
+// output_file_mode and returns the applied mode, or null when
+// output_file_mode is unset. A dry run writes no files, so nothing is applied.
+func (c *SousChefClient) applyOutputFileMode(outputFileMode types.String, diagnostics *diag.Diagnostics, paths ...string) types.String {
+	if outputFileMode.IsNull() || outputFileMode.IsUnknown() || c.isDryRun() {
+		return types.StringNull()
+	}
+	mode, err := parseOutputFileMode(outputFileMode.ValueString())
+	if err != nil {
+		diagnostics.AddAttributeError(path.Root("output_file_mode"), "Invalid output file mode", err.Error())
+		return types.StringNull()
+	}
+
+	for _, filePath := range paths {
+		if err := osChmod(filePath, mode); err != nil {
+			diagnostics.AddError(
+				"Error setting output file mode",
+				fmt.Sprintf("Could not change the mode of %s to %s: %s", filePath, formatFileMode(mode), err),
+			)
+			return types.StringNull()
+		}
+	}
+	return types.StringValue(formatFileMode(mode))
+}
+
+// refreshOutputFileMode records in appliedFileMode the mode the generated
+// files have on disk. When one no longer has output_file_mode, the mode found
+// is recorded as outputFileMode too, so the next plan updates the resource
+// and applies the configured mode again. Missing files are skipped.
+func refreshOutputFileMode(outputFileMode, appliedFileMode *types.String, diagnostics *diag.Diagnostics, paths ...string) {
+	if outputFileMode.IsNull() || outputFileMode.IsUnknown() {
+		*appliedFileMode = types.StringNull()
+		return
+	}
+	mode, err := parseOutputFileMode(outputFileMode.ValueString())
+	if err != nil {
+		diagnostics.AddAttributeError(path.Root("output_file_mode"), "Invalid output file mode", err.Error())
+		return
+	}
+
+	for _, filePath := range paths {
+		info, err := osStat(filePath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			diagnostics.AddError(
+				"Error checking output file mode",
+				fmt.Sprintf("Could not stat %s: %s", filePath, err),
+			)
+			return
+		}
+		if info.Mode().Perm() != mode {
+			*outputFileMode = types.StringValue(formatFileMode(info.Mode()))
+			*appliedFileMode = *outputFileMode
+			return
+		}
+	}
+	*appliedFileMode = types.StringValue(formatFileMode(mode))
+}
+
+// pruneEmptyDir removes dir when prune is set and the directory is empty.
+// A directory that still holds other files is left in place.
+func pruneEmptyDir(prune types.Bool, dir string, diagnostics *diag.Diagnostics) {
+	if !prune.ValueBool() || dir == "" {
+		return
+	}
+	err := osRemove(dir)
+	if err == nil || os.IsNotExist(err) || errors.Is(err, syscall.ENOTEMPTY) || errors.Is(err, syscall.EEXIST) {
+		return
+	}
+	diagnostics.AddWarning(
+		"Error pruning output directory",
+		fmt.Sprintf("Could not remove empty directory %s: %s", dir, err),
+	)
+}
+
+// retainGeneratedFiles reports whether Delete should leave the generated files
+// in outputPath in place because retain_on_delete is set, noting it in a
+// warning so the destroy output shows the files were kept. In dry-run mode
+// Delete is always a no-op, as the resource never wrote anything.
+func (c *SousChefClient) retainGeneratedFiles(retain types.Bool, outputPath string, diagnostics *diag.Diagnostics) bool {
+	if c.isDryRun() {
+		return true
+	}
+	if !retain.ValueBool() {
+		return false
+	}
+	diagnostics.AddWarning(
+		"Generated files retained",
+		fmt.Sprintf("retain_on_delete is set, so the generated files in %s were left in place and the resource was only removed from Terraform state.", outputPath),
+	)
+	return true
+}
+
 // checkFileExists checks if a file exists and returns whether it exists.
 // If it doesn't exist, adds an error diagnostic and returns false.
 func checkFileExists(filePath, fileType string, diagnostics *diag.Diagnostics) bool {
@@ -121,17 +489,54 @@ func readFileAndSetState(
 		return false
 	}
 
-	// Read file content
-	content := readGeneratedFile(filePath, errorTitle, diagnostics)
-	if diagnostics.HasError() {
+	// Read file content, tolerating a file that is still being written
+	content, err := readFileWithRetry(ctx, filePath)
+	if os.IsNotExist(err) {
+		removeResource(ctx)
+		return false
+	}
+	if err != nil {
+		diagnostics.AddError(
+			errorTitle,
+			fmt.Sprintf("Could not read file %s: %s", filePath, err),
+		)
 		return false
 	}
 
 	// Update the content field
-	contentSetter(content)
+	contentSetter(string(content))
 	return true
 }
 
+// handleMissingArtifact is called by Read when a resource's generated output
+// no longer exists. By default the resource is removed from state so the next
+// apply recreates it; with missing_artifact_behavior set to "error" an error
+// diagnostic naming the artifact is reported instead. In dry-run mode the
+// artifact was never written, so the state is kept as it is.
+func (c *SousChefClient) handleMissingArtifact(ctx context.Context, artifact string, state *tfsdk.State, diagnostics *diag.Diagnostics) {
+	if c.isDryRun() {
+		return
+	}
+	if c == nil || c.MissingArtifactBehavior != missingArtifactError {
+		state.RemoveResource(ctx)
+		return
+	}
+	diagnostics.AddError(
+		"Generated artifact not found",
+		fmt.Sprintf("Could not find %s. The provider missing_artifact_behavior is %q, so the resource was kept in state; "+
+			"restore the artifact, or set missing_artifact_behavior to %q to recreate it on the next apply.",
+			artifact, missingArtifactError, missingArtifactRemove),
+	)
+}
+
+// missingArtifactHandler returns handleMissingArtifact bound to artifact, for
+// use as the removeResource callback of readFileAndSetState.
+func (c *SousChefClient) missingArtifactHandler(artifact string, state *tfsdk.State, diagnostics *diag.Diagnostics) func(context.Context) {
+	return func(ctx context.Context) {
+		c.handleMissingArtifact(ctx, artifact, state, diagnostics)
+	}
+}
+
 // stringSliceFromTypesList converts []types.String to []string.
 func stringSliceFromTypesList(typesList []types.String) []string {
 	result := make([]string, len(typesList))
@@ -140,3 +545,89 @@ func stringSliceFromTypesList(typesList []types.String) []string {
 	}
 	return result
 }
+
+// typesListFromStringSlice converts []string to a types.List of strings.
+func typesListFromStringSlice(values []string) types.List {
+	elements := make([]attr.Value, len(values))
+	for i, v := range values {
+		elements[i] = types.StringValue(v)
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
+// isNestedPath reports whether childPath resolves to parentPath or a directory inside it.
+func isNestedPath(parentPath, childPath string) (bool, error) {
+	parentAbs, err := filepath.Abs(parentPath)
+	if err != nil {
+		return false, err
+	}
+
+	childAbs, err := filepath.Abs(childPath)
+	if err != nil {
+		return false, err
+	}
+
+	rel, err := filepath.Rel(parentAbs, childAbs)
+	if err != nil {
+		return false, err
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)), nil
+}
+
+// validateOutputNotNested checks that output_path is not inside cookbook_path.
+// Generated playbooks written into the cookbook tree are picked up again on the
+// next conversion, so this is an error unless the provider allows nested output,
+// in which case it is reported as a warning.
+func validateOutputNotNested(
+	ctx context.Context,
+	config tfsdk.Config,
+	client *SousChefClient,
+	diagnostics *diag.Diagnostics,
+) {
+	// Provider data is not available during offline validation
+	if client == nil {
+		return
+	}
+
+	var cookbookPath, outputPath types.String
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("cookbook_path"), &cookbookPath)...)
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("output_path"), &outputPath)...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	if cookbookPath.IsNull() || cookbookPath.IsUnknown() || outputPath.IsNull() || outputPath.IsUnknown() {
+		return
+	}
+
+	nested, err := isNestedPath(cookbookPath.ValueString(), client.resolveOutputPath(outputPath.ValueString()))
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root("output_path"),
+			"Invalid output path",
+			fmt.Sprintf("Could not resolve output_path %s: %s", outputPath.ValueString(), err),
+		)
+		return
+	}
+
+	if !nested {
+		return
+	}
+
+	summary := "Output path inside cookbook path"
+	detail := fmt.Sprintf(
+		"output_path %s is inside cookbook_path %s. Generated files will be re-read on subsequent conversions.",
+		outputPath.ValueString(), cookbookPath.ValueString(),
+	)
+	if client.AllowNestedOutput {
+		diagnostics.AddAttributeWarning(path.Root("output_path"), summary, detail)
+		return
+	}
+
+	diagnostics.AddAttributeError(
+		path.Root("output_path"),
+		summary,
+		detail+" Set allow_nested_output in the provider to permit this.",
+	)
+}
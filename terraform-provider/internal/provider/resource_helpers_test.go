@@ -3,12 +3,17 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -64,7 +69,7 @@ func TestCreateOutputDirectory(t *testing.T) {
 			return nil
 		})
 		diags := &diag.Diagnostics{}
-		result := createOutputDirectory("/test", diags)
+		result := (&SousChefClient{}).createOutputDirectory(t.TempDir(), diags)
 		if !result {
 			t.Error(expectedTrue)
 		}
@@ -73,12 +78,26 @@ func TestCreateOutputDirectory(t *testing.T) {
 		}
 	})
 
+	t.Run("not writable", func(t *testing.T) {
+		outputPath := readOnlyDir(t)
+		diags := &diag.Diagnostics{}
+		if (&SousChefClient{}).createOutputDirectory(outputPath, diags) {
+			t.Error(expectedFalse)
+		}
+		if !diags.HasError() || diags.Errors()[0].Summary() != "Output path not writable" {
+			t.Fatalf("expected not writable diagnostic, got %v", diags)
+		}
+		if entries, _ := os.ReadDir(outputPath); len(entries) != 0 {
+			t.Fatalf("expected no probe file left behind, found %d entries", len(entries))
+		}
+	})
+
 	t.Run("mkdir fails", func(t *testing.T) {
 		withOsMkdirAll(t, func(string, os.FileMode) error {
 			return errors.New(permissionDenied)
 		})
 		diags := &diag.Diagnostics{}
-		result := createOutputDirectory("/test", diags)
+		result := (&SousChefClient{}).createOutputDirectory("/test", diags)
 		if result {
 			t.Error(expectedFalse)
 		}
@@ -88,6 +107,56 @@ func TestCreateOutputDirectory(t *testing.T) {
 	})
 }
 
+func TestPrepareOutputDirectory(t *testing.T) {
+	t.Run("creates by default", func(t *testing.T) {
+		outputPath := filepath.Join(t.TempDir(), "output")
+		diags := &diag.Diagnostics{}
+		if !(&SousChefClient{}).prepareOutputDirectory(types.BoolNull(), outputPath, diags) || diags.HasError() {
+			t.Fatalf(unexpectedError, diags)
+		}
+		if _, err := os.Stat(outputPath); err != nil {
+			t.Fatalf("expected %s to be created: %v", outputPath, err)
+		}
+	})
+
+	t.Run("existing directory", func(t *testing.T) {
+		diags := &diag.Diagnostics{}
+		if !(&SousChefClient{}).prepareOutputDirectory(types.BoolValue(false), t.TempDir(), diags) || diags.HasError() {
+			t.Fatalf(unexpectedError, diags)
+		}
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		withOsMkdirAll(t, func(string, os.FileMode) error {
+			t.Fatal("expected the directory not to be created")
+			return nil
+		})
+		diags := &diag.Diagnostics{}
+		if (&SousChefClient{}).prepareOutputDirectory(types.BoolValue(false), filepath.Join(t.TempDir(), "missing"), diags) {
+			t.Error(expectedFalse)
+		}
+		if !diags.HasError() || diags.Errors()[0].Summary() != "Output directory not found" {
+			t.Fatalf("expected output directory not found diagnostic, got %v", diags)
+		}
+	})
+
+	t.Run("not a directory", func(t *testing.T) {
+		filePath := filepath.Join(t.TempDir(), "output")
+		if err := os.WriteFile(filePath, []byte("x"), testFilePermissions); err != nil {
+			t.Fatalf(testFailedToWriteFile, err)
+		}
+		for _, createOutputDir := range []types.Bool{types.BoolNull(), types.BoolValue(false)} {
+			diags := &diag.Diagnostics{}
+			if (&SousChefClient{}).prepareOutputDirectory(createOutputDir, filePath, diags) || !diags.HasError() {
+				t.Fatalf("expected error for a file output path, got %v", diags)
+			}
+			if want := "output_path must be a directory, but " + filePath + " is a file"; diags.Errors()[0].Detail() != want {
+				t.Fatalf("expected %q, got %v", want, diags)
+			}
+		}
+	})
+}
+
 func TestReadGeneratedFile(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		withOsReadFile(t, func(string) ([]byte, error) {
@@ -125,7 +194,7 @@ func TestExecuteSousChefCommand(t *testing.T) {
 			return cmd
 		})
 		diags := &diag.Diagnostics{}
-		output, success := executeSousChefCommand(context.Background(), "/souschef", []string{"test"}, "Test Command", diags)
+		output, success := executeSousChefCommand(context.Background(), &SousChefClient{Path: "/souschef"}, []string{"test"}, diags)
 		if !success {
 			t.Error("expected success")
 		}
@@ -143,7 +212,7 @@ func TestExecuteSousChefCommand(t *testing.T) {
 			return cmd
 		})
 		diags := &diag.Diagnostics{}
-		_, success := executeSousChefCommand(context.Background(), "/souschef", []string{"test"}, "Test Command", diags)
+		_, success := executeSousChefCommand(context.Background(), &SousChefClient{Path: "/souschef"}, []string{"test"}, diags)
 		if success {
 			t.Error("expected failure")
 		}
@@ -153,6 +222,48 @@ func TestExecuteSousChefCommand(t *testing.T) {
 	})
 }
 
+func TestExecuteConversionDryRunIgnoresStderr(t *testing.T) {
+	withExecCommandContext(t, func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		return exec.Command("/bin/sh", "-c", "echo 'FROM ubuntu'; echo 'warning: hab plan has no pkg_version' >&2")
+	})
+	diags := &diag.Diagnostics{}
+	client := &SousChefClient{Path: "/souschef", DryRun: true}
+	content, ok := executeConversion(context.Background(), client, []string{"convert-habitat"}, filepath.Join(t.TempDir(), "Dockerfile"), errReadingDockerfile, diags)
+	if !ok || diags.HasError() {
+		t.Fatalf(unexpectedError, diags)
+	}
+	if content != "FROM ubuntu\n" {
+		t.Fatalf("expected content from stdout only, got %q", content)
+	}
+}
+
+func TestExecuteSousChefCommandColorEnvironment(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm-256color")
+	fakePath := newFakeSousChef(t)
+
+	tests := map[string]struct {
+		preserveColor bool
+		want          string
+	}{
+		"plain output":   {want: "NO_COLOR=1 TERM=dumb"},
+		"preserve color": {preserveColor: true, want: "NO_COLOR= TERM=xterm-256color"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			diags := &diag.Diagnostics{}
+			client := &SousChefClient{Path: fakePath, PreserveCLIColor: tt.preserveColor}
+			output, ok := executeSousChefCommand(context.Background(), client, []string{"env"}, diags)
+			if !ok || diags.HasError() {
+				t.Fatalf(unexpectedError, diags)
+			}
+			if got := strings.TrimSpace(string(output)); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
 func TestDeleteGeneratedFile(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		withOsRemove(t, func(string) error {
@@ -405,6 +516,223 @@ func TestStringSliceFromTypesList(t *testing.T) {
 	}
 }
 
+func TestValidateOutputNotNested(t *testing.T) {
+	cookbookDir := t.TempDir()
+	schema := newResourceSchema(t, &migrationResource{})
+
+	tests := []struct {
+		name         string
+		outputPath   string
+		client       *SousChefClient
+		wantError    bool
+		wantWarnings int
+	}{
+		{name: "nested", outputPath: filepath.Join(cookbookDir, "ansible"), client: &SousChefClient{}, wantError: true},
+		{name: "identical", outputPath: cookbookDir, client: &SousChefClient{}, wantError: true},
+		{name: "sibling", outputPath: cookbookDir + "-ansible", client: &SousChefClient{}},
+		{name: "parent", outputPath: filepath.Dir(cookbookDir), client: &SousChefClient{}},
+		{name: "nested allowed", outputPath: filepath.Join(cookbookDir, "ansible"), client: &SousChefClient{AllowNestedOutput: true}, wantWarnings: 1},
+		{name: "unconfigured provider", outputPath: filepath.Join(cookbookDir, "ansible"), client: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newResourceConfig(t, schema, migrationResourceModel{
+				CookbookPath:      types.StringValue(cookbookDir),
+				OutputPath:        types.StringValue(tt.outputPath),
+				ReferencedEnvVars: types.ListNull(types.StringType),
+				ModuleCounts:      types.MapNull(types.Int64Type),
+				VariableRenameMap: types.MapNull(types.StringType),
+				VariableMappings:  types.MapNull(types.StringType),
+				Outputs:           types.MapNull(types.StringType),
+			})
+
+			diags := &diag.Diagnostics{}
+			validateOutputNotNested(context.Background(), config, tt.client, diags)
+
+			if diags.HasError() != tt.wantError {
+				t.Errorf("expected error %v, got diagnostics: %v", tt.wantError, diags)
+			}
+			if diags.WarningsCount() != tt.wantWarnings {
+				t.Errorf("expected %d warnings, got %d", tt.wantWarnings, diags.WarningsCount())
+			}
+		})
+	}
+}
+
+func TestValidateOutputNotNestedUnknownPath(t *testing.T) {
+	schema := newResourceSchema(t, &migrationResource{})
+	config := newResourceConfig(t, schema, migrationResourceModel{
+		CookbookPath:      types.StringValue(testTmpCookbook),
+		OutputPath:        types.StringUnknown(),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	})
+
+	diags := &diag.Diagnostics{}
+	validateOutputNotNested(context.Background(), config, &SousChefClient{}, diags)
+	if diags.HasError() {
+		t.Errorf(unexpectedError, diags)
+	}
+}
+
+func TestParseOutputFileMode(t *testing.T) {
+	tests := map[string]struct {
+		want    os.FileMode
+		wantErr bool
+	}{
+		"0600": {want: 0o600},
+		"640":  {want: 0o640},
+		"0777": {want: 0o777},
+		"1777": {wantErr: true},
+		"0800": {wantErr: true},
+		"rw-":  {wantErr: true},
+		"":     {wantErr: true},
+	}
+
+	for mode, tt := range tests {
+		got, err := parseOutputFileMode(mode)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseOutputFileMode(%q) = %o, %v; want %o, error %v", mode, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateOutputFileMode(t *testing.T) {
+	schema := newResourceSchema(t, &migrationResource{})
+
+	for mode, wantErr := range map[types.String]bool{types.StringNull(): false, types.StringUnknown(): false, types.StringValue("0600"): false, types.StringValue("u=rw"): true} {
+		config := newResourceConfig(t, schema, migrationResourceModel{
+			CookbookPath:      types.StringValue(testTmpCookbook),
+			OutputPath:        types.StringValue(t.TempDir()),
+			OutputFileMode:    mode,
+			ReferencedEnvVars: types.ListNull(types.StringType),
+			ModuleCounts:      types.MapNull(types.Int64Type),
+			VariableRenameMap: types.MapNull(types.StringType),
+			VariableMappings:  types.MapNull(types.StringType),
+			Outputs:           types.MapNull(types.StringType),
+		})
+
+		diags := &diag.Diagnostics{}
+		validateOutputFileMode(context.Background(), config, diags)
+		if diags.HasError() != wantErr {
+			t.Errorf("output_file_mode %v: expected error %v, got %v", mode, wantErr, diags)
+		}
+	}
+}
+
+func TestApplyOutputFileMode(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), testDefaultYml)
+	if err := os.WriteFile(filePath, []byte("- hosts: all\n"), 0o644); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+
+	// Unset and dry-run leave the file alone
+	diags := &diag.Diagnostics{}
+	if applied := (&SousChefClient{}).applyOutputFileMode(types.StringNull(), diags, filePath); !applied.IsNull() {
+		t.Fatalf("expected null applied mode when output_file_mode is unset, got %v", applied)
+	}
+	if applied := (&SousChefClient{DryRun: true}).applyOutputFileMode(types.StringValue("0600"), diags, filePath); !applied.IsNull() {
+		t.Fatalf("expected null applied mode in dry-run mode, got %v", applied)
+	}
+	if info, _ := os.Stat(filePath); info.Mode().Perm() != 0o644 {
+		t.Fatalf("expected mode to be unchanged, got %o", info.Mode().Perm())
+	}
+
+	if applied := (&SousChefClient{}).applyOutputFileMode(types.StringValue("600"), diags, filePath); applied.ValueString() != "0600" || diags.HasError() {
+		t.Fatalf("expected applied mode 0600, got %v, %v", applied, diags)
+	}
+	if info, _ := os.Stat(filePath); info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected mode 0600, got %o", info.Mode().Perm())
+	}
+
+	(&SousChefClient{}).applyOutputFileMode(types.StringValue("0600"), diags, filepath.Join(t.TempDir(), "missing.yml"))
+	if !diags.HasError() {
+		t.Fatal("expected error for a missing file")
+	}
+}
+
+func TestExecuteSousChefCommandCLIEnv(t *testing.T) {
+	t.Setenv("CHEF_LICENSE", "")
+	fakePath := newFakeSousChef(t)
+
+	for _, preserveColor := range []bool{false, true} {
+		diags := &diag.Diagnostics{}
+		client := &SousChefClient{Path: fakePath, PreserveCLIColor: preserveColor, CLIEnv: map[string]string{"CHEF_LICENSE": "accept"}}
+		output, ok := executeSousChefCommand(context.Background(), client, []string{"env", "CHEF_LICENSE"}, diags)
+		if !ok || diags.HasError() {
+			t.Fatalf(unexpectedError, diags)
+		}
+		if got := strings.TrimSpace(string(output)); got != "CHEF_LICENSE=accept" {
+			t.Fatalf("expected cli_env to be passed through with preserve_cli_color %v, got %q", preserveColor, got)
+		}
+	}
+
+	// cli_env wins over the colour defaults
+	diags := &diag.Diagnostics{}
+	client := &SousChefClient{Path: fakePath, CLIEnv: map[string]string{"TERM": "vt100"}}
+	output, ok := executeSousChefCommand(context.Background(), client, []string{"env", "TERM"}, diags)
+	if !ok || strings.TrimSpace(string(output)) != "TERM=vt100" {
+		t.Fatalf("expected cli_env to override TERM, got %q (%v)", output, diags)
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	t.Run("replaces existing file", func(t *testing.T) {
+		dir := t.TempDir()
+		filePath := filepath.Join(dir, "main.yml")
+		if err := os.WriteFile(filePath, []byte("old"), 0600); err != nil {
+			t.Fatalf(testFailedToWriteFile, err)
+		}
+
+		if err := writeFileAtomic(filePath, []byte("new"), 0644); err != nil {
+			t.Fatalf(unexpectedError, err)
+		}
+		content, err := os.ReadFile(filePath)
+		if err != nil || string(content) != "new" {
+			t.Fatalf("expected new content, got %q (%v)", content, err)
+		}
+		if info, err := os.Stat(filePath); err != nil || info.Mode().Perm() != 0644 {
+			t.Fatalf("expected mode 0644, got %v (%v)", info.Mode().Perm(), err)
+		}
+		if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+			t.Fatalf("expected only the target file, found %d entries", len(entries))
+		}
+	})
+
+	t.Run("interrupted before rename", func(t *testing.T) {
+		// Simulate the provider dying after writing the temp file but before it is renamed
+		withOsRename(t, func(string, string) error {
+			return errors.New("interrupted")
+		})
+		dir := t.TempDir()
+		filePath := filepath.Join(dir, "main.yml")
+		if err := os.WriteFile(filePath, []byte("old"), 0600); err != nil {
+			t.Fatalf(testFailedToWriteFile, err)
+		}
+
+		if err := writeFileAtomic(filePath, []byte("partially written"), 0644); err == nil {
+			t.Fatal("expected an error when the rename fails")
+		}
+		content, err := os.ReadFile(filePath)
+		if err != nil || string(content) != "old" {
+			t.Fatalf("expected the original file untouched, got %q (%v)", content, err)
+		}
+		if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+			t.Fatalf("expected no partial temp file left behind, found %d entries", len(entries))
+		}
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		if err := writeFileAtomic(filepath.Join(t.TempDir(), "missing", "main.yml"), []byte("x"), 0644); err == nil {
+			t.Fatal("expected an error for a missing directory")
+		}
+	})
+}
+
 // Helper functions to manage dependency injection in tests
 
 func withOsMkdirAll(t *testing.T, fn func(string, os.FileMode) error) {
@@ -416,6 +744,28 @@ func withOsMkdirAll(t *testing.T, fn func(string, os.FileMode) error) {
 	})
 }
 
+// readOnlyDir returns a directory without write permission. Root ignores
+// directory permissions, so the write probe is made to fail as well.
+func readOnlyDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.Chmod(dir, 0555); err != nil {
+		t.Fatalf("failed to make %s read-only: %v", dir, err)
+	}
+	t.Cleanup(func() { _ = os.Chmod(dir, 0755) })
+
+	if os.Geteuid() == 0 {
+		original := osCreateTemp
+		osCreateTemp = func(string, string) (*os.File, error) {
+			return nil, os.ErrPermission
+		}
+		t.Cleanup(func() {
+			osCreateTemp = original
+		})
+	}
+	return dir
+}
+
 func withOsStat(t *testing.T, fn func(string) (os.FileInfo, error)) {
 	t.Helper()
 	original := osStat
@@ -433,3 +783,632 @@ func withExecCommandContext(t *testing.T, fn func(context.Context, string, ...st
 		execCommandContext = original
 	})
 }
+
+func TestReadFileWithRetry(t *testing.T) {
+	attempts := 0
+	withOsReadFile(t, func(string) ([]byte, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, errors.New("resource temporarily unavailable")
+		}
+		return []byte("content"), nil
+	})
+
+	content, err := readFileWithRetry(context.Background(), "playbook.yml")
+	if err != nil {
+		t.Fatalf(unexpectedError, err)
+	}
+	if string(content) != "content" || attempts != 2 {
+		t.Fatalf("expected content on second attempt, got %q after %d attempts", content, attempts)
+	}
+}
+
+func TestReadFileWithRetryNotExist(t *testing.T) {
+	attempts := 0
+	withOsReadFile(t, func(string) ([]byte, error) {
+		attempts++
+		return nil, os.ErrNotExist
+	})
+
+	if _, err := readFileWithRetry(context.Background(), "playbook.yml"); !os.IsNotExist(err) {
+		t.Fatalf("expected not-exist error, got %v", err)
+	}
+	if attempts != 1 {
+		t.Fatalf("expected a single attempt, got %d", attempts)
+	}
+}
+
+func TestReadFileWithRetryExhausted(t *testing.T) {
+	attempts := 0
+	withOsReadFile(t, func(string) ([]byte, error) {
+		attempts++
+		return nil, errors.New(permissionDenied)
+	})
+
+	if _, err := readFileWithRetry(context.Background(), "playbook.yml"); err == nil {
+		t.Fatal("expected error after retries")
+	}
+	if attempts != readRetryAttempts {
+		t.Fatalf("expected %d attempts, got %d", readRetryAttempts, attempts)
+	}
+}
+
+func TestReadFileWithRetryContextCancelled(t *testing.T) {
+	attempts := 0
+	withOsReadFile(t, func(string) ([]byte, error) {
+		attempts++
+		return nil, errors.New(permissionDenied)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := readFileWithRetry(ctx, "playbook.yml"); err == nil {
+		t.Fatal("expected error when context is cancelled")
+	}
+	if attempts != 1 {
+		t.Fatalf("expected retries to stop on cancelled context, got %d attempts", attempts)
+	}
+}
+
+func TestBackoffWithJitter(t *testing.T) {
+	tests := map[string]struct {
+		attempt int
+		want    time.Duration
+	}{
+		"first retry":  {attempt: 0, want: readRetryDelay},
+		"second retry": {attempt: 1, want: 2 * readRetryDelay},
+		"third retry":  {attempt: 3, want: 8 * readRetryDelay},
+		"capped":       {attempt: 10, want: maxRetryDelay},
+		"huge attempt": {attempt: 100, want: maxRetryDelay},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			low := time.Duration(float64(tt.want) * (1 - retryJitterFraction))
+			high := time.Duration(float64(tt.want) * (1 + retryJitterFraction))
+			for i := 0; i < 100; i++ {
+				if got := backoffWithJitter(tt.attempt, readRetryDelay); got < low || got > high {
+					t.Fatalf("backoffWithJitter(%d) = %v, want within [%v, %v]", tt.attempt, got, low, high)
+				}
+			}
+		})
+	}
+}
+
+// cancelAfterCommand returns a context that is canceled once the CLI command
+// has run, when the resource reads back the generated file of that name. The
+// context is still live while the command runs, so its output is written
+// before the resource checks the context.
+func cancelAfterCommand(t *testing.T, generated string) context.Context {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	withOsReadFile(t, func(name string) ([]byte, error) {
+		content, err := os.ReadFile(name)
+		if err == nil && filepath.Base(name) == generated {
+			cancel()
+		}
+		return content, err
+	})
+	return ctx
+}
+
+func TestCleanupIfCanceled(t *testing.T) {
+	outputDir := t.TempDir()
+	previous := filepath.Join(outputDir, "web.yml")
+	if err := os.WriteFile(previous, []byte("content"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWritePlaybook, err)
+	}
+	existing := snapshotOutputs(outputDir)
+	generated := filepath.Join(outputDir, testDefaultYml)
+	if err := os.WriteFile(generated, []byte("content"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWritePlaybook, err)
+	}
+
+	var diags diag.Diagnostics
+	if cleanupIfCanceled(context.Background(), &diags, existing, generated, previous) {
+		t.Fatal("expected no cleanup for a live context")
+	}
+	if _, err := os.Stat(generated); err != nil || diags.HasError() {
+		t.Fatalf("expected file to be kept on normal completion, got %v / %v", err, diags)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if !cleanupIfCanceled(ctx, &diags, existing, generated, previous) {
+		t.Fatal("expected cleanup for a canceled context")
+	}
+	if _, err := os.Stat(generated); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be removed, got %v", generated, err)
+	}
+	if _, err := os.Stat(previous); err != nil {
+		t.Fatalf("expected %s, which predates the Create, to be kept, got %v", previous, err)
+	}
+	if !diags.HasError() {
+		t.Fatal(expectedErrorDiagnostic)
+	}
+}
+
+func TestCreateRemovesOutputWhenCanceled(t *testing.T) {
+	tests := []struct {
+		name      string
+		resource  resource.Resource
+		model     func(outputDir string) interface{}
+		generated string
+	}{
+		{
+			name:     "migration",
+			resource: &migrationResource{},
+			model: func(outputDir string) interface{} {
+				return migrationResourceModel{
+					CookbookPath:      types.StringValue(testTmpCookbook),
+					OutputPath:        types.StringValue(outputDir),
+					RecipeName:        types.StringValue("default"),
+					ReferencedEnvVars: types.ListNull(types.StringType),
+					ModuleCounts:      types.MapNull(types.Int64Type),
+					VariableRenameMap: types.MapNull(types.StringType),
+					VariableMappings:  types.MapNull(types.StringType),
+					Outputs:           types.MapNull(types.StringType),
+				}
+			},
+			generated: testDefaultYml,
+		},
+		{
+			name:     "batch",
+			resource: &batchMigrationResource{},
+			model: func(outputDir string) interface{} {
+				return batchMigrationResourceModel{
+					CookbookPath:        types.StringValue(testTmpCookbook),
+					OutputPath:          types.StringValue(outputDir),
+					RecipeNames:         []types.String{types.StringValue("default")},
+					Playbooks:           types.MapNull(types.StringType),
+					RecipeStatus:        types.MapNull(types.StringType),
+					ResolvedRecipeNames: types.ListNull(types.StringType),
+				}
+			},
+			generated: testDefaultYml,
+		},
+		{
+			name:     "habitat",
+			resource: &habitatMigrationResource{},
+			model: func(outputDir string) interface{} {
+				return habitatMigrationResourceModel{
+					PlanPath:   types.StringValue(testTmpPlanSh),
+					OutputPath: types.StringValue(outputDir),
+				}
+			},
+			generated: "Dockerfile",
+		},
+		{
+			name:     "inspec",
+			resource: &inspecMigrationResource{},
+			model: func(outputDir string) interface{} {
+				return inspecMigrationResourceModel{
+					ProfilePath:       types.StringValue(testTmpProfile),
+					OutputPath:        types.StringValue(outputDir),
+					OutputFormat:      types.StringValue("goss"),
+					ConvertedControls: types.ListNull(types.StringType),
+				}
+			},
+			generated: gossFilename,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configureResp := &resource.ConfigureResponse{}
+			tt.resource.(resource.ResourceWithConfigure).Configure(context.Background(),
+				resource.ConfigureRequest{ProviderData: &SousChefClient{Path: newFakeSousChef(t)}}, configureResp)
+			schema := newResourceSchema(t, tt.resource)
+			outputDir := t.TempDir()
+
+			ctx := cancelAfterCommand(t, tt.generated)
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+			tt.resource.Create(ctx, resource.CreateRequest{Plan: newPlan(t, schema, tt.model(outputDir))}, resp)
+
+			errs := resp.Diagnostics.Errors()
+			if len(errs) != 1 || errs[0].Summary() != "Operation interrupted" {
+				t.Fatalf("expected a single interruption error, got %v", resp.Diagnostics)
+			}
+			if !resp.State.Raw.IsNull() {
+				t.Fatal("expected no state to be saved")
+			}
+			if _, err := os.Stat(filepath.Join(outputDir, tt.generated)); !os.IsNotExist(err) {
+				t.Fatalf("expected orphaned %s to be removed, got %v", tt.generated, err)
+			}
+		})
+	}
+}
+
+func TestCreateKeepsExistingOutputWhenCanceled(t *testing.T) {
+	r := &batchMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputDir := t.TempDir()
+	previous := filepath.Join(outputDir, testDefaultYml)
+	if err := os.WriteFile(previous, []byte("recipe: default\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWritePlaybook, err)
+	}
+
+	ctx := cancelAfterCommand(t, testDefaultYml)
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, schema, batchMigrationResourceModel{
+		CookbookPath:        types.StringValue(testTmpCookbook),
+		OutputPath:          types.StringValue(outputDir),
+		RecipeNames:         []types.String{types.StringValue("default")},
+		Playbooks:           types.MapNull(types.StringType),
+		RecipeStatus:        types.MapNull(types.StringType),
+		ResolvedRecipeNames: types.ListNull(types.StringType),
+	})}, resp)
+
+	if errs := resp.Diagnostics.Errors(); len(errs) != 1 || errs[0].Summary() != "Operation interrupted" {
+		t.Fatalf("expected a single interruption error, got %v", resp.Diagnostics)
+	}
+	if _, err := os.Stat(previous); err != nil {
+		t.Fatalf("expected the playbook that predates the Create to be kept, got %v", err)
+	}
+}
+
+func TestPruneEmptyDir(t *testing.T) {
+	emptyDir := filepath.Join(t.TempDir(), "empty")
+	if err := os.Mkdir(emptyDir, testDirPermissions); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+
+	var diags diag.Diagnostics
+	pruneEmptyDir(types.BoolNull(), emptyDir, &diags)
+	if _, err := os.Stat(emptyDir); err != nil {
+		t.Fatalf("expected directory to be kept when pruning is disabled: %v", err)
+	}
+
+	pruneEmptyDir(types.BoolValue(true), emptyDir, &diags)
+	if _, err := os.Stat(emptyDir); !os.IsNotExist(err) {
+		t.Fatalf("expected empty directory to be removed, got %v", err)
+	}
+
+	pruneEmptyDir(types.BoolValue(true), emptyDir, &diags)
+	if diags.HasError() || diags.WarningsCount() != 0 {
+		t.Fatalf(testUnexpectedDiagnostics, diags)
+	}
+}
+
+func TestDeletePrunesEmptyOutputDir(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource resource.Resource
+		model    func(t *testing.T, outputDir string) interface{}
+	}{
+		{
+			name:     "migration",
+			resource: &migrationResource{},
+			model: func(t *testing.T, outputDir string) interface{} {
+				return migrationResourceModel{
+					CookbookPath:      types.StringValue(testTmpCookbook),
+					OutputPath:        types.StringValue(outputDir),
+					PruneEmptyDir:     types.BoolValue(true),
+					RecipeName:        types.StringValue("default"),
+					ReferencedEnvVars: types.ListNull(types.StringType),
+					ModuleCounts:      types.MapNull(types.Int64Type),
+					VariableRenameMap: types.MapNull(types.StringType),
+					VariableMappings:  types.MapNull(types.StringType),
+					Outputs:           types.MapNull(types.StringType),
+				}
+			},
+		},
+		{
+			name:     "batch",
+			resource: &batchMigrationResource{},
+			model: func(t *testing.T, outputDir string) interface{} {
+				return batchMigrationResourceModel{
+					CookbookPath:        types.StringValue(testTmpCookbook),
+					OutputPath:          types.StringValue(outputDir),
+					PruneEmptyDir:       types.BoolValue(true),
+					RecipeNames:         []types.String{types.StringValue("default"), types.StringValue("web")},
+					Playbooks:           types.MapNull(types.StringType),
+					RecipeStatus:        types.MapNull(types.StringType),
+					ResolvedRecipeNames: types.ListNull(types.StringType),
+				}
+			},
+		},
+		{
+			name:     "habitat",
+			resource: &habitatMigrationResource{},
+			model: func(t *testing.T, outputDir string) interface{} {
+				return habitatMigrationResourceModel{
+					PlanPath:      types.StringValue(testTmpPlanSh),
+					OutputPath:    types.StringValue(outputDir),
+					PruneEmptyDir: types.BoolValue(true),
+				}
+			},
+		},
+		{
+			name:     "inspec",
+			resource: &inspecMigrationResource{},
+			model: func(t *testing.T, outputDir string) interface{} {
+				return inspecMigrationResourceModel{
+					ProfilePath:       types.StringValue(testTmpProfile),
+					OutputPath:        types.StringValue(outputDir),
+					OutputFormat:      types.StringValue("goss"),
+					PruneEmptyDir:     types.BoolValue(true),
+					ConvertedControls: types.ListNull(types.StringType),
+				}
+			},
+		},
+		{
+			name:     "batch_habitat",
+			resource: &batchHabitatMigrationResource{},
+			model: func(t *testing.T, outputDir string) interface{} {
+				return batchHabitatMigrationResourceModel{
+					PlansRoot:     types.StringValue(newHabitatPlansFixture(t)),
+					OutputPath:    types.StringValue(outputDir),
+					PruneEmptyDir: types.BoolValue(true),
+					Dockerfiles:   types.MapUnknown(types.StringType),
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, unrelatedFile := range []string{"", "notes.txt"} {
+			label := "empty"
+			if unrelatedFile != "" {
+				label = "not_empty"
+			}
+			t.Run(tt.name+"/"+label, func(t *testing.T) {
+				configureResp := &resource.ConfigureResponse{}
+				tt.resource.(resource.ResourceWithConfigure).Configure(context.Background(),
+					resource.ConfigureRequest{ProviderData: &SousChefClient{Path: newFakeSousChef(t)}}, configureResp)
+				schema := newResourceSchema(t, tt.resource)
+				outputDir := filepath.Join(t.TempDir(), "output")
+
+				createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+				tt.resource.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, tt.model(t, outputDir))}, createResp)
+				if createResp.Diagnostics.HasError() {
+					t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+				}
+
+				if unrelatedFile != "" {
+					if err := os.WriteFile(filepath.Join(outputDir, unrelatedFile), []byte("keep"), testFilePermissions); err != nil {
+						t.Fatalf(testFailedToWriteFile, err)
+					}
+				}
+
+				deleteResp := &resource.DeleteResponse{}
+				tt.resource.Delete(context.Background(), resource.DeleteRequest{State: createResp.State}, deleteResp)
+				if deleteResp.Diagnostics.HasError() || deleteResp.Diagnostics.WarningsCount() != 0 {
+					t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+				}
+
+				if unrelatedFile == "" {
+					if _, err := os.Stat(outputDir); !os.IsNotExist(err) {
+						t.Fatalf("expected empty output directory to be pruned, got %v", err)
+					}
+					return
+				}
+				entries, err := os.ReadDir(outputDir)
+				if err != nil || len(entries) != 1 || entries[0].Name() != unrelatedFile {
+					t.Fatalf("expected only %s to remain, got %v (%v)", unrelatedFile, entries, err)
+				}
+			})
+		}
+	}
+}
+
+func TestRetainGeneratedFiles(t *testing.T) {
+	client := &SousChefClient{}
+	var diags diag.Diagnostics
+	if client.retainGeneratedFiles(types.BoolNull(), "/tmp/output", &diags) || client.retainGeneratedFiles(types.BoolValue(false), "/tmp/output", &diags) {
+		t.Fatal("expected files to be deleted unless retain_on_delete is set")
+	}
+	if diags.WarningsCount() != 0 {
+		t.Fatalf(testUnexpectedDiagnostics, diags)
+	}
+
+	if !client.retainGeneratedFiles(types.BoolValue(true), "/tmp/output", &diags) {
+		t.Fatal("expected files to be retained")
+	}
+	if diags.HasError() || diags.WarningsCount() != 1 || !strings.Contains(diags[0].Detail(), "/tmp/output") {
+		t.Fatalf("expected a single warning naming the output path, got %v", diags)
+	}
+}
+
+func TestDeleteRetainOnDelete(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource resource.Resource
+		model    func(outputDir string, retain types.Bool) interface{}
+	}{
+		{
+			name:     "migration",
+			resource: &migrationResource{},
+			model: func(outputDir string, retain types.Bool) interface{} {
+				model := roleMigrationModel(outputDir, types.StringNull())
+				model.RetainOnDelete = retain
+				return model
+			},
+		},
+		{
+			name:     "batch",
+			resource: &batchMigrationResource{},
+			model: func(outputDir string, retain types.Bool) interface{} {
+				return batchMigrationResourceModel{
+					CookbookPath:        types.StringValue(testTmpCookbook),
+					OutputPath:          types.StringValue(outputDir),
+					RetainOnDelete:      retain,
+					RecipeNames:         []types.String{types.StringValue("default"), types.StringValue("web")},
+					Playbooks:           types.MapNull(types.StringType),
+					RecipeStatus:        types.MapNull(types.StringType),
+					ResolvedRecipeNames: types.ListNull(types.StringType),
+				}
+			},
+		},
+		{
+			name:     "habitat",
+			resource: &habitatMigrationResource{},
+			model: func(outputDir string, retain types.Bool) interface{} {
+				return habitatMigrationResourceModel{
+					PlanPath:       types.StringValue(testTmpPlanSh),
+					OutputPath:     types.StringValue(outputDir),
+					RetainOnDelete: retain,
+				}
+			},
+		},
+		{
+			name:     "inspec",
+			resource: &inspecMigrationResource{},
+			model: func(outputDir string, retain types.Bool) interface{} {
+				return inspecMigrationResourceModel{
+					ProfilePath:       types.StringValue(testTmpProfile),
+					OutputPath:        types.StringValue(outputDir),
+					OutputFormat:      types.StringValue("goss"),
+					RetainOnDelete:    retain,
+					ConvertedControls: types.ListNull(types.StringType),
+				}
+			},
+		},
+		{
+			name:     "node",
+			resource: &nodeMigrationResource{},
+			model: func(outputDir string, retain types.Bool) interface{} {
+				return nodeMigrationResourceModel{
+					NodePath:       types.StringValue(testNodePath()),
+					OutputPath:     types.StringValue(outputDir),
+					RetainOnDelete: retain,
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, retain := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s/retain_%t", tt.name, retain), func(t *testing.T) {
+				configureResp := &resource.ConfigureResponse{}
+				tt.resource.(resource.ResourceWithConfigure).Configure(context.Background(),
+					resource.ConfigureRequest{ProviderData: &SousChefClient{Path: newFakeSousChef(t)}}, configureResp)
+				schema := newResourceSchema(t, tt.resource)
+				outputDir := filepath.Join(t.TempDir(), "output")
+
+				createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+				tt.resource.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, tt.model(outputDir, types.BoolValue(retain)))}, createResp)
+				if createResp.Diagnostics.HasError() {
+					t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+				}
+				generated, err := os.ReadDir(outputDir)
+				if err != nil || len(generated) == 0 {
+					t.Fatalf("expected generated files in %s, got %v (%v)", outputDir, generated, err)
+				}
+
+				deleteResp := &resource.DeleteResponse{}
+				tt.resource.Delete(context.Background(), resource.DeleteRequest{State: createResp.State}, deleteResp)
+				if deleteResp.Diagnostics.HasError() {
+					t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+				}
+
+				remaining, err := os.ReadDir(outputDir)
+				if err != nil {
+					t.Fatalf("failed to read output directory: %v", err)
+				}
+				if retain {
+					if len(remaining) != len(generated) || deleteResp.Diagnostics.WarningsCount() != 1 {
+						t.Fatalf("expected %d files to survive with a warning, got %v (%v)", len(generated), remaining, deleteResp.Diagnostics)
+					}
+					return
+				}
+				if len(remaining) != 0 || deleteResp.Diagnostics.WarningsCount() != 0 {
+					t.Fatalf("expected generated files to be removed, got %v (%v)", remaining, deleteResp.Diagnostics)
+				}
+			})
+		}
+	}
+}
+
+func TestCreateOutputDirDisabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource resource.Resource
+		model    func(outputDir string, create types.Bool) interface{}
+	}{
+		{
+			name:     "migration",
+			resource: &migrationResource{},
+			model: func(outputDir string, create types.Bool) interface{} {
+				model := roleMigrationModel(outputDir, types.StringNull())
+				model.CreateOutputDir = create
+				return model
+			},
+		},
+		{
+			name:     "batch",
+			resource: &batchMigrationResource{},
+			model: func(outputDir string, create types.Bool) interface{} {
+				return batchMigrationResourceModel{
+					CookbookPath:        types.StringValue(testTmpCookbook),
+					OutputPath:          types.StringValue(outputDir),
+					CreateOutputDir:     create,
+					RecipeNames:         []types.String{types.StringValue("default")},
+					Playbooks:           types.MapNull(types.StringType),
+					RecipeStatus:        types.MapNull(types.StringType),
+					ResolvedRecipeNames: types.ListNull(types.StringType),
+				}
+			},
+		},
+		{
+			name:     "habitat",
+			resource: &habitatMigrationResource{},
+			model: func(outputDir string, create types.Bool) interface{} {
+				return habitatMigrationResourceModel{
+					PlanPath:        types.StringValue(testTmpPlanSh),
+					OutputPath:      types.StringValue(outputDir),
+					CreateOutputDir: create,
+				}
+			},
+		},
+		{
+			name:     "inspec",
+			resource: &inspecMigrationResource{},
+			model: func(outputDir string, create types.Bool) interface{} {
+				return inspecMigrationResourceModel{
+					ProfilePath:       types.StringValue(testTmpProfile),
+					OutputPath:        types.StringValue(outputDir),
+					OutputFormat:      types.StringValue("goss"),
+					CreateOutputDir:   create,
+					ConvertedControls: types.ListNull(types.StringType),
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, existing := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s/existing_%t", tt.name, existing), func(t *testing.T) {
+				configureResp := &resource.ConfigureResponse{}
+				tt.resource.(resource.ResourceWithConfigure).Configure(context.Background(),
+					resource.ConfigureRequest{ProviderData: &SousChefClient{Path: newFakeSousChef(t)}}, configureResp)
+				schema := newResourceSchema(t, tt.resource)
+				outputDir := filepath.Join(t.TempDir(), "output")
+				if existing {
+					if err := os.Mkdir(outputDir, testDirPermissions); err != nil {
+						t.Fatalf(testFailedToCreateDirectory, err)
+					}
+				}
+
+				createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+				tt.resource.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, tt.model(outputDir, types.BoolValue(false)))}, createResp)
+				if existing {
+					if createResp.Diagnostics.HasError() {
+						t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+					}
+					if generated, err := os.ReadDir(outputDir); err != nil || len(generated) == 0 {
+						t.Fatalf("expected generated files in %s, got %v (%v)", outputDir, generated, err)
+					}
+					return
+				}
+				if !createResp.Diagnostics.HasError() || createResp.Diagnostics.Errors()[0].Summary() != "Output directory not found" {
+					t.Fatalf("expected output directory not found error, got %v", createResp.Diagnostics)
+				}
+				if _, err := os.Stat(outputDir); !os.IsNotExist(err) {
+					t.Fatalf("expected %s not to be created, got %v", outputDir, err)
+				}
+			})
+		}
+	}
+}
@@ -3,7 +3,9 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -16,8 +18,9 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces
 var (
-	_ resource.Resource                = &inspecMigrationResource{}
-	_ resource.ResourceWithImportState = &inspecMigrationResource{}
+	_ resource.Resource                   = &inspecMigrationResource{}
+	_ resource.ResourceWithImportState    = &inspecMigrationResource{}
+	_ resource.ResourceWithValidateConfig = &inspecMigrationResource{}
 )
 
 // NewInSpecMigrationResource creates a new InSpec migration resource
@@ -32,12 +35,18 @@ type inspecMigrationResource struct {
 
 // inspecMigrationResourceModel describes the resource data model
 type inspecMigrationResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	ProfilePath  types.String `tfsdk:"profile_path"`
-	OutputPath   types.String `tfsdk:"output_path"`
-	OutputFormat types.String `tfsdk:"output_format"`
-	ProfileName  types.String `tfsdk:"profile_name"`
-	TestContent  types.String `tfsdk:"test_content"`
+	ID                 types.String `tfsdk:"id"`
+	ProfilePath        types.String `tfsdk:"profile_path"`
+	OutputPath         types.String `tfsdk:"output_path"`
+	CreateOutputDir    types.Bool   `tfsdk:"create_output_dir"`
+	PruneEmptyDir      types.Bool   `tfsdk:"prune_empty_dir"`
+	RetainOnDelete     types.Bool   `tfsdk:"retain_on_delete"`
+	ResolvedOutputPath types.String `tfsdk:"resolved_output_path"`
+	OutputFormat       types.String `tfsdk:"output_format"`
+	ProfileName        types.String `tfsdk:"profile_name"`
+	TestContent        types.String `tfsdk:"test_content"`
+	OutputFile         types.String `tfsdk:"output_filename"`
+	ConvertedControls  types.List   `tfsdk:"converted_controls"`
 }
 
 const (
@@ -48,8 +57,15 @@ const (
 	defaultTestFilename = "test.txt"
 	errReadingTestFile  = "Error reading test file"
 	inspecIDFormat      = "inspec-%s-%s"
+	// controlsSidecarFilename is the JSON list of converted control IDs the
+	// CLI writes next to the generated tests.
+	controlsSidecarFilename = ".controls.json"
 )
 
+// inspecTestFilename returns the default filename generated for outputFormat.
+// Formats without a dedicated file fall back to test.txt. Every lifecycle
+// method resolves the filename through here so Create, Read, Update, Delete
+// and ImportState always agree on where the tests live.
 func inspecTestFilename(outputFormat string) string {
 	switch outputFormat {
 	case "testinfra":
@@ -65,6 +81,47 @@ func inspecTestFilename(outputFormat string) string {
 	}
 }
 
+// inspecExpectedExtensions lists the file extensions expected for each known output format.
+var inspecExpectedExtensions = map[string][]string{
+	"testinfra":  {".py"},
+	"serverspec": {".rb"},
+	"goss":       {".yaml", ".yml"},
+	"ansible":    {".yml", ".yaml"},
+}
+
+// inspecOutputFilename returns the configured output filename override, or
+// the default filename for the output format when no override is set.
+func inspecOutputFilename(outputFormat string, outputFilename types.String) string {
+	if !outputFilename.IsNull() && !outputFilename.IsUnknown() && outputFilename.ValueString() != "" {
+		return outputFilename.ValueString()
+	}
+	return inspecTestFilename(outputFormat)
+}
+
+// readConvertedControls returns the control IDs listed in the CLI's
+// .controls.json sidecar in outputPath. CLIs that do not write the sidecar
+// yield an empty list, and an unreadable sidecar adds a warning and does too.
+func readConvertedControls(outputPath string, diagnostics *diag.Diagnostics) types.List {
+	sidecarPath := filepath.Join(outputPath, controlsSidecarFilename)
+	content, err := osReadFile(sidecarPath)
+	if os.IsNotExist(err) {
+		return typesListFromStringSlice([]string{})
+	}
+
+	controls := make([]string, 0)
+	if err == nil {
+		err = json.Unmarshal(content, &controls)
+	}
+	if err != nil {
+		diagnostics.AddWarning(
+			"Error reading converted controls",
+			fmt.Sprintf("Could not read the list of converted controls from %s, so converted_controls is empty: %s", sidecarPath, err),
+		)
+		return typesListFromStringSlice([]string{})
+	}
+	return typesListFromStringSlice(controls)
+}
+
 // Metadata returns the resource type name
 func (r *inspecMigrationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_inspec_migration"
@@ -88,6 +145,22 @@ func (r *inspecMigrationResource) Schema(ctx context.Context, req resource.Schem
 				Required:            true,
 				MarkdownDescription: "Directory where converted tests will be written",
 			},
+			"create_output_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Create `output_path` when it does not exist (default: true). When false the directory must already exist, e.g. when it is pre-created with specific ownership and permissions",
+			},
+			"prune_empty_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove the output directory on destroy when no other files remain in it (default: false)",
+			},
+			"retain_on_delete": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Leave the generated files in place on destroy and only remove the resource from state, e.g. once they are committed to a repository (default: false)",
+			},
+			"resolved_output_path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Directory the tests are written to: `output_path` joined onto the provider `output_root` when `output_path` is relative",
+			},
 			"output_format": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Output test framework format (testinfra, serverspec, goss, or ansible)",
@@ -100,6 +173,15 @@ func (r *inspecMigrationResource) Schema(ctx context.Context, req resource.Schem
 				Computed:            true,
 				MarkdownDescription: "Generated test content",
 			},
+			"output_filename": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Filename for the converted tests, overriding the per-format default (e.g. goss.yml instead of goss.yaml)",
+			},
+			"converted_controls": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of the InSpec controls that were translated, read from the `.controls.json` file the CLI writes to the output directory. Empty when the CLI does not write it",
+			},
 		},
 	}
 }
@@ -109,6 +191,53 @@ func (r *inspecMigrationResource) Configure(ctx context.Context, req resource.Co
 	r.client = configureResource(req, resp)
 }
 
+// ValidateConfig checks output_filename is a bare filename with an extension suited to the output format
+func (r *inspecMigrationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config inspecMigrationResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.OutputFile.IsNull() || config.OutputFile.IsUnknown() {
+		return
+	}
+
+	filename := config.OutputFile.ValueString()
+	if filename == "" || filename == "." || filename == ".." || strings.ContainsAny(filename, `/\`) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("output_filename"),
+			"Invalid output filename",
+			fmt.Sprintf("output_filename must be a plain filename without path separators, got: %q", filename),
+		)
+		return
+	}
+
+	if config.OutputFormat.IsUnknown() {
+		return
+	}
+
+	outputFormat := config.OutputFormat.ValueString()
+	expected, ok := inspecExpectedExtensions[outputFormat]
+	if !ok {
+		return
+	}
+
+	ext := filepath.Ext(filename)
+	for _, candidate := range expected {
+		if ext == candidate {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("output_filename"),
+		"Unexpected output filename extension",
+		fmt.Sprintf("output_filename %q does not use an extension expected for the %s format (%s)",
+			filename, outputFormat, strings.Join(expected, ", ")),
+	)
+}
+
 // executeInSpecConversion executes the InSpec profile conversion and updates the model state.
 func (r *inspecMigrationResource) executeInSpecConversion(
 	ctx context.Context,
@@ -116,27 +245,41 @@ func (r *inspecMigrationResource) executeInSpecConversion(
 	diagnostics *diag.Diagnostics,
 ) {
 	profilePath := model.ProfilePath.ValueString()
-	outputPath := model.OutputPath.ValueString()
+	outputPath := r.client.resolveOutputPath(model.OutputPath.ValueString())
 	outputFormat := model.OutputFormat.ValueString()
+	profileName := filepath.Base(profilePath)
+	id := r.client.resourceID(fmt.Sprintf(inspecIDFormat, profileName, outputFormat), profilePath)
+	ctx = withReportResourceID(ctx, id)
 
 	// Call souschef CLI to convert InSpec profile
 	args := []string{"convert-inspec", "--profile-path", profilePath, "--output-path", outputPath, "--format", outputFormat}
-	if _, ok := executeSousChefCommand(ctx, r.client.Path, args, "Error converting InSpec profile", diagnostics); !ok {
+	defaultFilePath := filepath.Join(outputPath, inspecTestFilename(outputFormat))
+	content, ok := executeConversion(ctx, r.client, args, defaultFilePath, errReadingTestFile, diagnostics)
+	if !ok {
 		return
 	}
 
-	// Read generated test file
-	testFilePath := filepath.Join(outputPath, inspecTestFilename(outputFormat))
-	content := readGeneratedFile(testFilePath, errReadingTestFile, diagnostics)
-	if diagnostics.HasError() {
-		return
+	// Move the generated file into place when a custom filename is configured
+	testFilePath := filepath.Join(outputPath, inspecOutputFilename(outputFormat, model.OutputFile))
+	if testFilePath != defaultFilePath && !r.client.isDryRun() {
+		if err := osRename(defaultFilePath, testFilePath); err != nil {
+			diagnostics.AddError(
+				"Error renaming test file",
+				fmt.Sprintf("Could not rename %s to %s: %s", defaultFilePath, testFilePath, err),
+			)
+			return
+		}
 	}
 
-	// Extract profile name from path and set state
-	profileName := filepath.Base(profilePath)
-	model.ID = types.StringValue(fmt.Sprintf(inspecIDFormat, profileName, outputFormat))
+	// Set state
+	model.ID = types.StringValue(id)
+	model.ResolvedOutputPath = types.StringValue(outputPath)
 	model.ProfileName = types.StringValue(profileName)
 	model.TestContent = types.StringValue(string(content))
+	model.ConvertedControls = typesListFromStringSlice([]string{})
+	if !r.client.isDryRun() {
+		model.ConvertedControls = readConvertedControls(outputPath, diagnostics)
+	}
 }
 
 // Create creates the resource and sets the initial Terraform state
@@ -149,9 +292,10 @@ func (r *inspecMigrationResource) Create(ctx context.Context, req resource.Creat
 	}
 
 	// Create output directory
-	if !createOutputDirectory(plan.OutputPath.ValueString(), &resp.Diagnostics) {
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
 		return
 	}
+	existing := snapshotOutputs(r.client.resolveOutputPath(plan.OutputPath.ValueString()))
 
 	// Execute conversion and set state
 	r.executeInSpecConversion(ctx, &plan, &resp.Diagnostics)
@@ -159,6 +303,12 @@ func (r *inspecMigrationResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	// Don't leave an untracked test file behind if the apply was interrupted
+	testFilePath := filepath.Join(plan.ResolvedOutputPath.ValueString(), inspecOutputFilename(plan.OutputFormat.ValueString(), plan.OutputFile))
+	if cleanupIfCanceled(ctx, &resp.Diagnostics, existing, testFilePath, filepath.Join(plan.ResolvedOutputPath.ValueString(), controlsSidecarFilename)) {
+		return
+	}
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 }
@@ -172,10 +322,10 @@ func (r *inspecMigrationResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	outputPath := state.OutputPath.ValueString()
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
 	outputFormat := state.OutputFormat.ValueString()
 
-	testFilePath := filepath.Join(outputPath, inspecTestFilename(outputFormat))
+	testFilePath := filepath.Join(outputPath, inspecOutputFilename(outputFormat, state.OutputFile))
 
 	// Check if file exists and read content
 	if !readFileAndSetState(
@@ -185,10 +335,11 @@ func (r *inspecMigrationResource) Read(ctx context.Context, req resource.ReadReq
 		func(content string) { state.TestContent = types.StringValue(content) },
 		errReadingTestFile,
 		&resp.Diagnostics,
-		resp.State.RemoveResource,
+		r.client.missingArtifactHandler("test file "+testFilePath, &resp.State, &resp.Diagnostics),
 	) {
 		return
 	}
+	state.ConvertedControls = readConvertedControls(outputPath, &resp.Diagnostics)
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
@@ -203,6 +354,11 @@ func (r *inspecMigrationResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
+	// Check the output directory, creating it unless create_output_dir is false
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
+		return
+	}
+
 	// Execute conversion and set state
 	r.executeInSpecConversion(ctx, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
@@ -222,28 +378,42 @@ func (r *inspecMigrationResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
-	outputPath := state.OutputPath.ValueString()
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	if r.client.retainGeneratedFiles(state.RetainOnDelete, outputPath, &resp.Diagnostics) {
+		return
+	}
 	outputFormat := state.OutputFormat.ValueString()
 
-	testFilePath := filepath.Join(outputPath, inspecTestFilename(outputFormat))
+	testFilePath := filepath.Join(outputPath, inspecOutputFilename(outputFormat, state.OutputFile))
 	deleteGeneratedFile(testFilePath, "test file", &resp.Diagnostics)
+	deleteGeneratedFile(filepath.Join(outputPath, controlsSidecarFilename), "converted controls file", &resp.Diagnostics)
+	pruneEmptyDir(state.PruneEmptyDir, outputPath, &resp.Diagnostics)
 }
 
 // ImportState imports an existing resource into Terraform
 func (r *inspecMigrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import ID format: profile_path|output_path|output_format
-	parts := strings.Split(req.ID, "|")
-	if len(parts) != 3 {
+	// Import ID format: profile_path|output_path|output_format|output_filename (output_filename is optional)
+	parts, err := importIDParts(req.ID, "profile_path", "output_path", "output_format", "output_filename")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+	if len(parts) < 3 || len(parts) > 4 {
 		resp.Diagnostics.AddError(
 			"Invalid import ID",
-			"Import ID must be in format: profile_path|output_path|output_format",
+			"Import ID must be in format: profile_path|output_path|output_format or profile_path|output_path|output_format|output_filename",
 		)
 		return
 	}
 
 	profilePath := parts[0]
-	outputPath := parts[1]
+	configuredOutputPath := parts[1]
+	outputPath := r.client.resolveOutputPath(configuredOutputPath)
 	outputFormat := parts[2]
+	outputFilename := types.StringNull()
+	if len(parts) == 4 && parts[3] != "" {
+		outputFilename = types.StringValue(parts[3])
+	}
 
 	// Validate that the profile directory exists
 	if !checkFileExists(profilePath, "Profile", &resp.Diagnostics) {
@@ -251,7 +421,7 @@ func (r *inspecMigrationResource) ImportState(ctx context.Context, req resource.
 	}
 
 	// Check if test file exists
-	testFilePath := filepath.Join(outputPath, inspecTestFilename(outputFormat))
+	testFilePath := filepath.Join(outputPath, inspecOutputFilename(outputFormat, outputFilename))
 	if !checkFileExists(testFilePath, "Test file", &resp.Diagnostics) {
 		return
 	}
@@ -267,9 +437,12 @@ func (r *inspecMigrationResource) ImportState(ctx context.Context, req resource.
 
 	// Set state
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("profile_path"), profilePath)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_path"), outputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_path"), configuredOutputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resolved_output_path"), outputPath)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_format"), outputFormat)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("profile_name"), profileName)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("test_content"), string(content))...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), fmt.Sprintf(inspecIDFormat, profileName, outputFormat))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_filename"), outputFilename)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("converted_controls"), readConvertedControls(outputPath, &resp.Diagnostics))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), r.client.resourceID(fmt.Sprintf(inspecIDFormat, profileName, outputFormat), profilePath))...)
 }
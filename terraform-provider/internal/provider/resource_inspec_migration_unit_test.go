@@ -0,0 +1,301 @@
+// Package provider contains unit tests for the InSpec migration resource.
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testGossYml = "goss.yml"
+
+func TestInSpecMigrationOutputFilenameOverride(t *testing.T) {
+	r := &inspecMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+
+	outputDir := t.TempDir()
+	model := inspecMigrationResourceModel{
+		ProfilePath:       types.StringValue(testTmpProfile),
+		OutputPath:        types.StringValue(outputDir),
+		OutputFormat:      types.StringValue("goss"),
+		OutputFile:        types.StringValue(testGossYml),
+		ConvertedControls: types.ListNull(types.StringType),
+	}
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, model)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+
+	overridePath := filepath.Join(outputDir, testGossYml)
+	if _, err := os.Stat(overridePath); err != nil {
+		t.Fatalf("expected %s to exist: %v", overridePath, err)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, gossFilename)); !os.IsNotExist(err) {
+		t.Fatalf("expected default %s to be renamed", gossFilename)
+	}
+
+	// Read must use the override rather than goss.yaml
+	if err := os.WriteFile(overridePath, []byte("updated"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	var state inspecMigrationResourceModel
+	if diags := readResp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.TestContent.ValueString() != "updated" {
+		t.Fatalf("expected Read to use %s, got content %q", testGossYml, state.TestContent.ValueString())
+	}
+
+	// Delete must remove the override file
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+	}
+	if _, err := os.Stat(overridePath); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be deleted", overridePath)
+	}
+}
+
+func TestInSpecMigrationImportStateOutputFilename(t *testing.T) {
+	r := &inspecMigrationResource{}
+	schema := newResourceSchema(t, r)
+
+	profileDir := t.TempDir()
+	outputDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outputDir, testGossYml), []byte("content"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+
+	resp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: profileDir + "|" + outputDir + "|goss|" + testGossYml}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var state inspecMigrationResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.OutputFile.ValueString() != testGossYml {
+		t.Fatalf("expected output_filename %q, got %q", testGossYml, state.OutputFile.ValueString())
+	}
+}
+
+func TestInSpecMigrationImportStateJSON(t *testing.T) {
+	r := &inspecMigrationResource{}
+	schema := newResourceSchema(t, r)
+
+	profileDir := t.TempDir()
+	outputDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outputDir, testGossYml), []byte("content"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+
+	id, err := json.Marshal(map[string]string{
+		"profile_path":    profileDir,
+		"output_path":     outputDir,
+		"output_format":   "goss",
+		"output_filename": testGossYml,
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal import ID: %v", err)
+	}
+
+	resp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: string(id)}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var state inspecMigrationResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.ProfilePath.ValueString() != profileDir || state.OutputFormat.ValueString() != "goss" || state.OutputFile.ValueString() != testGossYml {
+		t.Fatalf("unexpected imported state: %+v", state)
+	}
+}
+
+func TestInSpecMigrationValidateConfigOutputFilename(t *testing.T) {
+	r := &inspecMigrationResource{}
+	schema := newResourceSchema(t, r)
+
+	tests := []struct {
+		name         string
+		format       string
+		filename     types.String
+		wantError    bool
+		wantWarnings int
+	}{
+		{name: "unset", format: "goss", filename: types.StringNull()},
+		{name: "goss yml", format: "goss", filename: types.StringValue(testGossYml)},
+		{name: "separator", format: "goss", filename: types.StringValue("tests/goss.yml"), wantError: true},
+		{name: "parent", format: "goss", filename: types.StringValue(".."), wantError: true},
+		{name: "unexpected extension", format: "testinfra", filename: types.StringValue("test_spec.rb"), wantWarnings: 1},
+		{name: "unknown format", format: "custom", filename: types.StringValue("tests.txt")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newResourceConfig(t, schema, inspecMigrationResourceModel{
+				ProfilePath:       types.StringValue(testTmpProfile),
+				OutputPath:        types.StringValue(t.TempDir()),
+				OutputFormat:      types.StringValue(tt.format),
+				OutputFile:        tt.filename,
+				ConvertedControls: types.ListNull(types.StringType),
+			})
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("expected error %v, got diagnostics: %v", tt.wantError, resp.Diagnostics)
+			}
+			if resp.Diagnostics.WarningsCount() != tt.wantWarnings {
+				t.Errorf("expected %d warnings, got %d", tt.wantWarnings, resp.Diagnostics.WarningsCount())
+			}
+		})
+	}
+}
+
+// TestInSpecMigrationLifecycleFilenameConsistency verifies every lifecycle
+// method resolves the same test file as Create, including for formats without
+// a dedicated filename.
+func TestInSpecMigrationLifecycleFilenameConsistency(t *testing.T) {
+	formats := []string{"testinfra", "serverspec", "goss", "ansible", "custom"}
+	for _, format := range formats {
+		t.Run(format, func(t *testing.T) {
+			r := &inspecMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+			schema := newResourceSchema(t, r)
+			ctx := context.Background()
+
+			profileDir := t.TempDir()
+			outputDir := t.TempDir()
+			model := inspecMigrationResourceModel{
+				ProfilePath:       types.StringValue(profileDir),
+				OutputPath:        types.StringValue(outputDir),
+				OutputFormat:      types.StringValue(format),
+				OutputFile:        types.StringNull(),
+				ConvertedControls: types.ListNull(types.StringType),
+			}
+
+			createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+			r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, schema, model)}, createResp)
+			if createResp.Diagnostics.HasError() {
+				t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+			}
+
+			testFilePath := filepath.Join(outputDir, inspecTestFilename(format))
+			if _, err := os.Stat(testFilePath); err != nil {
+				t.Fatalf("expected Create to write %s: %v", testFilePath, err)
+			}
+
+			// Read must find the file Create wrote and keep the resource
+			if err := os.WriteFile(testFilePath, []byte("refreshed"), testFilePermissions); err != nil {
+				t.Fatalf(testFailedToWriteFile, err)
+			}
+			readResp := &resource.ReadResponse{State: createResp.State}
+			r.Read(ctx, resource.ReadRequest{State: createResp.State}, readResp)
+			if readResp.Diagnostics.HasError() {
+				t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+			}
+			if readResp.State.Raw.IsNull() {
+				t.Fatal("expected Read to keep the resource in state")
+			}
+			var state inspecMigrationResourceModel
+			if diags := readResp.State.Get(ctx, &state); diags.HasError() {
+				t.Fatalf("failed to read state: %v", diags)
+			}
+			if state.TestContent.ValueString() != "refreshed" {
+				t.Fatalf("expected Read to use %s, got content %q", testFilePath, state.TestContent.ValueString())
+			}
+
+			// Update must rewrite the same file
+			updateResp := &resource.UpdateResponse{State: readResp.State}
+			r.Update(ctx, resource.UpdateRequest{Plan: newPlan(t, schema, model), State: readResp.State}, updateResp)
+			if updateResp.Diagnostics.HasError() {
+				t.Fatalf(testUnexpectedDiagnostics, updateResp.Diagnostics)
+			}
+			if _, err := os.Stat(testFilePath); err != nil {
+				t.Fatalf("expected Update to write %s: %v", testFilePath, err)
+			}
+
+			// ImportState must locate the same file
+			importResp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: profileDir + "|" + outputDir + "|" + format}, importResp)
+			if importResp.Diagnostics.HasError() {
+				t.Fatalf(testUnexpectedDiagnostics, importResp.Diagnostics)
+			}
+
+			// Delete must remove the file Create wrote
+			deleteResp := &resource.DeleteResponse{}
+			r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, deleteResp)
+			if deleteResp.Diagnostics.HasError() {
+				t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+			}
+			if _, err := os.Stat(testFilePath); !os.IsNotExist(err) {
+				t.Fatalf("expected Delete to remove %s", testFilePath)
+			}
+		})
+	}
+}
+
+func TestInSpecMigrationConvertedControls(t *testing.T) {
+	tests := map[string]struct {
+		sidecar      string
+		want         []string
+		wantWarnings int
+	}{
+		"sidecar":         {sidecar: `["ssh-01","ssh-02"]`, want: []string{"ssh-01", "ssh-02"}},
+		"no sidecar":      {want: []string{}},
+		"invalid sidecar": {sidecar: `{"controls":`, want: []string{}, wantWarnings: 1},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("SOUSCHEF_TEST_CONTROLS", tt.sidecar)
+			r := &inspecMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+			schema := newResourceSchema(t, r)
+			outputDir := t.TempDir()
+			model := inspecMigrationResourceModel{
+				ProfilePath:       types.StringValue(testTmpProfile),
+				OutputPath:        types.StringValue(outputDir),
+				OutputFormat:      types.StringValue("testinfra"),
+				ConvertedControls: types.ListNull(types.StringType),
+			}
+
+			createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+			r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, model)}, createResp)
+			if createResp.Diagnostics.HasError() || createResp.Diagnostics.WarningsCount() != tt.wantWarnings {
+				t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+			}
+			var state inspecMigrationResourceModel
+			createResp.State.Get(context.Background(), &state)
+			var got []string
+			state.ConvertedControls.ElementsAs(context.Background(), &got, false)
+			verifyStringSliceResult(t, got, tt.want)
+
+			// Destroy removes the sidecar along with the tests
+			deleteResp := &resource.DeleteResponse{State: createResp.State}
+			r.Delete(context.Background(), resource.DeleteRequest{State: createResp.State}, deleteResp)
+			if deleteResp.Diagnostics.HasError() {
+				t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+			}
+			if _, err := os.Stat(filepath.Join(outputDir, controlsSidecarFilename)); !os.IsNotExist(err) {
+				t.Fatalf("expected %s to be deleted, got %v", controlsSidecarFilename, err)
+			}
+		})
+	}
+}
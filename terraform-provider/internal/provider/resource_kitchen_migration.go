@@ -0,0 +1,322 @@
+// Package provider implements the SousChef Terraform provider resources
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	errReadingMoleculeConfig = "Error reading Molecule configuration"
+	moleculeConfigFilename   = "molecule.yml"
+	kitchenIDFormat          = "kitchen-%s"
+)
+
+// kitchenSuiteNamePattern matches a list item naming a Test Kitchen suite.
+var kitchenSuiteNamePattern = regexp.MustCompile(`^(\s*)-\s+name:`)
+
+// countKitchenSuites returns the number of entries in the top-level suites
+// list of a Test Kitchen configuration; each suite becomes a Molecule scenario.
+func countKitchenSuites(content string) int {
+	count := 0
+	inSuites := false
+	itemIndent := -1
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		// A top-level key starts or ends the suites section
+		if line[0] != ' ' && line[0] != '-' {
+			inSuites = strings.HasPrefix(line, "suites:")
+			itemIndent = -1
+			continue
+		}
+		if !inSuites {
+			continue
+		}
+
+		match := kitchenSuiteNamePattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		if itemIndent == -1 {
+			itemIndent = len(match[1])
+		}
+		if len(match[1]) == itemIndent {
+			count++
+		}
+	}
+	return count
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource                = &kitchenMigrationResource{}
+	_ resource.ResourceWithImportState = &kitchenMigrationResource{}
+)
+
+// NewKitchenMigrationResource creates a new Test Kitchen migration resource
+func NewKitchenMigrationResource() resource.Resource {
+	return &kitchenMigrationResource{}
+}
+
+// kitchenMigrationResource is the resource implementation
+type kitchenMigrationResource struct {
+	client *SousChefClient
+}
+
+// kitchenMigrationResourceModel describes the resource data model
+type kitchenMigrationResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	KitchenPath        types.String `tfsdk:"kitchen_path"`
+	OutputPath         types.String `tfsdk:"output_path"`
+	CreateOutputDir    types.Bool   `tfsdk:"create_output_dir"`
+	PruneEmptyDir      types.Bool   `tfsdk:"prune_empty_dir"`
+	RetainOnDelete     types.Bool   `tfsdk:"retain_on_delete"`
+	ResolvedOutputPath types.String `tfsdk:"resolved_output_path"`
+	ScenarioCount      types.Int64  `tfsdk:"scenario_count"`
+	MoleculeContent    types.String `tfsdk:"molecule_content"`
+}
+
+// Metadata returns the resource type name
+func (r *kitchenMigrationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kitchen_migration"
+}
+
+// Schema defines the schema for the resource
+func (r *kitchenMigrationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages conversion of Test Kitchen configurations to Molecule scenarios.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier for the Test Kitchen migration",
+			},
+			"kitchen_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the `.kitchen.yml` file",
+			},
+			"output_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Directory where `molecule.yml` will be written",
+			},
+			"create_output_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Create `output_path` when it does not exist (default: true). When false the directory must already exist, e.g. when it is pre-created with specific ownership and permissions",
+			},
+			"prune_empty_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove the output directory on destroy when no other files remain in it (default: false)",
+			},
+			"retain_on_delete": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Leave the generated files in place on destroy and only remove the resource from state, e.g. once they are committed to a repository (default: false)",
+			},
+			"resolved_output_path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Directory `molecule.yml` is written to: `output_path` joined onto the provider `output_root` when `output_path` is relative",
+			},
+			"scenario_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of Molecule scenarios, one per Test Kitchen suite",
+			},
+			"molecule_content": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Generated `molecule.yml` content",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *kitchenMigrationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = configureResource(req, resp)
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *kitchenMigrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan kitchenMigrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create output directory
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
+		return
+	}
+	existing := snapshotOutputs(r.client.resolveOutputPath(plan.OutputPath.ValueString()))
+
+	// Execute conversion and set state
+	r.executeKitchenConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Don't leave an untracked molecule.yml behind if the apply was interrupted
+	if cleanupIfCanceled(ctx, &resp.Diagnostics, existing, filepath.Join(plan.ResolvedOutputPath.ValueString(), moleculeConfigFilename)) {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *kitchenMigrationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state kitchenMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	moleculePath := filepath.Join(stateOutputPath(state.ResolvedOutputPath, state.OutputPath), moleculeConfigFilename)
+
+	// Check if file exists and read content
+	if !readFileAndSetState(
+		ctx,
+		moleculePath,
+		"molecule_content",
+		func(content string) { state.MoleculeContent = types.StringValue(content) },
+		errReadingMoleculeConfig,
+		&resp.Diagnostics,
+		r.client.missingArtifactHandler("Molecule configuration "+moleculePath, &resp.State, &resp.Diagnostics),
+	) {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *kitchenMigrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan kitchenMigrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check the output directory, creating it unless create_output_dir is false
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
+		return
+	}
+
+	// Execute conversion and set state
+	r.executeKitchenConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *kitchenMigrationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state kitchenMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	if r.client.retainGeneratedFiles(state.RetainOnDelete, outputPath, &resp.Diagnostics) {
+		return
+	}
+	deleteGeneratedFile(filepath.Join(outputPath, moleculeConfigFilename), "Molecule configuration", &resp.Diagnostics)
+	pruneEmptyDir(state.PruneEmptyDir, outputPath, &resp.Diagnostics)
+}
+
+// executeKitchenConversion is a helper that encapsulates the common logic for Create and Update.
+// It executes the Test Kitchen conversion, reads the output, and updates the model state.
+func (r *kitchenMigrationResource) executeKitchenConversion(ctx context.Context, model *kitchenMigrationResourceModel, diagnostics *diag.Diagnostics) {
+	kitchenPath := model.KitchenPath.ValueString()
+	outputPath := r.client.resolveOutputPath(model.OutputPath.ValueString())
+	id := r.client.resourceID(fmt.Sprintf(kitchenIDFormat, filepath.Base(filepath.Dir(kitchenPath))), kitchenPath)
+	ctx = withReportResourceID(ctx, id)
+
+	kitchenContent := readGeneratedFile(kitchenPath, "Error reading Test Kitchen configuration", diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+
+	// Call souschef CLI to convert the Test Kitchen configuration
+	args := []string{"convert-kitchen", "--kitchen-path", kitchenPath, "--output-path", outputPath}
+	content, ok := executeConversion(ctx, r.client, args, filepath.Join(outputPath, moleculeConfigFilename), errReadingMoleculeConfig, diagnostics)
+	if !ok {
+		return
+	}
+
+	// Set state
+	model.ID = types.StringValue(id)
+	model.ResolvedOutputPath = types.StringValue(outputPath)
+	model.ScenarioCount = types.Int64Value(int64(countKitchenSuites(kitchenContent)))
+	model.MoleculeContent = types.StringValue(content)
+}
+
+// ImportState imports an existing resource into Terraform
+func (r *kitchenMigrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format: kitchen_path|output_path
+	parts, err := importIDParts(req.ID, "kitchen_path", "output_path")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID must be in format: kitchen_path|output_path",
+		)
+		return
+	}
+
+	kitchenPath := parts[0]
+	configuredOutputPath := parts[1]
+	outputPath := r.client.resolveOutputPath(configuredOutputPath)
+
+	// Validate that the Test Kitchen configuration exists
+	if !checkFileExists(kitchenPath, "Test Kitchen configuration", &resp.Diagnostics) {
+		return
+	}
+	kitchenContent := readGeneratedFile(kitchenPath, "Error reading Test Kitchen configuration", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check if molecule.yml exists
+	moleculePath := filepath.Join(outputPath, moleculeConfigFilename)
+	if !checkFileExists(moleculePath, "Molecule configuration", &resp.Diagnostics) {
+		return
+	}
+
+	// Read molecule.yml content
+	content := readGeneratedFile(moleculePath, errReadingMoleculeConfig, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("kitchen_path"), kitchenPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_path"), configuredOutputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resolved_output_path"), outputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("scenario_count"), int64(countKitchenSuites(kitchenContent)))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("molecule_content"), content)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), r.client.resourceID(fmt.Sprintf(kitchenIDFormat, filepath.Base(filepath.Dir(kitchenPath))), kitchenPath))...)
+}
@@ -0,0 +1,184 @@
+// Package provider contains unit tests for the Test Kitchen migration resource.
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testKitchenYml is a Test Kitchen configuration with two suites.
+const testKitchenYml = `---
+driver:
+  name: docker
+
+platforms:
+  - name: ubuntu-22.04
+  - name: centos-8
+
+suites:
+  # one Molecule scenario per suite
+  - name: default
+    run_list:
+      - recipe[web::default]
+  - name: database
+    run_list:
+      - recipe[web::database]
+    attributes:
+      mysql:
+        version: '8.0'
+`
+
+// newKitchenFixture writes testKitchenYml into a cookbook directory named web.
+func newKitchenFixture(t *testing.T) string {
+	t.Helper()
+
+	cookbookDir := filepath.Join(t.TempDir(), "web")
+	if err := os.Mkdir(cookbookDir, testDirPermissions); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+	kitchenPath := filepath.Join(cookbookDir, ".kitchen.yml")
+	if err := os.WriteFile(kitchenPath, []byte(testKitchenYml), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	return kitchenPath
+}
+
+func TestCountKitchenSuites(t *testing.T) {
+	tests := map[string]int{
+		testKitchenYml:                          2,
+		"driver:\n  name: docker\n":             0,
+		"suites:\n- name: a\n- name: b\n":       2,
+		"suites: []\nplatforms:\n  - name: x\n": 0,
+	}
+	for content, want := range tests {
+		if got := countKitchenSuites(content); got != want {
+			t.Errorf("countKitchenSuites(%q) = %d, want %d", content, got, want)
+		}
+	}
+}
+
+func TestKitchenMigrationLifecycle(t *testing.T) {
+	r := &kitchenMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	kitchenPath := newKitchenFixture(t)
+	outputDir := t.TempDir()
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, kitchenMigrationResourceModel{
+		KitchenPath: types.StringValue(kitchenPath),
+		OutputPath:  types.StringValue(outputDir),
+	})}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+
+	var state kitchenMigrationResourceModel
+	if diags := createResp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.ID.ValueString() != "kitchen-web" {
+		t.Fatalf("unexpected id %q", state.ID.ValueString())
+	}
+	if state.ScenarioCount.ValueInt64() != 2 {
+		t.Fatalf("expected 2 scenarios, got %d", state.ScenarioCount.ValueInt64())
+	}
+	if !strings.Contains(state.MoleculeContent.ValueString(), "driver:") {
+		t.Fatalf("unexpected molecule_content %q", state.MoleculeContent.ValueString())
+	}
+
+	// Read picks up edits to the generated file
+	moleculePath := filepath.Join(outputDir, moleculeConfigFilename)
+	if err := os.WriteFile(moleculePath, []byte("driver:\n  name: podman\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	var refreshed kitchenMigrationResourceModel
+	readResp.State.Get(context.Background(), &refreshed)
+	if !strings.Contains(refreshed.MoleculeContent.ValueString(), "podman") {
+		t.Fatalf("expected Read to refresh molecule_content, got %q", refreshed.MoleculeContent.ValueString())
+	}
+
+	// ImportState reconstructs the same resource
+	importResp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: kitchenPath + "|" + outputDir}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, importResp.Diagnostics)
+	}
+	var imported kitchenMigrationResourceModel
+	importResp.State.Get(context.Background(), &imported)
+	if imported.ID.ValueString() != state.ID.ValueString() || imported.ScenarioCount.ValueInt64() != 2 {
+		t.Fatalf("unexpected imported state: %+v", imported)
+	}
+
+	// Delete removes molecule.yml, after which Read drops the resource
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+	}
+	if _, err := os.Stat(moleculePath); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be deleted, got %v", moleculePath, err)
+	}
+
+	readResp = &resource.ReadResponse{State: readResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: readResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Fatal("expected resource to be removed when molecule.yml is missing")
+	}
+}
+
+func TestKitchenMigrationCreateErrors(t *testing.T) {
+	r := &kitchenMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+
+	tests := map[string]struct {
+		kitchenPath string
+		failCLI     bool
+	}{
+		"missing kitchen file": {kitchenPath: filepath.Join(t.TempDir(), ".kitchen.yml")},
+		"CLI failure":          {kitchenPath: newKitchenFixture(t), failCLI: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tt.failCLI {
+				t.Setenv("SOUSCHEF_TEST_FAIL", "convert-kitchen")
+			}
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+			r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, kitchenMigrationResourceModel{
+				KitchenPath: types.StringValue(tt.kitchenPath),
+				OutputPath:  types.StringValue(t.TempDir()),
+			})}, resp)
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestKitchenMigrationImportStateErrors(t *testing.T) {
+	r := &kitchenMigrationResource{}
+	schema := newResourceSchema(t, r)
+	kitchenPath := newKitchenFixture(t)
+
+	for _, id := range []string{kitchenPath, filepath.Join(t.TempDir(), ".kitchen.yml") + "|" + t.TempDir(), kitchenPath + "|" + t.TempDir()} {
+		resp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+		r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, resp)
+		if !resp.Diagnostics.HasError() {
+			t.Fatalf("expected error importing %q", id)
+		}
+	}
+}
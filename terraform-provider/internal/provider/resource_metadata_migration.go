@@ -0,0 +1,326 @@
+// Package provider implements the SousChef Terraform provider resources
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	metadataGalaxyDir      = "meta"
+	metadataGalaxyFile     = "main.yml"
+	metadataIDFormat       = "metadata-%s"
+	errReadingGalaxyMeta   = "Error reading Galaxy metadata"
+	errReadingMetadataRb   = "Error reading metadata.rb"
+	metadataGalaxyFileType = "Galaxy metadata"
+)
+
+var (
+	// metadataNamePattern matches the cookbook name, e.g. `name 'web_server'`.
+	metadataNamePattern = regexp.MustCompile(`^\s*name\s*\(?\s*['"]([^'"]+)['"]`)
+	// metadataDependsPattern matches a dependency, e.g. `depends 'nginx', '~> 12.0'`.
+	metadataDependsPattern = regexp.MustCompile(`^\s*depends\s*\(?\s*['"]([^'"]+)['"]`)
+)
+
+// parseCookbookMetadata returns the cookbook name and the names of the
+// cookbooks it depends on, in declaration order, from metadata.rb content.
+// The name falls back to the metadata.rb directory when it is not declared.
+func parseCookbookMetadata(metadataPath, content string) (string, []string) {
+	name := filepath.Base(filepath.Dir(metadataPath))
+	dependencies := []string{}
+	for _, line := range strings.Split(content, "\n") {
+		if match := metadataNamePattern.FindStringSubmatch(line); match != nil {
+			name = match[1]
+		} else if match := metadataDependsPattern.FindStringSubmatch(line); match != nil {
+			dependencies = append(dependencies, match[1])
+		}
+	}
+	return name, dependencies
+}
+
+// metadataGalaxyPath returns the meta/main.yml path the CLI writes under outputPath.
+func metadataGalaxyPath(outputPath string) string {
+	return filepath.Join(outputPath, metadataGalaxyDir, metadataGalaxyFile)
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource                = &metadataMigrationResource{}
+	_ resource.ResourceWithImportState = &metadataMigrationResource{}
+)
+
+// NewMetadataMigrationResource creates a new metadata migration resource
+func NewMetadataMigrationResource() resource.Resource {
+	return &metadataMigrationResource{}
+}
+
+// metadataMigrationResource is the resource implementation
+type metadataMigrationResource struct {
+	client *SousChefClient
+}
+
+// metadataMigrationResourceModel describes the resource data model
+type metadataMigrationResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	MetadataPath       types.String `tfsdk:"metadata_path"`
+	OutputPath         types.String `tfsdk:"output_path"`
+	CreateOutputDir    types.Bool   `tfsdk:"create_output_dir"`
+	PruneEmptyDir      types.Bool   `tfsdk:"prune_empty_dir"`
+	RetainOnDelete     types.Bool   `tfsdk:"retain_on_delete"`
+	ResolvedOutputPath types.String `tfsdk:"resolved_output_path"`
+	RoleName           types.String `tfsdk:"role_name"`
+	Dependencies       types.List   `tfsdk:"dependencies"`
+	GalaxyContent      types.String `tfsdk:"galaxy_content"`
+}
+
+// Metadata returns the resource type name
+func (r *metadataMigrationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_metadata_migration"
+}
+
+// Schema defines the schema for the resource
+func (r *metadataMigrationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages conversion of a cookbook's `metadata.rb` to an Ansible Galaxy `meta/main.yml`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier for the metadata migration",
+			},
+			"metadata_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the cookbook's `metadata.rb`",
+			},
+			"output_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Role directory the `meta/main.yml` will be written under",
+			},
+			"create_output_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Create `output_path` when it does not exist (default: true). When false the directory must already exist, e.g. when it is pre-created with specific ownership and permissions",
+			},
+			"prune_empty_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove the `meta` and output directories on destroy when no other files remain in them (default: false)",
+			},
+			"retain_on_delete": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Leave the generated files in place on destroy and only remove the resource from state, e.g. once they are committed to a repository (default: false)",
+			},
+			"resolved_output_path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Directory the role metadata is written under: `output_path` joined onto the provider `output_root` when `output_path` is relative",
+			},
+			"role_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Role name, from the cookbook `name` in `metadata.rb` (or its directory name when undeclared)",
+			},
+			"dependencies": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Cookbooks declared with `depends` in `metadata.rb`, in declaration order",
+			},
+			"galaxy_content": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Generated `meta/main.yml` content",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *metadataMigrationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = configureResource(req, resp)
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *metadataMigrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan metadataMigrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create output directory
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
+		return
+	}
+	existing := snapshotOutputs(r.client.resolveOutputPath(plan.OutputPath.ValueString()))
+
+	// Execute conversion and set state
+	r.executeMetadataConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Don't leave an untracked meta/main.yml behind if the apply was interrupted
+	if cleanupIfCanceled(ctx, &resp.Diagnostics, existing, metadataGalaxyPath(plan.ResolvedOutputPath.ValueString())) {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *metadataMigrationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state metadataMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	galaxyPath := metadataGalaxyPath(stateOutputPath(state.ResolvedOutputPath, state.OutputPath))
+
+	// Check if file exists and read content
+	if !readFileAndSetState(
+		ctx,
+		galaxyPath,
+		"galaxy_content",
+		func(content string) { state.GalaxyContent = types.StringValue(content) },
+		errReadingGalaxyMeta,
+		&resp.Diagnostics,
+		r.client.missingArtifactHandler("Galaxy metadata "+galaxyPath, &resp.State, &resp.Diagnostics),
+	) {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *metadataMigrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan metadataMigrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check the output directory, creating it unless create_output_dir is false
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
+		return
+	}
+
+	// Execute conversion and set state
+	r.executeMetadataConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *metadataMigrationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state metadataMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	if r.client.retainGeneratedFiles(state.RetainOnDelete, outputPath, &resp.Diagnostics) {
+		return
+	}
+	deleteGeneratedFile(metadataGalaxyPath(outputPath), metadataGalaxyFileType, &resp.Diagnostics)
+	pruneEmptyDir(state.PruneEmptyDir, filepath.Join(outputPath, metadataGalaxyDir), &resp.Diagnostics)
+	pruneEmptyDir(state.PruneEmptyDir, outputPath, &resp.Diagnostics)
+}
+
+// executeMetadataConversion is a helper that encapsulates the common logic for Create and Update.
+// It executes the metadata conversion, reads the output, and updates the model state.
+func (r *metadataMigrationResource) executeMetadataConversion(ctx context.Context, model *metadataMigrationResourceModel, diagnostics *diag.Diagnostics) {
+	metadataPath := model.MetadataPath.ValueString()
+	outputPath := r.client.resolveOutputPath(model.OutputPath.ValueString())
+
+	metadataContent := readGeneratedFile(metadataPath, errReadingMetadataRb, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+
+	roleName, dependencies := parseCookbookMetadata(metadataPath, metadataContent)
+	id := r.client.resourceID(fmt.Sprintf(metadataIDFormat, roleName), metadataPath)
+	ctx = withReportResourceID(ctx, id)
+
+	// Call souschef CLI to convert the metadata
+	args := []string{"convert-metadata", "--metadata-path", metadataPath, "--output-path", outputPath}
+	content, ok := executeConversion(ctx, r.client, args, metadataGalaxyPath(outputPath), errReadingGalaxyMeta, diagnostics)
+	if !ok {
+		return
+	}
+
+	// Set state
+	model.ID = types.StringValue(id)
+	model.ResolvedOutputPath = types.StringValue(outputPath)
+	model.RoleName = types.StringValue(roleName)
+	model.Dependencies = typesListFromStringSlice(dependencies)
+	model.GalaxyContent = types.StringValue(content)
+}
+
+// ImportState imports an existing resource into Terraform
+func (r *metadataMigrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format: metadata_path|output_path
+	parts, err := importIDParts(req.ID, "metadata_path", "output_path")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID must be in format: metadata_path|output_path",
+		)
+		return
+	}
+
+	metadataPath := parts[0]
+	configuredOutputPath := parts[1]
+	outputPath := r.client.resolveOutputPath(configuredOutputPath)
+
+	// Validate that metadata.rb exists
+	if !checkFileExists(metadataPath, "metadata.rb", &resp.Diagnostics) {
+		return
+	}
+	metadataContent := readGeneratedFile(metadataPath, errReadingMetadataRb, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check if the Galaxy metadata exists
+	galaxyPath := metadataGalaxyPath(outputPath)
+	if !checkFileExists(galaxyPath, metadataGalaxyFileType, &resp.Diagnostics) {
+		return
+	}
+
+	// Read Galaxy metadata content
+	content := readGeneratedFile(galaxyPath, errReadingGalaxyMeta, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	roleName, dependencies := parseCookbookMetadata(metadataPath, metadataContent)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("metadata_path"), metadataPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_path"), configuredOutputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resolved_output_path"), outputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_name"), roleName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dependencies"), typesListFromStringSlice(dependencies))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("galaxy_content"), content)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), r.client.resourceID(fmt.Sprintf(metadataIDFormat, roleName), metadataPath))...)
+}
@@ -0,0 +1,181 @@
+// Package provider contains unit tests for the metadata migration resource.
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testMetadataPath is a metadata.rb for web_server with three dependencies.
+var testMetadataPath = filepath.Join(getFixturePath("metadata"), "metadata.rb")
+
+func TestParseCookbookMetadata(t *testing.T) {
+	name, dependencies := parseCookbookMetadata("/cookbooks/web/metadata.rb", "name 'nginx'\ndepends \"logrotate\", '>= 2.0'\n  depends('systemd')\n# depends 'ignored'\n")
+	if name != "nginx" || strings.Join(dependencies, ",") != "logrotate,systemd" {
+		t.Fatalf("unexpected metadata %q %v", name, dependencies)
+	}
+
+	name, dependencies = parseCookbookMetadata("/cookbooks/web/metadata.rb", "version '1.0.0'\n")
+	if name != "web" || len(dependencies) != 0 {
+		t.Fatalf("expected directory name and no dependencies, got %q %v", name, dependencies)
+	}
+}
+
+func TestMetadataMigrationLifecycle(t *testing.T) {
+	r := &metadataMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputDir := t.TempDir()
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, metadataMigrationResourceModel{
+		MetadataPath: types.StringValue(testMetadataPath),
+		OutputPath:   types.StringValue(outputDir),
+		Dependencies: types.ListUnknown(types.StringType),
+	})}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+
+	var state metadataMigrationResourceModel
+	if diags := createResp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.ID.ValueString() != "metadata-web_server" || state.RoleName.ValueString() != "web_server" {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+	var dependencies []string
+	state.Dependencies.ElementsAs(context.Background(), &dependencies, false)
+	if strings.Join(dependencies, ",") != "nginx,logrotate,firewall" {
+		t.Fatalf("unexpected dependencies %v", dependencies)
+	}
+	if !strings.Contains(state.GalaxyContent.ValueString(), "- role: firewall") {
+		t.Fatalf("unexpected galaxy_content %q", state.GalaxyContent.ValueString())
+	}
+
+	// Read picks up edits to the generated file
+	galaxyPath := filepath.Join(outputDir, "meta", "main.yml")
+	if err := os.WriteFile(galaxyPath, []byte("galaxy_info:\n  author: edited\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	var refreshed metadataMigrationResourceModel
+	readResp.State.Get(context.Background(), &refreshed)
+	if !strings.Contains(refreshed.GalaxyContent.ValueString(), "author: edited") {
+		t.Fatalf("expected Read to refresh galaxy_content, got %q", refreshed.GalaxyContent.ValueString())
+	}
+
+	// ImportState reconstructs the same resource
+	importResp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: testMetadataPath + "|" + outputDir}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, importResp.Diagnostics)
+	}
+	var imported metadataMigrationResourceModel
+	importResp.State.Get(context.Background(), &imported)
+	if imported.ID.ValueString() != state.ID.ValueString() || !imported.Dependencies.Equal(state.Dependencies) || imported.GalaxyContent.ValueString() != refreshed.GalaxyContent.ValueString() {
+		t.Fatalf("unexpected imported state: %+v", imported)
+	}
+
+	// Delete removes meta/main.yml, after which Read drops the resource
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+	}
+	if _, err := os.Stat(galaxyPath); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be deleted, got %v", galaxyPath, err)
+	}
+
+	readResp = &resource.ReadResponse{State: readResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: readResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Fatal("expected resource to be removed when meta/main.yml is missing")
+	}
+}
+
+func TestMetadataMigrationPruneEmptyDir(t *testing.T) {
+	r := &metadataMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputDir := filepath.Join(t.TempDir(), "web_server")
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, metadataMigrationResourceModel{
+		MetadataPath:  types.StringValue(testMetadataPath),
+		OutputPath:    types.StringValue(outputDir),
+		PruneEmptyDir: types.BoolValue(true),
+		Dependencies:  types.ListUnknown(types.StringType),
+	})}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+	}
+	if _, err := os.Stat(outputDir); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be pruned, got %v", outputDir, err)
+	}
+}
+
+func TestMetadataMigrationCreateErrors(t *testing.T) {
+	r := &metadataMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+
+	tests := map[string]struct {
+		metadataPath string
+		failCLI      bool
+	}{
+		"missing metadata.rb": {metadataPath: filepath.Join(t.TempDir(), "metadata.rb")},
+		"CLI failure":         {metadataPath: testMetadataPath, failCLI: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tt.failCLI {
+				t.Setenv("SOUSCHEF_TEST_FAIL", "convert-metadata")
+			}
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+			r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, metadataMigrationResourceModel{
+				MetadataPath: types.StringValue(tt.metadataPath),
+				OutputPath:   types.StringValue(t.TempDir()),
+				Dependencies: types.ListUnknown(types.StringType),
+			})}, resp)
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestMetadataMigrationImportStateErrors(t *testing.T) {
+	r := &metadataMigrationResource{}
+	schema := newResourceSchema(t, r)
+
+	for _, id := range []string{
+		testMetadataPath,
+		testMetadataPath + "|" + t.TempDir() + "|extra",
+		filepath.Join(t.TempDir(), "metadata.rb") + "|" + t.TempDir(),
+		testMetadataPath + "|" + t.TempDir(),
+	} {
+		resp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+		r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, resp)
+		if !resp.Diagnostics.HasError() {
+			t.Fatalf("expected error importing %q", id)
+		}
+	}
+}
@@ -3,27 +3,81 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-const errorReadingPlaybook = "Error reading playbook"
+const (
+	errorReadingPlaybook = "Error reading playbook"
+	outputSyntaxYAML     = "yaml"
+	outputSyntaxJSON     = "json"
+
+	playbookExtensionYML  = "yml"
+	playbookExtensionYAML = "yaml"
+)
+
+// playbookFilename returns the generated playbook filename for recipeName in
+// the given output_syntax; anything other than "json" produces a .yml file.
+func playbookFilename(recipeName string, outputSyntax types.String) string {
+	if outputSyntax.ValueString() == outputSyntaxJSON {
+		return recipeName + ".json"
+	}
+	return recipeName + ".yml"
+}
+
+// findPlaybookFile returns the path and extension of recipeName's generated
+// playbook in outputPath. A YAML playbook may have been written as .yml or
+// .yaml, so both are checked, starting with outputExtension when it records
+// one, and the first that exists wins. When neither exists the expected path
+// is returned so callers report it as missing.
+func findPlaybookFile(outputPath, recipeName string, outputSyntax, outputExtension types.String) (string, string) {
+	if outputSyntax.ValueString() == outputSyntaxJSON {
+		return filepath.Join(outputPath, playbookFilename(recipeName, outputSyntax)), outputSyntaxJSON
+	}
+
+	extensions := []string{playbookExtensionYML, playbookExtensionYAML}
+	if outputExtension.ValueString() == playbookExtensionYAML {
+		extensions = []string{playbookExtensionYAML, playbookExtensionYML}
+	}
+	for _, extension := range extensions {
+		playbookPath := filepath.Join(outputPath, recipeName+"."+extension)
+		if _, err := osStat(playbookPath); err == nil {
+			return playbookPath, extension
+		}
+	}
+	return filepath.Join(outputPath, recipeName+"."+extensions[0]), extensions[0]
+}
 
 // Ensure the implementation satisfies the expected interfaces
 var (
-	_ resource.Resource                = &migrationResource{}
-	_ resource.ResourceWithConfigure   = &migrationResource{}
-	_ resource.ResourceWithImportState = &migrationResource{}
+	_ resource.Resource                   = &migrationResource{}
+	_ resource.ResourceWithConfigure      = &migrationResource{}
+	_ resource.ResourceWithImportState    = &migrationResource{}
+	_ resource.ResourceWithValidateConfig = &migrationResource{}
+	_ resource.ResourceWithModifyPlan     = &migrationResource{}
+	_ resource.ResourceWithUpgradeState   = &migrationResource{}
 )
 
 // NewMigrationResource is a helper function to simplify the provider implementation.
@@ -38,12 +92,53 @@ type migrationResource struct {
 
 // migrationResourceModel maps the resource schema data.
 type migrationResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	CookbookPath    types.String `tfsdk:"cookbook_path"`
-	OutputPath      types.String `tfsdk:"output_path"`
-	CookbookName    types.String `tfsdk:"cookbook_name"`
-	RecipeName      types.String `tfsdk:"recipe_name"`
-	PlaybookContent types.String `tfsdk:"playbook_content"`
+	ID                       types.String                 `tfsdk:"id"`
+	CookbookPath             types.String                 `tfsdk:"cookbook_path"`
+	OutputPath               types.String                 `tfsdk:"output_path"`
+	CreateOutputDir          types.Bool                   `tfsdk:"create_output_dir"`
+	PruneEmptyDir            types.Bool                   `tfsdk:"prune_empty_dir"`
+	RetainOnDelete           types.Bool                   `tfsdk:"retain_on_delete"`
+	ResolvedOutputPath       types.String                 `tfsdk:"resolved_output_path"`
+	CookbookName             types.String                 `tfsdk:"cookbook_name"`
+	RecipeName               types.String                 `tfsdk:"recipe_name"`
+	OutputSyntax             types.String                 `tfsdk:"output_syntax"`
+	OutputExtension          types.String                 `tfsdk:"output_extension"`
+	RecipesSubdir            types.String                 `tfsdk:"recipes_subdir"`
+	AnsibleVersion           types.String                 `tfsdk:"ansible_version"`
+	PlaybookContent          types.String                 `tfsdk:"playbook_content"`
+	PlaybookContentSensitive types.String                 `tfsdk:"playbook_content_sensitive"`
+	PlaybookContentBase64    types.String                 `tfsdk:"playbook_content_base64"`
+	ContentTruncated         types.Bool                   `tfsdk:"content_truncated"`
+	CaptureOutput            types.Bool                   `tfsdk:"capture_output"`
+	ConversionLog            types.String                 `tfsdk:"conversion_log"`
+	ReferencedEnvVars        types.List                   `tfsdk:"referenced_env_vars"`
+	BlockDestructive         types.Bool                   `tfsdk:"block_destructive"`
+	DestructivePatterns      []types.String               `tfsdk:"destructive_patterns"`
+	OutputLayout             types.String                 `tfsdk:"output_layout"`
+	RoleLayoutTemplate       types.String                 `tfsdk:"role_layout_template"`
+	RolePath                 types.String                 `tfsdk:"role_path"`
+	ModuleCounts             types.Map                    `tfsdk:"module_counts"`
+	GitURL                   types.String                 `tfsdk:"git_url"`
+	GitRef                   types.String                 `tfsdk:"git_ref"`
+	GitCommit                types.String                 `tfsdk:"git_commit"`
+	VariableRenameMap        types.Map                    `tfsdk:"variable_rename_map"`
+	VariableMappings         types.Map                    `tfsdk:"variable_mappings"`
+	ContentSHA256            types.String                 `tfsdk:"content_sha256"`
+	SourceHash               types.String                 `tfsdk:"source_hash"`
+	WasChanged               types.Bool                   `tfsdk:"was_changed"`
+	OutputFileMode           types.String                 `tfsdk:"output_file_mode"`
+	AppliedFileMode          types.String                 `tfsdk:"applied_file_mode"`
+	AddHeader                types.Bool                   `tfsdk:"add_header"`
+	HeaderTemplate           types.String                 `tfsdk:"header_template"`
+	OutputTargets            []migrationOutputTargetModel `tfsdk:"output_targets"`
+	Outputs                  types.Map                    `tfsdk:"outputs"`
+}
+
+// migrationOutputTargetModel describes an additional output the recipe is
+// converted to.
+type migrationOutputTargetModel struct {
+	Layout     types.String `tfsdk:"layout"`
+	OutputPath types.String `tfsdk:"output_path"`
 }
 
 // Metadata returns the resource type name.
@@ -55,6 +150,7 @@ func (r *migrationResource) Metadata(_ context.Context, req resource.MetadataReq
 func (r *migrationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a Chef cookbook to Ansible playbook migration.",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Unique identifier for the migration (cookbook-recipe).",
@@ -64,13 +160,30 @@ func (r *migrationResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"cookbook_path": schema.StringAttribute{
-				Description: "Path to the Chef cookbook directory.",
-				Required:    true,
+				Description: "Path to the Chef cookbook directory. Computed from the temporary clone when git_url is set.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"output_path": schema.StringAttribute{
 				Description: "Directory where Ansible playbook will be written.",
 				Required:    true,
 			},
+			"create_output_dir": schema.BoolAttribute{
+				Description: "Create output_path when it does not exist (default: true). When false the directory must already exist, e.g. when it is pre-created with specific ownership and permissions.",
+				Optional:    true,
+			},
+			"prune_empty_dir": schema.BoolAttribute{
+				Description: "Remove the output directory on destroy when no other files remain in it (default: false).",
+				Optional:    true,
+			},
+			"retain_on_delete": schema.BoolAttribute{
+				Description: "Leave the generated files in place on destroy and only remove the resource from state, e.g. once they are committed to a repository (default: false).",
+				Optional:    true,
+			},
+			"resolved_output_path": schema.StringAttribute{
+				Description: "Directory the playbook is written to: output_path joined onto the provider output_root when output_path is relative, made absolute unless normalize_paths is false.",
+				Computed:    true,
+			},
 			"cookbook_name": schema.StringAttribute{
 				Description: "Name of the cookbook (parsed from metadata.rb).",
 				Computed:    true,
@@ -79,12 +192,254 @@ func (r *migrationResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Description: "Name of the recipe to convert (default: 'default').",
 				Optional:    true,
 			},
+			"output_syntax": schema.StringAttribute{
+				Description: "Syntax of the generated playbook: 'yaml' (written to <recipe>.yml) or 'json' (written to <recipe>.json) (default: 'yaml').",
+				Optional:    true,
+			},
+			"ansible_version": schema.StringAttribute{
+				Description: "Ansible version the playbook is generated for, e.g. '2.15' or '9.1.0', which decides details such as FQCN module names and loop syntax. Passed to the CLI as --ansible-version; changing it re-converts the recipe. Defaults to the CLI's own target.",
+				Optional:    true,
+			},
+			"recipes_subdir": schema.StringAttribute{
+				Description: "Directory holding the cookbook's recipes, relative to the cookbook, for nested cookbooks or Policyfile layouts (default: 'recipes').",
+				Optional:    true,
+			},
 			"playbook_content": schema.StringAttribute{
-				Description: "Generated Ansible playbook YAML content.",
+				Description: "Generated Ansible playbook content.",
+				Computed:    true,
+			},
+			"playbook_content_sensitive": schema.StringAttribute{
+				Description: "Copy of playbook_content marked sensitive so it is redacted from plan output. Reference this instead of playbook_content when the playbook may embed secrets from attributes or data bags.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"playbook_content_base64": schema.StringAttribute{
+				Description: "Base64-encoded playbook, set instead of playbook_content when the generated output is not valid UTF-8 and so cannot be stored as a string.",
+				Computed:    true,
+			},
+			"output_extension": schema.StringAttribute{
+				Description: "Extension of the generated playbook file: 'yml', 'yaml' or 'json'. Recorded so later refreshes find a playbook the CLI wrote as <recipe>.yaml instead of <recipe>.yml.",
+				Computed:    true,
+			},
+			"was_changed": schema.BoolAttribute{
+				Description: "Whether the last create or update changed the playbook on disk: false when the conversion reproduced the playbook already at the target path, true when it differed or did not exist.",
+				Computed:    true,
+			},
+			"content_truncated": schema.BoolAttribute{
+				Description: "Whether the playbook exceeded the provider max_content_bytes, in which case playbook_content holds only a notice with its SHA-256 hash. The full playbook is still written to disk.",
+				Computed:    true,
+			},
+			"capture_output": schema.BoolAttribute{
+				Description: "Store the SousChef CLI output in conversion_log (default: false).",
+				Optional:    true,
+			},
+			"conversion_log": schema.StringAttribute{
+				Description: "Combined stdout/stderr of the last conversion, populated when capture_output is true.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"referenced_env_vars": schema.ListAttribute{
+				Description: "Environment variables referenced by env lookups in the generated playbook.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"add_header": schema.BoolAttribute{
+				Description: "Prepend a provenance comment to the generated playbook, e.g. \"# Generated by SousChef from cookbook nginx recipe default on 2024-05-01\". Requires YAML output (default: false).",
+				Optional:    true,
+			},
+			"header_template": schema.StringAttribute{
+				Description: "Text of the header written when add_header is set, with {cookbook}, {recipe} and {date} replaced; each line becomes a comment line (default: \"Generated by SousChef from cookbook {cookbook} recipe {recipe} on {date}\").",
+				Optional:    true,
+			},
+			"output_file_mode": schema.StringAttribute{
+				Description: "Octal permission mode, e.g. \"0600\", the generated playbook (and, in role layout, the role's tasks file) is changed to after conversion, for playbooks that carry secrets. By default files keep the mode the SousChef CLI creates them with.",
+				Optional:    true,
+			},
+			"applied_file_mode": schema.StringAttribute{
+				Description: "Mode of the generated playbook as four octal digits, checked on refresh; null when output_file_mode is unset.",
+				Computed:    true,
+			},
+			"block_destructive": schema.BoolAttribute{
+				Description: "Fail the apply when the generated playbook matches a destructive pattern instead of only warning (default: false).",
+				Optional:    true,
+			},
+			"destructive_patterns": schema.ListAttribute{
+				Description: "Regular expressions identifying destructive commands in the generated playbook (default: a built-in set covering rm -rf, mkfs, dd to devices and similar).",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"output_layout": schema.StringAttribute{
+				Description: "Layout of the generated output: 'playbook' or 'role' (default: 'playbook'). With 'role' the CLI generates the role under roles/<recipe_name>, and playbook_content holds its tasks/main.yml. Cannot be combined with output_syntax 'json'.",
+				Optional:    true,
+			},
+			"output_targets": schema.ListNestedAttribute{
+				Description: "Additional outputs the recipe is converted to, once per target, e.g. a flat playbook for review alongside a role for distribution. Each target's output_path must differ from output_path and from the other targets'.",
+				Optional:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"layout": schema.StringAttribute{
+							Description: "Layout of the target's output: 'playbook' or 'role' (default: 'playbook').",
+							Optional:    true,
+						},
+						"output_path": schema.StringAttribute{
+							Description: "Directory the target's output is written to, resolved like output_path.",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"outputs": schema.MapAttribute{
+				Description: "Generated playbook content of each output target, keyed by the target's resolved output path.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"role_layout_template": schema.StringAttribute{
+				Description: "Skeleton directory mirrored into the generated role when output_layout is 'role', keeping the files the CLI generated.",
+				Optional:    true,
+			},
+			"role_path": schema.StringAttribute{
+				Description: "Directory of the generated role when output_layout is 'role'.",
+				Computed:    true,
+			},
+			"module_counts": schema.MapAttribute{
+				Description: "Number of tasks using each Ansible module in the generated playbook.",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"git_url": schema.StringAttribute{
+				Description: "Git repository to shallow-clone the cookbook from instead of cookbook_path.",
+				Optional:    true,
+			},
+			"git_ref": schema.StringAttribute{
+				Description: "Branch or tag of git_url to convert (default: the repository's default branch).",
+				Optional:    true,
+			},
+			"git_commit": schema.StringAttribute{
+				Description: "Commit SHA of git_url the playbook was generated from.",
+				Computed:    true,
+			},
+			"variable_rename_map": schema.MapAttribute{
+				Description: "Map of Chef attribute keys to the Ansible variable names they should be converted to.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"variable_mappings": schema.MapAttribute{
+				Description: "Renamed Chef attribute keys whose Ansible variable is used in the generated playbook.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"content_sha256": schema.StringAttribute{
+				Description: "SHA-256 hash of the generated playbook content.",
 				Computed:    true,
 			},
+			"source_hash": schema.StringAttribute{
+				Description: "SHA-256 hash of the cookbook sources the playbook was generated from. Edited sources plan a re-conversion.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					sourceHashPlanModifier{},
+				},
+			},
+		},
+	}
+}
+
+// UpgradeState upgrades state written by earlier schema versions.
+func (r *migrationResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &migrationSchemaV0,
+			StateUpgrader: upgradeMigrationStateV0,
+		},
+	}
+}
+
+// migrationSchemaV0 is the original souschef_migration schema, which state
+// written before content_sha256 was added is decoded with.
+var migrationSchemaV0 = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed: true,
+		},
+		"cookbook_path": schema.StringAttribute{
+			Required: true,
+		},
+		"output_path": schema.StringAttribute{
+			Required: true,
 		},
+		"cookbook_name": schema.StringAttribute{
+			Computed: true,
+		},
+		"recipe_name": schema.StringAttribute{
+			Optional: true,
+		},
+		"playbook_content": schema.StringAttribute{
+			Computed: true,
+		},
+	},
+}
+
+// upgradeMigrationStateV0 copies version 0 state into the current schema and
+// backfills the computed attributes derived from the on-disk playbook, falling
+// back to the stored playbook_content when the file cannot be read.
+func upgradeMigrationStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var priorAttributes map[string]tftypes.Value
+	if err := req.State.Raw.As(&priorAttributes); err != nil {
+		resp.Diagnostics.AddError(
+			"Error upgrading migration state",
+			fmt.Sprintf("Could not read prior state: %s", err),
+		)
+		return
+	}
+
+	objectType, ok := resp.State.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		resp.Diagnostics.AddError("Error upgrading migration state", "Unexpected schema type")
+		return
+	}
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, found := priorAttributes[name]; found {
+			attributes[name] = value
+		} else {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
 	}
+	resp.State.Raw = tftypes.NewValue(objectType, attributes)
+
+	var state migrationResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	playbookPath, extension := findPlaybookFile(stateOutputPath(state.ResolvedOutputPath, state.OutputPath), state.RecipeName.ValueString(), state.OutputSyntax, state.OutputExtension)
+	content, err := osReadFile(playbookPath)
+	if err != nil {
+		tflog.Warn(ctx, "Could not read playbook during state upgrade, using stored content", map[string]interface{}{
+			"path":  playbookPath,
+			"error": err.Error(),
+		})
+		content = []byte(state.PlaybookContent.ValueString())
+	}
+
+	state.ContentSHA256 = types.StringValue(contentSHA256(content))
+	if state.PlaybookContentSensitive.IsNull() {
+		state.PlaybookContentSensitive = state.PlaybookContent
+	}
+	if state.ContentTruncated.IsNull() {
+		state.ContentTruncated = types.BoolValue(false)
+	}
+	if state.ReferencedEnvVars.IsNull() {
+		state.ReferencedEnvVars = typesListFromStringSlice(parseReferencedEnvVars(string(content)))
+	}
+	if state.ModuleCounts.IsNull() {
+		state.ModuleCounts = moduleCountsFromContent(string(content))
+	}
+	if state.OutputExtension.IsNull() {
+		state.OutputExtension = types.StringValue(extension)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 // Configure adds the provider configured client to the resource.
@@ -106,63 +461,774 @@ func (r *migrationResource) Configure(_ context.Context, req resource.ConfigureR
 	r.client = client
 }
 
+// ValidateConfig requires exactly one cookbook source and rejects an output_path
+// nested inside cookbook_path, an invalid role layout or output syntax, output
+// targets sharing an output path and destructive_patterns that are not valid
+// regular expressions.
+func (r *migrationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateCookbookSource(ctx, req.Config, &resp.Diagnostics)
+	validateOutputNotNested(ctx, req.Config, r.client, &resp.Diagnostics)
+	validateRoleLayoutConfig(ctx, req.Config, &resp.Diagnostics)
+	validateOutputSyntax(ctx, req.Config, &resp.Diagnostics)
+	validateRecipesSubdir(ctx, req.Config, &resp.Diagnostics)
+	validateAnsibleVersion(ctx, req.Config, &resp.Diagnostics)
+	validateOutputFileMode(ctx, req.Config, &resp.Diagnostics)
+	validatePlaybookHeader(ctx, req.Config, &resp.Diagnostics)
+	validateOutputTargets(ctx, req.Config, r.client, &resp.Diagnostics)
+
+	var patterns types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("destructive_patterns"), &patterns)...)
+	if resp.Diagnostics.HasError() || patterns.IsNull() || patterns.IsUnknown() {
+		return
+	}
+
+	for i, element := range patterns.Elements() {
+		pattern, ok := element.(types.String)
+		if !ok || pattern.IsNull() || pattern.IsUnknown() {
+			continue
+		}
+		if _, err := regexp.Compile(pattern.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("destructive_patterns").AtListIndex(i),
+				"Invalid destructive pattern",
+				fmt.Sprintf("Could not compile regular expression %q: %s", pattern.ValueString(), err),
+			)
+		}
+	}
+}
+
+// writeVariableRenameMap writes the rename map to a temporary JSON file passed
+// to the CLI. It returns an empty path when there are no renames; the cleanup
+// function removes the file once the conversion has finished.
+func writeVariableRenameMap(ctx context.Context, renames map[string]string) (string, func(), error) {
+	if len(renames) == 0 {
+		return "", func() {}, nil
+	}
+
+	data, err := json.Marshal(renames)
+	if err != nil {
+		return "", nil, err
+	}
+
+	tempDir, err := osMkdirTemp("", "souschef-renames-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() {
+		if err := osRemoveAll(tempDir); err != nil {
+			tflog.Warn(ctx, "Could not remove variable rename map", map[string]interface{}{
+				"path":  tempDir,
+				"error": err.Error(),
+			})
+		}
+	}
+
+	mapPath := filepath.Join(tempDir, "variable_rename_map.json")
+	if err := osWriteFile(mapPath, data, 0600); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return mapPath, cleanup, nil
+}
+
+// variableMappingsFromContent returns the renames whose Ansible variable is
+// referenced in the generated playbook content.
+func variableMappingsFromContent(content string, renames map[string]string) types.Map {
+	mappings := make(map[string]attr.Value)
+	for chefKey, ansibleVar := range renames {
+		if playbookReferencesVariable(content, ansibleVar) {
+			mappings[chefKey] = types.StringValue(ansibleVar)
+		}
+	}
+	return types.MapValueMust(types.StringType, mappings)
+}
+
 // runConversion executes the SousChef convert-recipe command and reads the
-// resulting playbook file. Returns (content, cmdOutput, err); cmdOutput is
-// non-empty only when the command itself failed rather than a file-read failure.
+// resulting playbook file, or in role layout the tasks file of the role the
+// CLI generates; in dry-run mode the content is taken from stdout.
+// Returns (content, cmdOutput, err), where cmdOutput interleaves the CLI's
+// stdout and stderr for conversion_log; a failed command is reported as a
+// *cliError. CLI warnings are added to diagnostics.
 func (r *migrationResource) runConversion(
 	ctx context.Context,
-	cookbookPath, recipeName, outputPath string,
+	cookbookPath, recipeName, outputPath, renameMapPath string,
+	outputSyntax, recipesSubdir, ansibleVersion, layout types.String,
+	diagnostics *diag.Diagnostics,
 ) ([]byte, string, error) {
-	cmd := execCommandContext(ctx, r.client.Path, "convert-recipe",
+	args := []string{"convert-recipe",
 		"--cookbook-path", cookbookPath,
 		"--recipe-name", recipeName,
 		"--output-path", outputPath,
-	)
-	tflog.Debug(ctx, "Executing SousChef", map[string]interface{}{
-		"command": cmd.String(),
-	})
-	cmdOutput, err := cmd.CombinedOutput()
+	}
+	args = append(args, recipesSubdirArgs(recipesSubdir)...)
+	if renameMapPath != "" {
+		args = append(args, "--variable-rename-map", renameMapPath)
+	}
+	if !outputSyntax.IsNull() && outputSyntax.ValueString() != "" {
+		args = append(args, "--output-syntax", outputSyntax.ValueString())
+	}
+	if !ansibleVersion.IsNull() && ansibleVersion.ValueString() != "" {
+		args = append(args, "--ansible-version", ansibleVersion.ValueString())
+	}
+	if layout.ValueString() == outputLayoutRole {
+		args = append(args, "--layout", outputLayoutRole)
+	}
+	result, err := runCLIWithLog(ctx, r.client, diagnostics, r.client.dryRunArgs(args)...)
+	cmdOutput := string(result.combined)
 	if err != nil {
-		return nil, string(cmdOutput), err
+		return nil, cmdOutput, err
 	}
-	playbookPath := filepath.Join(outputPath, recipeName+".yml")
-	content, err := osReadFile(playbookPath)
+	if r.client.isDryRun() {
+		return result.stdout, cmdOutput, nil
+	}
+	content, err := osReadFile(migrationContentPath(outputPath, recipeName, outputSyntax, layout))
 	if err != nil {
 		return nil, "", err
 	}
-	return content, "", nil
+	return content, cmdOutput, nil
 }
 
-func addConversionError(
-	addError func(string, string),
-	conversionTitle, conversionPrefix, readPrefix string,
-	err error,
-	cmdOut string,
-) {
-	if cmdOut != "" {
-		addError(
-			conversionTitle,
-			fmt.Sprintf("%s: %s\n%s", conversionPrefix, err, cmdOut),
-		)
+// migrationContentPath returns the generated file playbook_content holds for
+// a conversion into outputPath: in role layout the tasks file of the role the
+// CLI generates, otherwise the playbook.
+func migrationContentPath(outputPath, recipeName string, outputSyntax, layout types.String) string {
+	if layout.ValueString() == outputLayoutRole {
+		return roleTasksPath(rolePathFor(outputPath, recipeName))
+	}
+	playbookPath, _ := findPlaybookFile(outputPath, recipeName, outputSyntax, types.StringNull())
+	return playbookPath
+}
+
+// addConversionError reports a runConversion failure: CLI failures via
+// diagnosticFromError, anything else as a failure to read the playbook.
+func addConversionError(diagnostics *diag.Diagnostics, readPrefix string, err error) {
+	var cliErr *cliError
+	if errors.As(err, &cliErr) {
+		diagnostics.Append(diagnosticFromError(err))
 		return
 	}
 
-	addError(
+	diagnostics.AddError(
 		errorReadingPlaybook,
 		fmt.Sprintf("%s: %s", readPrefix, err),
 	)
 }
 
+// validateCookbookSource ensures exactly one of cookbook_path and git_url is set.
+func validateCookbookSource(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var cookbookPath, gitURL, gitRef types.String
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("cookbook_path"), &cookbookPath)...)
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("git_url"), &gitURL)...)
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("git_ref"), &gitRef)...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case !cookbookPath.IsNull() && !gitURL.IsNull():
+		diagnostics.AddAttributeError(
+			path.Root("git_url"),
+			"Conflicting cookbook sources",
+			"Only one of cookbook_path and git_url can be set.",
+		)
+	case cookbookPath.IsNull() && gitURL.IsNull():
+		diagnostics.AddError(
+			"Missing cookbook source",
+			"One of cookbook_path or git_url must be set.",
+		)
+	case !gitRef.IsNull() && gitURL.IsNull():
+		diagnostics.AddAttributeError(
+			path.Root("git_ref"),
+			"Git ref requires git_url",
+			"git_ref can only be set together with git_url.",
+		)
+	}
+}
+
+// ModifyPlan plans a re-conversion when the cookbook changed since the last
+// apply: either git_ref of a Git-sourced cookbook now points to a different
+// commit, or the source_hash plan modifier detected edited local sources.
+func (r *migrationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if gitSourceChanged(ctx, req, &resp.Diagnostics) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("cookbook_path"), types.StringUnknown())...)
+		markMigrationContentUnknown(ctx, &resp.Plan, &resp.Diagnostics)
+		return
+	}
+
+	var plannedHash, stateHash types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("source_hash"), &plannedHash)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("source_hash"), &stateHash)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plannedHash.IsUnknown() && !stateHash.IsNull() {
+		markMigrationContentUnknown(ctx, &resp.Plan, &resp.Diagnostics)
+	}
+}
+
+// gitSourceChanged reports whether git_ref of a Git-sourced cookbook now
+// resolves to a different commit than the one recorded in state.
+func gitSourceChanged(ctx context.Context, req resource.ModifyPlanRequest, diagnostics *diag.Diagnostics) bool {
+	var gitURL, gitRef, gitCommit types.String
+	diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("git_url"), &gitURL)...)
+	diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("git_ref"), &gitRef)...)
+	diagnostics.Append(req.State.GetAttribute(ctx, path.Root("git_commit"), &gitCommit)...)
+	if diagnostics.HasError() || gitURL.IsNull() || gitURL.IsUnknown() || gitRef.IsUnknown() || gitCommit.IsNull() {
+		return false
+	}
+
+	commit, err := resolveGitRef(ctx, gitURL.ValueString(), gitRef.ValueString())
+	if err != nil {
+		diagnostics.AddWarning(
+			"Could not check cookbook repository",
+			fmt.Sprintf("Unable to resolve %s; upstream changes will not be detected: %s", gitURL.ValueString(), err),
+		)
+		return false
+	}
+	if commit == gitCommit.ValueString() {
+		return false
+	}
+
+	tflog.Info(ctx, "Cookbook repository changed upstream", map[string]interface{}{
+		"previous": gitCommit.ValueString(),
+		"current":  commit,
+	})
+	return true
+}
+
+// markMigrationContentUnknown marks the attributes derived from the generated
+// playbook as unknown so the plan shows the pending re-conversion.
+func markMigrationContentUnknown(ctx context.Context, plan *tfsdk.Plan, diagnostics *diag.Diagnostics) {
+	for _, name := range []string{"cookbook_name", "output_extension", "playbook_content", "playbook_content_sensitive", "playbook_content_base64", "content_sha256", "source_hash", "conversion_log", "role_path", "applied_file_mode", "git_commit"} {
+		diagnostics.Append(plan.SetAttribute(ctx, path.Root(name), types.StringUnknown())...)
+	}
+	diagnostics.Append(plan.SetAttribute(ctx, path.Root("referenced_env_vars"), types.ListUnknown(types.StringType))...)
+	diagnostics.Append(plan.SetAttribute(ctx, path.Root("module_counts"), types.MapUnknown(types.Int64Type))...)
+	diagnostics.Append(plan.SetAttribute(ctx, path.Root("variable_mappings"), types.MapUnknown(types.StringType))...)
+	diagnostics.Append(plan.SetAttribute(ctx, path.Root("outputs"), types.MapUnknown(types.StringType))...)
+	diagnostics.Append(plan.SetAttribute(ctx, path.Root("was_changed"), types.BoolUnknown())...)
+	diagnostics.Append(plan.SetAttribute(ctx, path.Root("content_truncated"), types.BoolUnknown())...)
+}
+
+// prepareCookbookSource clones the cookbook when git_url is set, pointing
+// cookbook_path at the clone and recording git_commit. The returned cleanup
+// function must be called once the conversion has finished.
+func prepareCookbookSource(ctx context.Context, plan *migrationResourceModel, diagnostics *diag.Diagnostics) (func(), bool) {
+	plan.GitCommit = types.StringNull()
+	if plan.GitURL.IsNull() {
+		return func() {}, true
+	}
+
+	clonePath, commit, cleanup, err := cloneGitCookbook(ctx, plan.GitURL.ValueString(), plan.GitRef.ValueString())
+	if err != nil {
+		diagnostics.AddError(
+			"Error cloning cookbook",
+			fmt.Sprintf("Could not clone %s: %s", plan.GitURL.ValueString(), err),
+		)
+		return nil, false
+	}
+
+	plan.CookbookPath = types.StringValue(clonePath)
+	plan.GitCommit = types.StringValue(commit)
+	return cleanup, true
+}
+
+// validateOutputSyntax checks output_syntax is "yaml" or "json".
+func validateOutputSyntax(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var outputSyntax types.String
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("output_syntax"), &outputSyntax)...)
+	if diagnostics.HasError() || outputSyntax.IsNull() || outputSyntax.IsUnknown() {
+		return
+	}
+
+	syntax := outputSyntax.ValueString()
+	if syntax != outputSyntaxYAML && syntax != outputSyntaxJSON {
+		diagnostics.AddAttributeError(
+			path.Root("output_syntax"),
+			"Invalid output syntax",
+			fmt.Sprintf("output_syntax must be %q or %q, got %q", outputSyntaxYAML, outputSyntaxJSON, syntax),
+		)
+	}
+}
+
+// migrationOutputFiles returns the generated files output_file_mode applies
+// to: the playbook and, in role layout, the role's tasks file.
+func migrationOutputFiles(playbookPath string, rolePath types.String) []string {
+	if rolePath.ValueString() == "" {
+		return []string{playbookPath}
+	}
+	return []string{playbookPath, roleTasksPath(rolePath.ValueString())}
+}
+
+// validateRecipesSubdir checks recipes_subdir is a relative path that stays
+// inside the cookbook.
+func validateRecipesSubdir(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var recipesSubdir types.String
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("recipes_subdir"), &recipesSubdir)...)
+	if diagnostics.HasError() || recipesSubdir.IsNull() || recipesSubdir.IsUnknown() {
+		return
+	}
+
+	subdir := recipesSubdir.ValueString()
+	escapes := false
+	for _, part := range strings.Split(filepath.ToSlash(subdir), "/") {
+		escapes = escapes || part == ".."
+	}
+	if subdir == "" || filepath.IsAbs(subdir) || escapes {
+		diagnostics.AddAttributeError(
+			path.Root("recipes_subdir"),
+			"Invalid recipes subdirectory",
+			fmt.Sprintf("recipes_subdir must be a relative path inside the cookbook without '..', got %q", subdir),
+		)
+	}
+}
+
+// ansibleVersionPattern matches the versions accepted by ansible_version: a
+// major version optionally followed by minor and patch numbers.
+var ansibleVersionPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+){0,2}$`)
+
+// checkAnsibleVersion returns an error when version is not a valid ansible_version.
+func checkAnsibleVersion(version string) error {
+	if !ansibleVersionPattern.MatchString(version) {
+		return fmt.Errorf("ansible_version must be a version such as \"2.15\" or \"9.1.0\", got %q", version)
+	}
+	return nil
+}
+
+// validateAnsibleVersion checks ansible_version looks like a version number.
+func validateAnsibleVersion(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var ansibleVersion types.String
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("ansible_version"), &ansibleVersion)...)
+	if diagnostics.HasError() || ansibleVersion.IsNull() || ansibleVersion.IsUnknown() {
+		return
+	}
+
+	if err := checkAnsibleVersion(ansibleVersion.ValueString()); err != nil {
+		diagnostics.AddAttributeError(path.Root("ansible_version"), "Invalid Ansible version", err.Error())
+	}
+}
+
+// recipesSubdirArgs returns the CLI arguments selecting a non-default
+// recipes directory, or none when recipes_subdir is unset.
+func recipesSubdirArgs(recipesSubdir types.String) []string {
+	if recipesSubdir.IsNull() || recipesSubdir.IsUnknown() || recipesSubdir.ValueString() == "" {
+		return nil
+	}
+	return []string{"--recipes-subdir", recipesSubdir.ValueString()}
+}
+
+// validateRoleLayoutConfig checks output_layout and role_layout_template,
+// and that the role layout is only used with YAML output.
+func validateRoleLayoutConfig(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var outputLayout, templatePath, outputSyntax types.String
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("output_layout"), &outputLayout)...)
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("role_layout_template"), &templatePath)...)
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("output_syntax"), &outputSyntax)...)
+	if diagnostics.HasError() || outputLayout.IsUnknown() || templatePath.IsUnknown() {
+		return
+	}
+
+	layout := outputLayout.ValueString()
+	if !outputLayout.IsNull() && layout != outputLayoutPlaybook && layout != outputLayoutRole {
+		diagnostics.AddAttributeError(
+			path.Root("output_layout"),
+			"Invalid output layout",
+			fmt.Sprintf("output_layout must be %q or %q, got %q", outputLayoutPlaybook, outputLayoutRole, layout),
+		)
+		return
+	}
+
+	if layout == outputLayoutRole && outputSyntax.ValueString() == outputSyntaxJSON {
+		diagnostics.AddAttributeError(
+			path.Root("output_syntax"),
+			"Role layout requires YAML output",
+			"output_syntax \"json\" cannot be used with output_layout \"role\", as role tasks files are YAML.",
+		)
+	}
+
+	if templatePath.IsNull() {
+		return
+	}
+	if layout != outputLayoutRole {
+		diagnostics.AddAttributeError(
+			path.Root("role_layout_template"),
+			"Role layout template requires role output",
+			"role_layout_template can only be set when output_layout is \"role\".",
+		)
+		return
+	}
+	if info, err := osStat(templatePath.ValueString()); err != nil || !info.IsDir() {
+		diagnostics.AddAttributeError(
+			path.Root("role_layout_template"),
+			"Role layout template not found",
+			fmt.Sprintf("role_layout_template must be an existing directory: %s", templatePath.ValueString()),
+		)
+	}
+}
+
+// validatePlaybookHeader checks header_template is only set with add_header
+// and that the header can be written as a comment.
+func validatePlaybookHeader(ctx context.Context, config tfsdk.Config, diagnostics *diag.Diagnostics) {
+	var addHeader types.Bool
+	var headerTemplate, outputSyntax types.String
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("add_header"), &addHeader)...)
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("header_template"), &headerTemplate)...)
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("output_syntax"), &outputSyntax)...)
+	if diagnostics.HasError() || addHeader.IsUnknown() {
+		return
+	}
+
+	if !addHeader.ValueBool() {
+		if !headerTemplate.IsNull() {
+			diagnostics.AddAttributeError(
+				path.Root("header_template"),
+				"Header template requires add_header",
+				"header_template can only be set when add_header is true.",
+			)
+		}
+		return
+	}
+	if outputSyntax.ValueString() == outputSyntaxJSON {
+		diagnostics.AddAttributeError(
+			path.Root("add_header"),
+			"Header requires YAML output",
+			"add_header cannot be used with output_syntax \"json\", which has no comment syntax.",
+		)
+	}
+}
+
+// writePlaybookHeader prepends the add_header provenance comment to the
+// generated playbook, or in role layout the role's tasks file, rewrites the
+// file and returns its content as read back.
+// In dry-run mode only the returned content carries the header.
+func (r *migrationResource) writePlaybookHeader(plan *migrationResourceModel, outputPath string, layout types.String, cookbookPath, recipeName string, content []byte, diagnostics *diag.Diagnostics) []byte {
+	if !plan.AddHeader.ValueBool() {
+		return content
+	}
+
+	content = addPlaybookHeader(content, plan.HeaderTemplate.ValueString(), r.client.cookbookName(cookbookPath), recipeName, time.Now().UTC().Format(headerDateFormat))
+	if r.client.isDryRun() {
+		return content
+	}
+
+	playbookPath := migrationContentPath(outputPath, recipeName, plan.OutputSyntax, layout)
+	mode := os.FileMode(0644)
+	if info, err := osStat(playbookPath); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := writeFileAtomic(playbookPath, content, mode); err != nil {
+		diagnostics.AddError(
+			"Error writing playbook header",
+			fmt.Sprintf("Could not write header to %s: %s", playbookPath, err),
+		)
+		return nil
+	}
+	return []byte(readGeneratedFile(playbookPath, errorReadingPlaybook, diagnostics))
+}
+
+// writeMigrationRole applies role_layout_template to the role the CLI
+// generated when output_layout is "role" and records the role directory in
+// role_path. In dry-run mode only role_path is recorded.
+func (r *migrationResource) writeMigrationRole(plan *migrationResourceModel, outputPath, recipeName string, diagnostics *diag.Diagnostics) {
+	plan.RolePath = types.StringNull()
+	if plan.OutputLayout.ValueString() != outputLayoutRole {
+		return
+	}
+
+	rolePath := rolePathFor(outputPath, recipeName)
+	if r.client.isDryRun() {
+		plan.RolePath = types.StringValue(rolePath)
+		return
+	}
+	if err := applyRoleLayoutTemplate(rolePath, plan.RoleLayoutTemplate.ValueString()); err != nil {
+		diagnostics.AddError(
+			"Error writing role layout",
+			fmt.Sprintf("Could not write role %s: %s", rolePath, err),
+		)
+		return
+	}
+	plan.RolePath = types.StringValue(rolePath)
+}
+
+// convertOutputTargets converts the recipe once per output_targets entry in
+// the target's own layout, and records the generated content in outputs keyed
+// by the target's resolved output path.
+func (r *migrationResource) convertOutputTargets(ctx context.Context, plan *migrationResourceModel, cookbookPath, recipeName, renameMapPath string, diagnostics *diag.Diagnostics) {
+	plan.Outputs = types.MapNull(types.StringType)
+	if len(plan.OutputTargets) == 0 {
+		return
+	}
+
+	outputs := make(map[string]attr.Value, len(plan.OutputTargets))
+	for _, target := range plan.OutputTargets {
+		targetPath := r.client.resolveOutputPath(target.OutputPath.ValueString())
+		if !r.client.prepareOutputDirectory(plan.CreateOutputDir, targetPath, diagnostics) {
+			return
+		}
+		content, _, err := r.runConversion(ctx, cookbookPath, recipeName, targetPath, renameMapPath, plan.OutputSyntax, plan.RecipesSubdir, plan.AnsibleVersion, target.Layout, diagnostics)
+		if err != nil {
+			addConversionError(diagnostics, fmt.Sprintf("Could not read playbook for output target %s", targetPath), err)
+			return
+		}
+		content = r.writePlaybookHeader(plan, targetPath, target.Layout, cookbookPath, recipeName, content, diagnostics)
+		if diagnostics.HasError() {
+			return
+		}
+
+		playbookPath, _ := findPlaybookFile(targetPath, recipeName, plan.OutputSyntax, types.StringNull())
+		files := []string{playbookPath}
+		if target.Layout.ValueString() == outputLayoutRole {
+			rolePath := rolePathFor(targetPath, recipeName)
+			if !r.client.isDryRun() {
+				if err := applyRoleLayoutTemplate(rolePath, plan.RoleLayoutTemplate.ValueString()); err != nil {
+					diagnostics.AddError(
+						"Error writing role layout",
+						fmt.Sprintf("Could not write role %s: %s", rolePath, err),
+					)
+					return
+				}
+			}
+			files = append(files, roleTasksPath(rolePath))
+		}
+		r.client.applyOutputFileMode(plan.OutputFileMode, diagnostics, files...)
+		if diagnostics.HasError() {
+			return
+		}
+		outputs[targetPath] = types.StringValue(string(content))
+	}
+	plan.Outputs = types.MapValueMust(types.StringType, outputs)
+}
+
+// outputTargetFiles returns the files and role directories generated for
+// targets, each role directory ahead of its target's playbook.
+func (r *migrationResource) outputTargetFiles(targets []migrationOutputTargetModel, recipeName string, outputSyntax, outputExtension types.String) []string {
+	var files []string
+	for _, target := range targets {
+		targetPath := r.client.resolveOutputPath(target.OutputPath.ValueString())
+		if target.Layout.ValueString() == outputLayoutRole {
+			files = append(files, rolePathFor(targetPath, recipeName))
+		}
+		playbookPath, _ := findPlaybookFile(targetPath, recipeName, outputSyntax, outputExtension)
+		files = append(files, playbookPath)
+	}
+	return files
+}
+
+// outputTargetPaths returns the resolved output directory of each output target.
+func (r *migrationResource) outputTargetPaths(targets []migrationOutputTargetModel) []string {
+	paths := make([]string, 0, len(targets))
+	for _, target := range targets {
+		paths = append(paths, r.client.resolveOutputPath(target.OutputPath.ValueString()))
+	}
+	return paths
+}
+
+// deleteOutputTargetFile removes an output target's playbook or role
+// directory, warning when it cannot be removed.
+func deleteOutputTargetFile(filePath string, diagnostics *diag.Diagnostics) {
+	if err := osRemoveAll(filePath); err != nil {
+		diagnostics.AddWarning(
+			"Error deleting output target",
+			fmt.Sprintf("Could not delete %s: %s", filePath, err),
+		)
+	}
+}
+
+// refreshOutputs re-reads each output target's playbook, or its role's tasks
+// file, into outputs. Targets whose output was removed are left out.
+func (r *migrationResource) refreshOutputs(state *migrationResourceModel, diagnostics *diag.Diagnostics) {
+	if len(state.OutputTargets) == 0 {
+		state.Outputs = types.MapNull(types.StringType)
+		return
+	}
+
+	recipeName := state.RecipeName.ValueString()
+	outputs := make(map[string]attr.Value, len(state.OutputTargets))
+	for _, target := range state.OutputTargets {
+		targetPath := r.client.resolveOutputPath(target.OutputPath.ValueString())
+		filePath, _ := findPlaybookFile(targetPath, recipeName, state.OutputSyntax, state.OutputExtension)
+		if target.Layout.ValueString() == outputLayoutRole {
+			filePath = roleTasksPath(rolePathFor(targetPath, recipeName))
+		}
+		content, err := osReadFile(filePath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			diagnostics.AddError(
+				errorReadingPlaybook,
+				fmt.Sprintf("Could not read output target %s: %s", filePath, err),
+			)
+			return
+		}
+		outputs[targetPath] = types.StringValue(string(content))
+	}
+	state.Outputs = types.MapValueMust(types.StringType, outputs)
+}
+
+// validateOutputTargets checks each output target's layout and that the
+// targets' output paths differ from each other and from output_path.
+func validateOutputTargets(ctx context.Context, config tfsdk.Config, client *SousChefClient, diagnostics *diag.Diagnostics) {
+	var targetList types.List
+	var outputPath, outputSyntax types.String
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("output_targets"), &targetList)...)
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("output_path"), &outputPath)...)
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("output_syntax"), &outputSyntax)...)
+	if diagnostics.HasError() || targetList.IsNull() || targetList.IsUnknown() {
+		return
+	}
+	var targets []migrationOutputTargetModel
+	diagnostics.Append(targetList.ElementsAs(ctx, &targets, false)...)
+	if diagnostics.HasError() {
+		return
+	}
+
+	seen := make(map[string]bool)
+	if !outputPath.IsNull() && !outputPath.IsUnknown() {
+		seen[filepath.Clean(client.resolveOutputPath(outputPath.ValueString()))] = true
+	}
+	for i, target := range targets {
+		targetAttr := path.Root("output_targets").AtListIndex(i)
+		layout := target.Layout.ValueString()
+		if !target.Layout.IsNull() && !target.Layout.IsUnknown() && layout != outputLayoutPlaybook && layout != outputLayoutRole {
+			diagnostics.AddAttributeError(
+				targetAttr.AtName("layout"),
+				"Invalid output target layout",
+				fmt.Sprintf("layout must be %q or %q, got %q", outputLayoutPlaybook, outputLayoutRole, layout),
+			)
+		}
+		if layout == outputLayoutRole && outputSyntax.ValueString() == outputSyntaxJSON {
+			diagnostics.AddAttributeError(
+				targetAttr.AtName("layout"),
+				"Role layout requires YAML output",
+				"output_syntax \"json\" cannot be used with a \"role\" output target, as role tasks files are YAML.",
+			)
+		}
+
+		if target.OutputPath.IsUnknown() {
+			continue
+		}
+		targetPath := filepath.Clean(client.resolveOutputPath(target.OutputPath.ValueString()))
+		if seen[targetPath] {
+			diagnostics.AddAttributeError(
+				targetAttr.AtName("output_path"),
+				"Duplicate output target path",
+				fmt.Sprintf("Each output target needs its own output_path, distinct from output_path and the other targets: %s", targetPath),
+			)
+		}
+		seen[targetPath] = true
+	}
+}
+
+// checkDestructiveContent scans generated playbook content for destructive
+// commands, adding an error when block_destructive is set and a warning otherwise.
+func checkDestructiveContent(plan *migrationResourceModel, content []byte, diagnostics *diag.Diagnostics) {
+	patterns, err := compileDestructivePatterns(stringSliceFromTypesList(plan.DestructivePatterns))
+	if err != nil {
+		diagnostics.AddError("Invalid destructive pattern", err.Error())
+		return
+	}
+
+	offending := findDestructiveLines(string(content), patterns)
+	if len(offending) == 0 {
+		return
+	}
+
+	summary := "Destructive command in generated playbook"
+	detail := fmt.Sprintf("The generated playbook for recipe %q contains potentially destructive commands:\n%s",
+		plan.RecipeName.ValueString(), strings.Join(offending, "\n"))
+	if plan.BlockDestructive.ValueBool() {
+		diagnostics.AddError(summary, detail+"\n\nReview the recipe or unset block_destructive to continue.")
+		return
+	}
+	diagnostics.AddWarning(summary, detail)
+}
+
+// migrationResourceID returns the ID of the migration of recipeName in the
+// cookbook at cookbookPath, hashing git_url and git_ref for a Git source.
+func migrationResourceID(plan *migrationResourceModel, client *SousChefClient, cookbookPath, recipeName string) string {
+	nameID := fmt.Sprintf("%s-%s", client.cookbookName(cookbookPath), recipeName)
+	if plan.GitURL.IsNull() {
+		return client.resourceID(nameID, cookbookPath)
+	}
+	return client.gitResourceID(nameID, plan.GitURL.ValueString(), plan.GitRef.ValueString())
+}
+
 func populateMigrationPlanState(
 	plan *migrationResourceModel,
+	client *SousChefClient,
 	cookbookPath, recipeName string,
 	content []byte,
+	cmdOutput string,
+	diagnostics *diag.Diagnostics,
 ) {
-	cookbookName := filepath.Base(cookbookPath)
-	plan.ID = types.StringValue(fmt.Sprintf("%s-%s", cookbookName, recipeName))
-	plan.CookbookName = types.StringValue(cookbookName)
+	plan.ID = types.StringValue(migrationResourceID(plan, client, cookbookPath, recipeName))
+	plan.CookbookName = types.StringValue(client.cookbookName(cookbookPath))
 	plan.RecipeName = types.StringValue(recipeName)
-	plan.PlaybookContent = types.StringValue(string(content))
+	setPlaybookContent(plan, client, content, diagnostics)
+
+	// Only keep the CLI output when explicitly requested to avoid bloating state
+	plan.ConversionLog = types.StringNull()
+	if plan.CaptureOutput.ValueBool() {
+		plan.ConversionLog = types.StringValue(cmdOutput)
+	}
+
+	plan.ReferencedEnvVars = typesListFromStringSlice(parseReferencedEnvVars(string(content)))
+	plan.ModuleCounts = moduleCountsFromContent(string(content))
+	plan.ContentSHA256 = types.StringValue(contentSHA256(content))
+	plan.SourceHash = sourceHashValue(cookbookPath)
+}
+
+// setPlaybookContent stores the playbook in the model, or only a truncation
+// notice when it is larger than the client's max_content_bytes. Output that is
+// not valid UTF-8, such as a binary file the CLI wrote by mistake, would
+// corrupt a string attribute, so it is stored base64-encoded in
+// playbook_content_base64 and playbook_content is left empty.
+func setPlaybookContent(model *migrationResourceModel, client *SousChefClient, content []byte, diagnostics *diag.Diagnostics) {
+	stored, truncated := client.storedContent(content)
+	model.PlaybookContentBase64 = types.StringNull()
+	if !utf8.ValidString(stored) {
+		diagnostics.AddWarning(
+			"Playbook is not valid UTF-8",
+			"The generated playbook contains bytes that are not valid UTF-8, so playbook_content is empty and the playbook is stored base64-encoded in playbook_content_base64. Check that the SousChef CLI produced the expected output.",
+		)
+		model.PlaybookContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(content))
+		stored = ""
+	}
+	model.PlaybookContent = types.StringValue(stored)
+	model.PlaybookContentSensitive = model.PlaybookContent
+	model.ContentTruncated = types.BoolValue(truncated)
+}
+
+// existingPlaybookHash returns the SHA-256 hash of the playbook, or in role
+// layout the role's tasks file, already generated for recipeName in
+// outputPath, or "" when there is none, so a conversion can report whether
+// it changed the file.
+func existingPlaybookHash(outputPath, recipeName string, outputSyntax, layout types.String) string {
+	content, err := osReadFile(migrationContentPath(outputPath, recipeName, outputSyntax, layout))
+	if err != nil {
+		return ""
+	}
+	return contentSHA256(content)
+}
+
+// contentSHA256 returns the hex-encoded SHA-256 hash of content.
+func contentSHA256(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// moduleCountsFromContent converts parsed module counts into a types.Map.
+func moduleCountsFromContent(content string) types.Map {
+	counts := make(map[string]attr.Value)
+	for module, count := range parseModuleCounts(content) {
+		counts[module] = types.Int64Value(int64(count))
+	}
+	return types.MapValueMust(types.Int64Type, counts)
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -180,25 +1246,83 @@ func (r *migrationResource) Create(ctx context.Context, req resource.CreateReque
 		recipeName = plan.RecipeName.ValueString()
 	}
 
+	// Clone the cookbook when it is sourced from Git
+	cleanup, ok := prepareCookbookSource(ctx, &plan, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	defer cleanup()
+
 	// Parse cookbook metadata
 	cookbookPath := plan.CookbookPath.ValueString()
-	outputPath := plan.OutputPath.ValueString()
+	outputPath := r.client.resolveOutputPath(plan.OutputPath.ValueString())
+	plan.ResolvedOutputPath = types.StringValue(outputPath)
+	ctx = withReportResourceID(ctx, migrationResourceID(&plan, r.client, cookbookPath, recipeName))
 
 	// Call souschef CLI to convert recipe and read the resulting playbook
-	content, cmdOut, err := r.runConversion(ctx, cookbookPath, recipeName, outputPath)
+	renames := make(map[string]string)
+	resp.Diagnostics.Append(plan.VariableRenameMap.ElementsAs(ctx, &renames, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	renameMapPath, cleanupRenames, err := writeVariableRenameMap(ctx, renames)
 	if err != nil {
-		addConversionError(
-			resp.Diagnostics.AddError,
-			"Error converting recipe",
-			"Could not convert recipe",
-			"Could not read generated playbook",
-			err,
-			cmdOut,
+		resp.Diagnostics.AddError(
+			"Error writing variable rename map",
+			fmt.Sprintf("Could not write variable rename map: %s", err),
 		)
 		return
 	}
+	defer cleanupRenames()
 
-	populateMigrationPlanState(&plan, cookbookPath, recipeName, content)
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, outputPath, &resp.Diagnostics) {
+		return
+	}
+	existing := snapshotOutputs(append([]string{outputPath}, r.outputTargetPaths(plan.OutputTargets)...)...)
+	previousHash := existingPlaybookHash(outputPath, recipeName, plan.OutputSyntax, plan.OutputLayout)
+	content, cmdOut, err := r.runConversion(ctx, cookbookPath, recipeName, outputPath, renameMapPath, plan.OutputSyntax, plan.RecipesSubdir, plan.AnsibleVersion, plan.OutputLayout, &resp.Diagnostics)
+	if err != nil {
+		addConversionError(&resp.Diagnostics, "Could not read generated playbook", err)
+		return
+	}
+	content = r.writePlaybookHeader(&plan, outputPath, plan.OutputLayout, cookbookPath, recipeName, content, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.WasChanged = types.BoolValue(previousHash != contentSHA256(content))
+
+	checkDestructiveContent(&plan, content, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.writeMigrationRole(&plan, outputPath, recipeName, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	playbookPath, extension := findPlaybookFile(outputPath, recipeName, plan.OutputSyntax, types.StringNull())
+	populateMigrationPlanState(&plan, r.client, cookbookPath, recipeName, content, cmdOut, &resp.Diagnostics)
+	plan.OutputExtension = types.StringValue(extension)
+	plan.VariableMappings = variableMappingsFromContent(string(content), renames)
+	plan.AppliedFileMode = r.client.applyOutputFileMode(plan.OutputFileMode, &resp.Diagnostics, migrationOutputFiles(playbookPath, plan.RolePath)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.convertOutputTargets(ctx, &plan, cookbookPath, recipeName, renameMapPath, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Don't leave an untracked playbook behind if the apply was interrupted
+	generated := []string{playbookPath}
+	if rolePath := plan.RolePath.ValueString(); rolePath != "" {
+		generated = append(generated, rolePath)
+	}
+	generated = append(generated, r.outputTargetFiles(plan.OutputTargets, recipeName, plan.OutputSyntax, types.StringValue(extension))...)
+	if cleanupIfCanceled(ctx, &resp.Diagnostics, existing, generated...) {
+		return
+	}
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -213,18 +1337,30 @@ func (r *migrationResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	// Check if playbook still exists
+	// Check if playbook still exists; in role layout the role's tasks file is
+	// what consumers use, so a deleted role is drift even if the playbook remains
 	recipeName := state.RecipeName.ValueString()
-	outputPath := state.OutputPath.ValueString()
-	playbookPath := filepath.Join(outputPath, recipeName+".yml")
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	playbookPath, extension := findPlaybookFile(outputPath, recipeName, state.OutputSyntax, state.OutputExtension)
+	artifact := "playbook " + playbookPath
+	if rolePath := state.RolePath.ValueString(); rolePath != "" {
+		playbookPath = roleTasksPath(rolePath)
+		artifact = "role tasks file " + playbookPath
+	} else {
+		state.OutputExtension = types.StringValue(extension)
+	}
 
 	if _, err := osStat(playbookPath); os.IsNotExist(err) {
-		resp.State.RemoveResource(ctx)
+		r.client.handleMissingArtifact(ctx, artifact, &resp.State, &resp.Diagnostics)
 		return
 	}
 
-	// Read current content
-	content, err := osReadFile(playbookPath)
+	// Read current content, tolerating a playbook that is still being written
+	content, err := readFileWithRetry(ctx, playbookPath)
+	if os.IsNotExist(err) {
+		r.client.handleMissingArtifact(ctx, artifact, &resp.State, &resp.Diagnostics)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			errorReadingPlaybook,
@@ -233,7 +1369,22 @@ func (r *migrationResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	state.PlaybookContent = types.StringValue(string(content))
+	setPlaybookContent(&state, r.client, content, &resp.Diagnostics)
+	state.ReferencedEnvVars = typesListFromStringSlice(parseReferencedEnvVars(string(content)))
+	state.ModuleCounts = moduleCountsFromContent(string(content))
+	state.ContentSHA256 = types.StringValue(contentSHA256(content))
+
+	renames := make(map[string]string)
+	resp.Diagnostics.Append(state.VariableRenameMap.ElementsAs(ctx, &renames, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.VariableMappings = variableMappingsFromContent(string(content), renames)
+	refreshOutputFileMode(&state.OutputFileMode, &state.AppliedFileMode, &resp.Diagnostics, playbookPath)
+	r.refreshOutputs(&state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -248,26 +1399,107 @@ func (r *migrationResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	// Re-clone the cookbook when it is sourced from Git
+	cleanup, ok := prepareCookbookSource(ctx, &plan, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	defer cleanup()
+
 	// Re-run conversion
 	recipeName := plan.RecipeName.ValueString()
 	cookbookPath := plan.CookbookPath.ValueString()
-	outputPath := plan.OutputPath.ValueString()
+	outputPath := r.client.resolveOutputPath(plan.OutputPath.ValueString())
+	plan.ResolvedOutputPath = types.StringValue(outputPath)
+	ctx = withReportResourceID(ctx, migrationResourceID(&plan, r.client, cookbookPath, recipeName))
 
 	// Re-run conversion and read the resulting playbook
-	content, cmdOut, err := r.runConversion(ctx, cookbookPath, recipeName, outputPath)
+	renames := make(map[string]string)
+	resp.Diagnostics.Append(plan.VariableRenameMap.ElementsAs(ctx, &renames, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	renameMapPath, cleanupRenames, err := writeVariableRenameMap(ctx, renames)
 	if err != nil {
-		addConversionError(
-			resp.Diagnostics.AddError,
-			"Error updating migration",
-			"Could not re-convert recipe",
-			"Could not read updated playbook",
-			err,
-			cmdOut,
+		resp.Diagnostics.AddError(
+			"Error writing variable rename map",
+			fmt.Sprintf("Could not write variable rename map: %s", err),
 		)
 		return
 	}
+	defer cleanupRenames()
+
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, outputPath, &resp.Diagnostics) {
+		return
+	}
+	previousHash := existingPlaybookHash(outputPath, recipeName, plan.OutputSyntax, plan.OutputLayout)
+	content, cmdOut, err := r.runConversion(ctx, cookbookPath, recipeName, outputPath, renameMapPath, plan.OutputSyntax, plan.RecipesSubdir, plan.AnsibleVersion, plan.OutputLayout, &resp.Diagnostics)
+	if err != nil {
+		addConversionError(&resp.Diagnostics, "Could not read updated playbook", err)
+		return
+	}
+	content = r.writePlaybookHeader(&plan, outputPath, plan.OutputLayout, cookbookPath, recipeName, content, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.WasChanged = types.BoolValue(previousHash != contentSHA256(content))
+
+	checkDestructiveContent(&plan, content, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.writeMigrationRole(&plan, outputPath, recipeName, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	playbookPath, extension := findPlaybookFile(outputPath, recipeName, plan.OutputSyntax, types.StringNull())
+	populateMigrationPlanState(&plan, r.client, cookbookPath, recipeName, content, cmdOut, &resp.Diagnostics)
+	plan.OutputExtension = types.StringValue(extension)
+	plan.VariableMappings = variableMappingsFromContent(string(content), renames)
+	plan.AppliedFileMode = r.client.applyOutputFileMode(plan.OutputFileMode, &resp.Diagnostics, migrationOutputFiles(playbookPath, plan.RolePath)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.convertOutputTargets(ctx, &plan, cookbookPath, recipeName, renameMapPath, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remove the previous playbook when the recipe, output path or syntax changed
+	if !req.State.Raw.IsNull() && !r.client.isDryRun() {
+		var state migrationResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		previousPath, _ := findPlaybookFile(stateOutputPath(state.ResolvedOutputPath, state.OutputPath), state.RecipeName.ValueString(), state.OutputSyntax, state.OutputExtension)
+		if previousPath != playbookPath {
+			deleteGeneratedFile(previousPath, "playbook", &resp.Diagnostics)
+		}
 
-	populateMigrationPlanState(&plan, cookbookPath, recipeName, content)
+		// Likewise remove the previous role when it moved or role layout was turned off
+		if previousRole := state.RolePath.ValueString(); previousRole != "" && previousRole != plan.RolePath.ValueString() {
+			if err := osRemoveAll(previousRole); err != nil {
+				resp.Diagnostics.AddWarning(
+					"Error deleting role",
+					fmt.Sprintf("Could not delete role %s: %s", previousRole, err),
+				)
+			}
+		}
+
+		// And the outputs of targets that were removed or changed
+		current := make(map[string]bool)
+		for _, filePath := range r.outputTargetFiles(plan.OutputTargets, recipeName, plan.OutputSyntax, plan.OutputExtension) {
+			current[filePath] = true
+		}
+		for _, filePath := range r.outputTargetFiles(state.OutputTargets, state.RecipeName.ValueString(), state.OutputSyntax, state.OutputExtension) {
+			if !current[filePath] {
+				deleteOutputTargetFile(filePath, &resp.Diagnostics)
+			}
+		}
+	}
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -282,10 +1514,14 @@ func (r *migrationResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	if r.client.retainGeneratedFiles(state.RetainOnDelete, outputPath, &resp.Diagnostics) {
+		return
+	}
+
 	// Remove generated playbook
 	recipeName := state.RecipeName.ValueString()
-	outputPath := state.OutputPath.ValueString()
-	playbookPath := filepath.Join(outputPath, recipeName+".yml")
+	playbookPath, _ := findPlaybookFile(outputPath, recipeName, state.OutputSyntax, state.OutputExtension)
 
 	if err := osRemove(playbookPath); err != nil && !os.IsNotExist(err) {
 		resp.Diagnostics.AddError(
@@ -295,6 +1531,24 @@ func (r *migrationResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	// Remove the generated role, if any
+	if rolePath := state.RolePath.ValueString(); rolePath != "" {
+		if err := osRemoveAll(rolePath); err != nil {
+			resp.Diagnostics.AddWarning(
+				"Error deleting role",
+				fmt.Sprintf("Could not delete role %s: %s", rolePath, err),
+			)
+		}
+		pruneEmptyDir(state.PruneEmptyDir, filepath.Dir(rolePath), &resp.Diagnostics)
+	}
+	pruneEmptyDir(state.PruneEmptyDir, outputPath, &resp.Diagnostics)
+
+	// Remove the outputs of any additional targets
+	for _, filePath := range r.outputTargetFiles(state.OutputTargets, recipeName, state.OutputSyntax, state.OutputExtension) {
+		deleteOutputTargetFile(filePath, &resp.Diagnostics)
+		pruneEmptyDir(state.PruneEmptyDir, filepath.Dir(filePath), &resp.Diagnostics)
+	}
+
 	tflog.Info(ctx, "Deleted migration resource", map[string]interface{}{
 		"id": state.ID.ValueString(),
 	})
@@ -302,19 +1556,44 @@ func (r *migrationResource) Delete(ctx context.Context, req resource.DeleteReque
 
 // ImportState imports an existing resource into Terraform
 func (r *migrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import ID format: cookbook_path|output_path|recipe_name
-	parts := strings.Split(req.ID, "|")
-	if len(parts) != 3 {
+	// Import ID format: cookbook_path|output_path|recipe_name|output_syntax|ansible_version
+	// (output_syntax and ansible_version are optional)
+	parts, err := importIDParts(req.ID, "cookbook_path", "output_path", "recipe_name", "output_syntax", "ansible_version")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+	if len(parts) < 3 || len(parts) > 5 {
 		resp.Diagnostics.AddError(
 			"Invalid import ID",
-			"Import ID must be in format: cookbook_path|output_path|recipe_name",
+			"Import ID must be in format: cookbook_path|output_path|recipe_name, optionally followed by |output_syntax and |ansible_version",
 		)
 		return
 	}
 
 	cookbookPath := parts[0]
-	outputPath := parts[1]
+	configuredOutputPath := parts[1]
+	outputPath := r.client.resolveOutputPath(configuredOutputPath)
 	recipeName := parts[2]
+	outputSyntax := types.StringNull()
+	if len(parts) == 4 && parts[3] != "" {
+		if parts[3] != outputSyntaxYAML && parts[3] != outputSyntaxJSON {
+			resp.Diagnostics.AddError(
+				"Invalid import ID",
+				fmt.Sprintf("output_syntax must be %q or %q, got %q", outputSyntaxYAML, outputSyntaxJSON, parts[3]),
+			)
+			return
+		}
+		outputSyntax = types.StringValue(parts[3])
+	}
+	ansibleVersion := types.StringNull()
+	if len(parts) == 5 && parts[4] != "" {
+		if err := checkAnsibleVersion(parts[4]); err != nil {
+			resp.Diagnostics.AddError("Invalid import ID", err.Error())
+			return
+		}
+		ansibleVersion = types.StringValue(parts[4])
+	}
 
 	// Validate that the cookbook exists
 	if _, err := osStat(cookbookPath); os.IsNotExist(err) {
@@ -326,7 +1605,7 @@ func (r *migrationResource) ImportState(ctx context.Context, req resource.Import
 	}
 
 	// Check if playbook exists
-	playbookPath := filepath.Join(outputPath, recipeName+".yml")
+	playbookPath, extension := findPlaybookFile(outputPath, recipeName, outputSyntax, types.StringNull())
 	if _, err := osStat(playbookPath); os.IsNotExist(err) {
 		resp.Diagnostics.AddError(
 			"Playbook not found",
@@ -346,13 +1625,27 @@ func (r *migrationResource) ImportState(ctx context.Context, req resource.Import
 	}
 
 	// Extract cookbook name from path
-	cookbookName := filepath.Base(cookbookPath)
+	cookbookName := r.client.cookbookName(cookbookPath)
 
 	// Set state
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cookbook_path"), cookbookPath)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_path"), outputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_path"), configuredOutputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resolved_output_path"), outputPath)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("recipe_name"), recipeName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_syntax"), outputSyntax)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ansible_version"), ansibleVersion)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_extension"), extension)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cookbook_name"), cookbookName)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("playbook_content"), string(content))...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), fmt.Sprintf("%s-%s", cookbookName, recipeName))...)
+	var stored migrationResourceModel
+	setPlaybookContent(&stored, r.client, content, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("playbook_content"), stored.PlaybookContent)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("playbook_content_sensitive"), stored.PlaybookContentSensitive)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("playbook_content_base64"), stored.PlaybookContentBase64)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("content_truncated"), stored.ContentTruncated)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("referenced_env_vars"), parseReferencedEnvVars(string(content)))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("module_counts"), parseModuleCounts(string(content)))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("variable_mappings"), map[string]string{})...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("content_sha256"), contentSHA256(content))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_hash"), sourceHashValue(cookbookPath))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), r.client.resourceID(fmt.Sprintf("%s-%s", cookbookName, recipeName), cookbookPath))...)
 }
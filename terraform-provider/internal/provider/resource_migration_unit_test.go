@@ -0,0 +1,2222 @@
+// Package provider contains unit tests for the migration resource.
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// createMigration runs Create for the given plan model and returns the resulting state model.
+func createMigration(t *testing.T, r *migrationResource, model migrationResourceModel) migrationResourceModel {
+	t.Helper()
+
+	schema := newResourceSchema(t, r)
+	plan := newPlan(t, schema, model)
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var state migrationResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+
+	return state
+}
+
+func TestMigrationResourceCaptureOutput(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+
+	state := createMigration(t, r, migrationResourceModel{
+		CookbookPath:      types.StringValue(testTmpCookbook),
+		OutputPath:        types.StringValue(t.TempDir()),
+		RecipeName:        types.StringValue("default"),
+		CaptureOutput:     types.BoolValue(true),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	})
+
+	if !strings.Contains(state.ConversionLog.ValueString(), "recipe: default") {
+		t.Fatalf("expected conversion_log to contain CLI output, got %q", state.ConversionLog.ValueString())
+	}
+}
+
+func TestMigrationResourceCaptureOutputDisabled(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+
+	state := createMigration(t, r, migrationResourceModel{
+		CookbookPath:      types.StringValue(testTmpCookbook),
+		OutputPath:        types.StringValue(t.TempDir()),
+		RecipeName:        types.StringValue("default"),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	})
+
+	if !state.ConversionLog.IsNull() {
+		t.Fatalf("expected conversion_log to be null by default, got %q", state.ConversionLog.ValueString())
+	}
+}
+
+func TestMigrationResourceValidateConfig(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: "souschef"}}
+	schema := newResourceSchema(t, r)
+	cookbookDir := t.TempDir()
+
+	config := newResourceConfig(t, schema, migrationResourceModel{
+		CookbookPath:      types.StringValue(cookbookDir),
+		OutputPath:        types.StringValue(filepath.Join(cookbookDir, "playbooks")),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	})
+	resp := &resource.ValidateConfigResponse{}
+	r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for output_path nested in cookbook_path")
+	}
+
+	config = newResourceConfig(t, schema, migrationResourceModel{
+		CookbookPath:      types.StringValue(cookbookDir),
+		OutputPath:        types.StringValue(t.TempDir()),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	})
+	resp = &resource.ValidateConfigResponse{}
+	r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+}
+
+func TestMigrationResourceValidateConfigHeader(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: "souschef"}}
+	schema := newResourceSchema(t, r)
+
+	tests := map[string]struct {
+		addHeader      types.Bool
+		headerTemplate types.String
+		outputSyntax   types.String
+		wantErr        bool
+	}{
+		"header":                  {addHeader: types.BoolValue(true), headerTemplate: types.StringValue("Source: {cookbook}")},
+		"template without header": {addHeader: types.BoolNull(), headerTemplate: types.StringValue("Source: {cookbook}"), wantErr: true},
+		"json output":             {addHeader: types.BoolValue(true), outputSyntax: types.StringValue(outputSyntaxJSON), wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := newResourceConfig(t, schema, migrationResourceModel{
+				CookbookPath:      types.StringValue(t.TempDir()),
+				OutputPath:        types.StringValue(t.TempDir()),
+				AddHeader:         tt.addHeader,
+				HeaderTemplate:    tt.headerTemplate,
+				OutputSyntax:      tt.outputSyntax,
+				ReferencedEnvVars: types.ListNull(types.StringType),
+				ModuleCounts:      types.MapNull(types.Int64Type),
+				VariableRenameMap: types.MapNull(types.StringType),
+				VariableMappings:  types.MapNull(types.StringType),
+				Outputs:           types.MapNull(types.StringType),
+			})
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestMigrationResourceValidateConfigOutputTargets(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: "souschef"}}
+	schema := newResourceSchema(t, r)
+	outputDir := t.TempDir()
+	reviewDir := filepath.Join(outputDir, "review")
+
+	tests := map[string]struct {
+		targets []migrationOutputTargetModel
+		syntax  types.String
+		wantErr bool
+	}{
+		"distinct paths": {targets: []migrationOutputTargetModel{
+			{OutputPath: types.StringValue(reviewDir)},
+			{Layout: types.StringValue(outputLayoutRole), OutputPath: types.StringValue(filepath.Join(outputDir, "dist"))},
+		}},
+		"duplicate target paths": {targets: []migrationOutputTargetModel{
+			{OutputPath: types.StringValue(reviewDir)},
+			{Layout: types.StringValue(outputLayoutRole), OutputPath: types.StringValue(reviewDir + "/")},
+		}, wantErr: true},
+		"same as output_path": {targets: []migrationOutputTargetModel{{OutputPath: types.StringValue(outputDir)}}, wantErr: true},
+		"invalid layout":      {targets: []migrationOutputTargetModel{{Layout: types.StringValue("collection"), OutputPath: types.StringValue(reviewDir)}}, wantErr: true},
+		"role with JSON syntax": {
+			targets: []migrationOutputTargetModel{{Layout: types.StringValue(outputLayoutRole), OutputPath: types.StringValue(reviewDir)}},
+			syntax:  types.StringValue(outputSyntaxJSON),
+			wantErr: true,
+		},
+		"playbook with JSON syntax": {
+			targets: []migrationOutputTargetModel{{OutputPath: types.StringValue(reviewDir)}},
+			syntax:  types.StringValue(outputSyntaxJSON),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := newResourceConfig(t, schema, migrationResourceModel{
+				CookbookPath:      types.StringValue(t.TempDir()),
+				OutputPath:        types.StringValue(outputDir),
+				OutputTargets:     tt.targets,
+				OutputSyntax:      tt.syntax,
+				ReferencedEnvVars: types.ListNull(types.StringType),
+				ModuleCounts:      types.MapNull(types.Int64Type),
+				VariableRenameMap: types.MapNull(types.StringType),
+				VariableMappings:  types.MapNull(types.StringType),
+				Outputs:           types.MapNull(types.StringType),
+			})
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+			if resp.Diagnostics.HasError() != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestMigrationResourceReadReferencedEnvVars(t *testing.T) {
+	r := &migrationResource{}
+	schema := newResourceSchema(t, r)
+
+	outputDir := t.TempDir()
+	content := "- name: deploy\n  shell: echo {{ lookup('env', 'DEPLOY_USER') }} {{ lookup('env', 'DEPLOY_HOST') }}\n"
+	if err := os.WriteFile(filepath.Join(outputDir, testDefaultYml), []byte(content), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWritePlaybook, err)
+	}
+
+	state := newState(t, schema, migrationResourceModel{
+		RecipeName:        types.StringValue("default"),
+		OutputPath:        types.StringValue(outputDir),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	})
+	resp := &resource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var got migrationResourceModel
+	if diags := resp.State.Get(context.Background(), &got); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+
+	var envVars []string
+	if diags := got.ReferencedEnvVars.ElementsAs(context.Background(), &envVars, false); diags.HasError() {
+		t.Fatalf("failed to read referenced_env_vars: %v", diags)
+	}
+	verifyStringSliceResult(t, envVars, []string{"DEPLOY_HOST", "DEPLOY_USER"})
+}
+
+func TestCheckDestructiveContent(t *testing.T) {
+	destructive := []byte("- name: Wipe\n  command: rm -rf /opt/app\n")
+	safe := []byte("- name: Restart\n  service:\n    name: app\n    state: restarted\n")
+
+	tests := []struct {
+		name         string
+		plan         migrationResourceModel
+		content      []byte
+		wantError    bool
+		wantWarnings int
+	}{
+		{name: "destructive blocked", plan: migrationResourceModel{BlockDestructive: types.BoolValue(true)}, content: destructive, wantError: true},
+		{name: "destructive warns", plan: migrationResourceModel{}, content: destructive, wantWarnings: 1},
+		{name: "safe content", plan: migrationResourceModel{BlockDestructive: types.BoolValue(true)}, content: safe},
+		{
+			name: "custom pattern",
+			plan: migrationResourceModel{
+				BlockDestructive:    types.BoolValue(true),
+				DestructivePatterns: []types.String{types.StringValue(`state:\s+restarted`)},
+			},
+			content:   safe,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			checkDestructiveContent(&tt.plan, tt.content, &diags)
+			if diags.HasError() != tt.wantError {
+				t.Fatalf("expected error=%v, got %v", tt.wantError, diags)
+			}
+			if diags.WarningsCount() != tt.wantWarnings {
+				t.Fatalf("expected %d warnings, got %v", tt.wantWarnings, diags)
+			}
+			if tt.wantError && !strings.Contains(diags.Errors()[0].Detail(), "line ") {
+				t.Fatalf("expected offending line in detail, got %q", diags.Errors()[0].Detail())
+			}
+		})
+	}
+}
+
+func TestMigrationResourceValidateConfigInvalidPattern(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: "souschef"}}
+	schema := newResourceSchema(t, r)
+
+	config := newResourceConfig(t, schema, migrationResourceModel{
+		CookbookPath:        types.StringValue(t.TempDir()),
+		OutputPath:          types.StringValue(t.TempDir()),
+		DestructivePatterns: []types.String{types.StringValue("rm -rf"), types.StringValue("(")},
+		ReferencedEnvVars:   types.ListNull(types.StringType),
+		ModuleCounts:        types.MapNull(types.Int64Type),
+		VariableRenameMap:   types.MapNull(types.StringType),
+		VariableMappings:    types.MapNull(types.StringType),
+		Outputs:             types.MapNull(types.StringType),
+	})
+	resp := &resource.ValidateConfigResponse{}
+	r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected one error for invalid pattern, got %v", resp.Diagnostics)
+	}
+}
+
+func TestMigrationResourceReadRetriesTransientError(t *testing.T) {
+	r := &migrationResource{}
+	schema := newResourceSchema(t, r)
+
+	outputDir := t.TempDir()
+	playbookPath := filepath.Join(outputDir, testDefaultYml)
+	if err := os.WriteFile(playbookPath, []byte("recipe: default\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWritePlaybook, err)
+	}
+
+	attempts := 0
+	withOsReadFile(t, func(name string) ([]byte, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, errors.New("unexpected EOF")
+		}
+		return os.ReadFile(name)
+	})
+
+	state := newState(t, schema, migrationResourceModel{
+		RecipeName:        types.StringValue("default"),
+		OutputPath:        types.StringValue(outputDir),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	})
+	resp := &resource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var got migrationResourceModel
+	if diags := resp.State.Get(context.Background(), &got); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if got.PlaybookContent.ValueString() != "recipe: default\n" || attempts != 2 {
+		t.Fatalf("expected playbook read on second attempt, got %q after %d attempts", got.PlaybookContent.ValueString(), attempts)
+	}
+}
+
+func TestMigrationResourceRoleLayout(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	skeleton := newRoleSkeleton(t)
+	outputDir := t.TempDir()
+
+	state := createMigration(t, r, migrationResourceModel{
+		CookbookPath:       types.StringValue(testTmpCookbook),
+		OutputPath:         types.StringValue(outputDir),
+		RecipeName:         types.StringValue("default"),
+		OutputLayout:       types.StringValue(outputLayoutRole),
+		RoleLayoutTemplate: types.StringValue(skeleton),
+		ReferencedEnvVars:  types.ListNull(types.StringType),
+		ModuleCounts:       types.MapNull(types.Int64Type),
+		VariableRenameMap:  types.MapNull(types.StringType),
+		VariableMappings:   types.MapNull(types.StringType),
+		Outputs:            types.MapNull(types.StringType),
+	})
+
+	rolePath := filepath.Join(outputDir, "roles", "default")
+	if state.RolePath.ValueString() != rolePath {
+		t.Fatalf("expected role_path %q, got %q", rolePath, state.RolePath.ValueString())
+	}
+	verifyRoleMirrorsSkeleton(t, skeleton, rolePath)
+	verifyRoleTasks(t, rolePath, state.PlaybookContent.ValueString())
+
+	// The playbook the CLI writes alongside the role applies it
+	playbook, err := os.ReadFile(filepath.Join(outputDir, testDefaultYml))
+	if err != nil || !strings.Contains(string(playbook), "roles:\n    - default") {
+		t.Fatalf("expected the playbook to apply the role, got %q, %v", playbook, err)
+	}
+}
+
+// roleMigrationModel returns a migration plan for the default recipe in the given layout.
+func roleMigrationModel(outputDir string, layout types.String) migrationResourceModel {
+	return migrationResourceModel{
+		CookbookPath:      types.StringValue(testTmpCookbook),
+		OutputPath:        types.StringValue(outputDir),
+		RecipeName:        types.StringValue("default"),
+		OutputLayout:      layout,
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	}
+}
+
+func TestMigrationResourceRoleLayoutLifecycle(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputDir := t.TempDir()
+
+	created := createMigration(t, r, roleMigrationModel(outputDir, types.StringValue(outputLayoutRole)))
+	state := newState(t, schema, created)
+	rolePath := created.RolePath.ValueString()
+
+	// Read follows the role's tasks file rather than the flat playbook
+	if err := os.WriteFile(roleTasksPath(rolePath), []byte("- name: edited\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	readResp := &resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	var refreshed migrationResourceModel
+	readResp.State.Get(context.Background(), &refreshed)
+	if refreshed.PlaybookContent.ValueString() != "- name: edited\n" {
+		t.Fatalf("expected content from tasks/main.yml, got %q", refreshed.PlaybookContent.ValueString())
+	}
+
+	// A removed role is drift even though the flat playbook remains
+	if err := os.Remove(roleTasksPath(rolePath)); err != nil {
+		t.Fatalf("failed to remove tasks file: %v", err)
+	}
+	readResp = &resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Fatal("expected resource to be removed when the role's tasks/main.yml is missing")
+	}
+
+	// Delete removes the whole role directory
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: state}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+	}
+	if _, err := os.Stat(rolePath); !os.IsNotExist(err) {
+		t.Fatalf("expected role %s to be removed, got %v", rolePath, err)
+	}
+}
+
+func TestMigrationResourceUpdateRoleToPlaybookLayout(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputDir := t.TempDir()
+
+	created := createMigration(t, r, roleMigrationModel(outputDir, types.StringValue(outputLayoutRole)))
+	state := newState(t, schema, created)
+
+	resp := &resource.UpdateResponse{State: state}
+	r.Update(context.Background(), resource.UpdateRequest{
+		Plan:  newPlan(t, schema, roleMigrationModel(outputDir, types.StringNull())),
+		State: state,
+	}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var updated migrationResourceModel
+	resp.State.Get(context.Background(), &updated)
+	if !updated.RolePath.IsNull() {
+		t.Fatalf("expected null role_path, got %q", updated.RolePath.ValueString())
+	}
+	if _, err := os.Stat(created.RolePath.ValueString()); !os.IsNotExist(err) {
+		t.Fatalf("expected previous role to be removed, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "default.yml")); err != nil {
+		t.Fatalf("expected flat playbook to remain: %v", err)
+	}
+}
+
+func TestMigrationResourcePlaybookLayoutHasNoRole(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	outputDir := t.TempDir()
+
+	state := createMigration(t, r, migrationResourceModel{
+		CookbookPath:      types.StringValue(testTmpCookbook),
+		OutputPath:        types.StringValue(outputDir),
+		RecipeName:        types.StringValue("default"),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	})
+
+	if !state.RolePath.IsNull() {
+		t.Fatalf("expected null role_path, got %q", state.RolePath.ValueString())
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "roles")); !os.IsNotExist(err) {
+		t.Fatalf("expected no roles directory, got %v", err)
+	}
+}
+
+func TestMigrationResourceValidateRoleLayout(t *testing.T) {
+	r := &migrationResource{}
+	schema := newResourceSchema(t, r)
+	skeleton := t.TempDir()
+
+	tests := []struct {
+		name      string
+		layout    types.String
+		template  types.String
+		syntax    types.String
+		wantError bool
+	}{
+		{name: "role with template", layout: types.StringValue(outputLayoutRole), template: types.StringValue(skeleton)},
+		{name: "role without template", layout: types.StringValue(outputLayoutRole), template: types.StringNull()},
+		{name: "unknown layout", layout: types.StringValue("collection"), template: types.StringNull(), wantError: true},
+		{name: "template without role layout", layout: types.StringNull(), template: types.StringValue(skeleton), wantError: true},
+		{name: "missing template", layout: types.StringValue(outputLayoutRole), template: types.StringValue(filepath.Join(skeleton, "missing")), wantError: true},
+		{name: "role with YAML syntax", layout: types.StringValue(outputLayoutRole), template: types.StringNull(), syntax: types.StringValue(outputSyntaxYAML)},
+		{name: "role with JSON syntax", layout: types.StringValue(outputLayoutRole), template: types.StringNull(), syntax: types.StringValue(outputSyntaxJSON), wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newResourceConfig(t, schema, migrationResourceModel{
+				CookbookPath:       types.StringValue(t.TempDir()),
+				OutputPath:         types.StringValue(t.TempDir()),
+				OutputLayout:       tt.layout,
+				RoleLayoutTemplate: tt.template,
+				OutputSyntax:       tt.syntax,
+				ReferencedEnvVars:  types.ListNull(types.StringType),
+				ModuleCounts:       types.MapNull(types.Int64Type),
+				VariableRenameMap:  types.MapNull(types.StringType),
+				VariableMappings:   types.MapNull(types.StringType),
+				Outputs:            types.MapNull(types.StringType),
+			})
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Fatalf("expected error=%v, got %v", tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestMigrationResourceReadModuleCounts(t *testing.T) {
+	r := &migrationResource{}
+	schema := newResourceSchema(t, r)
+
+	outputDir := t.TempDir()
+	content := "- name: a\n  copy:\n    src: a\n    dest: /a\n- name: b\n  copy:\n    src: b\n    dest: /b\n- name: c\n  service:\n    name: app\n"
+	if err := os.WriteFile(filepath.Join(outputDir, testDefaultYml), []byte(content), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWritePlaybook, err)
+	}
+
+	state := newState(t, schema, migrationResourceModel{
+		RecipeName:        types.StringValue("default"),
+		OutputPath:        types.StringValue(outputDir),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	})
+	resp := &resource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var got migrationResourceModel
+	if diags := resp.State.Get(context.Background(), &got); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	var counts map[string]int64
+	if diags := got.ModuleCounts.ElementsAs(context.Background(), &counts, false); diags.HasError() {
+		t.Fatalf("failed to read module_counts: %v", diags)
+	}
+	if counts["copy"] != 2 || counts["service"] != 1 {
+		t.Fatalf("expected copy=2 and service=1, got %v", got.ModuleCounts)
+	}
+}
+
+func TestMigrationResourceGitSource(t *testing.T) {
+	repo := newGitCookbookRepo(t)
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+
+	state := createMigration(t, r, migrationResourceModel{
+		GitURL:            types.StringValue(repo.url),
+		GitRef:            types.StringValue(testGitBranch),
+		OutputPath:        types.StringValue(t.TempDir()),
+		RecipeName:        types.StringValue("default"),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	})
+
+	if want := runTestGit(t, repo.workDir, "rev-parse", "HEAD"); state.GitCommit.ValueString() != want {
+		t.Fatalf("expected git_commit %s, got %s", want, state.GitCommit.ValueString())
+	}
+	if state.CookbookName.ValueString() != "web-cookbook" || state.ID.ValueString() != "web-cookbook-default" {
+		t.Fatalf("expected cookbook named after repository, got %q (%q)", state.CookbookName.ValueString(), state.ID.ValueString())
+	}
+	if _, err := os.Stat(state.CookbookPath.ValueString()); !os.IsNotExist(err) {
+		t.Fatalf("expected clone to be removed after conversion, got %v", err)
+	}
+}
+
+func TestMigrationResourceGitSourceHashID(t *testing.T) {
+	repo := newGitCookbookRepo(t)
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t), IDStrategy: idStrategyHash}}
+	schema := newResourceSchema(t, r)
+	model := migrationResourceModel{
+		GitURL:            types.StringValue(repo.url),
+		GitRef:            types.StringValue(testGitBranch),
+		OutputPath:        types.StringValue(t.TempDir()),
+		RecipeName:        types.StringValue("default"),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	}
+
+	created := createMigration(t, r, model)
+	if want := r.client.gitResourceID("web-cookbook-default", repo.url, testGitBranch); created.ID.ValueString() != want {
+		t.Fatalf("expected id %q from the git source, got %q", want, created.ID.ValueString())
+	}
+
+	// A second apply clones into a new directory but keeps the ID
+	updateResp := &resource.UpdateResponse{State: tfsdk.State{Schema: schema}}
+	r.Update(context.Background(), resource.UpdateRequest{Plan: newPlan(t, schema, model), State: newState(t, schema, created)}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, updateResp.Diagnostics)
+	}
+	var updated migrationResourceModel
+	if diags := updateResp.State.Get(context.Background(), &updated); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if updated.CookbookPath == created.CookbookPath {
+		t.Fatalf("expected each apply to use a fresh clone, both used %s", created.CookbookPath.ValueString())
+	}
+	if updated.ID != created.ID {
+		t.Fatalf("expected the id to survive a re-clone, got %q then %q", created.ID.ValueString(), updated.ID.ValueString())
+	}
+}
+
+func TestMigrationResourceModifyPlanDetectsUpstreamChange(t *testing.T) {
+	repo := newGitCookbookRepo(t)
+	r := &migrationResource{}
+	schema := newResourceSchema(t, r)
+	previous := runTestGit(t, repo.workDir, "rev-parse", "HEAD")
+
+	model := migrationResourceModel{
+		GitURL:            types.StringValue(repo.url),
+		OutputPath:        types.StringValue(t.TempDir()),
+		RecipeName:        types.StringValue("default"),
+		PlaybookContent:   types.StringValue("recipe: default\n"),
+		GitCommit:         types.StringValue(previous),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	}
+	modifyPlan := func() migrationResourceModel {
+		plan := newPlan(t, schema, model)
+		resp := &resource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(context.Background(), resource.ModifyPlanRequest{Plan: plan, State: newState(t, schema, model)}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+		}
+		var got migrationResourceModel
+		if diags := resp.Plan.Get(context.Background(), &got); diags.HasError() {
+			t.Fatalf("failed to read plan: %v", diags)
+		}
+		return got
+	}
+
+	if got := modifyPlan(); got.GitCommit.ValueString() != previous {
+		t.Fatalf("expected unchanged git_commit, got %v", got.GitCommit)
+	}
+
+	repo.commit(t, "recipes.rb", "package 'nginx'\n")
+	repo.push(t)
+
+	got := modifyPlan()
+	if !got.GitCommit.IsUnknown() || !got.PlaybookContent.IsUnknown() {
+		t.Fatalf("expected git_commit and playbook_content to be unknown after upstream change, got %v / %v", got.GitCommit, got.PlaybookContent)
+	}
+}
+
+func TestMigrationResourceValidateCookbookSource(t *testing.T) {
+	r := &migrationResource{}
+	schema := newResourceSchema(t, r)
+
+	tests := []struct {
+		name      string
+		model     migrationResourceModel
+		wantError bool
+	}{
+		{name: "cookbook path", model: migrationResourceModel{CookbookPath: types.StringValue(testTmpCookbook)}},
+		{name: "git url", model: migrationResourceModel{GitURL: types.StringValue("https://example.com/web.git"), GitRef: types.StringValue("main")}},
+		{name: "both set", model: migrationResourceModel{CookbookPath: types.StringValue(testTmpCookbook), GitURL: types.StringValue("https://example.com/web.git")}, wantError: true},
+		{name: "neither set", model: migrationResourceModel{}, wantError: true},
+		{name: "ref without url", model: migrationResourceModel{CookbookPath: types.StringValue(testTmpCookbook), GitRef: types.StringValue("main")}, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.model.OutputPath = types.StringValue(t.TempDir())
+			tt.model.ReferencedEnvVars = types.ListNull(types.StringType)
+			tt.model.ModuleCounts = types.MapNull(types.Int64Type)
+			tt.model.VariableRenameMap = types.MapNull(types.StringType)
+			tt.model.VariableMappings = types.MapNull(types.StringType)
+			tt.model.Outputs = types.MapNull(types.StringType)
+			config := newResourceConfig(t, schema, tt.model)
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Fatalf("expected error=%v, got %v", tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestMigrationResourceVariableRenameMap(t *testing.T) {
+	var tempDirs []string
+	original := osMkdirTemp
+	osMkdirTemp = func(dir, pattern string) (string, error) {
+		created, err := original(dir, pattern)
+		tempDirs = append(tempDirs, created)
+		return created, err
+	}
+	t.Cleanup(func() { osMkdirTemp = original })
+
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	state := createMigration(t, r, migrationResourceModel{
+		CookbookPath: types.StringValue(testTmpCookbook),
+		OutputPath:   types.StringValue(t.TempDir()),
+		RecipeName:   types.StringValue("default"),
+		VariableRenameMap: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"nginx.port":         types.StringValue("nginx_listen_port"),
+			"nginx.worker_count": types.StringValue("nginx_workers"),
+		}),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	})
+
+	// The fake CLI echoes each rename target from the mapping file into the playbook
+	for _, want := range []string{"nginx_listen_port: \"{{ nginx_listen_port }}\"", "nginx_workers: \"{{ nginx_workers }}\""} {
+		if !strings.Contains(state.PlaybookContent.ValueString(), want) {
+			t.Fatalf("expected playbook to contain %q, got %q", want, state.PlaybookContent.ValueString())
+		}
+	}
+
+	var mappings map[string]string
+	if diags := state.VariableMappings.ElementsAs(context.Background(), &mappings, false); diags.HasError() {
+		t.Fatalf("failed to read variable_mappings: %v", diags)
+	}
+	if len(mappings) != 2 || mappings["nginx.port"] != "nginx_listen_port" || mappings["nginx.worker_count"] != "nginx_workers" {
+		t.Fatalf("unexpected variable_mappings %v", mappings)
+	}
+
+	if len(tempDirs) != 1 {
+		t.Fatalf("expected a single mapping file directory, got %v", tempDirs)
+	}
+	if _, err := os.Stat(tempDirs[0]); !os.IsNotExist(err) {
+		t.Fatalf("expected mapping file directory %s to be removed", tempDirs[0])
+	}
+}
+
+func TestWriteVariableRenameMap(t *testing.T) {
+	mapPath, cleanup, err := writeVariableRenameMap(context.Background(), map[string]string{"nginx.port": "nginx_listen_port"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(mapPath)
+	if err != nil {
+		t.Fatalf("expected mapping file to be created: %v", err)
+	}
+	if string(content) != `{"nginx.port":"nginx_listen_port"}` {
+		t.Fatalf("unexpected mapping file content %q", content)
+	}
+
+	cleanup()
+	if _, err := os.Stat(mapPath); !os.IsNotExist(err) {
+		t.Fatalf("expected mapping file to be removed, got %v", err)
+	}
+
+	if mapPath, _, err := writeVariableRenameMap(context.Background(), nil); err != nil || mapPath != "" {
+		t.Fatalf("expected no mapping file without renames, got %q (%v)", mapPath, err)
+	}
+}
+
+func TestVariableMappingsFromContent(t *testing.T) {
+	renames := map[string]string{
+		"nginx.port":  "nginx_port",
+		"nginx.user":  "nginx_user",
+		"app.version": "app_ver",
+	}
+	got := variableMappingsFromContent("port: \"{{ nginx_port }}\"\nuser: \"{{ nginx_user_name }}\"\n", renames)
+
+	if len(got.Elements()) != 1 || got.Elements()["nginx.port"] != types.StringValue("nginx_port") {
+		t.Fatalf("expected only nginx.port mapping, got %v", got)
+	}
+}
+
+// upgradeMigrationStateFromV0 runs the version 0 upgrader on a prior state holding the original attributes.
+func upgradeMigrationStateFromV0(t *testing.T, outputDir, playbookContent string) migrationResourceModel {
+	t.Helper()
+
+	r := &migrationResource{}
+	upgrader, ok := r.UpgradeState(context.Background())[0]
+	if !ok {
+		t.Fatal("expected an upgrader for schema version 0")
+	}
+	if _, found := upgrader.PriorSchema.Attributes["content_sha256"]; found {
+		t.Fatal("expected prior schema without content_sha256")
+	}
+
+	priorType := upgrader.PriorSchema.Type().TerraformType(context.Background()).(tftypes.Object)
+	priorState := tfsdk.State{
+		Schema: *upgrader.PriorSchema,
+		Raw: tftypes.NewValue(priorType, nullFilledValues(priorType.AttributeTypes, map[string]tftypes.Value{
+			"id":               tftypes.NewValue(tftypes.String, "cookbook-default"),
+			"cookbook_path":    tftypes.NewValue(tftypes.String, testTmpCookbook),
+			"output_path":      tftypes.NewValue(tftypes.String, outputDir),
+			"recipe_name":      tftypes.NewValue(tftypes.String, "default"),
+			"cookbook_name":    tftypes.NewValue(tftypes.String, "cookbook"),
+			"playbook_content": tftypes.NewValue(tftypes.String, playbookContent),
+		})),
+	}
+
+	resp := &resource.UpgradeStateResponse{State: tfsdk.State{Schema: newResourceSchema(t, r)}}
+	upgrader.StateUpgrader(context.Background(), resource.UpgradeStateRequest{State: &priorState}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var state migrationResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read upgraded state: %v", diags)
+	}
+	return state
+}
+
+func TestMigrationResourceUpgradeStateFromV0(t *testing.T) {
+	outputDir := t.TempDir()
+	onDisk := "- name: a\n  copy:\n    dest: \"{{ lookup('env', 'APP_HOME') }}\"\n"
+	if err := os.WriteFile(filepath.Join(outputDir, testDefaultYml), []byte(onDisk), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWritePlaybook, err)
+	}
+
+	state := upgradeMigrationStateFromV0(t, outputDir, "stale content\n")
+
+	if state.ContentSHA256.ValueString() != contentSHA256([]byte(onDisk)) {
+		t.Fatalf("expected content_sha256 of on-disk playbook, got %q", state.ContentSHA256.ValueString())
+	}
+	if state.ID.ValueString() != "cookbook-default" || state.PlaybookContent.ValueString() != "stale content\n" {
+		t.Fatalf("expected prior attributes to be preserved, got %q / %q", state.ID.ValueString(), state.PlaybookContent.ValueString())
+	}
+	if len(state.ReferencedEnvVars.Elements()) != 1 || len(state.ModuleCounts.Elements()) != 1 {
+		t.Fatalf("expected computed attributes to be backfilled, got %v / %v", state.ReferencedEnvVars, state.ModuleCounts)
+	}
+	if state.PlaybookContentSensitive.ValueString() != "stale content\n" {
+		t.Fatalf("expected playbook_content_sensitive to mirror playbook_content, got %q", state.PlaybookContentSensitive.ValueString())
+	}
+}
+
+func TestMigrationResourceUpgradeStateFromV0JSON(t *testing.T) {
+	r := &migrationResource{}
+	upgrader := r.UpgradeState(context.Background())[0]
+	if len(upgrader.PriorSchema.Attributes) != 6 {
+		t.Fatalf("expected the six original attributes in the prior schema, got %d", len(upgrader.PriorSchema.Attributes))
+	}
+
+	// State as written by the first release of the provider
+	rawState := tfprotov6.RawState{JSON: []byte(`{
+		"id": "nginx-default",
+		"cookbook_path": "/srv/cookbooks/nginx",
+		"output_path": "/srv/ansible/missing",
+		"cookbook_name": "nginx",
+		"recipe_name": "default",
+		"playbook_content": "- name: a\n  service:\n    name: nginx\n"
+	}`)}
+	priorType := upgrader.PriorSchema.Type().TerraformType(context.Background())
+	priorValue, err := rawState.Unmarshal(priorType)
+	if err != nil {
+		t.Fatalf("failed to decode version 0 state: %v", err)
+	}
+
+	resp := &resource.UpgradeStateResponse{State: tfsdk.State{Schema: newResourceSchema(t, r)}}
+	upgrader.StateUpgrader(context.Background(), resource.UpgradeStateRequest{State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: priorValue}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var state migrationResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read upgraded state: %v", diags)
+	}
+	content := "- name: a\n  service:\n    name: nginx\n"
+	if state.ID.ValueString() != "nginx-default" || state.CookbookPath.ValueString() != "/srv/cookbooks/nginx" || state.PlaybookContent.ValueString() != content {
+		t.Fatalf("expected prior attributes to be preserved, got %+v", state)
+	}
+	if state.ContentSHA256.ValueString() != contentSHA256([]byte(content)) || state.OutputExtension.ValueString() != "yml" || len(state.ModuleCounts.Elements()) != 1 {
+		t.Fatalf("expected computed attributes to be backfilled, got %+v", state)
+	}
+}
+
+func TestMigrationResourceUpgradeStateMissingPlaybook(t *testing.T) {
+	state := upgradeMigrationStateFromV0(t, t.TempDir(), "recipe: default\n")
+
+	if state.ContentSHA256.ValueString() != contentSHA256([]byte("recipe: default\n")) {
+		t.Fatalf("expected content_sha256 of stored playbook_content, got %q", state.ContentSHA256.ValueString())
+	}
+}
+
+func TestMigrationResourcePlaybookContentSensitive(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+
+	if !schema.Attributes["playbook_content_sensitive"].IsSensitive() {
+		t.Fatal("expected playbook_content_sensitive to be marked sensitive")
+	}
+	if schema.Attributes["playbook_content"].IsSensitive() {
+		t.Fatal("expected playbook_content to remain visible in plans")
+	}
+
+	outputDir := t.TempDir()
+	state := createMigration(t, r, migrationResourceModel{
+		CookbookPath:      types.StringValue(testTmpCookbook),
+		OutputPath:        types.StringValue(outputDir),
+		RecipeName:        types.StringValue("default"),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	})
+	if state.PlaybookContentSensitive.IsNull() || state.PlaybookContentSensitive != state.PlaybookContent {
+		t.Fatalf("expected playbook_content_sensitive to mirror playbook_content, got %q", state.PlaybookContentSensitive.ValueString())
+	}
+
+	// Read must keep the mirror in sync with the on-disk playbook
+	updated := "- name: rotated\n  debug:\n    msg: \"{{ db_password }}\"\n"
+	if err := os.WriteFile(filepath.Join(outputDir, testDefaultYml), []byte(updated), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWritePlaybook, err)
+	}
+	readResp := &resource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	r.Read(context.Background(), resource.ReadRequest{State: newState(t, schema, state)}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+
+	var got migrationResourceModel
+	if diags := readResp.State.Get(context.Background(), &got); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if got.PlaybookContentSensitive.ValueString() != updated {
+		t.Fatalf("expected playbook_content_sensitive to be refreshed, got %q", got.PlaybookContentSensitive.ValueString())
+	}
+}
+
+func TestMigrationResourceOutputRoot(t *testing.T) {
+	root := t.TempDir()
+	absoluteOutput := t.TempDir()
+
+	tests := []struct {
+		name       string
+		outputPath string
+		wantDir    string
+	}{
+		{name: "relative", outputPath: "web/playbooks", wantDir: filepath.Join(root, "web", "playbooks")},
+		{name: "absolute", outputPath: absoluteOutput, wantDir: absoluteOutput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t), OutputRoot: root}}
+			schema := newResourceSchema(t, r)
+
+			state := createMigration(t, r, migrationResourceModel{
+				CookbookPath:      types.StringValue(testTmpCookbook),
+				OutputPath:        types.StringValue(tt.outputPath),
+				RecipeName:        types.StringValue("default"),
+				ReferencedEnvVars: types.ListNull(types.StringType),
+				ModuleCounts:      types.MapNull(types.Int64Type),
+				VariableRenameMap: types.MapNull(types.StringType),
+				VariableMappings:  types.MapNull(types.StringType),
+				Outputs:           types.MapNull(types.StringType),
+			})
+
+			if state.OutputPath.ValueString() != tt.outputPath {
+				t.Fatalf("expected output_path to keep the configured value, got %q", state.OutputPath.ValueString())
+			}
+			if state.ResolvedOutputPath.ValueString() != tt.wantDir {
+				t.Fatalf("expected resolved_output_path %q, got %q", tt.wantDir, state.ResolvedOutputPath.ValueString())
+			}
+			playbookPath := filepath.Join(tt.wantDir, testDefaultYml)
+			if _, err := os.Stat(playbookPath); err != nil {
+				t.Fatalf("expected playbook at %s: %v", playbookPath, err)
+			}
+
+			// Read and Delete must use the resolved location
+			readResp := &resource.ReadResponse{State: tfsdk.State{Schema: schema}}
+			r.Read(context.Background(), resource.ReadRequest{State: newState(t, schema, state)}, readResp)
+			if readResp.Diagnostics.HasError() || readResp.State.Raw.IsNull() {
+				t.Fatalf("expected Read to find the playbook, got %v", readResp.Diagnostics)
+			}
+
+			deleteResp := &resource.DeleteResponse{}
+			r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, deleteResp)
+			if deleteResp.Diagnostics.HasError() {
+				t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+			}
+			if _, err := os.Stat(playbookPath); !os.IsNotExist(err) {
+				t.Fatalf("expected %s to be deleted", playbookPath)
+			}
+		})
+	}
+}
+
+func TestMigrationResourceValidateConfigOutputSyntax(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: "souschef"}}
+	schema := newResourceSchema(t, r)
+
+	tests := map[string]bool{
+		"":     false,
+		"yaml": false,
+		"json": false,
+		"toml": true,
+	}
+	for syntax, wantError := range tests {
+		outputSyntax := types.StringNull()
+		if syntax != "" {
+			outputSyntax = types.StringValue(syntax)
+		}
+		config := newResourceConfig(t, schema, migrationResourceModel{
+			CookbookPath:      types.StringValue(t.TempDir()),
+			OutputPath:        types.StringValue(t.TempDir()),
+			OutputSyntax:      outputSyntax,
+			ReferencedEnvVars: types.ListNull(types.StringType),
+			ModuleCounts:      types.MapNull(types.Int64Type),
+			VariableRenameMap: types.MapNull(types.StringType),
+			VariableMappings:  types.MapNull(types.StringType),
+			Outputs:           types.MapNull(types.StringType),
+		})
+		resp := &resource.ValidateConfigResponse{}
+		r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+		if resp.Diagnostics.HasError() != wantError {
+			t.Fatalf("output_syntax %q: expected error %t, got %v", syntax, wantError, resp.Diagnostics)
+		}
+	}
+}
+
+func TestMigrationResourceOutputSyntax(t *testing.T) {
+	tests := []struct {
+		syntax      types.String
+		filename    string
+		wantContent string
+		importID    string
+	}{
+		{syntax: types.StringValue(outputSyntaxYAML), filename: testDefaultYml, wantContent: "recipe: default", importID: "|yaml"},
+		{syntax: types.StringValue(outputSyntaxJSON), filename: "default.json", wantContent: `{"recipe": "default"}`, importID: "|json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+			schema := newResourceSchema(t, r)
+			outputDir := t.TempDir()
+
+			state := createMigration(t, r, migrationResourceModel{
+				CookbookPath:      types.StringValue(testTmpCookbook),
+				OutputPath:        types.StringValue(outputDir),
+				RecipeName:        types.StringValue("default"),
+				OutputSyntax:      tt.syntax,
+				ReferencedEnvVars: types.ListNull(types.StringType),
+				ModuleCounts:      types.MapNull(types.Int64Type),
+				VariableRenameMap: types.MapNull(types.StringType),
+				VariableMappings:  types.MapNull(types.StringType),
+				Outputs:           types.MapNull(types.StringType),
+			})
+			playbookPath := filepath.Join(outputDir, tt.filename)
+			if _, err := os.Stat(playbookPath); err != nil {
+				t.Fatalf("expected playbook at %s: %v", playbookPath, err)
+			}
+			if !strings.Contains(state.PlaybookContent.ValueString(), tt.wantContent) {
+				t.Fatalf("expected playbook_content to contain %q, got %q", tt.wantContent, state.PlaybookContent.ValueString())
+			}
+
+			readResp := &resource.ReadResponse{State: tfsdk.State{Schema: schema}}
+			r.Read(context.Background(), resource.ReadRequest{State: newState(t, schema, state)}, readResp)
+			if readResp.Diagnostics.HasError() || readResp.State.Raw.IsNull() {
+				t.Fatalf("expected Read to find the playbook, got %v", readResp.Diagnostics)
+			}
+
+			importResp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+			importID := t.TempDir() + "|" + outputDir + "|default" + tt.importID
+			r.ImportState(context.Background(), resource.ImportStateRequest{ID: importID}, importResp)
+			if importResp.Diagnostics.HasError() {
+				t.Fatalf(testUnexpectedDiagnostics, importResp.Diagnostics)
+			}
+			var imported migrationResourceModel
+			if diags := importResp.State.Get(context.Background(), &imported); diags.HasError() {
+				t.Fatalf("failed to read state: %v", diags)
+			}
+			if imported.PlaybookContent.ValueString() != state.PlaybookContent.ValueString() {
+				t.Fatalf("expected imported content %q, got %q", state.PlaybookContent.ValueString(), imported.PlaybookContent.ValueString())
+			}
+
+			deleteResp := &resource.DeleteResponse{}
+			r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, deleteResp)
+			if deleteResp.Diagnostics.HasError() {
+				t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+			}
+			if _, err := os.Stat(playbookPath); !os.IsNotExist(err) {
+				t.Fatalf("expected %s to be deleted", playbookPath)
+			}
+		})
+	}
+}
+
+func TestMigrationResourceUpdateOutputSyntaxRemovesPreviousPlaybook(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputDir := t.TempDir()
+
+	model := migrationResourceModel{
+		CookbookPath:      types.StringValue(testTmpCookbook),
+		OutputPath:        types.StringValue(outputDir),
+		RecipeName:        types.StringValue("default"),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	}
+	state := createMigration(t, r, model)
+
+	model.OutputSyntax = types.StringValue(outputSyntaxJSON)
+	resp := &resource.UpdateResponse{State: tfsdk.State{Schema: schema}}
+	r.Update(context.Background(), resource.UpdateRequest{Plan: newPlan(t, schema, model), State: newState(t, schema, state)}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	if _, err := os.Stat(filepath.Join(outputDir, "default.json")); err != nil {
+		t.Fatalf("expected JSON playbook: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, testDefaultYml)); !os.IsNotExist(err) {
+		t.Fatalf("expected previous YAML playbook to be removed, got %v", err)
+	}
+}
+
+func TestMigrationImportStateInvalidOutputSyntax(t *testing.T) {
+	r := &migrationResource{}
+	schema := newResourceSchema(t, r)
+
+	resp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: t.TempDir() + "|" + t.TempDir() + "|default|toml"}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for invalid output_syntax")
+	}
+}
+
+func TestMigrationImportStateJSON(t *testing.T) {
+	r := &migrationResource{}
+	schema := newResourceSchema(t, r)
+
+	cookbookDir := t.TempDir()
+	outputDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outputDir, "default.json"), []byte(`{"recipe": "default"}`), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWritePlaybook, err)
+	}
+
+	id, err := json.Marshal(map[string]string{
+		"cookbook_path": cookbookDir,
+		"output_path":   outputDir,
+		"recipe_name":   "default",
+		"output_syntax": outputSyntaxJSON,
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal import ID: %v", err)
+	}
+
+	resp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: string(id)}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var state migrationResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.CookbookPath.ValueString() != cookbookDir || state.RecipeName.ValueString() != "default" || state.OutputSyntax.ValueString() != outputSyntaxJSON {
+		t.Fatalf("unexpected imported state: %+v", state)
+	}
+
+	resp = &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: `{"cookbook_path": "` + cookbookDir + `", "recipe": "default"}`}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for an unknown JSON field")
+	}
+}
+
+func TestMigrationResourceMaxContentBytes(t *testing.T) {
+	t.Setenv("SOUSCHEF_TEST_PAD_BYTES", "200")
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t), MaxContentBytes: 64}}
+	schema := newResourceSchema(t, r)
+
+	outputDir := t.TempDir()
+	state := createMigration(t, r, migrationResourceModel{
+		CookbookPath:      types.StringValue(testTmpCookbook),
+		OutputPath:        types.StringValue(outputDir),
+		RecipeName:        types.StringValue("default"),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	})
+
+	// The full playbook is still written to disk
+	content, err := os.ReadFile(filepath.Join(outputDir, testDefaultYml))
+	if err != nil {
+		t.Fatalf("failed to read playbook: %v", err)
+	}
+	if len(content) <= 64 {
+		t.Fatalf("expected an oversized playbook on disk, got %d bytes", len(content))
+	}
+	if !state.ContentTruncated.ValueBool() {
+		t.Fatal("expected content_truncated to be true")
+	}
+	if stored := state.PlaybookContent.ValueString(); !strings.Contains(stored, contentSHA256(content)) || strings.Contains(stored, "recipe: default") {
+		t.Fatalf("expected playbook_content to hold only a truncation notice, got %q", stored)
+	}
+	if state.ContentSHA256.ValueString() != contentSHA256(content) {
+		t.Fatalf("expected content_sha256 of the full playbook, got %q", state.ContentSHA256.ValueString())
+	}
+
+	// Read stores the full content again once the playbook fits
+	small := "recipe: default\n"
+	if err := os.WriteFile(filepath.Join(outputDir, testDefaultYml), []byte(small), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWritePlaybook, err)
+	}
+	readResp := &resource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	r.Read(context.Background(), resource.ReadRequest{State: newState(t, schema, state)}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+
+	var got migrationResourceModel
+	if diags := readResp.State.Get(context.Background(), &got); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if got.ContentTruncated.ValueBool() || got.PlaybookContent.ValueString() != small {
+		t.Fatalf("expected full playbook_content after Read, got truncated %v content %q", got.ContentTruncated.ValueBool(), got.PlaybookContent.ValueString())
+	}
+}
+
+func TestMigrationResourceInvalidUTF8Content(t *testing.T) {
+	t.Setenv("SOUSCHEF_TEST_INVALID_UTF8", "1")
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+
+	outputDir := t.TempDir()
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, migrationResourceModel{
+		CookbookPath:      types.StringValue(testTmpCookbook),
+		OutputPath:        types.StringValue(outputDir),
+		RecipeName:        types.StringValue("default"),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	})}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 || resp.Diagnostics.Warnings()[0].Summary() != "Playbook is not valid UTF-8" {
+		t.Fatalf("expected an invalid UTF-8 warning, got %v", resp.Diagnostics)
+	}
+
+	var state migrationResourceModel
+	if diags := resp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	content, err := os.ReadFile(filepath.Join(outputDir, testDefaultYml))
+	if err != nil {
+		t.Fatalf("failed to read playbook: %v", err)
+	}
+	if state.PlaybookContent.ValueString() != "" || state.PlaybookContentSensitive.ValueString() != "" {
+		t.Fatalf("expected empty playbook_content, got %q", state.PlaybookContent.ValueString())
+	}
+	decoded, err := base64.StdEncoding.DecodeString(state.PlaybookContentBase64.ValueString())
+	if err != nil || !bytes.Equal(decoded, content) {
+		t.Fatalf("expected playbook_content_base64 to decode to the playbook, got %q (%v)", decoded, err)
+	}
+
+	// Read stores the playbook as a string again once it is valid UTF-8
+	valid := "recipe: default\n"
+	if err := os.WriteFile(filepath.Join(outputDir, testDefaultYml), []byte(valid), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWritePlaybook, err)
+	}
+	readResp := &resource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	r.Read(context.Background(), resource.ReadRequest{State: newState(t, schema, state)}, readResp)
+	if readResp.Diagnostics.HasError() || readResp.Diagnostics.WarningsCount() != 0 {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	var got migrationResourceModel
+	readResp.State.Get(context.Background(), &got)
+	if got.PlaybookContent.ValueString() != valid || !got.PlaybookContentBase64.IsNull() {
+		t.Fatalf("expected string playbook_content after Read, got %q and base64 %q", got.PlaybookContent.ValueString(), got.PlaybookContentBase64.ValueString())
+	}
+}
+
+func TestSousChefClientStoredContent(t *testing.T) {
+	content := []byte(strings.Repeat("x", 10))
+
+	tests := []struct {
+		name          string
+		client        *SousChefClient
+		wantTruncated bool
+	}{
+		{name: "nil client uses default", client: nil},
+		{name: "unset limit uses default", client: &SousChefClient{}},
+		{name: "content at limit", client: &SousChefClient{MaxContentBytes: 10}},
+		{name: "content over limit", client: &SousChefClient{MaxContentBytes: 9}, wantTruncated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored, truncated := tt.client.storedContent(content)
+			if truncated != tt.wantTruncated {
+				t.Fatalf("expected truncated %v, got %v", tt.wantTruncated, truncated)
+			}
+			if !truncated && stored != string(content) {
+				t.Fatalf("expected content stored in full, got %q", stored)
+			}
+			if truncated && !strings.Contains(stored, contentSHA256(content)) {
+				t.Fatalf("expected truncation notice with SHA-256, got %q", stored)
+			}
+		})
+	}
+}
+
+func TestMigrationResourceOutputPathNotWritable(t *testing.T) {
+	// The CLI path does not exist, so any CLI call would fail with a different error
+	r := &migrationResource{client: &SousChefClient{Path: filepath.Join(t.TempDir(), "missing-souschef")}}
+	schema := newResourceSchema(t, r)
+	plan := newPlan(t, schema, migrationResourceModel{
+		CookbookPath:      types.StringValue(testTmpCookbook),
+		OutputPath:        types.StringValue(readOnlyDir(t)),
+		RecipeName:        types.StringValue("default"),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	})
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for a read-only output path")
+	}
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Output path not writable" {
+		t.Fatalf("expected the preflight diagnostic before any CLI call, got %q", summary)
+	}
+}
+
+func TestMigrationResourceHashIDStrategy(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t), IDStrategy: idStrategyHash}}
+
+	// Two cookbooks sharing a base name at different paths
+	ids := make([]string, 0, 2)
+	cookbookPaths := make([]string, 0, 2)
+	for range 2 {
+		cookbookPath := filepath.Join(t.TempDir(), "nginx")
+		if err := os.MkdirAll(cookbookPath, 0755); err != nil {
+			t.Fatalf("failed to create cookbook: %v", err)
+		}
+		state := createMigration(t, r, migrationResourceModel{
+			CookbookPath:      types.StringValue(cookbookPath),
+			OutputPath:        types.StringValue(t.TempDir()),
+			RecipeName:        types.StringValue("default"),
+			ReferencedEnvVars: types.ListNull(types.StringType),
+			ModuleCounts:      types.MapNull(types.Int64Type),
+			VariableRenameMap: types.MapNull(types.StringType),
+			VariableMappings:  types.MapNull(types.StringType),
+			Outputs:           types.MapNull(types.StringType),
+		})
+		ids = append(ids, state.ID.ValueString())
+		cookbookPaths = append(cookbookPaths, cookbookPath)
+	}
+
+	if ids[0] == ids[1] {
+		t.Fatalf("expected distinct IDs for same-named cookbooks, both got %q", ids[0])
+	}
+	if !strings.HasPrefix(ids[0], "nginx-default-") {
+		t.Fatalf("expected hash ID to keep the name prefix, got %q", ids[0])
+	}
+
+	// Importing the first cookbook must yield the ID Create assigned
+	outputDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outputDir, testDefaultYml), []byte("recipe: default\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWritePlaybook, err)
+	}
+	schema := newResourceSchema(t, r)
+	resp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: cookbookPaths[0] + "|" + outputDir + "|default"}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+	var imported migrationResourceModel
+	if diags := resp.State.Get(context.Background(), &imported); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if imported.ID.ValueString() != ids[0] {
+		t.Fatalf("expected imported ID %q, got %q", ids[0], imported.ID.ValueString())
+	}
+}
+
+// newPolicyfileCookbook returns a cookbook whose recipes live in policy/recipes.
+func newPolicyfileCookbook(t *testing.T, recipes ...string) string {
+	t.Helper()
+
+	cookbookPath := filepath.Join(t.TempDir(), "web")
+	recipesDir := filepath.Join(cookbookPath, "policy", "recipes")
+	if err := os.MkdirAll(recipesDir, testDirPermissions); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+	for _, recipe := range recipes {
+		if err := os.WriteFile(filepath.Join(recipesDir, recipe+".rb"), []byte("package 'nginx'\n"), testFilePermissions); err != nil {
+			t.Fatalf(testFailedToWriteFile, err)
+		}
+	}
+	return cookbookPath
+}
+
+func TestMigrationResourceRecipesSubdir(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	cookbookPath := newPolicyfileCookbook(t, "web")
+
+	model := roleMigrationModel(t.TempDir(), types.StringNull())
+	model.CookbookPath = types.StringValue(cookbookPath)
+	model.RecipeName = types.StringValue("web")
+	model.RecipesSubdir = types.StringValue("policy/recipes")
+
+	state := createMigration(t, r, model)
+	if state.PlaybookContent.ValueString() != "recipe: web\n" {
+		t.Fatalf("unexpected playbook_content %q", state.PlaybookContent.ValueString())
+	}
+
+	// The subdirectory is passed to the CLI, which cannot find a missing recipe there
+	schema := newResourceSchema(t, r)
+	model.RecipeName = types.StringValue("db")
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, model)}, resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for a recipe missing from recipes_subdir")
+	}
+}
+
+func TestMigrationResourceValidateRecipesSubdir(t *testing.T) {
+	r := &migrationResource{}
+	schema := newResourceSchema(t, r)
+
+	tests := map[string]struct {
+		subdir    types.String
+		wantError bool
+	}{
+		"unset":         {subdir: types.StringNull()},
+		"nested":        {subdir: types.StringValue("policy/recipes")},
+		"dotted name":   {subdir: types.StringValue("recipes..old")},
+		"empty":         {subdir: types.StringValue(""), wantError: true},
+		"absolute":      {subdir: types.StringValue("/etc/recipes"), wantError: true},
+		"parent":        {subdir: types.StringValue("../shared/recipes"), wantError: true},
+		"inner parent":  {subdir: types.StringValue("policy/../../recipes"), wantError: true},
+		"windows style": {subdir: types.StringValue(`policy\..\..\recipes`), wantError: filepath.Separator == '\\'},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := newResourceConfig(t, schema, migrationResourceModel{
+				CookbookPath:      types.StringValue(t.TempDir()),
+				OutputPath:        types.StringValue(t.TempDir()),
+				RecipesSubdir:     tt.subdir,
+				ReferencedEnvVars: types.ListNull(types.StringType),
+				ModuleCounts:      types.MapNull(types.Int64Type),
+				VariableRenameMap: types.MapNull(types.StringType),
+				VariableMappings:  types.MapNull(types.StringType),
+				Outputs:           types.MapNull(types.StringType),
+			})
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Fatalf("expected error=%v, got %v", tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestMigrationResourceAnsibleVersion(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+
+	model := roleMigrationModel(t.TempDir(), types.StringNull())
+	model.AnsibleVersion = types.StringValue("2.15")
+	created := createMigration(t, r, model)
+	if created.PlaybookContent.ValueString() != "recipe: default\nansible_version: 2.15\n" || created.AnsibleVersion.ValueString() != "2.15" {
+		t.Fatalf("expected the CLI to be given --ansible-version 2.15, got %q", created.PlaybookContent.ValueString())
+	}
+
+	// Changing the version re-converts the recipe for the new target
+	model.AnsibleVersion = types.StringValue("9.1.0")
+	state := newState(t, schema, created)
+	updateResp := &resource.UpdateResponse{State: state}
+	r.Update(context.Background(), resource.UpdateRequest{Plan: newPlan(t, schema, model), State: state}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, updateResp.Diagnostics)
+	}
+	var updated migrationResourceModel
+	updateResp.State.Get(context.Background(), &updated)
+	if updated.PlaybookContent.ValueString() != "recipe: default\nansible_version: 9.1.0\n" || updated.AnsibleVersion.ValueString() != "9.1.0" {
+		t.Fatalf("expected a playbook for Ansible 9.1.0, got %q", updated.PlaybookContent.ValueString())
+	}
+
+	// Import records the version from the optional fifth ID part
+	importResp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: t.TempDir() + "|" + model.OutputPath.ValueString() + "|default||9.1.0"}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, importResp.Diagnostics)
+	}
+	var imported migrationResourceModel
+	importResp.State.Get(context.Background(), &imported)
+	if imported.AnsibleVersion.ValueString() != "9.1.0" {
+		t.Fatalf("expected imported ansible_version 9.1.0, got %v", imported.AnsibleVersion)
+	}
+
+	importResp = &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: t.TempDir() + "|" + model.OutputPath.ValueString() + "|default||latest"}, importResp)
+	if !importResp.Diagnostics.HasError() {
+		t.Fatal("expected error for an invalid ansible_version in the import ID")
+	}
+}
+
+func TestMigrationResourceValidateAnsibleVersion(t *testing.T) {
+	r := &migrationResource{}
+	schema := newResourceSchema(t, r)
+
+	tests := map[string]struct {
+		version   types.String
+		wantError bool
+	}{
+		"unset":       {version: types.StringNull()},
+		"major":       {version: types.StringValue("9")},
+		"minor":       {version: types.StringValue("2.15")},
+		"patch":       {version: types.StringValue("2.15.3")},
+		"prefixed":    {version: types.StringValue("v2.15"), wantError: true},
+		"too precise": {version: types.StringValue("2.15.3.1"), wantError: true},
+		"word":        {version: types.StringValue("latest"), wantError: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := newResourceConfig(t, schema, migrationResourceModel{
+				CookbookPath:      types.StringValue(t.TempDir()),
+				OutputPath:        types.StringValue(t.TempDir()),
+				AnsibleVersion:    tt.version,
+				ReferencedEnvVars: types.ListNull(types.StringType),
+				ModuleCounts:      types.MapNull(types.Int64Type),
+				VariableRenameMap: types.MapNull(types.StringType),
+				VariableMappings:  types.MapNull(types.StringType),
+				Outputs:           types.MapNull(types.StringType),
+			})
+			resp := &resource.ValidateConfigResponse{}
+			r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, resp)
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Fatalf("expected error=%v, got %v", tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestMigrationResourceReadMissingArtifactBehavior(t *testing.T) {
+	tests := map[string]struct {
+		behavior  string
+		wantError bool
+	}{
+		"unset":  {},
+		"remove": {behavior: missingArtifactRemove},
+		"error":  {behavior: missingArtifactError, wantError: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t), MissingArtifactBehavior: tt.behavior}}
+			schema := newResourceSchema(t, r)
+			outputDir := t.TempDir()
+			state := createMigration(t, r, roleMigrationModel(outputDir, types.StringNull()))
+
+			playbookPath := filepath.Join(outputDir, "default.yml")
+			if err := os.Remove(playbookPath); err != nil {
+				t.Fatalf("failed to remove playbook: %v", err)
+			}
+
+			current := newState(t, schema, state)
+			resp := &resource.ReadResponse{State: current}
+			r.Read(context.Background(), resource.ReadRequest{State: current}, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Fatalf("expected error %v, got %v", tt.wantError, resp.Diagnostics)
+			}
+			if tt.wantError {
+				if resp.State.Raw.IsNull() {
+					t.Fatal("expected the resource to stay in state")
+				}
+				if !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), playbookPath) {
+					t.Fatalf("expected the missing playbook in the diagnostic, got %v", resp.Diagnostics)
+				}
+				return
+			}
+			if !resp.State.Raw.IsNull() {
+				t.Fatal("expected the resource to be removed from state")
+			}
+		})
+	}
+}
+
+func TestFindPlaybookFile(t *testing.T) {
+	outputDir := t.TempDir()
+	if playbookPath, extension := findPlaybookFile(outputDir, "default", types.StringNull(), types.StringNull()); extension != playbookExtensionYML || playbookPath != filepath.Join(outputDir, testDefaultYml) {
+		t.Fatalf("expected the .yml path when no playbook exists, got %s (%s)", playbookPath, extension)
+	}
+	if _, extension := findPlaybookFile(outputDir, "default", types.StringValue(outputSyntaxJSON), types.StringNull()); extension != outputSyntaxJSON {
+		t.Fatalf("expected json extension, got %s", extension)
+	}
+
+	for _, name := range []string{testDefaultYml, "default.yaml"} {
+		if err := os.WriteFile(filepath.Join(outputDir, name), []byte("recipe: default\n"), testFilePermissions); err != nil {
+			t.Fatalf(testFailedToWritePlaybook, err)
+		}
+	}
+	if _, extension := findPlaybookFile(outputDir, "default", types.StringNull(), types.StringNull()); extension != playbookExtensionYML {
+		t.Fatalf("expected .yml to be preferred, got %s", extension)
+	}
+	if _, extension := findPlaybookFile(outputDir, "default", types.StringNull(), types.StringValue(playbookExtensionYAML)); extension != playbookExtensionYAML {
+		t.Fatalf("expected the recorded .yaml extension to be preferred, got %s", extension)
+	}
+
+	if err := os.Remove(filepath.Join(outputDir, testDefaultYml)); err != nil {
+		t.Fatalf("failed to remove playbook: %v", err)
+	}
+	if _, extension := findPlaybookFile(outputDir, "default", types.StringNull(), types.StringNull()); extension != playbookExtensionYAML {
+		t.Fatalf("expected the existing .yaml playbook, got %s", extension)
+	}
+}
+
+func TestMigrationResourceYAMLExtension(t *testing.T) {
+	t.Setenv("SOUSCHEF_TEST_YAML_EXT", "1")
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputDir := t.TempDir()
+
+	model := migrationResourceModel{
+		CookbookPath:      types.StringValue(testTmpCookbook),
+		OutputPath:        types.StringValue(outputDir),
+		RecipeName:        types.StringValue("default"),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	}
+	state := createMigration(t, r, model)
+	if state.OutputExtension.ValueString() != playbookExtensionYAML {
+		t.Fatalf("expected output_extension yaml, got %q", state.OutputExtension.ValueString())
+	}
+	if !strings.Contains(state.PlaybookContent.ValueString(), "recipe: default") {
+		t.Fatalf("unexpected playbook_content %q", state.PlaybookContent.ValueString())
+	}
+
+	// Read finds the .yaml playbook rather than removing the resource
+	playbookPath := filepath.Join(outputDir, "default.yaml")
+	if err := os.WriteFile(playbookPath, []byte("recipe: edited\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWritePlaybook, err)
+	}
+	readResp := &resource.ReadResponse{State: tfsdk.State{Schema: schema}}
+	r.Read(context.Background(), resource.ReadRequest{State: newState(t, schema, state)}, readResp)
+	if readResp.Diagnostics.HasError() || readResp.State.Raw.IsNull() {
+		t.Fatalf("expected Read to find the .yaml playbook, got %v", readResp.Diagnostics)
+	}
+	var refreshed migrationResourceModel
+	readResp.State.Get(context.Background(), &refreshed)
+	if refreshed.PlaybookContent.ValueString() != "recipe: edited\n" || refreshed.OutputExtension.ValueString() != playbookExtensionYAML {
+		t.Fatalf("unexpected refreshed state: %+v", refreshed)
+	}
+
+	// ImportState records the extension it found
+	importResp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: t.TempDir() + "|" + outputDir + "|default"}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, importResp.Diagnostics)
+	}
+	var imported migrationResourceModel
+	importResp.State.Get(context.Background(), &imported)
+	if imported.OutputExtension.ValueString() != playbookExtensionYAML || imported.PlaybookContent.ValueString() != "recipe: edited\n" {
+		t.Fatalf("unexpected imported state: %+v", imported)
+	}
+
+	// Delete removes the .yaml playbook
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+	}
+	if _, err := os.Stat(playbookPath); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be deleted, got %v", playbookPath, err)
+	}
+}
+
+func TestMigrationResourceSurfacesCLIWarnings(t *testing.T) {
+	t.Setenv("SOUSCHEF_TEST_WARN", "warning: ruby_block converted to a command task")
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, migrationResourceModel{
+		CookbookPath:      types.StringValue(testTmpCookbook),
+		OutputPath:        types.StringValue(t.TempDir()),
+		RecipeName:        types.StringValue("default"),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	})}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 || !strings.Contains(resp.Diagnostics.Warnings()[0].Detail(), "ruby_block converted to a command task") {
+		t.Fatalf("expected the CLI warning as a diagnostic, got %v", resp.Diagnostics)
+	}
+}
+
+func TestMigrationResourceCoalescesIdenticalCreates(t *testing.T) {
+	countFile := filepath.Join(t.TempDir(), "invocations")
+	t.Setenv("SOUSCHEF_TEST_COUNT", countFile)
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputDir := t.TempDir()
+
+	var wg sync.WaitGroup
+	resps := make([]*resource.CreateResponse, 2)
+	for i := range resps {
+		resps[i] = &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+		plan := newPlan(t, schema, migrationResourceModel{
+			CookbookPath:      types.StringValue(testTmpCookbook),
+			OutputPath:        types.StringValue(outputDir),
+			RecipeName:        types.StringValue("default"),
+			ReferencedEnvVars: types.ListNull(types.StringType),
+			ModuleCounts:      types.MapNull(types.Int64Type),
+			VariableRenameMap: types.MapNull(types.StringType),
+			VariableMappings:  types.MapNull(types.StringType),
+			Outputs:           types.MapNull(types.StringType),
+		})
+		wg.Add(1)
+		go func(resp *resource.CreateResponse) {
+			defer wg.Done()
+			r.Create(context.Background(), resource.CreateRequest{Plan: plan}, resp)
+		}(resps[i])
+	}
+	wg.Wait()
+
+	for _, resp := range resps {
+		if resp.Diagnostics.HasError() {
+			t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+		}
+	}
+	invocations, err := os.ReadFile(countFile)
+	if err != nil {
+		t.Fatalf("failed to read invocation count: %v", err)
+	}
+	if got := strings.Count(string(invocations), "convert-recipe\n"); got != 1 {
+		t.Fatalf("expected a single convert-recipe invocation, got %d:\n%s", got, invocations)
+	}
+}
+
+func TestMigrationResourceWasChanged(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputDir := t.TempDir()
+
+	create := func() migrationResourceModel {
+		t.Helper()
+		resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+		r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, migrationResourceModel{
+			CookbookPath:      types.StringValue(testTmpCookbook),
+			OutputPath:        types.StringValue(outputDir),
+			RecipeName:        types.StringValue("default"),
+			ReferencedEnvVars: types.ListNull(types.StringType),
+			ModuleCounts:      types.MapNull(types.Int64Type),
+			VariableRenameMap: types.MapNull(types.StringType),
+			VariableMappings:  types.MapNull(types.StringType),
+			Outputs:           types.MapNull(types.StringType),
+		})}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+		}
+		var state migrationResourceModel
+		resp.State.Get(context.Background(), &state)
+		return state
+	}
+
+	if state := create(); !state.WasChanged.ValueBool() {
+		t.Fatal("expected was_changed for a new playbook")
+	}
+	if state := create(); state.WasChanged.ValueBool() {
+		t.Fatal("expected was_changed to be false when the conversion reproduces the existing playbook")
+	}
+
+	if err := os.WriteFile(filepath.Join(outputDir, "default.yml"), []byte("- hosts: stale\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	if state := create(); !state.WasChanged.ValueBool() {
+		t.Fatal("expected was_changed when the conversion replaces different content")
+	}
+}
+
+func TestMigrationResourceDryRun(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t), DryRun: true}}
+	schema := newResourceSchema(t, r)
+	outputDir := filepath.Join(t.TempDir(), "out")
+	plan := migrationResourceModel{
+		CookbookPath:      types.StringValue(testTmpCookbook),
+		OutputPath:        types.StringValue(outputDir),
+		RecipeName:        types.StringValue("default"),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	}
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, plan)}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+	var state migrationResourceModel
+	createResp.State.Get(context.Background(), &state)
+	if state.PlaybookContent.ValueString() != "recipe: default\n" || !state.WasChanged.ValueBool() {
+		t.Fatalf("expected playbook_content from the CLI's stdout, got %+v", state)
+	}
+
+	// Update converts the new recipe without removing anything
+	plan.RecipeName = types.StringValue("web")
+	updateResp := &resource.UpdateResponse{State: tfsdk.State{Schema: schema}}
+	r.Update(context.Background(), resource.UpdateRequest{Plan: newPlan(t, schema, plan), State: createResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, updateResp.Diagnostics)
+	}
+	updateResp.State.Get(context.Background(), &state)
+	if state.PlaybookContent.ValueString() != "recipe: web\n" {
+		t.Fatalf("expected the updated playbook_content, got %q", state.PlaybookContent.ValueString())
+	}
+	if _, err := os.Stat(outputDir); !os.IsNotExist(err) {
+		t.Fatalf("expected nothing written to %s in dry-run mode, got %v", outputDir, err)
+	}
+
+	// Read keeps the resource although its playbook was never written
+	readResp := &resource.ReadResponse{State: updateResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: updateResp.State}, readResp)
+	if readResp.Diagnostics.HasError() || readResp.State.Raw.IsNull() {
+		t.Fatalf("expected Read to keep the resource, got %v", readResp.Diagnostics)
+	}
+
+	// Delete leaves an existing playbook alone
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+	playbookPath := filepath.Join(outputDir, "web.yml")
+	if err := os.WriteFile(playbookPath, []byte("recipe: web\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: updateResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+	}
+	if _, err := os.Stat(playbookPath); err != nil {
+		t.Fatalf("expected Delete to be a no-op in dry-run mode, got %v", err)
+	}
+}
+
+func TestMigrationResourceDryRunIgnoresStderr(t *testing.T) {
+	t.Setenv("SOUSCHEF_TEST_WARN", "warning: ruby_block converted to a command task")
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t), DryRun: true}}
+	schema := newResourceSchema(t, r)
+
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, migrationResourceModel{
+		CookbookPath:      types.StringValue(testTmpCookbook),
+		OutputPath:        types.StringValue(filepath.Join(t.TempDir(), "out")),
+		RecipeName:        types.StringValue("default"),
+		CaptureOutput:     types.BoolValue(true),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	})}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var state migrationResourceModel
+	resp.State.Get(context.Background(), &state)
+	if state.PlaybookContent.ValueString() != "recipe: default\n" || state.ContentSHA256.ValueString() != contentSHA256([]byte("recipe: default\n")) {
+		t.Fatalf("expected playbook_content and its hash from stdout only, got %+v", state)
+	}
+	if !strings.Contains(state.ConversionLog.ValueString(), "ruby_block converted to a command task") {
+		t.Fatalf("expected conversion_log to keep the CLI's stderr, got %q", state.ConversionLog.ValueString())
+	}
+}
+
+func TestMigrationResourceAddHeader(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	cookbookDir := filepath.Join(t.TempDir(), "nginx")
+	if err := os.Mkdir(cookbookDir, testDirPermissions); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+	outputDir := t.TempDir()
+	plan := newPlan(t, schema, migrationResourceModel{
+		CookbookPath:      types.StringValue(cookbookDir),
+		OutputPath:        types.StringValue(outputDir),
+		RecipeName:        types.StringValue("default"),
+		AddHeader:         types.BoolValue(true),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	})
+	header := "# Generated by SousChef from cookbook nginx recipe default on " + time.Now().UTC().Format(headerDateFormat) + "\n"
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+	state := createResp.State
+
+	// Repeated applies keep exactly one header
+	for i := 0; i < 2; i++ {
+		updateResp := &resource.UpdateResponse{State: state}
+		r.Update(context.Background(), resource.UpdateRequest{Plan: plan, State: state}, updateResp)
+		if updateResp.Diagnostics.HasError() {
+			t.Fatalf(testUnexpectedDiagnostics, updateResp.Diagnostics)
+		}
+		state = updateResp.State
+	}
+
+	content, err := os.ReadFile(filepath.Join(outputDir, testDefaultYml))
+	if err != nil {
+		t.Fatalf("failed to read playbook: %v", err)
+	}
+	if !strings.HasPrefix(string(content), header) || strings.Count(string(content), "# Generated by SousChef") != 1 {
+		t.Fatalf("expected a single header, got %q", content)
+	}
+	var model migrationResourceModel
+	state.Get(context.Background(), &model)
+	if model.PlaybookContent.ValueString() != string(content) || model.WasChanged.ValueBool() {
+		t.Fatalf("expected state to hold the playbook with its header and was_changed false, got %q, %v", model.PlaybookContent.ValueString(), model.WasChanged)
+	}
+}
+
+func TestMigrationResourceOutputTargets(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputDir := t.TempDir()
+	flatDir := filepath.Join(outputDir, "flat")
+	roleDir := filepath.Join(outputDir, "dist")
+	plan := newPlan(t, schema, migrationResourceModel{
+		CookbookPath: types.StringValue(testTmpCookbook),
+		OutputPath:   types.StringValue(filepath.Join(outputDir, "primary")),
+		RecipeName:   types.StringValue("default"),
+		OutputTargets: []migrationOutputTargetModel{
+			{OutputPath: types.StringValue(flatDir)},
+			{Layout: types.StringValue(outputLayoutRole), OutputPath: types.StringValue(roleDir)},
+		},
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	})
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+
+	generated := []string{
+		filepath.Join(flatDir, testDefaultYml),
+		filepath.Join(roleDir, testDefaultYml),
+		roleTasksPath(rolePathFor(roleDir, "default")),
+	}
+	for _, filePath := range generated {
+		if _, err := os.Stat(filePath); err != nil {
+			t.Fatalf("expected %s to be generated: %v", filePath, err)
+		}
+	}
+	if _, err := os.Stat(rolePathFor(flatDir, "default")); !os.IsNotExist(err) {
+		t.Fatalf("expected no role in the flat target, got %v", err)
+	}
+
+	var state migrationResourceModel
+	createResp.State.Get(context.Background(), &state)
+	outputs := make(map[string]string)
+	state.Outputs.ElementsAs(context.Background(), &outputs, false)
+	if len(outputs) != 2 || outputs[flatDir] == "" || outputs[roleDir] == "" {
+		t.Fatalf("expected outputs for both targets, got %v", outputs)
+	}
+	// Each target is converted in its own layout
+	if outputs[flatDir] != state.PlaybookContent.ValueString() {
+		t.Fatalf("expected the flat target to hold the playbook %q, got %q", state.PlaybookContent.ValueString(), outputs[flatDir])
+	}
+	verifyRoleTasks(t, rolePathFor(roleDir, "default"), outputs[roleDir])
+
+	// Read keeps the outputs of targets still on disk
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	readResp.State.Get(context.Background(), &state)
+	if len(state.Outputs.Elements()) != 2 {
+		t.Fatalf("expected outputs to survive read, got %v", state.Outputs)
+	}
+
+	deleteResp := &resource.DeleteResponse{State: readResp.State}
+	r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+	}
+	for _, filePath := range generated {
+		if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be deleted, got %v", filePath, err)
+		}
+	}
+}
+
+func TestMigrationResourceOutputFileMode(t *testing.T) {
+	r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputDir := t.TempDir()
+	plan := newPlan(t, schema, migrationResourceModel{
+		CookbookPath:      types.StringValue(testTmpCookbook),
+		OutputPath:        types.StringValue(outputDir),
+		RecipeName:        types.StringValue("default"),
+		OutputFileMode:    types.StringValue("0600"),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	})
+	playbookPath := filepath.Join(outputDir, testDefaultYml)
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+	var state migrationResourceModel
+	createResp.State.Get(context.Background(), &state)
+	info, err := os.Stat(playbookPath)
+	if err != nil {
+		t.Fatalf("expected playbook: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 || state.AppliedFileMode.ValueString() != "0600" {
+		t.Fatalf("expected playbook mode 0600, got %o (applied_file_mode %q)", info.Mode().Perm(), state.AppliedFileMode.ValueString())
+	}
+
+	// Read reports a mode changed outside Terraform as drift
+	if err := os.Chmod(playbookPath, 0o644); err != nil {
+		t.Fatalf("failed to change mode: %v", err)
+	}
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	readResp.State.Get(context.Background(), &state)
+	if state.OutputFileMode.ValueString() != "0644" || state.AppliedFileMode.ValueString() != "0644" {
+		t.Fatalf("expected drifted mode 0644 in state, got output_file_mode %q and applied_file_mode %q", state.OutputFileMode.ValueString(), state.AppliedFileMode.ValueString())
+	}
+
+	// Update applies the configured mode again
+	updateResp := &resource.UpdateResponse{State: readResp.State}
+	r.Update(context.Background(), resource.UpdateRequest{Plan: plan, State: readResp.State}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, updateResp.Diagnostics)
+	}
+	if info, _ := os.Stat(playbookPath); info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected Update to restore mode 0600, got %o", info.Mode().Perm())
+	}
+}
+
+func TestMigrationResourceSymlinkedCookbookName(t *testing.T) {
+	cookbookDir := filepath.Join(t.TempDir(), "postgresql")
+	if err := os.Mkdir(cookbookDir, 0o755); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+	link := filepath.Join(t.TempDir(), "current")
+	if err := os.Symlink(cookbookDir, link); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+
+	for keepSymlinks, wantName := range map[bool]string{false: "postgresql", true: "current"} {
+		r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t), KeepSymlinks: keepSymlinks}}
+		schema := newResourceSchema(t, r)
+		resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+		r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, migrationResourceModel{
+			CookbookPath:      types.StringValue(link),
+			OutputPath:        types.StringValue(t.TempDir()),
+			RecipeName:        types.StringValue("default"),
+			ReferencedEnvVars: types.ListNull(types.StringType),
+			ModuleCounts:      types.MapNull(types.Int64Type),
+			VariableRenameMap: types.MapNull(types.StringType),
+			VariableMappings:  types.MapNull(types.StringType),
+			Outputs:           types.MapNull(types.StringType),
+		})}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+		}
+		var state migrationResourceModel
+		resp.State.Get(context.Background(), &state)
+		if state.CookbookName.ValueString() != wantName || state.ID.ValueString() != wantName+"-default" || state.CookbookPath.ValueString() != link {
+			t.Fatalf("KeepSymlinks %t: expected cookbook_name %q with cookbook_path kept as configured, got %+v", keepSymlinks, wantName, state)
+		}
+	}
+}
+
+func TestMigrationResourceNormalizesResolvedOutputPath(t *testing.T) {
+	workDir := t.TempDir()
+	t.Chdir(workDir)
+	absOutput := filepath.Join(workDir, "out")
+
+	for _, outputPath := range []string{"out", "./web/../out", absOutput} {
+		r := &migrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+		schema := newResourceSchema(t, r)
+		createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+		r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, migrationResourceModel{
+			CookbookPath:      types.StringValue(testTmpCookbook),
+			OutputPath:        types.StringValue(outputPath),
+			RecipeName:        types.StringValue("default"),
+			ReferencedEnvVars: types.ListNull(types.StringType),
+			ModuleCounts:      types.MapNull(types.Int64Type),
+			VariableRenameMap: types.MapNull(types.StringType),
+			VariableMappings:  types.MapNull(types.StringType),
+			Outputs:           types.MapNull(types.StringType),
+		})}, createResp)
+		if createResp.Diagnostics.HasError() {
+			t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+		}
+		var state migrationResourceModel
+		createResp.State.Get(context.Background(), &state)
+		if state.ResolvedOutputPath.ValueString() != absOutput || state.OutputPath.ValueString() != outputPath {
+			t.Fatalf("output_path %q: expected resolved_output_path %q and output_path kept as configured, got %q and %q",
+				outputPath, absOutput, state.ResolvedOutputPath.ValueString(), state.OutputPath.ValueString())
+		}
+
+		// Refresh from another working directory still finds the playbook
+		t.Chdir(t.TempDir())
+		readResp := &resource.ReadResponse{State: createResp.State}
+		r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+		if readResp.Diagnostics.HasError() || readResp.State.Raw.IsNull() {
+			t.Fatalf("output_path %q: expected Read to find the playbook, got %v", outputPath, readResp.Diagnostics)
+		}
+		t.Chdir(workDir)
+	}
+
+	// Import resolves a relative output_path the same way
+	cookbookDir := t.TempDir()
+	r := &migrationResource{client: &SousChefClient{}}
+	schema := newResourceSchema(t, r)
+	importResp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: cookbookDir + "|out|default"}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, importResp.Diagnostics)
+	}
+	var imported migrationResourceModel
+	importResp.State.Get(context.Background(), &imported)
+	if imported.ResolvedOutputPath.ValueString() != absOutput {
+		t.Fatalf("expected imported resolved_output_path %q, got %q", absOutput, imported.ResolvedOutputPath.ValueString())
+	}
+
+	// normalize_paths = false keeps the relative path
+	r.client.KeepRelativePaths = true
+	importResp = &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: cookbookDir + "|out|default"}, importResp)
+	importResp.State.Get(context.Background(), &imported)
+	if importResp.Diagnostics.HasError() || imported.ResolvedOutputPath.ValueString() != "out" {
+		t.Fatalf("expected relative resolved_output_path with normalize_paths false, got %q, %v", imported.ResolvedOutputPath.ValueString(), importResp.Diagnostics)
+	}
+}
@@ -0,0 +1,363 @@
+// Package provider implements the SousChef Terraform provider resources
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	errReadingNodeJSON = "Error reading node JSON"
+	errReadingHostVars = "Error reading host_vars"
+	nodeIDFormat       = "node-%s"
+)
+
+// nodeName returns the name of the Chef node in content, falling back to the
+// file name of nodePath without its .json extension when the node is unnamed.
+func nodeName(nodePath string, content []byte) (string, error) {
+	var node struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(content, &node); err != nil {
+		return "", err
+	}
+	if node.Name != "" {
+		return node.Name, nil
+	}
+	return strings.TrimSuffix(filepath.Base(nodePath), ".json"), nil
+}
+
+// readNodeName reads the node JSON at nodePath and returns its name.
+func readNodeName(nodePath string, diagnostics *diag.Diagnostics) string {
+	content, err := osReadFile(nodePath)
+	if err != nil {
+		diagnostics.AddError(
+			errReadingNodeJSON,
+			fmt.Sprintf("Could not read file %s: %s", nodePath, err),
+		)
+		return ""
+	}
+	name, err := nodeName(nodePath, content)
+	if err != nil {
+		diagnostics.AddError(
+			errReadingNodeJSON,
+			fmt.Sprintf("Could not parse node JSON %s: %s", nodePath, err),
+		)
+		return ""
+	}
+	return name
+}
+
+// hostVarsPath returns the host_vars file generated for nodeName.
+func hostVarsPath(outputPath, nodeName string) string {
+	return filepath.Join(outputPath, nodeName+".yml")
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource                   = &nodeMigrationResource{}
+	_ resource.ResourceWithImportState    = &nodeMigrationResource{}
+	_ resource.ResourceWithValidateConfig = &nodeMigrationResource{}
+)
+
+// NewNodeMigrationResource creates a new Chef node migration resource
+func NewNodeMigrationResource() resource.Resource {
+	return &nodeMigrationResource{}
+}
+
+// nodeMigrationResource is the resource implementation
+type nodeMigrationResource struct {
+	client *SousChefClient
+}
+
+// nodeMigrationResourceModel describes the resource data model
+type nodeMigrationResourceModel struct {
+	ID                       types.String `tfsdk:"id"`
+	NodePath                 types.String `tfsdk:"node_path"`
+	OutputPath               types.String `tfsdk:"output_path"`
+	CreateOutputDir          types.Bool   `tfsdk:"create_output_dir"`
+	PruneEmptyDir            types.Bool   `tfsdk:"prune_empty_dir"`
+	RetainOnDelete           types.Bool   `tfsdk:"retain_on_delete"`
+	ResolvedOutputPath       types.String `tfsdk:"resolved_output_path"`
+	NodeName                 types.String `tfsdk:"node_name"`
+	HostVarsContent          types.String `tfsdk:"host_vars_content"`
+	HostVarsContentSensitive types.String `tfsdk:"host_vars_content_sensitive"`
+	OutputFileMode           types.String `tfsdk:"output_file_mode"`
+	AppliedFileMode          types.String `tfsdk:"applied_file_mode"`
+}
+
+// Metadata returns the resource type name
+func (r *nodeMigrationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_node_migration"
+}
+
+// Schema defines the schema for the resource
+func (r *nodeMigrationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages conversion of a Chef node object (`nodes/*.json`) to an Ansible `host_vars` file.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier for the node migration",
+			},
+			"node_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the Chef node JSON file",
+			},
+			"output_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Directory where `<node_name>.yml` will be written, typically an inventory's `host_vars` directory",
+			},
+			"create_output_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Create `output_path` when it does not exist (default: true). When false the directory must already exist, e.g. when it is pre-created with specific ownership and permissions",
+			},
+			"prune_empty_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove the output directory on destroy when no other files remain in it (default: false)",
+			},
+			"retain_on_delete": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Leave the generated files in place on destroy and only remove the resource from state, e.g. once they are committed to a repository (default: false)",
+			},
+			"output_file_mode": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Octal permission mode, e.g. `0600`, the host_vars file is changed to after conversion, for node attributes that carry secrets. By default the file keeps the mode the SousChef CLI creates it with",
+			},
+			"applied_file_mode": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Mode of the host_vars file as four octal digits, checked on refresh; null when `output_file_mode` is unset",
+			},
+			"resolved_output_path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Directory the host_vars file is written to: `output_path` joined onto the provider `output_root` when `output_path` is relative",
+			},
+			"node_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the node: its `name` field, or the file name without `.json` when unset",
+			},
+			"host_vars_content": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Generated `host_vars` content",
+			},
+			"host_vars_content_sensitive": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Copy of `host_vars_content` marked sensitive so it is redacted from plan output. Reference this instead of `host_vars_content` when node attributes may contain secrets",
+			},
+		},
+	}
+}
+
+// ValidateConfig checks output_file_mode is an octal permission mode
+func (r *nodeMigrationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateOutputFileMode(ctx, req.Config, &resp.Diagnostics)
+}
+
+// Configure adds the provider configured client to the resource
+func (r *nodeMigrationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = configureResource(req, resp)
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *nodeMigrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan nodeMigrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create output directory
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
+		return
+	}
+	existing := snapshotOutputs(r.client.resolveOutputPath(plan.OutputPath.ValueString()))
+
+	// Execute conversion and set state
+	r.executeNodeConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Don't leave an untracked host_vars file behind if the apply was interrupted
+	if cleanupIfCanceled(ctx, &resp.Diagnostics, existing, hostVarsPath(plan.ResolvedOutputPath.ValueString(), plan.NodeName.ValueString())) {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *nodeMigrationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state nodeMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filePath := hostVarsPath(stateOutputPath(state.ResolvedOutputPath, state.OutputPath), state.NodeName.ValueString())
+
+	// Check if file exists and read content
+	if !readFileAndSetState(
+		ctx,
+		filePath,
+		"host_vars_content",
+		func(content string) {
+			state.HostVarsContent = types.StringValue(content)
+			state.HostVarsContentSensitive = state.HostVarsContent
+		},
+		errReadingHostVars,
+		&resp.Diagnostics,
+		r.client.missingArtifactHandler("host_vars file "+filePath, &resp.State, &resp.Diagnostics),
+	) {
+		return
+	}
+	refreshOutputFileMode(&state.OutputFileMode, &state.AppliedFileMode, &resp.Diagnostics, filePath)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *nodeMigrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state nodeMigrationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check the output directory, creating it unless create_output_dir is false
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
+		return
+	}
+
+	// Execute conversion and set state
+	r.executeNodeConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remove the previous host_vars file when the node was renamed or moved
+	previousPath := hostVarsPath(stateOutputPath(state.ResolvedOutputPath, state.OutputPath), state.NodeName.ValueString())
+	if previousPath != hostVarsPath(plan.ResolvedOutputPath.ValueString(), plan.NodeName.ValueString()) && !r.client.isDryRun() {
+		deleteGeneratedFile(previousPath, "previous host_vars file", &resp.Diagnostics)
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *nodeMigrationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state nodeMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	if r.client.retainGeneratedFiles(state.RetainOnDelete, outputPath, &resp.Diagnostics) {
+		return
+	}
+	deleteGeneratedFile(hostVarsPath(outputPath, state.NodeName.ValueString()), "host_vars file", &resp.Diagnostics)
+	pruneEmptyDir(state.PruneEmptyDir, outputPath, &resp.Diagnostics)
+}
+
+// executeNodeConversion is a helper that encapsulates the common logic for Create and Update.
+// It executes the node conversion, reads the output, and updates the model state.
+func (r *nodeMigrationResource) executeNodeConversion(ctx context.Context, model *nodeMigrationResourceModel, diagnostics *diag.Diagnostics) {
+	nodePath := model.NodePath.ValueString()
+	outputPath := r.client.resolveOutputPath(model.OutputPath.ValueString())
+
+	name := readNodeName(nodePath, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+
+	id := r.client.resourceID(fmt.Sprintf(nodeIDFormat, name), nodePath)
+	ctx = withReportResourceID(ctx, id)
+
+	// Call souschef CLI to convert the node
+	args := []string{"convert-node", "--node-path", nodePath, "--output-path", outputPath}
+	content, ok := executeConversion(ctx, r.client, args, hostVarsPath(outputPath, name), errReadingHostVars, diagnostics)
+	if !ok {
+		return
+	}
+
+	// Set state
+	model.ID = types.StringValue(id)
+	model.ResolvedOutputPath = types.StringValue(outputPath)
+	model.NodeName = types.StringValue(name)
+	model.HostVarsContent = types.StringValue(content)
+	model.HostVarsContentSensitive = model.HostVarsContent
+	model.AppliedFileMode = r.client.applyOutputFileMode(model.OutputFileMode, diagnostics, hostVarsPath(outputPath, name))
+}
+
+// ImportState imports an existing resource into Terraform
+func (r *nodeMigrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format: node_path|output_path
+	parts, err := importIDParts(req.ID, "node_path", "output_path")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID must be in format: node_path|output_path",
+		)
+		return
+	}
+
+	nodePath := parts[0]
+	configuredOutputPath := parts[1]
+	outputPath := r.client.resolveOutputPath(configuredOutputPath)
+
+	// Validate that the node JSON exists
+	if !checkFileExists(nodePath, "Node JSON", &resp.Diagnostics) {
+		return
+	}
+	name := readNodeName(nodePath, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check if the host_vars file exists
+	filePath := hostVarsPath(outputPath, name)
+	if !checkFileExists(filePath, "host_vars file", &resp.Diagnostics) {
+		return
+	}
+
+	// Read host_vars content
+	content := readGeneratedFile(filePath, errReadingHostVars, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("node_path"), nodePath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_path"), configuredOutputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resolved_output_path"), outputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("node_name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("host_vars_content"), content)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("host_vars_content_sensitive"), content)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), r.client.resourceID(fmt.Sprintf(nodeIDFormat, name), nodePath))...)
+}
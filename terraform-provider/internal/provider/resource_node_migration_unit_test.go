@@ -0,0 +1,159 @@
+// Package provider contains unit tests for the Chef node migration resource.
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testNodePath returns the path of the sample node JSON fixture.
+func testNodePath() string {
+	return filepath.Join(getFixturePath("nodes"), "web01.json")
+}
+
+func TestNodeName(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		want    string
+		wantErr bool
+	}{
+		"named":   {content: `{"name": "db01"}`, want: "db01"},
+		"unnamed": {content: `{"run_list": []}`, want: "web01"},
+		"invalid": {content: `{"name":`, wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := nodeName("/chef/nodes/web01.json", []byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNodeMigrationLifecycle(t *testing.T) {
+	r := &nodeMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	nodePath := testNodePath()
+	outputDir := filepath.Join(t.TempDir(), "host_vars")
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, nodeMigrationResourceModel{
+		NodePath:      types.StringValue(nodePath),
+		OutputPath:    types.StringValue(outputDir),
+		PruneEmptyDir: types.BoolValue(true),
+	})}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+
+	var state nodeMigrationResourceModel
+	if diags := createResp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.ID.ValueString() != "node-web01" || state.NodeName.ValueString() != "web01" {
+		t.Fatalf("unexpected id %q and node_name %q", state.ID.ValueString(), state.NodeName.ValueString())
+	}
+	if !strings.Contains(state.HostVarsContent.ValueString(), "nginx_port: 8080") {
+		t.Fatalf("unexpected host_vars_content %q", state.HostVarsContent.ValueString())
+	}
+	if state.HostVarsContentSensitive != state.HostVarsContent {
+		t.Fatal("expected host_vars_content_sensitive to mirror host_vars_content")
+	}
+
+	// Read picks up edits to the generated file
+	hostVarsFile := filepath.Join(outputDir, "web01.yml")
+	if err := os.WriteFile(hostVarsFile, []byte("nginx_port: 9090\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	var refreshed nodeMigrationResourceModel
+	readResp.State.Get(context.Background(), &refreshed)
+	if refreshed.HostVarsContent.ValueString() != "nginx_port: 9090\n" || refreshed.HostVarsContentSensitive != refreshed.HostVarsContent {
+		t.Fatalf("expected Read to refresh both content attributes, got %+v", refreshed)
+	}
+
+	// ImportState reconstructs the same resource
+	assertImportMatchesRead(t, r, nodePath+"|"+outputDir)
+
+	// Delete removes the host_vars file, after which Read drops the resource
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+	}
+	if _, err := os.Stat(outputDir); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be pruned, got %v", outputDir, err)
+	}
+
+	readResp = &resource.ReadResponse{State: readResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: readResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Fatal("expected resource to be removed when the host_vars file is missing")
+	}
+}
+
+func TestNodeMigrationCreateErrors(t *testing.T) {
+	r := &nodeMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+
+	invalidNode := filepath.Join(t.TempDir(), "broken.json")
+	if err := os.WriteFile(invalidNode, []byte("{"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+
+	tests := map[string]struct {
+		nodePath string
+		failCLI  bool
+	}{
+		"missing node file": {nodePath: filepath.Join(t.TempDir(), "web01.json")},
+		"invalid node JSON": {nodePath: invalidNode},
+		"CLI failure":       {nodePath: testNodePath(), failCLI: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tt.failCLI {
+				t.Setenv("SOUSCHEF_TEST_FAIL", "convert-node")
+			}
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+			r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, nodeMigrationResourceModel{
+				NodePath:   types.StringValue(tt.nodePath),
+				OutputPath: types.StringValue(t.TempDir()),
+			})}, resp)
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestNodeMigrationImportStateErrors(t *testing.T) {
+	r := &nodeMigrationResource{}
+	schema := newResourceSchema(t, r)
+	nodePath := testNodePath()
+
+	for _, id := range []string{nodePath, filepath.Join(t.TempDir(), "web01.json") + "|" + t.TempDir(), nodePath + "|" + t.TempDir()} {
+		resp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+		r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, resp)
+		if !resp.Diagnostics.HasError() {
+			t.Fatalf("expected error importing %q", id)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+// Package provider implements the SousChef Terraform provider resources
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	ohaiFactExtension  = ".fact"
+	ohaiIDFormat       = "ohai-%s"
+	errReadingOhaiFact = "Error reading converted Ohai fact"
+)
+
+// ohaiPluginName returns the plugin name used for the fact file and the
+// resource ID, e.g. "nginx" for nginx.rb.
+func ohaiPluginName(pluginPath string) string {
+	return strings.TrimSuffix(filepath.Base(pluginPath), filepath.Ext(pluginPath))
+}
+
+// ohaiFactFilename returns the custom fact script the CLI writes for
+// pluginPath, e.g. nginx.fact, ready to install in /etc/ansible/facts.d.
+func ohaiFactFilename(pluginPath string) string {
+	return ohaiPluginName(pluginPath) + ohaiFactExtension
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource                = &ohaiMigrationResource{}
+	_ resource.ResourceWithImportState = &ohaiMigrationResource{}
+)
+
+// NewOhaiMigrationResource creates a new Ohai migration resource
+func NewOhaiMigrationResource() resource.Resource {
+	return &ohaiMigrationResource{}
+}
+
+// ohaiMigrationResource is the resource implementation
+type ohaiMigrationResource struct {
+	client *SousChefClient
+}
+
+// ohaiMigrationResourceModel describes the resource data model
+type ohaiMigrationResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	PluginPath         types.String `tfsdk:"plugin_path"`
+	OutputPath         types.String `tfsdk:"output_path"`
+	CreateOutputDir    types.Bool   `tfsdk:"create_output_dir"`
+	PruneEmptyDir      types.Bool   `tfsdk:"prune_empty_dir"`
+	RetainOnDelete     types.Bool   `tfsdk:"retain_on_delete"`
+	ResolvedOutputPath types.String `tfsdk:"resolved_output_path"`
+	FactContent        types.String `tfsdk:"fact_content"`
+}
+
+// Metadata returns the resource type name
+func (r *ohaiMigrationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ohai_migration"
+}
+
+// Schema defines the schema for the resource
+func (r *ohaiMigrationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages conversion of a custom Ohai plugin to an Ansible custom fact script.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier for the Ohai migration",
+			},
+			"plugin_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the Ohai plugin, e.g. `files/default/plugins/nginx.rb`",
+			},
+			"output_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Directory where the fact script `<plugin>.fact` will be written",
+			},
+			"create_output_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Create `output_path` when it does not exist (default: true). When false the directory must already exist, e.g. when it is pre-created with specific ownership and permissions",
+			},
+			"prune_empty_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove the output directory on destroy when no other files remain in it (default: false)",
+			},
+			"retain_on_delete": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Leave the generated files in place on destroy and only remove the resource from state, e.g. once they are committed to a repository (default: false)",
+			},
+			"resolved_output_path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Directory the fact script is written to: `output_path` joined onto the provider `output_root` when `output_path` is relative",
+			},
+			"fact_content": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Generated fact script content",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *ohaiMigrationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = configureResource(req, resp)
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *ohaiMigrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ohaiMigrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create output directory
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
+		return
+	}
+	existing := snapshotOutputs(r.client.resolveOutputPath(plan.OutputPath.ValueString()))
+
+	// Execute conversion and set state
+	r.executeOhaiConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Don't leave an untracked fact script behind if the apply was interrupted
+	factPath := filepath.Join(plan.ResolvedOutputPath.ValueString(), ohaiFactFilename(plan.PluginPath.ValueString()))
+	if cleanupIfCanceled(ctx, &resp.Diagnostics, existing, factPath) {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *ohaiMigrationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ohaiMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	factPath := filepath.Join(stateOutputPath(state.ResolvedOutputPath, state.OutputPath), ohaiFactFilename(state.PluginPath.ValueString()))
+
+	// Check if file exists and read content
+	if !readFileAndSetState(
+		ctx,
+		factPath,
+		"fact_content",
+		func(content string) { state.FactContent = types.StringValue(content) },
+		errReadingOhaiFact,
+		&resp.Diagnostics,
+		r.client.missingArtifactHandler("converted Ohai fact "+factPath, &resp.State, &resp.Diagnostics),
+	) {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *ohaiMigrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ohaiMigrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check the output directory, creating it unless create_output_dir is false
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
+		return
+	}
+
+	// Execute conversion and set state
+	r.executeOhaiConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *ohaiMigrationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ohaiMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	if r.client.retainGeneratedFiles(state.RetainOnDelete, outputPath, &resp.Diagnostics) {
+		return
+	}
+	deleteGeneratedFile(filepath.Join(outputPath, ohaiFactFilename(state.PluginPath.ValueString())), "converted Ohai fact", &resp.Diagnostics)
+	pruneEmptyDir(state.PruneEmptyDir, outputPath, &resp.Diagnostics)
+}
+
+// executeOhaiConversion is a helper that encapsulates the common logic for Create and Update.
+// It executes the Ohai plugin conversion, reads the output, and updates the model state.
+func (r *ohaiMigrationResource) executeOhaiConversion(ctx context.Context, model *ohaiMigrationResourceModel, diagnostics *diag.Diagnostics) {
+	pluginPath := model.PluginPath.ValueString()
+	outputPath := r.client.resolveOutputPath(model.OutputPath.ValueString())
+	id := r.client.resourceID(fmt.Sprintf(ohaiIDFormat, ohaiPluginName(pluginPath)), pluginPath)
+	ctx = withReportResourceID(ctx, id)
+
+	if !checkFileExists(pluginPath, "Ohai plugin", diagnostics) {
+		return
+	}
+
+	// Call souschef CLI to convert the plugin
+	args := []string{"convert-ohai", "--plugin-path", pluginPath, "--output-path", outputPath}
+	content, ok := executeConversion(ctx, r.client, args, filepath.Join(outputPath, ohaiFactFilename(pluginPath)), errReadingOhaiFact, diagnostics)
+	if !ok {
+		return
+	}
+
+	// Set state
+	model.ID = types.StringValue(id)
+	model.ResolvedOutputPath = types.StringValue(outputPath)
+	model.FactContent = types.StringValue(content)
+}
+
+// ImportState imports an existing resource into Terraform
+func (r *ohaiMigrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format: plugin_path|output_path
+	parts, err := importIDParts(req.ID, "plugin_path", "output_path")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID must be in format: plugin_path|output_path",
+		)
+		return
+	}
+
+	pluginPath := parts[0]
+	configuredOutputPath := parts[1]
+	outputPath := r.client.resolveOutputPath(configuredOutputPath)
+
+	// Validate that the Ohai plugin exists
+	if !checkFileExists(pluginPath, "Ohai plugin", &resp.Diagnostics) {
+		return
+	}
+
+	// Check if the converted fact exists
+	factPath := filepath.Join(outputPath, ohaiFactFilename(pluginPath))
+	if !checkFileExists(factPath, "Converted Ohai fact", &resp.Diagnostics) {
+		return
+	}
+
+	// Read fact content
+	content := readGeneratedFile(factPath, errReadingOhaiFact, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("plugin_path"), pluginPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_path"), configuredOutputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resolved_output_path"), outputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("fact_content"), content)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), r.client.resourceID(fmt.Sprintf(ohaiIDFormat, ohaiPluginName(pluginPath)), pluginPath))...)
+}
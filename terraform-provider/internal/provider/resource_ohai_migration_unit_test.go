@@ -0,0 +1,142 @@
+// Package provider contains unit tests for the Ohai migration resource.
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testOhaiPluginPath is a custom Ohai plugin providing nginx attributes.
+var testOhaiPluginPath = filepath.Join(getFixturePath("ohai"), "nginx.rb")
+
+func TestOhaiFactFilename(t *testing.T) {
+	if got := ohaiFactFilename("/cookbook/files/default/plugins/nginx.rb"); got != "nginx.fact" {
+		t.Errorf("unexpected fact filename %q", got)
+	}
+}
+
+func TestOhaiMigrationLifecycle(t *testing.T) {
+	r := &ohaiMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	outputDir := t.TempDir()
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, ohaiMigrationResourceModel{
+		PluginPath: types.StringValue(testOhaiPluginPath),
+		OutputPath: types.StringValue(outputDir),
+	})}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+
+	var state ohaiMigrationResourceModel
+	if diags := createResp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.ID.ValueString() != "ohai-nginx" {
+		t.Fatalf("unexpected id %q", state.ID.ValueString())
+	}
+	if !strings.Contains(state.FactContent.ValueString(), `"plugin\": \"nginx\"`) {
+		t.Fatalf("unexpected fact_content %q", state.FactContent.ValueString())
+	}
+
+	// Read picks up edits to the generated fact script
+	factPath := filepath.Join(outputDir, "nginx.fact")
+	if err := os.WriteFile(factPath, []byte("#!/bin/sh\necho '{}'\n"), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	var refreshed ohaiMigrationResourceModel
+	readResp.State.Get(context.Background(), &refreshed)
+	if !strings.Contains(refreshed.FactContent.ValueString(), "echo '{}'") {
+		t.Fatalf("expected Read to refresh fact_content, got %q", refreshed.FactContent.ValueString())
+	}
+
+	// ImportState reconstructs the same resource
+	importResp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: testOhaiPluginPath + "|" + outputDir}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, importResp.Diagnostics)
+	}
+	var imported ohaiMigrationResourceModel
+	importResp.State.Get(context.Background(), &imported)
+	if imported.ID.ValueString() != state.ID.ValueString() || imported.FactContent.ValueString() != refreshed.FactContent.ValueString() {
+		t.Fatalf("unexpected imported state: %+v", imported)
+	}
+
+	// Delete removes the fact script, after which Read drops the resource
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+	}
+	if _, err := os.Stat(factPath); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be deleted, got %v", factPath, err)
+	}
+
+	readResp = &resource.ReadResponse{State: readResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: readResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Fatal("expected resource to be removed when the fact script is missing")
+	}
+}
+
+func TestOhaiMigrationCreateErrors(t *testing.T) {
+	r := &ohaiMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+
+	tests := map[string]struct {
+		pluginPath string
+		failCLI    bool
+	}{
+		"missing plugin": {pluginPath: filepath.Join(t.TempDir(), "nginx.rb")},
+		"CLI failure":    {pluginPath: testOhaiPluginPath, failCLI: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tt.failCLI {
+				t.Setenv("SOUSCHEF_TEST_FAIL", "convert-ohai")
+			}
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+			r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, ohaiMigrationResourceModel{
+				PluginPath: types.StringValue(tt.pluginPath),
+				OutputPath: types.StringValue(t.TempDir()),
+			})}, resp)
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestOhaiMigrationImportStateErrors(t *testing.T) {
+	r := &ohaiMigrationResource{}
+	schema := newResourceSchema(t, r)
+
+	for _, id := range []string{
+		testOhaiPluginPath,
+		testOhaiPluginPath + "|" + t.TempDir() + "|extra",
+		filepath.Join(t.TempDir(), "nginx.rb") + "|" + t.TempDir(),
+		testOhaiPluginPath + "|" + t.TempDir(),
+	} {
+		resp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+		r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, resp)
+		if !resp.Diagnostics.HasError() {
+			t.Fatalf("expected error importing %q", id)
+		}
+	}
+}
@@ -0,0 +1,331 @@
+// Package provider implements the SousChef Terraform provider resources
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	errReadingInventory = "Error reading inventory"
+	searchIDFormat      = "%s-%s-search"
+)
+
+// chefSearchPattern matches search(:index, "query") and search("index", 'query') calls.
+var chefSearchPattern = regexp.MustCompile(`\bsearch\(\s*:?['"]?(\w+)['"]?\s*,\s*(?:"([^"]*)"|'([^']*)')`)
+
+// parseChefSearchQueries returns the de-duplicated search queries in recipe
+// source, in order of appearance, formatted as "index: query".
+func parseChefSearchQueries(content string) []string {
+	seen := make(map[string]bool)
+	queries := make([]string, 0)
+	for _, match := range chefSearchPattern.FindAllStringSubmatch(content, -1) {
+		query := fmt.Sprintf("%s: %s", match[1], match[2]+match[3])
+		if !seen[query] {
+			seen[query] = true
+			queries = append(queries, query)
+		}
+	}
+	return queries
+}
+
+// searchInventoryFilename returns the inventory filename generated for recipeName.
+func searchInventoryFilename(recipeName string) string {
+	return recipeName + "_inventory.yml"
+}
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource                = &searchMigrationResource{}
+	_ resource.ResourceWithImportState = &searchMigrationResource{}
+)
+
+// NewSearchMigrationResource creates a new Chef search migration resource
+func NewSearchMigrationResource() resource.Resource {
+	return &searchMigrationResource{}
+}
+
+// searchMigrationResource is the resource implementation
+type searchMigrationResource struct {
+	client *SousChefClient
+}
+
+// searchMigrationResourceModel describes the resource data model
+type searchMigrationResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	CookbookPath       types.String `tfsdk:"cookbook_path"`
+	RecipeName         types.String `tfsdk:"recipe_name"`
+	OutputPath         types.String `tfsdk:"output_path"`
+	CreateOutputDir    types.Bool   `tfsdk:"create_output_dir"`
+	PruneEmptyDir      types.Bool   `tfsdk:"prune_empty_dir"`
+	RetainOnDelete     types.Bool   `tfsdk:"retain_on_delete"`
+	ResolvedOutputPath types.String `tfsdk:"resolved_output_path"`
+	SearchQueries      types.List   `tfsdk:"search_queries"`
+	InventoryContent   types.String `tfsdk:"inventory_content"`
+}
+
+// Metadata returns the resource type name
+func (r *searchMigrationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_search_migration"
+}
+
+// Schema defines the schema for the resource
+func (r *searchMigrationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages conversion of Chef `search()` calls in a recipe to an Ansible dynamic inventory.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier for the search migration",
+			},
+			"cookbook_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Path to the Chef cookbook directory",
+			},
+			"recipe_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Name of the recipe whose searches are converted (default: \"default\")",
+			},
+			"output_path": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Directory where `<recipe>_inventory.yml` will be written",
+			},
+			"create_output_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Create `output_path` when it does not exist (default: true). When false the directory must already exist, e.g. when it is pre-created with specific ownership and permissions",
+			},
+			"prune_empty_dir": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Remove the output directory on destroy when no other files remain in it (default: false)",
+			},
+			"retain_on_delete": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Leave the generated files in place on destroy and only remove the resource from state, e.g. once they are committed to a repository (default: false)",
+			},
+			"resolved_output_path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Directory the inventory is written to: `output_path` joined onto the provider `output_root` when `output_path` is relative",
+			},
+			"search_queries": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Chef search queries found in the recipe, formatted as `index: query`",
+			},
+			"inventory_content": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Generated dynamic inventory content",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *searchMigrationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client = configureResource(req, resp)
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *searchMigrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan searchMigrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create output directory
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
+		return
+	}
+	existing := snapshotOutputs(r.client.resolveOutputPath(plan.OutputPath.ValueString()))
+
+	// Execute conversion and set state
+	r.executeSearchConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Don't leave an untracked inventory behind if the apply was interrupted
+	inventoryPath := filepath.Join(plan.ResolvedOutputPath.ValueString(), searchInventoryFilename(plan.RecipeName.ValueString()))
+	if cleanupIfCanceled(ctx, &resp.Diagnostics, existing, inventoryPath) {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *searchMigrationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state searchMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inventoryPath := filepath.Join(stateOutputPath(state.ResolvedOutputPath, state.OutputPath), searchInventoryFilename(state.RecipeName.ValueString()))
+
+	// Check if file exists and read content
+	if !readFileAndSetState(
+		ctx,
+		inventoryPath,
+		"inventory_content",
+		func(content string) { state.InventoryContent = types.StringValue(content) },
+		errReadingInventory,
+		&resp.Diagnostics,
+		r.client.missingArtifactHandler("inventory "+inventoryPath, &resp.State, &resp.Diagnostics),
+	) {
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success
+func (r *searchMigrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan searchMigrationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check the output directory, creating it unless create_output_dir is false
+	if !r.client.prepareOutputDirectory(plan.CreateOutputDir, r.client.resolveOutputPath(plan.OutputPath.ValueString()), &resp.Diagnostics) {
+		return
+	}
+
+	// Execute conversion and set state
+	r.executeSearchConversion(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success
+func (r *searchMigrationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state searchMigrationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	outputPath := stateOutputPath(state.ResolvedOutputPath, state.OutputPath)
+	if r.client.retainGeneratedFiles(state.RetainOnDelete, outputPath, &resp.Diagnostics) {
+		return
+	}
+	deleteGeneratedFile(filepath.Join(outputPath, searchInventoryFilename(state.RecipeName.ValueString())), "inventory", &resp.Diagnostics)
+	pruneEmptyDir(state.PruneEmptyDir, outputPath, &resp.Diagnostics)
+}
+
+// readRecipeSearchQueries reads the recipe source and returns its search queries.
+func readRecipeSearchQueries(cookbookPath, recipeName string, diagnostics *diag.Diagnostics) []string {
+	recipePath := filepath.Join(cookbookPath, "recipes", recipeName+".rb")
+	content := readGeneratedFile(recipePath, "Error reading recipe", diagnostics)
+	if diagnostics.HasError() {
+		return nil
+	}
+	return parseChefSearchQueries(content)
+}
+
+// executeSearchConversion is a helper that encapsulates the common logic for Create and Update.
+// It executes the search conversion, reads the output, and updates the model state.
+func (r *searchMigrationResource) executeSearchConversion(ctx context.Context, model *searchMigrationResourceModel, diagnostics *diag.Diagnostics) {
+	cookbookPath := model.CookbookPath.ValueString()
+	outputPath := r.client.resolveOutputPath(model.OutputPath.ValueString())
+	recipeName := "default"
+	if !model.RecipeName.IsNull() && model.RecipeName.ValueString() != "" {
+		recipeName = model.RecipeName.ValueString()
+	}
+	id := r.client.resourceID(fmt.Sprintf(searchIDFormat, filepath.Base(cookbookPath), recipeName), cookbookPath)
+	ctx = withReportResourceID(ctx, id)
+
+	queries := readRecipeSearchQueries(cookbookPath, recipeName, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+
+	// Call souschef CLI to convert the recipe's search calls
+	args := []string{"convert-search", "--cookbook-path", cookbookPath, "--recipe-name", recipeName, "--output-path", outputPath}
+	content, ok := executeConversion(ctx, r.client, args, filepath.Join(outputPath, searchInventoryFilename(recipeName)), errReadingInventory, diagnostics)
+	if !ok {
+		return
+	}
+
+	// Set state
+	model.ID = types.StringValue(id)
+	model.RecipeName = types.StringValue(recipeName)
+	model.ResolvedOutputPath = types.StringValue(outputPath)
+	model.SearchQueries = typesListFromStringSlice(queries)
+	model.InventoryContent = types.StringValue(content)
+}
+
+// ImportState imports an existing resource into Terraform
+func (r *searchMigrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format: cookbook_path|output_path|recipe_name (recipe_name is optional)
+	parts, err := importIDParts(req.ID, "cookbook_path", "output_path", "recipe_name")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+	if len(parts) < 2 || len(parts) > 3 {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID must be in format: cookbook_path|output_path or cookbook_path|output_path|recipe_name",
+		)
+		return
+	}
+
+	cookbookPath := parts[0]
+	configuredOutputPath := parts[1]
+	outputPath := r.client.resolveOutputPath(configuredOutputPath)
+	recipeName := "default"
+	if len(parts) == 3 && parts[2] != "" {
+		recipeName = parts[2]
+	}
+
+	// Validate that the cookbook exists and read its search queries
+	if !checkFileExists(cookbookPath, "Cookbook", &resp.Diagnostics) {
+		return
+	}
+	queries := readRecipeSearchQueries(cookbookPath, recipeName, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Check if inventory exists
+	inventoryPath := filepath.Join(outputPath, searchInventoryFilename(recipeName))
+	if !checkFileExists(inventoryPath, "Inventory", &resp.Diagnostics) {
+		return
+	}
+
+	// Read inventory content
+	content := readGeneratedFile(inventoryPath, errReadingInventory, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cookbook_path"), cookbookPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("recipe_name"), recipeName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_path"), configuredOutputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resolved_output_path"), outputPath)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("search_queries"), queries)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("inventory_content"), content)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), r.client.resourceID(fmt.Sprintf(searchIDFormat, filepath.Base(cookbookPath), recipeName), cookbookPath))...)
+}
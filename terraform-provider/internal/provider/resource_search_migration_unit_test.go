@@ -0,0 +1,166 @@
+// Package provider contains unit tests for the Chef search migration resource.
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testSearchRecipe is a recipe that discovers web and database nodes with search.
+const testSearchRecipe = `web_nodes = search(:node, "role:web AND chef_environment:#{node.chef_environment}")
+db_nodes = search(:node, 'role:db')
+admins = search("users", "groups:admin")
+
+template '/etc/haproxy/haproxy.cfg' do
+  source 'haproxy.cfg.erb'
+  variables(backends: search(:node, 'role:db'))
+end
+`
+
+// newSearchFixture writes testSearchRecipe as recipes/default.rb in a cookbook named web.
+func newSearchFixture(t *testing.T) string {
+	t.Helper()
+
+	cookbookDir := filepath.Join(t.TempDir(), "web")
+	recipesDir := filepath.Join(cookbookDir, "recipes")
+	if err := os.MkdirAll(recipesDir, testDirPermissions); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+	if err := os.WriteFile(filepath.Join(recipesDir, "default.rb"), []byte(testSearchRecipe), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+	return cookbookDir
+}
+
+func TestParseChefSearchQueries(t *testing.T) {
+	want := []string{
+		"node: role:web AND chef_environment:#{node.chef_environment}",
+		"node: role:db",
+		"users: groups:admin",
+	}
+	if got := parseChefSearchQueries(testSearchRecipe); !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseChefSearchQueries() = %v, want %v", got, want)
+	}
+	if got := parseChefSearchQueries("package 'nginx'\n"); len(got) != 0 {
+		t.Fatalf("expected no queries, got %v", got)
+	}
+}
+
+func TestSearchMigrationLifecycle(t *testing.T) {
+	r := &searchMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+	cookbookPath := newSearchFixture(t)
+	outputDir := t.TempDir()
+
+	createResp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, searchMigrationResourceModel{
+		CookbookPath:  types.StringValue(cookbookPath),
+		OutputPath:    types.StringValue(outputDir),
+		SearchQueries: types.ListUnknown(types.StringType),
+	})}, createResp)
+	if createResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, createResp.Diagnostics)
+	}
+
+	var state searchMigrationResourceModel
+	if diags := createResp.State.Get(context.Background(), &state); diags.HasError() {
+		t.Fatalf("failed to read state: %v", diags)
+	}
+	if state.ID.ValueString() != "web-default-search" || state.RecipeName.ValueString() != "default" {
+		t.Fatalf("unexpected id %q / recipe_name %q", state.ID.ValueString(), state.RecipeName.ValueString())
+	}
+	if len(state.SearchQueries.Elements()) != 3 {
+		t.Fatalf("expected 3 search queries, got %v", state.SearchQueries)
+	}
+	if !strings.Contains(state.InventoryContent.ValueString(), "plugin: constructed") {
+		t.Fatalf("unexpected inventory_content %q", state.InventoryContent.ValueString())
+	}
+
+	// ImportState reconstructs the same resource
+	importResp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: cookbookPath + "|" + outputDir}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, importResp.Diagnostics)
+	}
+	var imported searchMigrationResourceModel
+	importResp.State.Get(context.Background(), &imported)
+	if imported.ID.ValueString() != state.ID.ValueString() || !imported.SearchQueries.Equal(state.SearchQueries) {
+		t.Fatalf("unexpected imported state: %+v", imported)
+	}
+
+	// Delete removes the inventory, after which Read drops the resource
+	inventoryPath := filepath.Join(outputDir, "default_inventory.yml")
+	deleteResp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: createResp.State}, deleteResp)
+	if deleteResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, deleteResp.Diagnostics)
+	}
+	if _, err := os.Stat(inventoryPath); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be deleted, got %v", inventoryPath, err)
+	}
+
+	readResp := &resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Fatal("expected resource to be removed when the inventory is missing")
+	}
+}
+
+func TestSearchMigrationCreateErrors(t *testing.T) {
+	r := &searchMigrationResource{client: &SousChefClient{Path: newFakeSousChef(t)}}
+	schema := newResourceSchema(t, r)
+
+	tests := map[string]struct {
+		cookbookPath string
+		failCLI      bool
+	}{
+		"missing recipe": {cookbookPath: t.TempDir()},
+		"CLI failure":    {cookbookPath: newSearchFixture(t), failCLI: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tt.failCLI {
+				t.Setenv("SOUSCHEF_TEST_FAIL", "convert-search")
+			}
+			resp := &resource.CreateResponse{State: tfsdk.State{Schema: schema}}
+			r.Create(context.Background(), resource.CreateRequest{Plan: newPlan(t, schema, searchMigrationResourceModel{
+				CookbookPath:  types.StringValue(tt.cookbookPath),
+				OutputPath:    types.StringValue(t.TempDir()),
+				SearchQueries: types.ListUnknown(types.StringType),
+			})}, resp)
+			if !resp.Diagnostics.HasError() {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestSearchMigrationImportStateErrors(t *testing.T) {
+	r := &searchMigrationResource{}
+	schema := newResourceSchema(t, r)
+	cookbookPath := newSearchFixture(t)
+
+	for _, id := range []string{
+		cookbookPath,
+		filepath.Join(t.TempDir(), "missing") + "|" + t.TempDir(),
+		cookbookPath + "|" + t.TempDir() + "|server",
+		cookbookPath + "|" + t.TempDir(),
+	} {
+		resp := &resource.ImportStateResponse{State: newEmptyState(schema)}
+		r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, resp)
+		if !resp.Diagnostics.HasError() {
+			t.Fatalf("expected error importing %q", id)
+		}
+	}
+}
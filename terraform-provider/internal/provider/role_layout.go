@@ -0,0 +1,69 @@
+// Package provider contains helpers for laying out generated content as an Ansible role
+package provider
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+)
+
+const (
+	outputLayoutPlaybook = "playbook"
+	outputLayoutRole     = "role"
+)
+
+// rolePathFor returns the directory the role for recipeName is written to.
+func rolePathFor(outputPath, recipeName string) string {
+	return filepath.Join(outputPath, "roles", recipeName)
+}
+
+// roleTasksPath returns the tasks file of the role at rolePath.
+func roleTasksPath(rolePath string) string {
+	return filepath.Join(rolePath, "tasks", "main.yml")
+}
+
+// applyRoleLayoutTemplate mirrors the directories and files of the skeleton
+// at templatePath into the role the CLI generated at rolePath, so the role
+// has the team's expected structure. Files the CLI generated are kept rather
+// than replaced by the skeleton's. It does nothing when templatePath is empty.
+func applyRoleLayoutTemplate(rolePath, templatePath string) error {
+	if templatePath == "" {
+		return nil
+	}
+	return mirrorRoleTemplate(templatePath, rolePath)
+}
+
+// mirrorRoleTemplate copies the directory tree at templatePath into rolePath,
+// skipping files that already exist there.
+func mirrorRoleTemplate(templatePath, rolePath string) error {
+	return filepath.WalkDir(templatePath, func(srcPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("could not read role layout template: %w", err)
+		}
+
+		relPath, err := filepath.Rel(templatePath, srcPath)
+		if err != nil {
+			return err
+		}
+		destPath := filepath.Join(rolePath, relPath)
+
+		if entry.IsDir() {
+			if err := osMkdirAll(destPath, 0755); err != nil {
+				return fmt.Errorf("could not create role directory %s: %w", relPath, err)
+			}
+			return nil
+		}
+
+		if _, err := osStat(destPath); err == nil {
+			return nil
+		}
+		content, err := osReadFile(srcPath)
+		if err != nil {
+			return fmt.Errorf("could not read template file %s: %w", relPath, err)
+		}
+		if err := writeFileAtomic(destPath, content, 0644); err != nil {
+			return fmt.Errorf("could not write role file %s: %w", relPath, err)
+		}
+		return nil
+	})
+}
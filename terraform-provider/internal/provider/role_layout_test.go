@@ -0,0 +1,147 @@
+// Package provider contains unit tests for the Ansible role layout helpers.
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// cliRoleFiles are the files the fake CLI generates in a role, which a
+// role layout template must not replace.
+var cliRoleFiles = map[string]bool{"tasks/main.yml": true, "defaults/main.yml": true, "handlers/main.yml": true}
+
+// newGeneratedRole runs the fake CLI's role layout conversion of the web
+// recipe and returns the role directory it generated.
+func newGeneratedRole(t *testing.T) string {
+	t.Helper()
+
+	client := &SousChefClient{Path: newFakeSousChef(t)}
+	outputDir := t.TempDir()
+	if _, err := runCLI(context.Background(), client, nil, "convert-recipe", "--cookbook-path", testTmpCookbook, "--recipe-name", "web", "--output-path", outputDir, "--layout", outputLayoutRole); err != nil {
+		t.Fatalf("failed to generate role: %v", err)
+	}
+	return rolePathFor(outputDir, "web")
+}
+
+// newRoleSkeleton creates a role layout template with team-specific structure.
+func newRoleSkeleton(t *testing.T) string {
+	t.Helper()
+
+	skeleton := t.TempDir()
+	files := map[string]string{
+		"defaults/main.yml": "---\n# team defaults\n",
+		"handlers/main.yml": "---\n# team handlers\n",
+		"meta/main.yml":     "galaxy_info:\n  author: platform-team\n",
+		"tasks/.keep":       "",
+		"tasks/main.yml":    "---\n# tasks go here\n",
+	}
+	for name, content := range files {
+		filePath := filepath.Join(skeleton, name)
+		if err := os.MkdirAll(filepath.Dir(filePath), testDirPermissions); err != nil {
+			t.Fatalf(testFailedToCreateDirectory, err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), testFilePermissions); err != nil {
+			t.Fatalf(testFailedToWriteFile, err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(skeleton, "templates"), testDirPermissions); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+	return skeleton
+}
+
+// verifyRoleMirrorsSkeleton checks every path in skeleton exists in rolePath,
+// with the skeleton's content unless the CLI generated the file.
+func verifyRoleMirrorsSkeleton(t *testing.T, skeleton, rolePath string) {
+	t.Helper()
+
+	err := filepath.WalkDir(skeleton, func(srcPath string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, _ := filepath.Rel(skeleton, srcPath)
+		info, statErr := os.Stat(filepath.Join(rolePath, relPath))
+		if statErr != nil {
+			t.Errorf("expected role to contain %s: %v", relPath, statErr)
+			return nil
+		}
+		if entry.IsDir() != info.IsDir() {
+			t.Errorf("expected %s to have matching type in role", relPath)
+			return nil
+		}
+		if !entry.IsDir() && !cliRoleFiles[filepath.ToSlash(relPath)] {
+			want, _ := os.ReadFile(srcPath)
+			got, _ := os.ReadFile(filepath.Join(rolePath, relPath))
+			if string(got) != string(want) {
+				t.Errorf("expected %s content %q, got %q", relPath, want, got)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk skeleton: %v", err)
+	}
+}
+
+// verifyRoleTasks checks the role's tasks file is the task list the CLI
+// generated rather than a playbook.
+func verifyRoleTasks(t *testing.T, rolePath, want string) {
+	t.Helper()
+
+	got, err := os.ReadFile(roleTasksPath(rolePath))
+	if err != nil {
+		t.Fatalf("expected tasks/main.yml in role: %v", err)
+	}
+	if string(got) != want || !strings.HasPrefix(string(got), "- name: ") || strings.Contains(string(got), "hosts:") {
+		t.Fatalf("expected the generated task list %q, got %q", want, got)
+	}
+}
+
+func TestApplyRoleLayoutTemplateWithoutTemplate(t *testing.T) {
+	rolePath := newGeneratedRole(t)
+	tasks, err := os.ReadFile(roleTasksPath(rolePath))
+	if err != nil {
+		t.Fatalf("expected the CLI to generate tasks/main.yml: %v", err)
+	}
+
+	if err := applyRoleLayoutTemplate(rolePath, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, dir := range []string{"tasks", "defaults", "handlers"} {
+		if info, err := os.Stat(filepath.Join(rolePath, dir)); err != nil || !info.IsDir() {
+			t.Fatalf("expected role directory %s, got %v", dir, err)
+		}
+	}
+	verifyRoleTasks(t, rolePath, string(tasks))
+}
+
+func TestApplyRoleLayoutTemplateMirrorsTemplate(t *testing.T) {
+	skeleton := newRoleSkeleton(t)
+	rolePath := newGeneratedRole(t)
+	tasks, err := os.ReadFile(roleTasksPath(rolePath))
+	if err != nil {
+		t.Fatalf("expected the CLI to generate tasks/main.yml: %v", err)
+	}
+
+	if err := applyRoleLayoutTemplate(rolePath, skeleton); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	verifyRoleMirrorsSkeleton(t, skeleton, rolePath)
+	verifyRoleTasks(t, rolePath, string(tasks))
+	if defaults, _ := os.ReadFile(filepath.Join(rolePath, "defaults", "main.yml")); string(defaults) != "---\n" {
+		t.Fatalf("expected the CLI's defaults to be kept, got %q", defaults)
+	}
+}
+
+func TestApplyRoleLayoutTemplateMissingTemplate(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	if err := applyRoleLayoutTemplate(newGeneratedRole(t), missing); err == nil {
+		t.Fatal("expected error for missing template")
+	}
+}
@@ -0,0 +1,114 @@
+// Package provider contains helpers for detecting changes to cookbook sources
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// hashCookbookSource returns a SHA-256 hash over the relative paths and
+// contents of every file in the cookbook, skipping hidden files and
+// directories such as .git.
+func hashCookbookSource(cookbookPath string) (string, error) {
+	hash := sha256.New()
+	err := filepath.WalkDir(cookbookPath, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if filePath != cookbookPath && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(cookbookPath, filePath)
+		if err != nil {
+			return err
+		}
+		content, err := osReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		hash.Write([]byte(filepath.ToSlash(relPath)))
+		hash.Write([]byte{0})
+		hash.Write(content)
+		hash.Write([]byte{0})
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// sourceHashValue returns the cookbook source hash, or null when the
+// cookbook cannot be read.
+func sourceHashValue(cookbookPath string) types.String {
+	hash, err := hashCookbookSource(cookbookPath)
+	if err != nil {
+		return types.StringNull()
+	}
+	return types.StringValue(hash)
+}
+
+// sourceHashPlanModifier re-hashes a local cookbook at plan time and plans
+// source_hash as unknown when the sources changed since the last apply, which
+// the resource's ModifyPlan turns into a planned re-conversion.
+type sourceHashPlanModifier struct{}
+
+// Description returns a plain text description of the modifier's behavior.
+func (m sourceHashPlanModifier) Description(_ context.Context) string {
+	return "Plans a re-conversion when the cookbook sources changed since the last apply."
+}
+
+// MarkdownDescription returns a markdown description of the modifier's behavior.
+func (m sourceHashPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString compares the current cookbook source hash with state.
+func (m sourceHashPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// Git-sourced cookbooks are tracked through git_commit instead
+	var gitURL, cookbookPath types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("git_url"), &gitURL)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("cookbook_path"), &cookbookPath)...)
+	if resp.Diagnostics.HasError() || !gitURL.IsNull() || cookbookPath.IsNull() || cookbookPath.IsUnknown() {
+		return
+	}
+
+	hash, err := hashCookbookSource(cookbookPath.ValueString())
+	if err != nil {
+		tflog.Debug(ctx, "Could not hash cookbook sources", map[string]interface{}{
+			"path":  cookbookPath.ValueString(),
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if hash == req.StateValue.ValueString() {
+		resp.PlanValue = req.StateValue
+		return
+	}
+
+	tflog.Info(ctx, "Cookbook sources changed since last apply", map[string]interface{}{
+		"path": cookbookPath.ValueString(),
+	})
+	resp.PlanValue = types.StringUnknown()
+}
@@ -0,0 +1,208 @@
+// Package provider contains unit tests for cookbook source change detection.
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newSourceCookbook creates a cookbook with a default recipe and returns its path.
+func newSourceCookbook(t *testing.T) string {
+	t.Helper()
+
+	cookbookPath := filepath.Join(t.TempDir(), "web")
+	if err := os.MkdirAll(filepath.Join(cookbookPath, "recipes"), testDirPermissions); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+	writeSourceFile(t, cookbookPath, "metadata.rb", "name 'web'\n")
+	writeSourceFile(t, cookbookPath, "recipes/default.rb", "package 'nginx'\n")
+	return cookbookPath
+}
+
+func writeSourceFile(t *testing.T, cookbookPath, name, content string) {
+	t.Helper()
+
+	filePath := filepath.Join(cookbookPath, name)
+	if err := os.MkdirAll(filepath.Dir(filePath), testDirPermissions); err != nil {
+		t.Fatalf(testFailedToCreateDirectory, err)
+	}
+	if err := os.WriteFile(filePath, []byte(content), testFilePermissions); err != nil {
+		t.Fatalf(testFailedToWriteFile, err)
+	}
+}
+
+func mustHashCookbookSource(t *testing.T, cookbookPath string) string {
+	t.Helper()
+
+	hash, err := hashCookbookSource(cookbookPath)
+	if err != nil {
+		t.Fatalf("failed to hash cookbook: %v", err)
+	}
+	return hash
+}
+
+func TestHashCookbookSource(t *testing.T) {
+	cookbookPath := newSourceCookbook(t)
+	original := mustHashCookbookSource(t, cookbookPath)
+
+	writeSourceFile(t, cookbookPath, ".git/HEAD", "ref: refs/heads/main\n")
+	if got := mustHashCookbookSource(t, cookbookPath); got != original {
+		t.Fatal("expected hidden directories to be ignored")
+	}
+
+	writeSourceFile(t, cookbookPath, "recipes/default.rb", "package 'apache2'\n")
+	if got := mustHashCookbookSource(t, cookbookPath); got == original {
+		t.Fatal("expected hash to change after editing a recipe")
+	}
+
+	if _, err := hashCookbookSource(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatal("expected error for missing cookbook")
+	}
+}
+
+// planSourceHash runs the source_hash plan modifier for cookbookPath against stateHash.
+func planSourceHash(t *testing.T, cookbookPath, stateHash string) types.String {
+	t.Helper()
+
+	schema := newResourceSchema(t, &migrationResource{})
+	plan := newPlan(t, schema, migrationResourceModel{
+		CookbookPath:      types.StringValue(cookbookPath),
+		OutputPath:        types.StringValue(t.TempDir()),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	})
+
+	req := planmodifier.StringRequest{
+		Path:       path.Root("source_hash"),
+		Plan:       plan,
+		StateValue: types.StringValue(stateHash),
+		PlanValue:  types.StringValue(stateHash),
+	}
+	resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+	sourceHashPlanModifier{}.PlanModifyString(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+	return resp.PlanValue
+}
+
+func TestSourceHashPlanModifier(t *testing.T) {
+	cookbookPath := newSourceCookbook(t)
+	stateHash := mustHashCookbookSource(t, cookbookPath)
+
+	if got := planSourceHash(t, cookbookPath, stateHash); got.ValueString() != stateHash {
+		t.Fatalf("expected unchanged source_hash, got %v", got)
+	}
+
+	writeSourceFile(t, cookbookPath, "recipes/default.rb", "package 'apache2'\n")
+	if got := planSourceHash(t, cookbookPath, stateHash); !got.IsUnknown() {
+		t.Fatalf("expected unknown source_hash after editing sources, got %v", got)
+	}
+}
+
+func TestSourceHashPlanModifierSkipsCreate(t *testing.T) {
+	resp := &planmodifier.StringResponse{PlanValue: types.StringUnknown()}
+	sourceHashPlanModifier{}.PlanModifyString(context.Background(), planmodifier.StringRequest{
+		StateValue: types.StringNull(),
+		PlanValue:  types.StringUnknown(),
+	}, resp)
+
+	if !resp.PlanValue.IsUnknown() {
+		t.Fatalf("expected plan value to be left unknown on create, got %v", resp.PlanValue)
+	}
+}
+
+func TestMigrationResourcePlansReconversionOnSourceChange(t *testing.T) {
+	cookbookPath := newSourceCookbook(t)
+	r := &migrationResource{}
+	schema := newResourceSchema(t, r)
+
+	model := migrationResourceModel{
+		CookbookPath:      types.StringValue(cookbookPath),
+		OutputPath:        types.StringValue(t.TempDir()),
+		RecipeName:        types.StringValue("default"),
+		PlaybookContent:   types.StringValue("recipe: default\n"),
+		SourceHash:        types.StringValue(mustHashCookbookSource(t, cookbookPath)),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	}
+	state := newState(t, schema, model)
+
+	writeSourceFile(t, cookbookPath, "recipes/default.rb", "package 'apache2'\n")
+	model.SourceHash = planSourceHash(t, cookbookPath, model.SourceHash.ValueString())
+	plan := newPlan(t, schema, model)
+
+	resp := &resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(context.Background(), resource.ModifyPlanRequest{Plan: plan, State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var got migrationResourceModel
+	if diags := resp.Plan.Get(context.Background(), &got); diags.HasError() {
+		t.Fatalf("failed to read plan: %v", diags)
+	}
+	if !got.PlaybookContent.IsUnknown() || !got.ContentSHA256.IsUnknown() {
+		t.Fatalf("expected playbook content to be planned for re-conversion, got %v / %v", got.PlaybookContent, got.ContentSHA256)
+	}
+	if got.CookbookPath.ValueString() != cookbookPath {
+		t.Fatalf("expected configured cookbook_path to be kept, got %v", got.CookbookPath)
+	}
+}
+
+func TestMigrationResourceSourceChangePlansComputedAttributes(t *testing.T) {
+	cookbookPath := newSourceCookbook(t)
+	r := &migrationResource{}
+	schema := newResourceSchema(t, r)
+
+	model := migrationResourceModel{
+		CookbookPath:      types.StringValue(cookbookPath),
+		OutputPath:        types.StringValue(t.TempDir()),
+		RecipeName:        types.StringValue("default"),
+		PlaybookContent:   types.StringValue("recipe: default\n"),
+		ContentTruncated:  types.BoolValue(false),
+		OutputFileMode:    types.StringValue("0600"),
+		AppliedFileMode:   types.StringValue("0644"),
+		GitCommit:         types.StringValue("0123456789abcdef"),
+		SourceHash:        types.StringValue(mustHashCookbookSource(t, cookbookPath)),
+		ReferencedEnvVars: types.ListNull(types.StringType),
+		ModuleCounts:      types.MapNull(types.Int64Type),
+		VariableRenameMap: types.MapNull(types.StringType),
+		VariableMappings:  types.MapNull(types.StringType),
+		Outputs:           types.MapNull(types.StringType),
+	}
+	state := newState(t, schema, model)
+
+	// The re-conversion may truncate the playbook or find it at another mode
+	writeSourceFile(t, cookbookPath, "recipes/default.rb", strings.Repeat("package 'apache2'\n", 100))
+	model.SourceHash = planSourceHash(t, cookbookPath, model.SourceHash.ValueString())
+	plan := newPlan(t, schema, model)
+
+	resp := &resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(context.Background(), resource.ModifyPlanRequest{Plan: plan, State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf(testUnexpectedDiagnostics, resp.Diagnostics)
+	}
+
+	var got migrationResourceModel
+	if diags := resp.Plan.Get(context.Background(), &got); diags.HasError() {
+		t.Fatalf("failed to read plan: %v", diags)
+	}
+	if !got.ContentTruncated.IsUnknown() || !got.AppliedFileMode.IsUnknown() || !got.GitCommit.IsUnknown() {
+		t.Fatalf("expected content_truncated, applied_file_mode and git_commit to be unknown, got %v / %v / %v", got.ContentTruncated, got.AppliedFileMode, got.GitCommit)
+	}
+}
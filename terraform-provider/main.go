@@ -3,6 +3,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"log"
 	"os"
@@ -15,6 +16,7 @@ import (
 var version string = "dev"
 
 var serve = providerserver.Serve
+var flushReports = provider.FlushReports
 var logFatal = log.Fatal
 
 func main() {
@@ -37,5 +39,8 @@ func run(args []string) error {
 		Debug:   debug,
 	}
 
-	return serve(context.Background(), provider.New(version), opts)
+	// Terraform stops the provider server at the end of each operation,
+	// which is when the operation's report_path report is complete
+	err := serve(context.Background(), provider.New(version), opts)
+	return errors.Join(err, flushReports())
 }
@@ -114,6 +114,33 @@ func TestMainErrorCallsLogFatal(t *testing.T) {
 	}
 }
 
+func TestRunFlushesReportsAfterServe(t *testing.T) {
+	originalServe := serve
+	originalFlushReports := flushReports
+	defer func() {
+		serve = originalServe
+		flushReports = originalFlushReports
+	}()
+
+	var calls []string
+	serve = func(_ context.Context, _ func() provider.Provider, _ providerserver.ServeOpts) error {
+		calls = append(calls, "serve")
+		return nil
+	}
+	flushReports = func() error {
+		calls = append(calls, "flush")
+		return testError{msg: "report directory removed"}
+	}
+
+	err := run([]string{})
+	if err == nil || err.Error() != "report directory removed" {
+		t.Fatalf("expected the flush error, got %v", err)
+	}
+	if len(calls) != 2 || calls[0] != "serve" || calls[1] != "flush" {
+		t.Fatalf("expected reports to be flushed once serve returns, got %v", calls)
+	}
+}
+
 type testError struct {
 	msg string
 }
@@ -0,0 +1,28 @@
+# ChefSpec unit tests for the web cookbook
+require 'chefspec'
+
+describe 'web::default' do
+  platform 'ubuntu', '22.04'
+
+  it 'installs nginx' do
+    expect(chef_run).to install_package('nginx')
+  end
+
+  it 'renders the site configuration' do
+    expect(chef_run).to create_template('/etc/nginx/sites-available/default').with(
+      owner: 'root',
+      mode: '0644'
+    )
+  end
+
+  it { is_expected.to enable_service('nginx') }
+  it { is_expected.to start_service('nginx') }
+
+  context 'when TLS is enabled' do
+    default_attributes['web']['tls'] = true
+
+    it 'creates the certificate directory' do
+      expect(chef_run).to create_directory('/etc/nginx/ssl')
+    end
+  end
+end
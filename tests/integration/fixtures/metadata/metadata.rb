@@ -0,0 +1,14 @@
+name 'web_server'
+maintainer 'Example Ops'
+maintainer_email 'ops@example.com'
+license 'Apache-2.0'
+description 'Installs and configures a web server'
+version '2.3.1'
+chef_version '>= 16.0'
+
+depends 'nginx', '~> 12.0'
+depends 'logrotate'
+depends 'firewall', '>= 2.7.0'
+
+supports 'ubuntu', '>= 20.04'
+supports 'centos'
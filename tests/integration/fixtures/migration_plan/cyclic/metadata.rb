@@ -0,0 +1,2 @@
+name 'cyclic'
+version '1.0.0'
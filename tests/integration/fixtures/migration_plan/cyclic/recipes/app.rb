@@ -0,0 +1 @@
+include_recipe 'cyclic::config'
@@ -0,0 +1,2 @@
+name 'ordered'
+version '1.0.0'
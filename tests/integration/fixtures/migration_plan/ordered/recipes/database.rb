@@ -0,0 +1 @@
+# database recipe
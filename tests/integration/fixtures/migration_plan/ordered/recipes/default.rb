@@ -0,0 +1,3 @@
+include_recipe 'ordered::database'
+include_recipe 'ordered::web'
+include_recipe 'ordered::monitoring'
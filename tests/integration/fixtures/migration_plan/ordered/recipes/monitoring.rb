@@ -0,0 +1 @@
+# monitoring recipe
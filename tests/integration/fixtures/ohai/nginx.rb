@@ -0,0 +1,13 @@
+Ohai.plugin(:Nginx) do
+  provides 'nginx'
+  depends 'languages'
+
+  collect_data(:linux) do
+    nginx Mash.new
+    so = shell_out('nginx -v')
+    if so.exitstatus == 0
+      nginx[:version] = so.stderr.split('/').last.strip
+    end
+    nginx[:config_path] = '/etc/nginx/nginx.conf'
+  end
+end
@@ -0,0 +1,3 @@
+#!/bin/sh
+set -e
+curl -fsS http://localhost:8080/health >/dev/null